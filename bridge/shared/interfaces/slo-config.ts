@@ -11,6 +11,17 @@ export interface ISloObjectives {
   pass?: Target[];
   warning?: Target[];
   weight?: number;
+  group?: string;
+  provider?: string;
+}
+
+export interface ISloGroup {
+  name: string;
+  weight?: number;
+  total_score?: {
+    pass?: scoreType;
+    warning?: scoreType;
+  };
 }
 
 export interface SloConfig {
@@ -22,6 +33,7 @@ export interface SloConfig {
     number_of_comparison_results: number;
   };
   filter: unknown;
+  groups?: ISloGroup[];
   objectives: ISloObjectives[];
   total_score?: {
     pass?: scoreType;
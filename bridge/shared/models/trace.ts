@@ -7,10 +7,19 @@ import { KeptnService } from './keptn-service';
 import { DateUtil } from '../utils/date.utils';
 import { ISloObjectives } from '../interfaces/slo-config';
 
+export interface ISloGroupResult {
+  name: string;
+  weight: number;
+  score: number;
+  result: ResultTypes;
+  objectives: string[];
+}
+
 /* eslint-disable @typescript-eslint/naming-convention */
 export interface IEvaluationData {
   comparedEvents?: string[];
   indicatorResults: IndicatorResult[];
+  groups?: ISloGroupResult[];
   result: ResultTypes;
   score: number;
   sloFileContent: string;
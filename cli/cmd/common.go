@@ -1,5 +1,15 @@
 package cmd
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+)
+
 const MsgDeprecatedUseHelm = "please, use the Helm CLI instead. For further information, refer to the documentation https://keptn.sh/docs/%s/operate/%s"
 
 func areStringFlagsSet(el ...*string) bool {
@@ -27,3 +37,30 @@ func areBoolFlagsSet(el ...*bool) bool {
 func isBoolFlagSet(b *bool) bool {
 	return b != nil && *b
 }
+
+// getEndpointAndToken returns the endpoint and the API token of the Keptn API, or of the mock server when mocking
+func getEndpointAndToken() (url.URL, string, error) {
+	var endPoint url.URL
+	var apiToken string
+	var err error
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = os.Getenv("MOCK_API_TOKEN")
+	}
+	if err != nil {
+		return url.URL{}, "", errors.New(authErrorMsg)
+	}
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+	return endPoint, apiToken, nil
+}
+
+// orDash returns the value, or a dash if it is empty, to keep the columns of a table aligned
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
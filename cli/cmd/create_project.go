@@ -32,6 +32,7 @@ type createProjectCmdParams struct {
 	GitProxyPassword  *string
 	GitPemCertificate *string
 	InsecureSkipTLS   *bool
+	StageLayout       *string
 }
 
 var createProjectParams *createProjectCmdParams
@@ -70,10 +71,14 @@ For using proxy please specify proxy IP address together with port (*--git-proxy
 used scheme (*--git-proxy-scheme=*) to connect to proxy. Please be aware that authentication with public/private key and via proxy is 
 supported only when using resource-service.
 
+The stages of the project are represented either as branches (*--stage-layout=branch*) or as directories within the default branch (*--stage-layout=directory*) of the Git repository.
+If no stage layout is specified, the default stage layout of the Keptn installation is used.
+
 For more information about Shipyard, creating projects, or upstream repositories, please go to [Manage Keptn](https://keptn.sh/docs/` + getReleaseDocsURL() + `/manage/)
 `,
 	Example: `keptn create project PROJECTNAME --shipyard=FILEPATH
 keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-remote-url=GIT_REMOTE_URL --git-token=GIT_TOKEN
+keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-remote-url=GIT_REMOTE_URL --git-token=GIT_TOKEN --stage-layout=directory
 
 or (only for resource-service)
 
@@ -97,7 +102,7 @@ keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-r
 			cmd.SilenceUsage = false
 			return errors.New("too many arguments set")
 		}
-		return nil
+		return internal.ValidateStageLayout(*createProjectParams.StageLayout)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 
@@ -173,6 +178,16 @@ keptn create project PROJECTNAME --shipyard=FILEPATH --git-user=GIT_USER --git-r
 		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
 
 		if !mocking {
+			// the stage layout is not covered by the go-utils API set, hence projects with a stage layout are created by a separate handler
+			if *createProjectParams.StageLayout != "" {
+				projectHandler := internal.NewProjectHandler(endPoint.String(), apiToken)
+				if err := projectHandler.CreateProject(internal.Project{CreateProject: project, StageLayout: *createProjectParams.StageLayout}); err != nil {
+					return fmt.Errorf("Create project was unsuccessful.\n%s", err.Error())
+				}
+				logging.PrintLog("Project created successfully", logging.InfoLevel)
+				return nil
+			}
+
 			_, err := api.APIV1().CreateProject(project)
 			if err != nil {
 				return fmt.Errorf("Create project was unsuccessful.\n%s", *err.Message)
@@ -244,5 +259,6 @@ func init() {
 	createProjectParams.InsecureSkipTLS = crProjectCmd.Flags().BoolP("insecure-skip-tls", "x", false, "Disable TLS verification to allow connections to servers using self signed certificates")
 
 	createProjectParams.GitPemCertificate = crProjectCmd.Flags().StringP("git-pem-certificate", "g", "", "The git PEM Certificate file")
+	createProjectParams.StageLayout = crProjectCmd.Flags().String("stage-layout", "", "The stage layout of the project, i.e. either 'branch' or 'directory'. If not set, the default stage layout of the Keptn installation is used")
 
 }
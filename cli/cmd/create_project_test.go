@@ -58,6 +58,29 @@ func TestCreateProjectCmd(t *testing.T) {
 	}
 }
 
+// TestCreateProjectCmdWithStageLayout tests a create project command with a stage layout
+func TestCreateProjectCmdWithStageLayout(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	defer func() {
+		*createProjectParams.StageLayout = ""
+	}()
+
+	shipyardFilePath := "./shipyard.yaml"
+	defer testShipyard(t, shipyardFilePath, "")()
+
+	cmd := fmt.Sprintf("create project sockshop --shipyard=%s --stage-layout=directory --mock", shipyardFilePath)
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+
+	cmd = fmt.Sprintf("create project sockshop --shipyard=%s --stage-layout=folder --mock", shipyardFilePath)
+	_, err = executeActionCommandC(cmd)
+	if !errorContains(err, "stage layout must be either 'branch' or 'directory'") {
+		t.Errorf("missing expected error, but got %v", err)
+	}
+}
+
 // TestCreateProjectCmdWithGitMissingParam tests whether the create project command aborts
 // due to a missing flag for defining a git upstream
 func TestCreateProjectCmdWithGitMissingParam(t *testing.T) {
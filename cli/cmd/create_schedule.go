@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+type createScheduleStruct struct {
+	Project     *string
+	Stage       *string
+	Service     *string
+	Cron        *string
+	TimeZone    *string
+	Description *string
+	Labels      *map[string]string
+}
+
+var createScheduleParams createScheduleStruct
+
+var createScheduleCmd = &cobra.Command{
+	Use:   "schedule SEQUENCE_NAME --project=PROJECT --stage=STAGE --service=SERVICE --cron=CRON_EXPRESSION",
+	Short: "Creates a schedule that periodically triggers a sequence",
+	Long: `Creates a schedule that periodically triggers a sequence for a service.
+The point in time at which the sequence is triggered is defined by a standard cron expression (minute, hour, day of month, month, day of week).
+Alternatively, one of the descriptors @yearly, @monthly, @weekly, @daily or @hourly can be used.
+By default, the cron expression is evaluated in UTC. Use the --timezone flag to evaluate it in a different time zone.
+`,
+	Example: `keptn create schedule evaluation --project=sockshop --stage=staging --service=carts --cron="0 2 * * *"
+keptn create schedule performance --project=sockshop --stage=staging --service=carts --cron="0 22 * * 5" --timezone=Europe/Vienna --labels=type=weekly`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			cmd.SilenceUsage = false
+			return errors.New("required argument SEQUENCE_NAME not set")
+		} else if len(args) >= 2 {
+			cmd.SilenceUsage = false
+			return errors.New("too many arguments set")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doCreateSchedule(createScheduleParams, args[0])
+	},
+}
+
+func doCreateSchedule(params createScheduleStruct, sequenceName string) error {
+	scheduleHandler, err := newScheduleHandler()
+	if err != nil {
+		return err
+	}
+
+	schedule := internal.Schedule{
+		Project:     *params.Project,
+		Stage:       *params.Stage,
+		Service:     *params.Service,
+		Sequence:    sequenceName,
+		Cron:        *params.Cron,
+		TimeZone:    *params.TimeZone,
+		Description: *params.Description,
+	}
+	if params.Labels != nil {
+		schedule.Labels = *params.Labels
+	}
+
+	createdSchedule, err := scheduleHandler.CreateSchedule(schedule)
+	if err != nil {
+		return fmt.Errorf("Failed to create schedule: %v", internal.OnAPIError(err))
+	}
+
+	logging.PrintLog(fmt.Sprintf("Schedule %s created successfully", createdSchedule.ID), logging.InfoLevel)
+	if createdSchedule.NextTrigger != nil {
+		logging.PrintLog(fmt.Sprintf("Sequence %s will be triggered next at %s", sequenceName, createdSchedule.NextTrigger.Format(time.RFC3339)), logging.InfoLevel)
+	}
+	return nil
+}
+
+// newScheduleHandler creates a handler for the schedule endpoints of the Keptn API
+func newScheduleHandler() (*internal.ScheduleHandler, error) {
+	var endPoint url.URL
+	var apiToken string
+	var err error
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = os.Getenv("MOCK_API_TOKEN")
+	}
+	if err != nil {
+		return nil, errors.New(authErrorMsg)
+	}
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+	return internal.NewScheduleHandler(endPoint.String(), apiToken), nil
+}
+
+func init() {
+	createCmd.AddCommand(createScheduleCmd)
+
+	createScheduleParams.Project = createScheduleCmd.Flags().StringP("project", "", "", "The project containing the service for which the sequence will be triggered")
+	createScheduleCmd.MarkFlagRequired("project")
+
+	createScheduleParams.Stage = createScheduleCmd.Flags().StringP("stage", "", "", "The stage in which the sequence will be triggered")
+	createScheduleCmd.MarkFlagRequired("stage")
+
+	createScheduleParams.Service = createScheduleCmd.Flags().StringP("service", "", "", "The service for which the sequence will be triggered")
+	createScheduleCmd.MarkFlagRequired("service")
+
+	createScheduleParams.Cron = createScheduleCmd.Flags().StringP("cron", "", "", "The cron expression defining when the sequence will be triggered, e.g. \"0 2 * * *\"")
+	createScheduleCmd.MarkFlagRequired("cron")
+
+	createScheduleParams.TimeZone = createScheduleCmd.Flags().StringP("timezone", "", "", "The IANA time zone in which the cron expression is evaluated, e.g. Europe/Vienna. Defaults to UTC")
+	createScheduleParams.Description = createScheduleCmd.Flags().StringP("description", "", "", "A description of the schedule")
+	createScheduleParams.Labels = createScheduleCmd.Flags().StringToStringP("labels", "l", nil, "Additional labels to be included in the triggered sequences")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logging.InitLoggers(os.Stdout, os.Stdout, os.Stderr)
+}
+
+// TestCreateSchedule tests whether the schedule is sent to the shipyard controller
+func TestCreateSchedule(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	receivedSchedules := []internal.Schedule{}
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method != http.MethodPost || r.URL.Path != "/controlPlane/v1/project/sockshop/schedule" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": 404, "message": "not found"}`))
+				return
+			}
+			defer r.Body.Close()
+			bytes, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+			schedule := internal.Schedule{}
+			require.Nil(t, json.Unmarshal(bytes, &schedule))
+			receivedSchedules = append(receivedSchedules, schedule)
+
+			schedule.ID = "my-schedule"
+			response, _ := json.Marshal(schedule)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(response)
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	cmd := fmt.Sprintf(`create schedule evaluation --project=sockshop --stage=staging --service=carts --cron="0 2 * * *" --timezone=Europe/Vienna --labels=type=nightly --mock`)
+	_, err := executeActionCommandC(cmd)
+	require.Nil(t, err)
+
+	require.Len(t, receivedSchedules, 1)
+	require.Equal(t, internal.Schedule{
+		Project:  "sockshop",
+		Stage:    "staging",
+		Service:  "carts",
+		Sequence: "evaluation",
+		Cron:     "0 2 * * *",
+		TimeZone: "Europe/Vienna",
+		Labels:   map[string]string{"type": "nightly"},
+	}, receivedSchedules[0])
+}
+
+// TestCreateScheduleInvalidCron tests whether the error returned by the shipyard controller is shown
+func TestCreateScheduleInvalidCron(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": 400, "message": "invalid schedule: could not parse cron expression"}`))
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	testInvalidInputHelper(`create schedule evaluation --project=sockshop --stage=staging --service=carts --cron=nightly --mock`, "Failed to create schedule: invalid schedule: could not parse cron expression", t)
+}
+
+func TestCreateScheduleMissingSequence(t *testing.T) {
+	testInvalidInputHelper(`create schedule --project=sockshop --stage=staging --service=carts --cron="0 2 * * *" --mock`, "required argument SEQUENCE_NAME not set", t)
+}
+
+func TestCreateScheduleUnknownParameter(t *testing.T) {
+	testInvalidInputHelper(`create schedule evaluation --projectt=sockshop --stage=staging --service=carts --cron="0 2 * * *" --mock`, "unknown flag: --projectt", t)
+}
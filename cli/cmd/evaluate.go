@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate [ slo ]",
+	Short: "Evaluates Keptn resources without triggering a sequence",
+}
+
+func init() {
+	rootCmd.AddCommand(evaluateCmd)
+}
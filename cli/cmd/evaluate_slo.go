@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/keptn/go-utils/pkg/common/fileutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type evaluateSLOStruct struct {
+	SLO          *string
+	SLI          *string
+	Previous     *string
+	OutputFormat *string
+}
+
+var evaluateSLOParams evaluateSLOStruct
+
+var evaluateSLOCmd = &cobra.Command{
+	Use:   "slo",
+	Short: "Evaluates SLI results against an SLO file without triggering an evaluation",
+	Long: `Evaluates SLI results against an SLO file without triggering an evaluation.
+
+The evaluation is done locally, using the same evaluation logic as the lighthouse-service uses for evaluation sequences.
+Therefore, no Keptn installation is needed, and neither is 'keptn auth'.
+This allows testing changes of an SLO file, e.g., in a CI pipeline, before adding it to a project.
+
+* The SLI results (--sli) are a JSON file containing either a list of SLI results, or a get-sli.finished event.
+* The previous evaluations (--previous) are a JSON file containing a list of evaluation.finished events, ordered from the most recent to the oldest one.
+  They are used for criteria comparing the SLI results with previous results.
+
+The command fails if the evaluation result is 'fail'.
+`,
+	Example: `keptn evaluate slo --slo=slo.yaml --sli=results.json
+RESULT    SCORE
+pass      100
+
+SLI                VALUE     COMPARED VALUE  STATUS    SCORE     VIOLATED CRITERIA
+response_time_p95  500       0               pass      1         -
+
+keptn evaluate slo --slo=slo.yaml --sli=results.json --previous=previous.json --output=json  # Returns the evaluation.finished event data in JSON format
+`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if *evaluateSLOParams.OutputFormat != "" {
+			if *evaluateSLOParams.OutputFormat != "yaml" && *evaluateSLOParams.OutputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		request, err := newDryRunEvaluationRequest(*evaluateSLOParams.SLO, *evaluateSLOParams.SLI, *evaluateSLOParams.Previous)
+		if err != nil {
+			return err
+		}
+
+		evaluationResult, err := evaluation.EvaluateDryRun(*request)
+		if err != nil {
+			return fmt.Errorf("Failed to evaluate SLO: %v", err)
+		}
+
+		output, err := formatEvaluationResult(evaluationResult, *evaluateSLOParams.OutputFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+
+		if evaluationResult.Result == keptnv2.ResultFailed {
+			return errors.New("SLO evaluation failed")
+		}
+		return nil
+	},
+}
+
+func newDryRunEvaluationRequest(sloFile, sliFile, previousFile string) (*evaluation.DryRunRequest, error) {
+	request := &evaluation.DryRunRequest{}
+
+	slo, err := fileutils.ReadFile(sloFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read SLO file %s: %v", sloFile, err)
+	}
+	request.SLO = string(slo)
+
+	sliContent, err := fileutils.ReadFile(sliFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read SLI results file %s: %v", sliFile, err)
+	}
+	if err := parseSLIResults(sliContent, request); err != nil {
+		return nil, fmt.Errorf("Failed to parse SLI results file %s: %v", sliFile, err)
+	}
+
+	if previousFile != "" {
+		previousContent, err := fileutils.ReadFile(previousFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read previous evaluations file %s: %v", previousFile, err)
+		}
+		request.PreviousEvaluations, err = parsePreviousEvaluations(previousContent)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse previous evaluations file %s: %v", previousFile, err)
+		}
+	}
+	return request, nil
+}
+
+// parseSLIResults accepts either a list of SLI results, or a get-sli.finished event (or its data)
+func parseSLIResults(content []byte, request *evaluation.DryRunRequest) error {
+	if strings.HasPrefix(strings.TrimSpace(string(content)), "[") {
+		return json.Unmarshal(content, &request.IndicatorValues)
+	}
+
+	eventData := &keptnv2.GetSLIFinishedEventData{}
+	if err := json.Unmarshal(unwrapEventData(content), eventData); err != nil {
+		return err
+	}
+	request.IndicatorValues = eventData.GetSLI.IndicatorValues
+	request.Project = eventData.Project
+	request.Stage = eventData.Stage
+	request.Service = eventData.Service
+	request.Labels = eventData.Labels
+	request.Start = eventData.GetSLI.Start
+	request.End = eventData.GetSLI.End
+	return nil
+}
+
+// parsePreviousEvaluations accepts a list of evaluation.finished events (or their data)
+func parsePreviousEvaluations(content []byte) ([]*keptnv2.EvaluationFinishedEventData, error) {
+	var events []json.RawMessage
+	if err := json.Unmarshal(content, &events); err != nil {
+		return nil, err
+	}
+
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{}
+	for _, event := range events {
+		eventData := &keptnv2.EvaluationFinishedEventData{}
+		if err := json.Unmarshal(unwrapEventData(event), eventData); err != nil {
+			return nil, err
+		}
+		previousEvaluations = append(previousEvaluations, eventData)
+	}
+	return previousEvaluations, nil
+}
+
+// unwrapEventData returns the data of the given event. If the content is not an event, it is returned as it is
+func unwrapEventData(content []byte) []byte {
+	event := struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(content, &event); err != nil || event.Type == "" || len(event.Data) == 0 {
+		return content
+	}
+	return event.Data
+}
+
+func formatEvaluationResult(evaluationResult *evaluation.EvaluationFinishedEventData, outputFormat string) (string, error) {
+	switch strings.ToLower(outputFormat) {
+	case "yaml":
+		// the go-utils types only provide json tags, which are preserved by converting the result to a generic map first
+		jsonBytes, err := json.Marshal(evaluationResult)
+		if err != nil {
+			return "", err
+		}
+		var result map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &result); err != nil {
+			return "", err
+		}
+		yamlBytes, err := yaml.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	case "json":
+		jsonBytes, err := json.MarshalIndent(evaluationResult, "", "   ")
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes) + "\n", nil
+	}
+
+	sb := &strings.Builder{}
+	w := new(tabwriter.Writer)
+	w.Init(sb, 10, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "RESULT\tSCORE")
+	fmt.Fprintf(w, "%s\t%v\n", evaluationResult.Evaluation.Result, evaluationResult.Evaluation.Score)
+	if len(evaluationResult.Evaluation.Groups) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "GROUP\tWEIGHT\tSCORE\tRESULT\tOBJECTIVES")
+		for _, group := range evaluationResult.Evaluation.Groups {
+			fmt.Fprintf(w, "%s\t%d\t%v\t%s\t%s\n", group.Name, group.Weight, group.Score, group.Result, orDash(strings.Join(group.Objectives, ", ")))
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "SLI\tVALUE\tCOMPARED VALUE\tSTATUS\tSCORE\tVIOLATED CRITERIA")
+	for _, indicatorResult := range evaluationResult.Evaluation.IndicatorResults {
+		if indicatorResult == nil || indicatorResult.Value == nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%v\t%v\t%s\t%v\t%s\n",
+			indicatorResult.Value.Metric,
+			indicatorResult.Value.Value,
+			indicatorResult.Value.ComparedValue,
+			indicatorResult.Status,
+			indicatorResult.Score,
+			orDash(getViolatedCriteria(indicatorResult)),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	if evaluationResult.Message != "" {
+		sb.WriteString("\n" + evaluationResult.Message + "\n")
+	}
+	return sb.String(), nil
+}
+
+func getViolatedCriteria(indicatorResult *keptnv2.SLIEvaluationResult) string {
+	violatedCriteria := []string{}
+	for _, target := range append(indicatorResult.PassTargets, indicatorResult.WarningTargets...) {
+		if target != nil && target.Violated {
+			violatedCriteria = append(violatedCriteria, target.Criteria)
+		}
+	}
+	return strings.Join(violatedCriteria, ", ")
+}
+
+func init() {
+	evaluateCmd.AddCommand(evaluateSLOCmd)
+
+	evaluateSLOParams.SLO = evaluateSLOCmd.Flags().StringP("slo", "", "", "The SLO file to evaluate")
+	evaluateSLOCmd.MarkFlagRequired("slo")
+
+	evaluateSLOParams.SLI = evaluateSLOCmd.Flags().StringP("sli", "", "", "A JSON file containing the SLI results")
+	evaluateSLOCmd.MarkFlagRequired("sli")
+
+	evaluateSLOParams.Previous = evaluateSLOCmd.Flags().StringP("previous", "", "", "A JSON file containing the previous evaluation.finished events")
+
+	evaluateSLOParams.OutputFormat = evaluateSLOCmd.Flags().StringP("output", "o", "", "Output format. One of json|yaml")
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/stretchr/testify/require"
+)
+
+const evaluateSLOTestSLO = `spec_version: "1.0"
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<600"
+          - "<=+10%"
+total_score:
+  pass: "90%"
+`
+
+const evaluateSLOTestGetSLIFinishedEvent = `{
+	"type": "sh.keptn.event.get-sli.finished",
+	"data": {
+		"project": "sockshop",
+		"stage": "staging",
+		"service": "carts",
+		"get-sli": {
+			"start": "2022-03-01T12:00:00Z",
+			"end": "2022-03-01T12:05:00Z",
+			"indicatorValues": [{"metric": "response_time_p95", "value": 500, "success": true}]
+		}
+	}
+}`
+
+const evaluateSLOTestPreviousEvaluations = `[
+	{
+		"type": "sh.keptn.event.evaluation.finished",
+		"data": {"result": "pass", "evaluation": {"indicatorResults": [{"value": {"metric": "response_time_p95", "value": 400, "success": true}}]}}
+	},
+	{"result": "fail", "evaluation": {"indicatorResults": [{"value": {"metric": "response_time_p95", "value": 900, "success": true}}]}}
+]`
+
+func writeEvaluateSLOTestFile(t *testing.T, name string, content string) string {
+	file := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+// TestEvaluateSLO tests whether the SLI results are evaluated locally against the SLO, taking the previous evaluations into account
+func TestEvaluateSLO(t *testing.T) {
+	sloFile := writeEvaluateSLOTestFile(t, "slo.yaml", evaluateSLOTestSLO)
+	sliFile := writeEvaluateSLOTestFile(t, "results.json", evaluateSLOTestGetSLIFinishedEvent)
+	previousFile := writeEvaluateSLOTestFile(t, "previous.json", evaluateSLOTestPreviousEvaluations)
+
+	// no comparison is possible without previous evaluations, hence only the fixed threshold is evaluated
+	_, err := executeActionCommandC("evaluate slo --slo=" + sloFile + " --sli=" + sliFile + " --previous= --output=")
+	require.Nil(t, err)
+
+	// the response time exceeds the one of the most recent previous evaluation by more than 10%
+	_, err = executeActionCommandC("evaluate slo --slo=" + sloFile + " --sli=" + sliFile + " --previous=" + previousFile + " --output=json")
+	require.EqualError(t, err, "SLO evaluation failed")
+
+	invalidSLOFile := writeEvaluateSLOTestFile(t, "slo.yaml", "objectives:\n  - sli: response_time_p95\n    pass:\n      - criteria:\n          - \"<600\"\n")
+	_, err = executeActionCommandC("evaluate slo --slo=" + invalidSLOFile + " --sli=" + sliFile + " --previous= --output=")
+	require.EqualError(t, err, "Failed to evaluate SLO: no target score defined")
+}
+
+func TestNewDryRunEvaluationRequest(t *testing.T) {
+	sloFile := writeEvaluateSLOTestFile(t, "slo.yaml", evaluateSLOTestSLO)
+	sliFile := writeEvaluateSLOTestFile(t, "results.json", evaluateSLOTestGetSLIFinishedEvent)
+	previousFile := writeEvaluateSLOTestFile(t, "previous.json", evaluateSLOTestPreviousEvaluations)
+
+	request, err := newDryRunEvaluationRequest(sloFile, sliFile, previousFile)
+	require.Nil(t, err)
+	require.Equal(t, evaluateSLOTestSLO, request.SLO)
+	require.Equal(t, "sockshop", request.Project)
+	require.Equal(t, "2022-03-01T12:05:00Z", request.End)
+	require.Equal(t, []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}}, request.IndicatorValues)
+	require.Len(t, request.PreviousEvaluations, 2)
+	require.Equal(t, keptnv2.ResultPass, request.PreviousEvaluations[0].Result)
+	require.Equal(t, 900.0, request.PreviousEvaluations[1].Evaluation.IndicatorResults[0].Value.Value)
+
+	request, err = newDryRunEvaluationRequest(sloFile, sliFile, "")
+	require.Nil(t, err)
+	require.Empty(t, request.PreviousEvaluations)
+
+	_, err = newDryRunEvaluationRequest(sloFile, filepath.Join(t.TempDir(), "unknown.json"), "")
+	require.NotNil(t, err)
+}
+
+func TestParseSLIResults(t *testing.T) {
+	request := &evaluation.DryRunRequest{}
+	require.Nil(t, parseSLIResults([]byte(`[{"metric": "error_rate", "value": 0.5, "success": true}]`), request))
+	require.Equal(t, []*keptnv2.SLIResult{{Metric: "error_rate", Value: 0.5, Success: true}}, request.IndicatorValues)
+	require.Empty(t, request.Project)
+
+	request = &evaluation.DryRunRequest{}
+	require.Nil(t, parseSLIResults([]byte(`{"project": "sockshop", "get-sli": {"indicatorValues": [{"metric": "error_rate", "value": 1}]}}`), request))
+	require.Equal(t, []*keptnv2.SLIResult{{Metric: "error_rate", Value: 1}}, request.IndicatorValues)
+	require.Equal(t, "sockshop", request.Project)
+
+	require.NotNil(t, parseSLIResults([]byte(`not json`), &evaluation.DryRunRequest{}))
+}
+
+func TestFormatEvaluationResult(t *testing.T) {
+	evaluationResult := &evaluation.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{Result: keptnv2.ResultWarning, Message: "Evaluation returned a warning"},
+		Evaluation: evaluation.EvaluationDetails{EvaluationDetails: keptnv2.EvaluationDetails{
+			Result: "warning",
+			Score:  75,
+			IndicatorResults: []*keptnv2.SLIEvaluationResult{
+				{
+					Score:  0.5,
+					Status: "warning",
+					Value:  &keptnv2.SLIResult{Metric: "response_time_p95", Value: 500, ComparedValue: 400, Success: true},
+					PassTargets: []*keptnv2.SLITarget{
+						{Criteria: "<=+10%", TargetValue: 440, Violated: true},
+						{Criteria: "<600", TargetValue: 600},
+					},
+				},
+				{
+					Score:  1,
+					Status: "pass",
+					Value:  &keptnv2.SLIResult{Metric: "error_rate", Value: 0, Success: true},
+				},
+			},
+		}},
+	}
+
+	output, err := formatEvaluationResult(evaluationResult, "")
+	require.Nil(t, err)
+	require.Equal(t, `RESULT    SCORE
+warning   75
+
+SLI                VALUE     COMPARED VALUE  STATUS    SCORE     VIOLATED CRITERIA
+response_time_p95  500       400             warning   0.5       <=+10%
+error_rate         0         0               pass      1         -
+
+Evaluation returned a warning
+`, output)
+
+	evaluationResult.Evaluation.Groups = []*evaluation.SLOGroupResult{
+		{Name: "latency", Weight: 2, Score: 50, Result: "warning", Objectives: []string{"response_time_p95"}},
+		{Name: "errors", Weight: 1, Score: 100, Result: "pass", Objectives: []string{"error_rate"}},
+	}
+	output, err = formatEvaluationResult(evaluationResult, "")
+	require.Nil(t, err)
+	require.Contains(t, output, `GROUP     WEIGHT    SCORE     RESULT    OBJECTIVES
+latency   2         50        warning   response_time_p95
+errors    1         100       pass      error_rate
+`)
+
+	output, err = formatEvaluationResult(evaluationResult, "yaml")
+	require.Nil(t, err)
+	require.Contains(t, output, "indicatorResults:")
+	require.Contains(t, output, "comparedValue: 400")
+	require.Contains(t, output, "groups:")
+}
+
+// TestEvaluateSLOInvalidOutputFormat
+func TestEvaluateSLOInvalidOutputFormat(t *testing.T) {
+	testInvalidInputHelper("evaluate slo --slo=slo.yaml --sli=results.json --output=xml", "Invalid output format, only yaml or json allowed", t)
+}
+
+// TestEvaluateSLOUnknownParameter
+func TestEvaluateSLOUnknownParameter(t *testing.T) {
+	testInvalidInputHelper("evaluate slo --sloo=slo.yaml --sli=results.json", "unknown flag: --sloo", t)
+}
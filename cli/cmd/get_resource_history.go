@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type getResourceHistoryStruct struct {
+	Project      *string
+	Stage        *string
+	Service      *string
+	Resource     *string
+	OutputFormat *string
+}
+
+var getResourceHistoryParams getResourceHistoryStruct
+
+var getResourceHistoryCmd = &cobra.Command{
+	Use:   "resource-history",
+	Short: "Get the history of a resource",
+	Long: `Get the commits that changed a resource of a project, stage, or service, starting with the most recent one.
+The commit IDs can be used to retrieve or restore a former revision of the resource.`,
+	Example: `keptn get resource-history --project=sockshop --stage=dev --service=carts --resource=helm/carts.tgz
+COMMIT                                    TIME                  AUTHOR     MESSAGE
+3f2a1c0b9e8d7f6a5b4c3d2e1f0a9b8c7d6e5f4a  2022-03-02T09:30:00Z  keptn      Updated resource
+9b8c7d6e5f4a3f2a1c0b9e8d7f6a5b4c3d2e1f0a  2022-03-01T12:00:00Z  keptn      Added resource
+
+keptn get resource-history --project=sockshop --resource=shipyard.yaml --output=yaml  # Returns the history of a project resource in YAML format
+
+keptn get resource-history --project=sockshop --stage=dev --resource=slo.yaml --output=json  # Returns the history of a stage resource in JSON format
+`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if *getResourceHistoryParams.OutputFormat != "" {
+			if *getResourceHistoryParams.OutputFormat != "yaml" && *getResourceHistoryParams.OutputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+		if *getResourceHistoryParams.Service != "" && *getResourceHistoryParams.Stage == "" {
+			return errors.New("Flag 'stage' is required when the resource belongs to a service")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := getEndpointAndToken()
+		if err != nil {
+			return err
+		}
+		resourceHandler := internal.NewResourceHandler(endPoint.String(), apiToken)
+
+		commits, err := resourceHandler.GetResourceHistory(
+			*getResourceHistoryParams.Project,
+			*getResourceHistoryParams.Stage,
+			*getResourceHistoryParams.Service,
+			*getResourceHistoryParams.Resource,
+		)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve history of resource %s: %v", *getResourceHistoryParams.Resource, internal.OnAPIError(err))
+		}
+
+		output, err := formatResourceHistory(commits, *getResourceHistoryParams.OutputFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	},
+}
+
+func formatResourceHistory(commits []internal.ResourceCommit, outputFormat string) (string, error) {
+	switch strings.ToLower(outputFormat) {
+	case "yaml":
+		yamlBytes, err := yaml.Marshal(internal.ResourceHistory{Commits: commits})
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	case "json":
+		jsonBytes, err := json.MarshalIndent(internal.ResourceHistory{Commits: commits}, "", "   ")
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes) + "\n", nil
+	}
+
+	if len(commits) == 0 {
+		return "No history found\n", nil
+	}
+
+	sb := &strings.Builder{}
+	w := new(tabwriter.Writer)
+	w.Init(sb, 10, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMIT\tTIME\tAUTHOR\tMESSAGE")
+	for _, commit := range commits {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			commit.CommitID,
+			commit.Timestamp.UTC().Format(time.RFC3339),
+			orDash(commit.Author),
+			orDash(strings.SplitN(commit.Message, "\n", 2)[0]),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func init() {
+	getCmd.AddCommand(getResourceHistoryCmd)
+
+	getResourceHistoryParams.Project = getResourceHistoryCmd.Flags().StringP("project", "p", "", "The Keptn project the resource belongs to")
+	getResourceHistoryCmd.MarkFlagRequired("project")
+
+	getResourceHistoryParams.Stage = getResourceHistoryCmd.Flags().StringP("stage", "s", "", "The stage the resource belongs to. Omit it for project resources")
+	getResourceHistoryParams.Service = getResourceHistoryCmd.Flags().StringP("service", "", "", "The service the resource belongs to. Omit it for project and stage resources")
+
+	getResourceHistoryParams.Resource = getResourceHistoryCmd.Flags().StringP("resource", "r", "", "The URI of the resource, e.g. helm/carts.tgz")
+	getResourceHistoryCmd.MarkFlagRequired("resource")
+
+	getResourceHistoryParams.OutputFormat = getResourceHistoryCmd.Flags().StringP("output", "o", "", "Output format. One of json|yaml")
+}
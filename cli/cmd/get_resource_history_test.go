@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetResourceHistory tests whether the history of a resource is retrieved across all pages
+func TestGetResourceHistory(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method != http.MethodGet || r.URL.EscapedPath() != "/configuration-service/v1/project/sockshop/stage/dev/service/carts/resource/helm%2Fcarts.tgz/history" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": 404, "message": "Resource not found"}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			if r.URL.Query().Get("nextPageKey") == "" {
+				w.Write([]byte(`{"nextPageKey": "1", "pageSize": 1, "totalCount": 2, "commits": [{"commitID": "commit-2", "author": "keptn", "message": "Updated resource", "timestamp": "2022-03-02T09:30:00Z"}]}`))
+				return
+			}
+			w.Write([]byte(`{"nextPageKey": "0", "pageSize": 1, "totalCount": 2, "commits": [{"commitID": "commit-1", "author": "keptn", "message": "Added resource", "timestamp": "2022-03-01T12:00:00Z"}]}`))
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	commits, err := internal.NewResourceHandler(ts.URL, "").GetResourceHistory("sockshop", "dev", "carts", "helm/carts.tgz")
+	require.Nil(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, "commit-2", commits[0].CommitID)
+	require.Equal(t, "commit-1", commits[1].CommitID)
+
+	_, err = executeActionCommandC("get resource-history --project=sockshop --stage=dev --service=carts --resource=helm/carts.tgz --mock")
+	require.Nil(t, err)
+
+	_, err = executeActionCommandC("get resource-history --project=sockshop --stage=dev --service=carts --resource=values.yaml --mock")
+	require.EqualError(t, err, "Failed to retrieve history of resource values.yaml: Resource not found")
+}
+
+func TestGetResourceHistoryInvalidInput(t *testing.T) {
+	defer func() {
+		*getResourceHistoryParams.OutputFormat = ""
+		*getResourceHistoryParams.Stage = ""
+		*getResourceHistoryParams.Service = ""
+	}()
+	testInvalidInputHelper("get resource-history --project=sockshop --resource=shipyard.yaml --output=xml --mock", "Invalid output format, only yaml or json allowed", t)
+	*getResourceHistoryParams.OutputFormat = ""
+	*getResourceHistoryParams.Stage = ""
+	testInvalidInputHelper("get resource-history --project=sockshop --service=carts --resource=values.yaml --mock", "Flag 'stage' is required when the resource belongs to a service", t)
+}
+
+func TestFormatResourceHistory(t *testing.T) {
+	commits := []internal.ResourceCommit{
+		{
+			CommitID:  "commit-2",
+			Author:    "keptn",
+			Message:   "Reverted resource values.yaml to commit-1\n\ndetails",
+			Timestamp: time.Date(2022, 3, 2, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			CommitID:  "commit-1",
+			Message:   "Added resource",
+			Timestamp: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	output, err := formatResourceHistory(commits, "")
+	require.Nil(t, err)
+	require.Equal(t, "COMMIT    TIME                  AUTHOR    MESSAGE\n"+
+		"commit-2  2022-03-02T09:30:00Z  keptn     Reverted resource values.yaml to commit-1\n"+
+		"commit-1  2022-03-01T12:00:00Z  -         Added resource\n", output)
+
+	output, err = formatResourceHistory(nil, "")
+	require.Nil(t, err)
+	require.Equal(t, "No history found\n", output)
+
+	output, err = formatResourceHistory(commits, "yaml")
+	require.Nil(t, err)
+	require.Contains(t, output, "commitID: commit-1")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type getSchedulesStruct struct {
+	Project      *string
+	Stage        *string
+	Service      *string
+	OutputFormat *string
+}
+
+var getSchedulesParams getSchedulesStruct
+
+var getSchedulesCmd = &cobra.Command{
+	Use:     "schedules",
+	Aliases: []string{"schedule"},
+	Short:   "Get the schedules of a project",
+	Long:    `Get the schedules that periodically trigger sequences in a project, optionally filtered by stage and service`,
+	Example: `keptn get schedules --project=sockshop
+ID                                    SEQUENCE     STAGE    SERVICE  CRON       NEXT TRIGGER          LAST TRIGGERED
+4ec66f36-6ad4-4a4a-9c0a-7d0b8e1b2c3d  evaluation   staging  carts    0 2 * * *  2022-03-02T02:00:00Z  2022-03-01T02:00:00Z
+
+keptn get schedules --project=sockshop --stage=staging --service=carts
+
+keptn get schedules --project=sockshop -output=yaml  # Returns the schedules in YAML format
+
+keptn get schedules --project=sockshop -output=json  # Returns the schedules in JSON format
+`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if *getSchedulesParams.OutputFormat != "" {
+			if *getSchedulesParams.OutputFormat != "yaml" && *getSchedulesParams.OutputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scheduleHandler, err := newScheduleHandler()
+		if err != nil {
+			return err
+		}
+
+		schedules, err := scheduleHandler.GetSchedules(*getSchedulesParams.Project, *getSchedulesParams.Stage, *getSchedulesParams.Service)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve schedules of project %s: %v", *getSchedulesParams.Project, internal.OnAPIError(err))
+		}
+
+		output, err := formatSchedules(schedules, *getSchedulesParams.OutputFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	},
+}
+
+func formatSchedules(schedules []internal.Schedule, outputFormat string) (string, error) {
+	switch strings.ToLower(outputFormat) {
+	case "yaml":
+		yamlBytes, err := yaml.Marshal(internal.Schedules{Schedules: schedules})
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	case "json":
+		jsonBytes, err := json.MarshalIndent(internal.Schedules{Schedules: schedules}, "", "   ")
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes) + "\n", nil
+	}
+
+	if len(schedules) == 0 {
+		return "No schedules found\n", nil
+	}
+
+	sb := &strings.Builder{}
+	w := new(tabwriter.Writer)
+	w.Init(sb, 10, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSEQUENCE\tSTAGE\tSERVICE\tCRON\tNEXT TRIGGER\tLAST TRIGGERED")
+	for _, schedule := range schedules {
+		cron := schedule.Cron
+		if schedule.TimeZone != "" {
+			cron = cron + " (" + schedule.TimeZone + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", schedule.ID, schedule.Sequence, schedule.Stage, schedule.Service, cron, formatScheduleTime(schedule.NextTrigger), formatScheduleTime(schedule.LastTriggered))
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func formatScheduleTime(t *time.Time) string {
+	if t == nil {
+		return "n/a"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func init() {
+	getCmd.AddCommand(getSchedulesCmd)
+
+	getSchedulesParams.Project = getSchedulesCmd.Flags().StringP("project", "", "", "The name of the project")
+	getSchedulesCmd.MarkFlagRequired("project")
+
+	getSchedulesParams.Stage = getSchedulesCmd.Flags().StringP("stage", "", "", "Only return the schedules of this stage")
+	getSchedulesParams.Service = getSchedulesCmd.Flags().StringP("service", "", "", "Only return the schedules of this service")
+	getSchedulesParams.OutputFormat = getSchedulesCmd.Flags().StringP("output", "o", "", "Output format. One of json|yaml")
+}
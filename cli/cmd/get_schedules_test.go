@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+const getSchedulesMockResponse = `{
+	"schedules": [
+		{
+			"id": "my-schedule",
+			"project": "sockshop",
+			"stage": "staging",
+			"service": "carts",
+			"sequence": "evaluation",
+			"cron": "0 2 * * *",
+			"createdAt": "2022-03-01T12:00:00Z",
+			"nextTrigger": "2022-03-02T02:00:00Z"
+		}
+	]
+}`
+
+func init() {
+	logging.InitLoggers(os.Stdout, os.Stdout, os.Stderr)
+}
+
+// TestGetSchedules tests whether the schedules are retrieved with the given filter
+func TestGetSchedules(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	defer func() {
+		*getSchedulesParams.Stage = ""
+		*getSchedulesParams.Service = ""
+	}()
+
+	receivedQueries := []string{}
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method != http.MethodGet || r.URL.Path != "/controlPlane/v1/project/sockshop/schedule" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code": 404, "message": "project not found"}`))
+				return
+			}
+			receivedQueries = append(receivedQueries, r.URL.RawQuery)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(getSchedulesMockResponse))
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	_, err := executeActionCommandC("get schedules --project=sockshop --stage=staging --service=carts --mock")
+	require.Nil(t, err)
+	require.Equal(t, []string{"service=carts&stage=staging"}, receivedQueries)
+
+	_, err = executeActionCommandC("get schedules --project=unknown --mock")
+	require.EqualError(t, err, "Failed to retrieve schedules of project unknown: project not found")
+}
+
+func TestGetSchedulesInvalidOutputFormat(t *testing.T) {
+	defer func() {
+		*getSchedulesParams.OutputFormat = ""
+	}()
+	testInvalidInputHelper("get schedules --project=sockshop --output=xml --mock", "Invalid output format, only yaml or json allowed", t)
+}
+
+func TestFormatSchedules(t *testing.T) {
+	nextTrigger := time.Date(2022, 3, 2, 2, 0, 0, 0, time.UTC)
+	schedules := []internal.Schedule{
+		{
+			ID:          "my-schedule",
+			Stage:       "staging",
+			Service:     "carts",
+			Sequence:    "evaluation",
+			Cron:        "0 2 * * *",
+			TimeZone:    "Europe/Vienna",
+			NextTrigger: &nextTrigger,
+		},
+	}
+
+	output, err := formatSchedules(schedules, "")
+	require.Nil(t, err)
+	require.Equal(t, "ID           SEQUENCE    STAGE     SERVICE   CRON                       NEXT TRIGGER          LAST TRIGGERED\n"+
+		"my-schedule  evaluation  staging   carts     0 2 * * * (Europe/Vienna)  2022-03-02T02:00:00Z  n/a\n", output)
+
+	output, err = formatSchedules(nil, "")
+	require.Nil(t, err)
+	require.Equal(t, "No schedules found\n", output)
+
+	output, err = formatSchedules(schedules, "yaml")
+	require.Nil(t, err)
+	require.Contains(t, output, "nextTrigger: 2022-03-02T02:00:00Z")
+}
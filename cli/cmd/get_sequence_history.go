@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type getSequenceHistoryStruct struct {
+	Project      *string
+	KeptnContext *string
+	OutputFormat *string
+}
+
+var getSequenceHistoryParams getSequenceHistoryStruct
+
+var getSequenceHistoryCmd = &cobra.Command{
+	Use:   "sequence-history",
+	Short: "Get the history of a sequence",
+	Long: `Get the state transitions of a sequence in chronological order.
+For each transition, the history shows who or what caused it and why, e.g. that a user paused or aborted the sequence.
+Note that the Keptn API token is shared by all users, hence transitions requested with it are attributed to the user 'keptn-api-token'.`,
+	Example: `keptn get sequence-history --project=sockshop --keptn-context=9a2a6b6b-ef3b-4a1b-a4b2-3e5a3f8e8b5f
+TIME                  STATE      STAGE  SEQUENCE  USER      SOURCE               REASON
+2022-03-01T12:00:00Z  triggered  dev    delivery  -         cli                  -
+2022-03-01T12:00:01Z  started    dev    delivery  -         shipyard-controller  -
+2022-03-01T12:05:00Z  paused     dev    -         jane.doe  api                  waiting for the database migration
+
+keptn get sequence-history --project=sockshop --keptn-context=9a2a6b6b-ef3b-4a1b-a4b2-3e5a3f8e8b5f -output=yaml  # Returns the history in YAML format
+
+keptn get sequence-history --project=sockshop --keptn-context=9a2a6b6b-ef3b-4a1b-a4b2-3e5a3f8e8b5f -output=json  # Returns the history in JSON format
+`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if *getSequenceHistoryParams.OutputFormat != "" {
+			if *getSequenceHistoryParams.OutputFormat != "yaml" && *getSequenceHistoryParams.OutputFormat != "json" {
+				return errors.New("Invalid output format, only yaml or json allowed")
+			}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceHandler, err := newSequenceHandler()
+		if err != nil {
+			return err
+		}
+
+		transitions, err := sequenceHandler.GetSequenceHistory(*getSequenceHistoryParams.Project, *getSequenceHistoryParams.KeptnContext)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve history of sequence %s: %v", *getSequenceHistoryParams.KeptnContext, internal.OnAPIError(err))
+		}
+
+		output, err := formatSequenceHistory(transitions, *getSequenceHistoryParams.OutputFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	},
+}
+
+func formatSequenceHistory(transitions []internal.SequenceStateTransition, outputFormat string) (string, error) {
+	switch strings.ToLower(outputFormat) {
+	case "yaml":
+		yamlBytes, err := yaml.Marshal(internal.SequenceHistory{Transitions: transitions})
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	case "json":
+		jsonBytes, err := json.MarshalIndent(internal.SequenceHistory{Transitions: transitions}, "", "   ")
+		if err != nil {
+			return "", err
+		}
+		return string(jsonBytes) + "\n", nil
+	}
+
+	if len(transitions) == 0 {
+		return "No history found\n", nil
+	}
+
+	sb := &strings.Builder{}
+	w := new(tabwriter.Writer)
+	w.Init(sb, 10, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tSTATE\tSTAGE\tSEQUENCE\tUSER\tSOURCE\tREASON")
+	for _, transition := range transitions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			transition.Time.Format(time.RFC3339),
+			transition.State,
+			orDash(transition.Stage),
+			orDash(transition.Sequence),
+			orDash(transition.User),
+			orDash(transition.Source),
+			orDash(transition.Reason),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// newSequenceHandler creates a handler for the sequence endpoints of the Keptn API
+func newSequenceHandler() (*internal.SequenceHandler, error) {
+	endPoint, apiToken, err := getEndpointAndToken()
+	if err != nil {
+		return nil, err
+	}
+	return internal.NewSequenceHandler(endPoint.String(), apiToken), nil
+}
+
+func init() {
+	getCmd.AddCommand(getSequenceHistoryCmd)
+
+	getSequenceHistoryParams.Project = getSequenceHistoryCmd.Flags().StringP("project", "p", "", "The Keptn project the sequence belongs to")
+	getSequenceHistoryCmd.MarkFlagRequired("project")
+
+	getSequenceHistoryParams.KeptnContext = getSequenceHistoryCmd.Flags().StringP("keptn-context", "c", "", "The Keptn context of the sequence")
+	getSequenceHistoryCmd.MarkFlagRequired("keptn-context")
+
+	getSequenceHistoryParams.OutputFormat = getSequenceHistoryCmd.Flags().StringP("output", "o", "", "Output format. One of json|yaml")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+const getSequenceHistoryMockResponse = `{
+	"transitions": [
+		{
+			"project": "sockshop",
+			"stage": "dev",
+			"sequence": "delivery",
+			"keptnContext": "my-context",
+			"state": "triggered",
+			"source": "cli",
+			"time": "2022-03-01T12:00:00Z"
+		},
+		{
+			"project": "sockshop",
+			"stage": "dev",
+			"keptnContext": "my-context",
+			"state": "paused",
+			"user": "jane.doe",
+			"source": "api",
+			"reason": "waiting for the database migration",
+			"time": "2022-03-01T12:05:00Z"
+		}
+	]
+}`
+
+// TestGetSequenceHistory tests whether the history of a sequence is retrieved
+func TestGetSequenceHistory(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method != http.MethodGet || r.URL.Path != "/controlPlane/v1/sequence/sockshop/my-context/history" {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"code": 500, "message": "Unable to query sequence history: oops"}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(getSequenceHistoryMockResponse))
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	_, err := executeActionCommandC("get sequence-history --project=sockshop --keptn-context=my-context --mock")
+	require.Nil(t, err)
+
+	_, err = executeActionCommandC("get sequence-history --project=sockshop --keptn-context=other-context --mock")
+	require.EqualError(t, err, "Failed to retrieve history of sequence other-context: Unable to query sequence history: oops")
+}
+
+func TestGetSequenceHistoryInvalidOutputFormat(t *testing.T) {
+	defer func() {
+		*getSequenceHistoryParams.OutputFormat = ""
+	}()
+	testInvalidInputHelper("get sequence-history --project=sockshop --keptn-context=my-context --output=xml --mock", "Invalid output format, only yaml or json allowed", t)
+}
+
+func TestFormatSequenceHistory(t *testing.T) {
+	transitions := []internal.SequenceStateTransition{
+		{
+			Stage:    "dev",
+			Sequence: "delivery",
+			State:    "triggered",
+			Source:   "cli",
+			Time:     time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			Stage:  "dev",
+			State:  "paused",
+			User:   "jane.doe",
+			Source: "api",
+			Reason: "waiting for the database migration",
+			Time:   time.Date(2022, 3, 1, 12, 5, 0, 0, time.UTC),
+		},
+	}
+
+	output, err := formatSequenceHistory(transitions, "")
+	require.Nil(t, err)
+	require.Equal(t, "TIME                  STATE      STAGE     SEQUENCE  USER      SOURCE    REASON\n"+
+		"2022-03-01T12:00:00Z  triggered  dev       delivery  -         cli       -\n"+
+		"2022-03-01T12:05:00Z  paused     dev       -         jane.doe  api       waiting for the database migration\n", output)
+
+	output, err = formatSequenceHistory(nil, "")
+	require.Nil(t, err)
+	require.Equal(t, "No history found\n", output)
+
+	output, err = formatSequenceHistory(transitions, "json")
+	require.Nil(t, err)
+	require.Contains(t, output, `"user": "jane.doe"`)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/spf13/cobra"
+)
+
+type restartSequenceStruct struct {
+	sequenceControlStruct
+	fromTask *string
+	reason   *string
+}
+
+var restartSequenceParams restartSequenceStruct
+
+var restartSequenceCmd = &cobra.Command{
+	Use:   "sequence",
+	Short: "Restarts the execution of a finished sequence in a stage, starting with a given task",
+	Long: `Restarts the execution of a finished sequence in a stage, starting with a given task.
+
+The sequence keeps its Keptn context. The results of the tasks that have been executed before the given task are passed on to the restarted tasks,
+i.e., they are not executed again. If no task is given, the sequence is restarted from the task that has failed or timed out.
+If the task is part of a parallel group, the sequence is restarted from the first task of the group.`,
+	Example: `keptn restart sequence --project=sockshop --keptn-context=9a2a6b6b-ef3b-4a1b-a4b2-3e5a3f8e8b5f --stage=staging --from-task=test
+
+keptn restart sequence --project=sockshop --keptn-context=9a2a6b6b-ef3b-4a1b-a4b2-3e5a3f8e8b5f --stage=staging --reason="fixed the test data"  # Restarts the sequence from the failed task`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sequenceHandler, err := newSequenceHandler()
+		if err != nil {
+			return err
+		}
+
+		err = sequenceHandler.ControlSequence(*restartSequenceParams.project, *restartSequenceParams.keptnContext, internal.SequenceControlCommand{
+			State:    string(restartSequence),
+			Stage:    *restartSequenceParams.stage,
+			FromTask: *restartSequenceParams.fromTask,
+			Reason:   *restartSequenceParams.reason,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to restart sequence %s: %v", *restartSequenceParams.keptnContext, internal.OnAPIError(err))
+		}
+		fmt.Println("Successfully restarted sequence")
+		return nil
+	},
+}
+
+func init() {
+	restartCmd.AddCommand(restartSequenceCmd)
+	restartSequenceParams.keptnContext = restartSequenceCmd.Flags().StringP("keptn-context", "c", "",
+		"The Keptn context the sequence execution is bound to")
+	restartSequenceParams.project = restartSequenceCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the sequence belongs to")
+	restartSequenceParams.stage = restartSequenceCmd.Flags().StringP("stage", "s", "",
+		"The Keptn stage in which the sequence shall be restarted")
+	restartSequenceParams.fromTask = restartSequenceCmd.Flags().StringP("from-task", "t", "",
+		"The task from which the sequence shall be restarted. Defaults to the task that has failed or timed out")
+	restartSequenceParams.reason = restartSequenceCmd.Flags().StringP("reason", "r", "",
+		"The reason for restarting the sequence, which is stored in its history")
+	restartSequenceCmd.MarkFlagRequired("keptn-context")
+	restartSequenceCmd.MarkFlagRequired("project")
+	restartSequenceCmd.MarkFlagRequired("stage")
+}
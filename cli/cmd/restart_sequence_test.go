@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRestartSequence tests whether the restart of a sequence is requested
+func TestRestartSequence(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	var receivedCommand internal.SequenceControlCommand
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method != http.MethodPost || r.URL.Path != "/controlPlane/v1/sequence/sockshop/my-context/control" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code": 400, "message": "Unable to control sequence: sequence cannot be restarted: sequence delivery is still active in stage staging"}`))
+				return
+			}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(&receivedCommand))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		}),
+	)
+	defer ts.Close()
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	_, err := executeActionCommandC("restart sequence --project=sockshop --keptn-context=my-context --stage=staging --from-task=test --reason=flaky --mock")
+	require.Nil(t, err)
+	require.Equal(t, internal.SequenceControlCommand{State: "restart", Stage: "staging", FromTask: "test", Reason: "flaky"}, receivedCommand)
+
+	_, err = executeActionCommandC("restart sequence --project=sockshop --keptn-context=other-context --stage=staging --mock")
+	require.EqualError(t, err, "Failed to restart sequence other-context: Unable to control sequence: sequence cannot be restarted: sequence delivery is still active in stage staging")
+}
+
+// TestRestartSequenceUnknownCommand
+func TestRestartSequenceUnknownCommand(t *testing.T) {
+	testInvalidInputHelper("restart sequence someUnknownCommand --project=sockshop --keptn-context=djsfjdfdsjjcs --stage=dev", "unknown command \"someUnknownCommand\" for \"keptn restart sequence\"", t)
+}
+
+// TestRestartSequenceUnknownParameter
+func TestRestartSequenceUnknownParmeter(t *testing.T) {
+	testInvalidInputHelper("restart sequence --projectt=sockshop --keptn-context=djsfjdfdsjjcs --stage=dev", "unknown flag: --projectt", t)
+}
@@ -19,6 +19,8 @@ const (
 	pauseSequence  SequenceState = "pause"
 	resumeSequence SequenceState = "resume"
 	abortSequence  SequenceState = "abort"
+	// restartSequence is not supported by the go-utils API set, since it requires the task from which the sequence is restarted
+	restartSequence SequenceState = "restart"
 )
 
 func AbortSequence(params sequenceControlStruct) error {
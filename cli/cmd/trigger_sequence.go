@@ -22,6 +22,14 @@ type sequenceStruct struct {
 	Project  *string `json:"project"`
 	Service  *string `json:"service"`
 	Stage    *string `json:"stage"`
+	Priority *int    `json:"priority"`
+}
+
+// sequenceTriggeredEventData is the payload of the event that triggers the sequence
+type sequenceTriggeredEventData struct {
+	keptnv2.EventData
+	// Priority of the sequence. Sequences with a higher priority are started before queued sequences with a lower priority
+	Priority int `json:"priority,omitempty"`
 }
 
 var sequence = sequenceStruct{}
@@ -60,7 +68,8 @@ var triggerSequenceCmd = &cobra.Command{
 	Long: `Triggers the execution of any sequence in a project with an arbitrary name.
 The name of the sequence has to be provided as an argument to the command. The sequence name is used to identify the sequence to be triggered.
 `,
-	Example:      `keptn trigger sequence <sequence-name> --project=<project> --service=<service> --stage=<stage>`,
+	Example: `keptn trigger sequence <sequence-name> --project=<project> --service=<service> --stage=<stage>
+keptn trigger sequence <sequence-name> --project=<project> --service=<service> --stage=<stage> --priority=10`,
 	SilenceUsage: true,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -69,6 +78,9 @@ The name of the sequence has to be provided as an argument to the command. The s
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := doTriggerSequencePreRunCheck(sequence); err != nil {
+			return err
+		}
 		return doTriggerSequence(sequence, args[0])
 	},
 }
@@ -104,13 +116,16 @@ func doTriggerSequence(sequenceInputData sequenceStruct, sequenceName string) er
 		return fmt.Errorf("could not start sequence because service %s has not been found in project %s", *sequenceInputData.Service, *sequenceInputData.Project)
 	}
 
-	triggeredEvent := keptnv2.DeploymentTriggeredEventData{
+	triggeredEvent := sequenceTriggeredEventData{
 		EventData: keptnv2.EventData{
 			Project: *sequenceInputData.Project,
 			Stage:   *sequenceInputData.Stage,
 			Service: *sequenceInputData.Service,
 		},
 	}
+	if sequenceInputData.Priority != nil {
+		triggeredEvent.Priority = *sequenceInputData.Priority
+	}
 
 	sdkEvent := cloudevents.NewEvent()
 	sdkEvent.SetID(uuid.New().String())
@@ -155,6 +170,10 @@ func doTriggerSequencePreRunCheck(sequenceInputData sequenceStruct) error {
 		return fmt.Errorf("Stage has to be provided")
 	}
 
+	if sequenceInputData.Priority != nil && *sequenceInputData.Priority < 0 {
+		return fmt.Errorf("Priority must not be negative")
+	}
+
 	return nil
 }
 
@@ -172,4 +191,6 @@ func init() {
 		"The stage in which the new artifact will be triggered")
 	triggerSequenceCmd.MarkFlagRequired("stage")
 
+	sequence.Priority = triggerSequenceCmd.Flags().IntP("priority", "", 0,
+		"The priority of the sequence. Queued sequences with a higher priority are started before sequences with a lower priority")
 }
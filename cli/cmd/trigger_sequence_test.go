@@ -235,3 +235,69 @@ func TestTriggerSequenceFlag(t *testing.T) {
 func TestTriggerSequenceMissing(t *testing.T) {
 	testInvalidInputHelper("trigger sequence --project=proj --service=serv --stage=dev --mock", "required argument sequence-name not set", t)
 }
+
+// TestTriggerSequenceWithPriority tests whether the priority is passed to the triggered event
+func TestTriggerSequenceWithPriority(t *testing.T) {
+
+	credentialmanager.MockAuthCreds = true
+	defer func() {
+		*sequence.Priority = 0
+	}()
+
+	receivedPriority := make(chan interface{})
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+			if strings.Contains(r.RequestURI, "v1/event") {
+				defer r.Body.Close()
+				bytes, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("could not read received event payload: %s", err.Error())
+				}
+				event := &apimodels.KeptnContextExtendedCE{}
+				if err := json.Unmarshal(bytes, event); err != nil {
+					t.Errorf("could not decode received event: %s", err.Error())
+				}
+				data, _ := event.Data.(map[string]interface{})
+				go func() {
+					receivedPriority <- data["priority"]
+				}()
+			} else if strings.Contains(r.RequestURI, "/v1/metadata") {
+				defer r.Body.Close()
+				w.Write([]byte(metadataMockResponse))
+				return
+			} else if strings.Contains(r.RequestURI, "service") {
+				res := fmt.Sprintf(getSvcMockResponseSequence, "demo")
+				w.Write([]byte(res))
+				return
+			}
+		}),
+	)
+	defer ts.Close()
+
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	cmd := fmt.Sprintf("trigger sequence %s --project=%s --service=%s --stage=%s --priority=%d --mock", "hello", "hello-world", "demo", "dev", 5)
+	_, err := executeActionCommandC(cmd)
+
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+
+	select {
+	case priority := <-receivedPriority:
+		if priority != float64(5) {
+			t.Errorf("did not receive correct priority: %v", priority)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("event was not sent")
+	}
+}
+
+func TestTriggerSequenceNegativePriority(t *testing.T) {
+	defer func() {
+		*sequence.Priority = 0
+	}()
+	testInvalidInputHelper("trigger sequence seq --project=proj --service=serv --stage=dev --priority=-1 --mock", "Priority must not be negative", t)
+}
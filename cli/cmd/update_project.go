@@ -29,6 +29,7 @@ type updateProjectCmdParams struct {
 	GitProxyPassword  *string
 	GitPemCertificate *string
 	InsecureSkipTLS   *bool
+	StageLayout       *string
 }
 
 var updateProjectParams *updateProjectCmdParams
@@ -47,6 +48,9 @@ together with private key (*--git-private-key*) or access token (*--git-token*).
 used scheme (*--git-proxy-scheme=*) to connect to proxy. Please be aware that authentication with public/private key and via proxy is 
 supported only when using resource-service.
 
+The project can be migrated to another stage layout with *--stage-layout*, i.e. its stages are converted from branches (*branch*) to directories
+within the default branch (*directory*) of the Git repository, or back. The history of the project is preserved, and the upstream repository is updated.
+
 For more information about updating projects or upstream repositories, please go to [Manage Keptn](https://keptn.sh/docs/` + getReleaseDocsURL() + `/manage/)
 `,
 	Example: `keptn update project PROJECTNAME --git-token=GIT_TOKEN --git-remote-url=GIT_REMOTE_URL
@@ -55,6 +59,10 @@ or (if the git repo requires user)
 
 keptn update project PROJECTNAME --git-user=GIT_USER --git-token=GIT_TOKEN --git-remote-url=GIT_REMOTE_URL
 
+or (to migrate the stages of the project to directories)
+
+keptn update project PROJECTNAME --git-user=GIT_USER --git-token=GIT_TOKEN --git-remote-url=GIT_REMOTE_URL --stage-layout=directory
+
 or (only for resource-service)
 
 keptn update project PROJECTNAME --git-user=GIT_USER --git-remote-url=GIT_REMOTE_URL --git-private-key=PRIVATE_KEY_PATH --git-private-key-pass=PRIVATE_KEY_PASSPHRASE
@@ -84,7 +92,7 @@ keptn update project PROJECTNAME --git-user=GIT_USER --git-remote-url=GIT_REMOTE
 			errorMsg += "Please update project name and try again."
 			return errors.New(errorMsg)
 		}
-		return nil
+		return internal.ValidateStageLayout(*updateProjectParams.StageLayout)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
@@ -152,6 +160,16 @@ keptn update project PROJECTNAME --git-user=GIT_USER --git-remote-url=GIT_REMOTE
 		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
 
 		if !mocking {
+			// the stage layout is not covered by the go-utils API set, hence projects are migrated by a separate handler
+			if *updateProjectParams.StageLayout != "" {
+				projectHandler := internal.NewProjectHandler(endPoint.String(), apiToken)
+				if err := projectHandler.UpdateProject(internal.Project{CreateProject: project, StageLayout: *updateProjectParams.StageLayout}); err != nil {
+					return fmt.Errorf("Update project was unsuccessful. %s", err.Error())
+				}
+				logging.PrintLog("Project updated successfully", logging.InfoLevel)
+				return nil
+			}
+
 			_, err := api.APIV1().UpdateProject(project)
 			if err != nil {
 				return fmt.Errorf("Update project was unsuccessful. %s", *err.Message)
@@ -186,4 +204,5 @@ func init() {
 	updateProjectParams.InsecureSkipTLS = upProjectCmd.Flags().BoolP("insecure-skip-tls", "x", false, "Disable TLS verification to allow connections to servers using self signed certificates")
 
 	updateProjectParams.GitPemCertificate = upProjectCmd.Flags().StringP("git-pem-certificate", "g", "", "The git PEM Certificate file")
+	updateProjectParams.StageLayout = upProjectCmd.Flags().String("stage-layout", "", "Migrates the project to the given stage layout, i.e. either 'branch' or 'directory'")
 }
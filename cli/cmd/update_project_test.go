@@ -36,6 +36,24 @@ func TestUpdateProjectCmdWithUser(t *testing.T) {
 	}
 }
 
+// TestUpdateProjectCmdWithStageLayout tests an update project command that migrates the project to another stage layout
+func TestUpdateProjectCmdWithStageLayout(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	defer func() {
+		*updateProjectParams.StageLayout = ""
+	}()
+
+	_, err := executeActionCommandC("update project sockshop --git-token=token --git-remote-url=https://some.url --stage-layout=branch --mock")
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+
+	_, err = executeActionCommandC("update project sockshop --git-token=token --git-remote-url=https://some.url --stage-layout=folder --mock")
+	if !errorContains(err, "stage layout must be either 'branch' or 'directory'") {
+		t.Errorf("missing expected error, but got %v", err)
+	}
+}
+
 // TestUpdateProjectIncorrectProjectNameCmd tests whether the update project command aborts
 // due to a project name with upper case character
 func TestUpdateProjectIncorrectProjectNameCmd(t *testing.T) {
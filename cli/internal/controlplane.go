@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// controlPlaneClient sends requests to the endpoints of the control plane services which are not covered by the go-utils API set
+type controlPlaneClient struct {
+	BaseURL    string
+	AuthToken  string
+	HTTPClient *http.Client
+}
+
+func newControlPlaneClient(baseURL string, authToken string) controlPlaneClient {
+	return controlPlaneClient{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		AuthToken:  authToken,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c controlPlaneClient) do(method, path string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payloadBytes)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.AuthToken != "" {
+		req.Header.Set("x-token", c.AuthToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &apimodels.Error{}
+		if err := json.Unmarshal(respBody, apiErr); err == nil && apiErr.Message != nil && *apiErr.Message != "" {
+			return errors.New(*apiErr.Message)
+		}
+		return fmt.Errorf(ErrWithStatusCode, resp.StatusCode)
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+const projectPath = "/controlPlane/v1/project"
+
+// StageLayoutBranch represents each stage of a project as a branch of its Git repository
+const StageLayoutBranch = "branch"
+
+// StageLayoutDirectory represents each stage of a project as a directory within the default branch of its Git repository
+const StageLayoutDirectory = "directory"
+
+// Project contains the parameters for creating or updating a project, including the stage layout which is not covered by the go-utils API set
+type Project struct {
+	apimodels.CreateProject
+	StageLayout string `json:"stageLayout,omitempty"`
+}
+
+// ProjectHandler provides access to the project endpoints of the shipyard controller for the parameters which are not covered by the go-utils API set
+type ProjectHandler struct {
+	controlPlaneClient
+}
+
+// NewProjectHandler creates a new ProjectHandler for the Keptn API reachable at the given base URL
+func NewProjectHandler(baseURL string, authToken string) *ProjectHandler {
+	return &ProjectHandler{controlPlaneClient: newControlPlaneClient(baseURL, authToken)}
+}
+
+// CreateProject creates the project with the given stage layout
+func (p *ProjectHandler) CreateProject(project Project) error {
+	return p.do(http.MethodPost, projectPath, project, nil)
+}
+
+// UpdateProject updates the project and migrates it to the given stage layout
+func (p *ProjectHandler) UpdateProject(project Project) error {
+	return p.do(http.MethodPut, projectPath, project, nil)
+}
+
+// ValidateStageLayout checks whether the given stage layout is supported. An empty stage layout is valid
+func ValidateStageLayout(stageLayout string) error {
+	if stageLayout != "" && stageLayout != StageLayoutBranch && stageLayout != StageLayoutDirectory {
+		return fmt.Errorf("stage layout must be either '%s' or '%s'", StageLayoutBranch, StageLayoutDirectory)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectHandler_CreateAndUpdateProject(t *testing.T) {
+	methods := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/controlPlane/v1/project", r.URL.Path)
+		require.Equal(t, "my-token", r.Header.Get("x-token"))
+		payload := map[string]interface{}{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Equal(t, "sockshop", payload["name"])
+		require.Equal(t, "https://my-repo", payload["gitRemoteURL"])
+		require.Equal(t, StageLayoutDirectory, payload["stageLayout"])
+		methods = append(methods, r.Method)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	projectName := "sockshop"
+	project := Project{
+		CreateProject: apimodels.CreateProject{Name: &projectName, GitRemoteURL: "https://my-repo"},
+		StageLayout:   StageLayoutDirectory,
+	}
+	handler := NewProjectHandler(server.URL, "my-token")
+	require.Nil(t, handler.CreateProject(project))
+	require.Nil(t, handler.UpdateProject(project))
+	require.Equal(t, []string{http.MethodPost, http.MethodPut}, methods)
+}
+
+func TestValidateStageLayout(t *testing.T) {
+	require.Nil(t, ValidateStageLayout(""))
+	require.Nil(t, ValidateStageLayout(StageLayoutBranch))
+	require.Nil(t, ValidateStageLayout(StageLayoutDirectory))
+	require.EqualError(t, ValidateStageLayout("folder"), "stage layout must be either 'branch' or 'directory'")
+}
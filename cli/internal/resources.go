@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const resourceServicePath = "/configuration-service/v1"
+
+// ResourceCommit is an entry of the history of a resource
+type ResourceCommit struct {
+	CommitID  string    `json:"commitID" yaml:"commitID"`
+	Author    string    `json:"author" yaml:"author"`
+	Message   string    `json:"message" yaml:"message"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+}
+
+// ResourceHistory contains the commits that changed a resource, starting with the most recent one
+type ResourceHistory struct {
+	Commits     []ResourceCommit `json:"commits" yaml:"commits"`
+	NextPageKey string           `json:"nextPageKey,omitempty" yaml:"-"`
+}
+
+// ResourceHandler provides access to the resource endpoints of the resource-service, which are not covered by the go-utils API set
+type ResourceHandler struct {
+	controlPlaneClient
+}
+
+// NewResourceHandler creates a new ResourceHandler for the Keptn API reachable at the given base URL
+func NewResourceHandler(baseURL string, authToken string) *ResourceHandler {
+	return &ResourceHandler{controlPlaneClient: newControlPlaneClient(baseURL, authToken)}
+}
+
+// GetResourceHistory returns all commits that changed the given resource of a project, stage, or service.
+// The stage and service are optional, depending on the level of the resource
+func (r *ResourceHandler) GetResourceHistory(project, stage, service, resourceURI string) ([]ResourceCommit, error) {
+	commits := []ResourceCommit{}
+	nextPageKey := ""
+	for {
+		history := &ResourceHistory{}
+		path := getResourcePath(project, stage, service, resourceURI) + "/history"
+		if nextPageKey != "" {
+			path += "?nextPageKey=" + url.QueryEscape(nextPageKey)
+		}
+		if err := r.do(http.MethodGet, path, nil, history); err != nil {
+			return nil, err
+		}
+		commits = append(commits, history.Commits...)
+		if history.NextPageKey == "" || history.NextPageKey == "0" {
+			return commits, nil
+		}
+		nextPageKey = history.NextPageKey
+	}
+}
+
+func getResourcePath(project, stage, service, resourceURI string) string {
+	path := resourceServicePath + "/project/" + url.PathEscape(project)
+	if stage != "" {
+		path += "/stage/" + url.PathEscape(stage)
+		if service != "" {
+			path += "/service/" + url.PathEscape(service)
+		}
+	}
+	return fmt.Sprintf("%s/resource/%s", path, url.PathEscape(resourceURI))
+}
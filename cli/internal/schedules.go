@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const schedulePath = "/controlPlane/v1/project/%s/schedule"
+
+// Schedule describes a sequence that is triggered periodically by the shipyard controller according to a cron expression
+type Schedule struct {
+	ID               string            `json:"id,omitempty" yaml:"id,omitempty"`
+	Project          string            `json:"project" yaml:"project"`
+	Stage            string            `json:"stage" yaml:"stage"`
+	Service          string            `json:"service" yaml:"service"`
+	Sequence         string            `json:"sequence" yaml:"sequence"`
+	Description      string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Cron             string            `json:"cron" yaml:"cron"`
+	TimeZone         string            `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	CreatedAt        *time.Time        `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	LastTriggered    *time.Time        `json:"lastTriggered,omitempty" yaml:"lastTriggered,omitempty"`
+	LastKeptnContext string            `json:"lastKeptnContext,omitempty" yaml:"lastKeptnContext,omitempty"`
+	NextTrigger      *time.Time        `json:"nextTrigger,omitempty" yaml:"nextTrigger,omitempty"`
+}
+
+// Schedules contains a list of schedules
+type Schedules struct {
+	Schedules []Schedule `json:"schedules" yaml:"schedules"`
+}
+
+// ScheduleHandler provides access to the schedule endpoints of the shipyard controller, which are not covered by the go-utils API set
+type ScheduleHandler struct {
+	controlPlaneClient
+}
+
+// NewScheduleHandler creates a new ScheduleHandler for the Keptn API reachable at the given base URL
+func NewScheduleHandler(baseURL string, authToken string) *ScheduleHandler {
+	return &ScheduleHandler{controlPlaneClient: newControlPlaneClient(baseURL, authToken)}
+}
+
+// CreateSchedule creates a new schedule and returns it, including the ID assigned by the shipyard controller
+func (s *ScheduleHandler) CreateSchedule(schedule Schedule) (*Schedule, error) {
+	createdSchedule := &Schedule{}
+	if err := s.do(http.MethodPost, fmt.Sprintf(schedulePath, url.PathEscape(schedule.Project)), schedule, createdSchedule); err != nil {
+		return nil, err
+	}
+	return createdSchedule, nil
+}
+
+// GetSchedules returns the schedules of a project. If stage or service are set, only the schedules matching them are returned
+func (s *ScheduleHandler) GetSchedules(project, stage, service string) ([]Schedule, error) {
+	query := url.Values{}
+	if stage != "" {
+		query.Set("stage", stage)
+	}
+	if service != "" {
+		query.Set("service", service)
+	}
+	path := fmt.Sprintf(schedulePath, url.PathEscape(project))
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+
+	schedules := &Schedules{}
+	if err := s.do(http.MethodGet, path, nil, schedules); err != nil {
+		return nil, err
+	}
+	return schedules.Schedules, nil
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const sequenceHistoryPath = "/controlPlane/v1/sequence/%s/%s/history"
+const sequenceControlPath = "/controlPlane/v1/sequence/%s/%s/control"
+
+// SequenceControlCommand contains instructions to change the state of a sequence
+type SequenceControlCommand struct {
+	State string `json:"state"`
+	Stage string `json:"stage,omitempty"`
+	// FromTask is the task from which a sequence is restarted. It is only considered for the 'restart' state
+	FromTask string `json:"fromTask,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// SequenceStateTransition is an entry of the history of a sequence execution
+type SequenceStateTransition struct {
+	Project      string    `json:"project" yaml:"project"`
+	Stage        string    `json:"stage,omitempty" yaml:"stage,omitempty"`
+	Service      string    `json:"service,omitempty" yaml:"service,omitempty"`
+	Sequence     string    `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	KeptnContext string    `json:"keptnContext" yaml:"keptnContext"`
+	State        string    `json:"state" yaml:"state"`
+	User         string    `json:"user,omitempty" yaml:"user,omitempty"`
+	Source       string    `json:"source" yaml:"source"`
+	Reason       string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Time         time.Time `json:"time" yaml:"time"`
+}
+
+// SequenceHistory contains the state transitions of a sequence execution in chronological order
+type SequenceHistory struct {
+	Transitions []SequenceStateTransition `json:"transitions" yaml:"transitions"`
+}
+
+// SequenceHandler provides access to the sequence endpoints of the shipyard controller, which are not covered by the go-utils API set
+type SequenceHandler struct {
+	controlPlaneClient
+}
+
+// NewSequenceHandler creates a new SequenceHandler for the Keptn API reachable at the given base URL
+func NewSequenceHandler(baseURL string, authToken string) *SequenceHandler {
+	return &SequenceHandler{controlPlaneClient: newControlPlaneClient(baseURL, authToken)}
+}
+
+// GetSequenceHistory returns the state transitions of the sequence execution with the given keptnContext
+func (s *SequenceHandler) GetSequenceHistory(project, keptnContext string) ([]SequenceStateTransition, error) {
+	history := &SequenceHistory{}
+	if err := s.do(http.MethodGet, fmt.Sprintf(sequenceHistoryPath, url.PathEscape(project), url.PathEscape(keptnContext)), nil, history); err != nil {
+		return nil, err
+	}
+	return history.Transitions, nil
+}
+
+// ControlSequence changes the state of the sequence execution with the given keptnContext
+func (s *SequenceHandler) ControlSequence(project, keptnContext string, command SequenceControlCommand) error {
+	return s.do(http.MethodPost, fmt.Sprintf(sequenceControlPath, url.PathEscape(project), url.PathEscape(keptnContext)), command, nil)
+}
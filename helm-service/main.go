@@ -31,7 +31,6 @@ import (
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 
-	utils "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/keptn/helm-service/pkg/mesh"
 	"github.com/keptn/keptn/helm-service/pkg/serviceutils"
 	authorizationv1 "k8s.io/api/authorization/v1"
@@ -148,7 +147,7 @@ func createActionTriggeredHandler(configServiceURL *url.URL, keptn *keptnv2.Kept
 func createReleaseHandler(url *url.URL, mesh *mesh.IstioMesh, keptn *keptnv2.Keptn) *controller.ReleaseHandler {
 	configChanger := configurationchanger.NewConfigurationChanger(url.String())
 	chartGenerator := helm.NewGeneratedChartGenerator(mesh)
-	chartStorer := common.NewChartStorer(utils.NewResourceHandler(url.String()))
+	chartStorer := common.NewChartStorer(common.NewResourceHandler(url.String()))
 	chartPackager := common.NewChartPackager()
 	keptnBaseHandler := createKeptnBaseHandler(url, keptn)
 	releaseHandler := controller.NewReleaseHandler(keptnBaseHandler, mesh, configChanger, chartGenerator, chartStorer, chartPackager)
@@ -164,7 +163,7 @@ func createRollbackHandler(url *url.URL, mesh *mesh.IstioMesh, keptn *keptnv2.Ke
 
 func createOnboarder(configServiceURL *url.URL, keptn *keptnv2.Keptn, mesh *mesh.IstioMesh) controller.Onboarder {
 	namespaceManager := namespacemanager.NewNamespaceManager(keptn.Logger)
-	chartStorer := common.NewChartStorer(utils.NewResourceHandler(configServiceURL.String()))
+	chartStorer := common.NewChartStorer(common.NewResourceHandler(configServiceURL.String()))
 	chartGenerator := helm.NewGeneratedChartGenerator(mesh)
 	chartPackager := common.NewChartPackager()
 	keptnBaseHandler := createKeptnBaseHandler(configServiceURL, keptn)
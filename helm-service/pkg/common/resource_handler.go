@@ -0,0 +1,37 @@
+package common
+
+import (
+	"net/http"
+
+	goutils "github.com/keptn/go-utils/pkg/api/utils"
+)
+
+// requestOriginHeader names the sender of a request to the resource-service
+const requestOriginHeader = "X-Keptn-Request-Origin"
+
+// requestOrigin identifies the helm-service at the resource-service, which commits the Helm charts written by the helm-service directly,
+// even if the changes of the project are proposed via pull requests
+const requestOrigin = "helm-service"
+
+// originTransport sets the origin of all requests sent by the wrapped transport
+type originTransport struct {
+	next http.RoundTripper
+}
+
+func (t originTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(requestOriginHeader, requestOrigin)
+	return t.next.RoundTrip(req)
+}
+
+// NewResourceHandler creates a resource handler whose requests are marked as sent by the helm-service.
+// It has to be used for storing Helm charts, since the deployment continues with the commit of the stored chart
+func NewResourceHandler(configServiceURL string) *goutils.ResourceHandler {
+	resourceHandler := goutils.NewResourceHandler(configServiceURL)
+	next := resourceHandler.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resourceHandler.HTTPClient.Transport = originTransport{next: next}
+	return resourceHandler
+}
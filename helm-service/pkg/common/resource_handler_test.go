@@ -0,0 +1,26 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResourceHandler(t *testing.T) {
+	origins := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origins <- r.Header.Get(requestOriginHeader)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"commitID": "my-commit"}`))
+	}))
+	defer ts.Close()
+
+	uri := "helm/carts.tgz"
+	_, err := NewResourceHandler(ts.URL).CreateServiceResources("my-project", "dev", "carts", []*models.Resource{{ResourceURI: &uri, ResourceContent: "chart"}})
+	require.Nil(t, err)
+	require.Equal(t, "helm-service", <-origins)
+}
@@ -3,7 +3,6 @@ package configurationchanger
 import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 
-	goutils "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/keptn/helm-service/pkg/common"
 	"github.com/keptn/keptn/helm-service/pkg/helm"
 	"helm.sh/helm/v3/pkg/chart"
@@ -43,7 +42,7 @@ func (c *ConfigurationChanger) UpdateLoadedChart(chart *chart.Chart, event keptn
 		return nil, "", err
 	}
 	// Store chart
-	chartStorer := common.NewChartStorer(goutils.NewResourceHandler(c.configServiceURL))
+	chartStorer := common.NewChartStorer(common.NewResourceHandler(c.configServiceURL))
 	opts := common.StoreChartOptions{
 		Project:   event.Project,
 		Service:   event.Service,
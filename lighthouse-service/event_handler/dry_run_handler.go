@@ -0,0 +1,50 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	logger "github.com/sirupsen/logrus"
+)
+
+// DryRunEvaluationPath is the path of the endpoint that evaluates SLI results against an SLO without emitting any events
+const DryRunEvaluationPath = "/v1/evaluation/dry-run"
+
+// maxDryRunRequestSize is the maximum size of a dry-run request in bytes, which limits the memory used for decoding the SLI results and previous evaluations
+const maxDryRunRequestSize = 10 << 20
+
+// DryRunEvaluationHandler handles requests to evaluate SLI results against an SLO and responds with the resulting evaluation.finished event data
+func DryRunEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", r.Method))
+		return
+	}
+
+	request := evaluation.DryRunRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxDryRunRequestSize)).Decode(&request); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "could not parse request: "+err.Error())
+		return
+	}
+
+	evaluationResult, err := evaluation.EvaluateDryRun(request)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "could not evaluate SLO: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(evaluationResult); err != nil {
+		logger.WithError(err).Error("could not write evaluation result")
+	}
+}
+
+func writeErrorResponse(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(models.Error{Code: int64(code), Message: &message}); err != nil {
+		logger.WithError(err).Error("could not write error response")
+	}
+}
@@ -0,0 +1,71 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/lighthouse-service/pkg/evaluation"
+	"github.com/stretchr/testify/require"
+)
+
+const dryRunHandlerTestSLO = `---
+spec_version: "1.0"
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<600"
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "<1"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+func TestDryRunEvaluationHandler(t *testing.T) {
+	request := evaluation.DryRunRequest{
+		SLO:             dryRunHandlerTestSLO,
+		IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}, {Metric: "error_rate", Value: 0, Success: true}},
+		Project:         "sockshop",
+		Stage:           "staging",
+		Service:         "carts",
+	}
+	body, _ := json.Marshal(request)
+
+	w := httptest.NewRecorder()
+	DryRunEvaluationHandler(w, httptest.NewRequest(http.MethodPost, DryRunEvaluationPath, strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	result := &keptnv2.EvaluationFinishedEventData{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), result))
+	require.Equal(t, keptnv2.ResultPass, result.Result)
+	require.Equal(t, "sockshop", result.Project)
+	require.Equal(t, "carts", result.Service)
+	require.Len(t, result.Evaluation.IndicatorResults, 2)
+
+	w = httptest.NewRecorder()
+	DryRunEvaluationHandler(w, httptest.NewRequest(http.MethodPost, DryRunEvaluationPath, strings.NewReader(`{"slo": ""}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	apiErr := &models.Error{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), apiErr))
+	require.Equal(t, "could not evaluate SLO: no SLO provided", *apiErr.Message)
+
+	// requests exceeding the maximum size are rejected before they are decoded completely
+	w = httptest.NewRecorder()
+	oversizedRequest := `{"slo": "` + strings.Repeat(" ", maxDryRunRequestSize) + `"}`
+	DryRunEvaluationHandler(w, httptest.NewRequest(http.MethodPost, DryRunEvaluationPath, strings.NewReader(oversizedRequest)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), apiErr))
+	require.Contains(t, *apiErr.Message, "could not parse request")
+
+	w = httptest.NewRecorder()
+	DryRunEvaluationHandler(w, httptest.NewRequest(http.MethodGet, DryRunEvaluationPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
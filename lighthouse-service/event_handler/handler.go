@@ -43,13 +43,17 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event) (EvaluationEv
 	switch event.Type() {
 	case keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName):
 		return &StartEvaluationHandler{
-			Event:             event,
-			KeptnHandler:      keptnHandler,
-			SLIProviderConfig: K8sSLIProviderConfig{},
+			Event:        event,
+			KeptnHandler: keptnHandler,
+			SLIProviderConfig: ResourceSLIProviderConfig{
+				ResourceHandler: resourceHandler,
+				Fallback:        K8sSLIProviderConfig{},
+			},
 			SLOFileRetriever: SLOFileRetriever{
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
+			SLIResultAggregator: GetSLIResultAggregator(),
 		}, nil
 	case keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName):
 		return &EvaluateSLIHandler{
@@ -60,7 +64,8 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event) (EvaluationEv
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
-			EventStore: keptnHandler.EventHandler,
+			EventStore:          keptnHandler.EventHandler,
+			SLIResultAggregator: GetSLIResultAggregator(),
 		}, nil
 	case keptn.ConfigureMonitoringEventType:
 		return NewConfigureMonitoringHandler(event, logger.StandardLogger())
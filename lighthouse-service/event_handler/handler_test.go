@@ -54,7 +54,7 @@ func TestNewEventHandler(t *testing.T) {
 			want: &StartEvaluationHandler{
 				Event:             incomingEvent,
 				KeptnHandler:      keptnHandler,
-				SLIProviderConfig: K8sSLIProviderConfig{},
+				SLIProviderConfig: ResourceSLIProviderConfig{Fallback: K8sSLIProviderConfig{}},
 			},
 			wantErr: false,
 		},
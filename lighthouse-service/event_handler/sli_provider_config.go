@@ -0,0 +1,102 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	utils "github.com/keptn/go-utils/pkg/api/utils"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// SLIProviderConfigResourceName is the name of the resource that contains the SLI provider configuration of a project, stage or service
+const SLIProviderConfigResourceName = "lighthouse-config.yaml"
+
+// SLIProviderConfigFile is the content of the lighthouse-config.yaml resource
+type SLIProviderConfigFile struct {
+	SLIProvider string `yaml:"sliProvider"`
+}
+
+// ErrReadSLIProviderConfig is returned if a lighthouse-config.yaml resource cannot be retrieved or parsed. In this case, no other SLI provider configuration is used
+var ErrReadSLIProviderConfig = errors.New("could not read SLI provider configuration")
+
+// ResourceSLIProviderConfig reads the SLI provider from the lighthouse-config.yaml resource of the service, stage or project, in this order.
+// If none of them exists, the Fallback is used, i.e. the resources take precedence over the ConfigMap written by 'keptn configure monitoring'
+type ResourceSLIProviderConfig struct {
+	ResourceHandler ResourceHandler `deep:"-"`
+	Fallback        SLIProviderConfig
+}
+
+// GetDefaultSLIProvider returns the default SLI provider of the Fallback
+func (c ResourceSLIProviderConfig) GetDefaultSLIProvider() (string, error) {
+	if c.Fallback == nil {
+		return "", errors.New("no default SLI provider specified")
+	}
+	return c.Fallback.GetDefaultSLIProvider()
+}
+
+// GetSLIProvider returns the SLI provider of the most specific lighthouse-config.yaml resource for the given service.
+// If a commitID is set, the resources of the service and stage are read at this revision, i.e. the revision of the SLO file of the evaluation.
+// The resource of the project is always read from the default branch, since the commit of a stage branch does not contain its latest version
+func (c ResourceSLIProviderConfig) GetSLIProvider(project, stage, service, commitID string) (string, error) {
+	type scopedRevision struct {
+		scope    *utils.ResourceScope
+		commitID string
+	}
+	scopes := []scopedRevision{}
+	if stage != "" && service != "" {
+		scopes = append(scopes, scopedRevision{scope: utils.NewResourceScope().Project(project).Stage(stage).Service(service), commitID: commitID})
+	}
+	if stage != "" {
+		scopes = append(scopes, scopedRevision{scope: utils.NewResourceScope().Project(project).Stage(stage), commitID: commitID})
+	}
+	scopes = append(scopes, scopedRevision{scope: utils.NewResourceScope().Project(project)})
+
+	for _, s := range scopes {
+		scope := s.scope
+		sliProvider, err := c.getSLIProviderOfScope(scope.Resource(SLIProviderConfigResourceName), s.commitID)
+		if err != nil {
+			return "", err
+		}
+		if sliProvider != "" {
+			// the ConfigMap written by 'keptn configure monitoring' is not read here, since it would be requested for every evaluation
+			logger.Debugf("Using SLI provider %s of %s for project %s", sliProvider, getResourceScopePath(scope), project)
+			return sliProvider, nil
+		}
+	}
+
+	if c.Fallback == nil {
+		return "", errors.New("no SLI provider specified for project " + project)
+	}
+	return c.Fallback.GetSLIProvider(project, stage, service, commitID)
+}
+
+func (c ResourceSLIProviderConfig) getSLIProviderOfScope(scope *utils.ResourceScope, commitID string) (string, error) {
+	options := []utils.URIOption{}
+	if commitID != "" {
+		options = append(options, utils.AppendQuery(url.Values{"gitCommitID": []string{commitID}}))
+	}
+	resource, err := c.ResourceHandler.GetResource(*scope, options...)
+	if err != nil {
+		if errors.Is(err, utils.ResourceNotFoundError) {
+			// the resource is optional on each level
+			return "", nil
+		}
+		return "", fmt.Errorf("%w from %s: %v", ErrReadSLIProviderConfig, getResourceScopePath(scope), err)
+	}
+	if resource == nil || resource.ResourceContent == "" {
+		return "", nil
+	}
+
+	configFile := &SLIProviderConfigFile{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), configFile); err != nil {
+		return "", fmt.Errorf("%w from %s: %v", ErrReadSLIProviderConfig, getResourceScopePath(scope), err)
+	}
+	return configFile.SLIProvider, nil
+}
+
+func getResourceScopePath(scope *utils.ResourceScope) string {
+	return strings.TrimPrefix(scope.GetProjectPath()+scope.GetStagePath()+scope.GetServicePath()+scope.GetResourcePath(), "/v1")
+}
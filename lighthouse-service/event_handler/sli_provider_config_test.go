@@ -0,0 +1,179 @@
+package event_handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceSLIProviderConfig_GetSLIProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources map[string]string
+		errs      map[string]error
+		fallback  SLIProviderConfig
+		want      string
+		wantErr   error
+	}{
+		{
+			name: "service level config",
+			resources: map[string]string{
+				"/v1/project/sockshop/stage/staging/service/carts": "sliProvider: dynatrace",
+				"/v1/project/sockshop/stage/staging":               "sliProvider: prometheus",
+				"/v1/project/sockshop":                             "sliProvider: datadog",
+			},
+			want: "dynatrace",
+		},
+		{
+			name: "stage level config",
+			resources: map[string]string{
+				"/v1/project/sockshop/stage/staging": "sliProvider: prometheus",
+				"/v1/project/sockshop":               "sliProvider: datadog",
+			},
+			want: "prometheus",
+		},
+		{
+			name: "project level config",
+			resources: map[string]string{
+				"/v1/project/sockshop": "sliProvider: datadog",
+			},
+			fallback: &MockSLIProviderConfig{ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "dynatrace"}},
+			want: "datadog",
+		},
+		{
+			name: "invalid config",
+			resources: map[string]string{
+				"/v1/project/sockshop/stage/staging/service/carts": "invalid",
+				"/v1/project/sockshop":                             "sliProvider: datadog",
+			},
+			wantErr: ErrReadSLIProviderConfig,
+		},
+		{
+			name: "config cannot be retrieved",
+			errs: map[string]error{
+				"/v1/project/sockshop/stage/staging": errors.New("could not check out branch"),
+			},
+			fallback: &MockSLIProviderConfig{ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "dynatrace"}},
+			wantErr: ErrReadSLIProviderConfig,
+		},
+		{
+			name:      "fallback to configmap",
+			resources: map[string]string{},
+			fallback: &MockSLIProviderConfig{ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "dynatrace"}},
+			want: "dynatrace",
+		},
+		{
+			name:      "no config available",
+			resources: map[string]string{},
+			wantErr:   errors.New("no SLI provider specified for project sockshop"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resourceHandler := &event_handler_mock.ResourceHandlerMock{
+				GetResourceFunc: func(scope keptnapi.ResourceScope, options ...keptnapi.URIOption) (*models.Resource, error) {
+					require.Equal(t, "/resource/"+SLIProviderConfigResourceName, scope.GetResourcePath())
+					path := scope.GetProjectPath() + scope.GetStagePath() + scope.GetServicePath()
+					if err, ok := tt.errs[path]; ok {
+						return nil, err
+					}
+					content, ok := tt.resources[path]
+					if !ok {
+						return nil, keptnapi.ResourceNotFoundError
+					}
+					return &models.Resource{ResourceContent: content}, nil
+				},
+			}
+			config := ResourceSLIProviderConfig{ResourceHandler: resourceHandler, Fallback: tt.fallback}
+
+			got, err := config.GetSLIProvider("sockshop", "staging", "carts", "")
+			if tt.wantErr != nil {
+				if errors.Is(tt.wantErr, ErrReadSLIProviderConfig) {
+					require.ErrorIs(t, err, ErrReadSLIProviderConfig)
+				} else {
+					require.EqualError(t, err, tt.wantErr.Error())
+				}
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// countingSLIProviderConfig counts how often the SLI provider is requested from it
+type countingSLIProviderConfig struct {
+	MockSLIProviderConfig
+	calls int
+}
+
+func (c *countingSLIProviderConfig) GetSLIProvider(project, stage, service, commitID string) (string, error) {
+	c.calls++
+	return c.MockSLIProviderConfig.GetSLIProvider(project, stage, service, commitID)
+}
+
+func TestResourceSLIProviderConfig_GetSLIProvider_FallbackNotReadIfConfigExists(t *testing.T) {
+	resources := map[string]string{"/v1/project/sockshop": "sliProvider: datadog"}
+	resourceHandler := &event_handler_mock.ResourceHandlerMock{
+		GetResourceFunc: func(scope keptnapi.ResourceScope, options ...keptnapi.URIOption) (*models.Resource, error) {
+			content, ok := resources[scope.GetProjectPath()+scope.GetStagePath()+scope.GetServicePath()]
+			if !ok {
+				return nil, keptnapi.ResourceNotFoundError
+			}
+			return &models.Resource{ResourceContent: content}, nil
+		},
+	}
+	fallback := &countingSLIProviderConfig{}
+	fallback.ProjectSLIProvider.val = "dynatrace"
+	config := ResourceSLIProviderConfig{ResourceHandler: resourceHandler, Fallback: fallback}
+
+	got, err := config.GetSLIProvider("sockshop", "staging", "carts", "")
+	require.Nil(t, err)
+	require.Equal(t, "datadog", got)
+	require.Equal(t, 0, fallback.calls)
+
+	// the ConfigMap is only read if no lighthouse-config.yaml exists
+	delete(resources, "/v1/project/sockshop")
+	got, err = config.GetSLIProvider("sockshop", "staging", "carts", "")
+	require.Nil(t, err)
+	require.Equal(t, "dynatrace", got)
+	require.Equal(t, 1, fallback.calls)
+}
+
+func TestResourceSLIProviderConfig_GetSLIProvider_CommitID(t *testing.T) {
+	queries := map[string]string{}
+	resourceHandler := &event_handler_mock.ResourceHandlerMock{
+		GetResourceFunc: func(scope keptnapi.ResourceScope, options ...keptnapi.URIOption) (*models.Resource, error) {
+			path := scope.GetProjectPath() + scope.GetStagePath() + scope.GetServicePath()
+			queries[path] = ""
+			for _, option := range options {
+				queries[path] = option(queries[path])
+			}
+			return nil, keptnapi.ResourceNotFoundError
+		},
+	}
+	config := ResourceSLIProviderConfig{ResourceHandler: resourceHandler, Fallback: &MockSLIProviderConfig{}}
+
+	_, err := config.GetSLIProvider("sockshop", "staging", "carts", "my-commit-id")
+	require.Nil(t, err)
+
+	// the resources of the stage are read at the revision of the SLO file, while the resource of the project is read from the default branch
+	require.Equal(t, map[string]string{
+		"/v1/project/sockshop/stage/staging/service/carts": "?gitCommitID=my-commit-id",
+		"/v1/project/sockshop/stage/staging":               "?gitCommitID=my-commit-id",
+		"/v1/project/sockshop":                             "",
+	}, queries)
+}
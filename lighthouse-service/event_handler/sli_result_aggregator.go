@@ -0,0 +1,447 @@
+package event_handler
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	logger "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+const sliProviderTimeoutEnvVar = "SLI_PROVIDER_TIMEOUT"
+
+const defaultSLIProviderTimeout = 10 * time.Minute
+
+// completedSLIRetrievalRetention is the duration for which the IDs of completed SLI retrievals are kept, in order to ignore results that are received after the timeout
+const completedSLIRetrievalRetention = time.Hour
+
+// SLIRequest is a get-sli.triggered event that has been sent to one of multiple SLI providers of an evaluation
+type SLIRequest struct {
+	TriggeredID string
+	Provider    string
+	Indicators  []string
+}
+
+type sliRetrieval struct {
+	keptnContext string
+	requests     []SLIRequest
+	// template contains the event data that is common to the results of all providers
+	template *keptnv2.GetSLIFinishedEventData
+	results  map[string]*keptnv2.GetSLIFinishedEventData
+	timer    *time.Timer
+}
+
+// SLIResultAggregator collects the get-sli.finished events of evaluations whose SLIs are retrieved from multiple SLI providers,
+// and merges them once all providers have responded.
+// Retrievals are only tracked in memory. Results of retrievals that are unknown to this instance, e.g. after a restart or if the retrieval
+// has been started by another replica, are assigned to their retrieval based on the get-sli.triggered events stored in the datastore (see Restore)
+type SLIResultAggregator struct {
+	mutex      sync.Mutex
+	timeout    time.Duration
+	retrievals map[string]*sliRetrieval
+	completed  map[string]time.Time
+}
+
+var sliResultAggregator *SLIResultAggregator
+var sliResultAggregatorOnce sync.Once
+
+// GetSLIResultAggregator returns the SLIResultAggregator that is shared by all event handlers. The timeout for SLI providers can be set via the SLI_PROVIDER_TIMEOUT env var
+func GetSLIResultAggregator() *SLIResultAggregator {
+	sliResultAggregatorOnce.Do(func() {
+		timeout := defaultSLIProviderTimeout
+		if timeoutStr := os.Getenv(sliProviderTimeoutEnvVar); timeoutStr != "" {
+			if parsedTimeout, err := time.ParseDuration(timeoutStr); err == nil && parsedTimeout > 0 {
+				timeout = parsedTimeout
+			} else {
+				logger.Errorf("could not parse %s env var, using the default timeout of %s", sliProviderTimeoutEnvVar, timeout)
+			}
+		}
+		sliResultAggregator = NewSLIResultAggregator(timeout)
+	})
+	return sliResultAggregator
+}
+
+// NewSLIResultAggregator creates a new SLIResultAggregator that waits for SLI providers for the given timeout
+func NewSLIResultAggregator(timeout time.Duration) *SLIResultAggregator {
+	return &SLIResultAggregator{
+		timeout:    timeout,
+		retrievals: map[string]*sliRetrieval{},
+		completed:  map[string]time.Time{},
+	}
+}
+
+// Register starts to collect the results of the given requests. If not all providers have responded within the timeout,
+// onTimeout is called with the merged results, in which the SLIs of the missing providers are marked as failed.
+// If an event store is passed, the results that have been received by other instances are included before the timeout is handled
+func (a *SLIResultAggregator) Register(keptnContext string, requests []SLIRequest, template *keptnv2.GetSLIFinishedEventData, eventStore EventStore, onTimeout func(merged *keptnv2.GetSLIFinishedEventData)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.pruneCompleted()
+
+	retrieval := &sliRetrieval{
+		keptnContext: keptnContext,
+		requests:     requests,
+		template:     template,
+		results:      map[string]*keptnv2.GetSLIFinishedEventData{},
+	}
+	a.track(retrieval, a.timeout, eventStore, onTimeout)
+}
+
+// Add adds the result of an SLI provider. It returns whether the result belongs to a registered retrieval and, once the results of all providers have been received, the merged results.
+// Results that are received after the retrieval has been completed are ignored
+func (a *SLIResultAggregator) Add(triggeredID string, result *keptnv2.GetSLIFinishedEventData) (*keptnv2.GetSLIFinishedEventData, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.add(triggeredID, result)
+}
+
+// Restore adds the result of an SLI provider for a retrieval that is not known to this instance. The retrieval is derived from the get-sli.triggered events
+// of the keptn context: if the get-sli.triggered event of the result has been sent to one of multiple SLI providers, the result is tracked like a registered retrieval,
+// taking the results into account that have already been stored. It returns whether the result belongs to a retrieval of multiple providers and,
+// once the results of all providers are available, the merged results. Results of retrievals whose evaluation has already finished are ignored
+func (a *SLIResultAggregator) Restore(eventStore EventStore, keptnContext string, triggeredID string, result *keptnv2.GetSLIFinishedEventData, onTimeout func(merged *keptnv2.GetSLIFinishedEventData)) (*keptnv2.GetSLIFinishedEventData, bool, error) {
+	retrieval, triggeredAt, err := getSLIRetrievalOfEvent(eventStore, keptnContext, triggeredID, result)
+	if err != nil {
+		return nil, false, err
+	}
+	if retrieval == nil {
+		// the SLIs have been retrieved from a single SLI provider
+		return nil, false, nil
+	}
+
+	storedResults, err := getStoredSLIResults(eventStore, retrieval)
+	if err != nil {
+		return nil, false, err
+	}
+	finished, err := isEvaluationFinished(eventStore, retrieval)
+	if err != nil {
+		return nil, false, err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if merged, tracked := a.add(triggeredID, result); tracked {
+		// the retrieval has been restored by a result that has been received in the meantime
+		return merged, true, nil
+	}
+	if finished {
+		logger.Infof("Ignoring SLI results for get-sli.triggered event %s, since the SLIs have already been evaluated", triggeredID)
+		a.complete(retrieval)
+		return nil, true, nil
+	}
+
+	retrieval.results = storedResults
+	retrieval.results[triggeredID] = result
+	remaining := a.timeout - time.Since(triggeredAt)
+	if len(retrieval.results) >= len(retrieval.requests) || remaining <= 0 {
+		a.complete(retrieval)
+		return mergeSLIResults(retrieval, a.timeout), true, nil
+	}
+
+	a.track(retrieval, remaining, eventStore, onTimeout)
+	return nil, true, nil
+}
+
+func (a *SLIResultAggregator) track(retrieval *sliRetrieval, timeout time.Duration, eventStore EventStore, onTimeout func(merged *keptnv2.GetSLIFinishedEventData)) {
+	for _, request := range retrieval.requests {
+		a.retrievals[request.TriggeredID] = retrieval
+	}
+
+	retrieval.timer = time.AfterFunc(timeout, func() {
+		a.mutex.Lock()
+		if a.retrievals[retrieval.requests[0].TriggeredID] != retrieval {
+			// all results have been received in the meantime
+			a.mutex.Unlock()
+			return
+		}
+		a.complete(retrieval)
+		a.mutex.Unlock()
+
+		if eventStore != nil {
+			// the results may have been received by another instance of the lighthouse-service, which then has conducted the evaluation
+			if finished, err := isEvaluationFinished(eventStore, retrieval); err != nil {
+				logger.WithError(err).Error("Could not check whether the SLIs have already been evaluated")
+			} else if finished {
+				return
+			}
+			if storedResults, err := getStoredSLIResults(eventStore, retrieval); err != nil {
+				logger.WithError(err).Error("Could not retrieve the stored SLI results")
+			} else {
+				for triggeredID, result := range storedResults {
+					if _, ok := retrieval.results[triggeredID]; !ok {
+						retrieval.results[triggeredID] = result
+					}
+				}
+			}
+		}
+
+		if len(retrieval.results) < len(retrieval.requests) {
+			logger.Infof("Not all SLI providers responded within %s, evaluating the available SLIs", a.timeout)
+		}
+		onTimeout(mergeSLIResults(retrieval, a.timeout))
+	})
+}
+
+func (a *SLIResultAggregator) add(triggeredID string, result *keptnv2.GetSLIFinishedEventData) (*keptnv2.GetSLIFinishedEventData, bool) {
+	if _, ok := a.completed[triggeredID]; ok {
+		logger.Infof("Ignoring SLI results for get-sli.triggered event %s, since the SLIs have already been evaluated", triggeredID)
+		return nil, true
+	}
+	retrieval, ok := a.retrievals[triggeredID]
+	if !ok {
+		return nil, false
+	}
+	retrieval.results[triggeredID] = result
+	if len(retrieval.results) < len(retrieval.requests) {
+		return nil, true
+	}
+
+	retrieval.timer.Stop()
+	a.complete(retrieval)
+	return mergeSLIResults(retrieval, a.timeout), true
+}
+
+func (a *SLIResultAggregator) complete(retrieval *sliRetrieval) {
+	now := time.Now()
+	for _, request := range retrieval.requests {
+		delete(a.retrievals, request.TriggeredID)
+		a.completed[request.TriggeredID] = now
+	}
+}
+
+func (a *SLIResultAggregator) pruneCompleted() {
+	for triggeredID, completedAt := range a.completed {
+		if time.Since(completedAt) > completedSLIRetrievalRetention {
+			delete(a.completed, triggeredID)
+		}
+	}
+}
+
+// getSLIRetrievalOfEvent derives the retrieval of the given get-sli.triggered event from the get-sli.triggered events that have been sent for the same evaluation.
+// It returns nil if the event does not exist or has been the only get-sli.triggered event of the evaluation, as well as the time of the earliest get-sli.triggered event
+func getSLIRetrievalOfEvent(eventStore EventStore, keptnContext string, triggeredID string, result *keptnv2.GetSLIFinishedEventData) (*sliRetrieval, time.Time, error) {
+	triggeredEvents, errObj := eventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      result.Project,
+		Stage:        result.Stage,
+		Service:      result.Service,
+		EventType:    keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName),
+		KeptnContext: keptnContext,
+	})
+	if errObj != nil {
+		return nil, time.Time{}, fmt.Errorf("could not retrieve get-sli.triggered events for context %s: %s", keptnContext, errObj.GetMessage())
+	}
+
+	getSLIEvents := map[string]*keptnv2.GetSLITriggeredEventData{}
+	triggeredAt := map[string]time.Time{}
+	for _, event := range triggeredEvents {
+		if event == nil || event.Type == nil || *event.Type != keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName) {
+			continue
+		}
+		data := &keptnv2.GetSLITriggeredEventData{}
+		if err := keptnv2.Decode(event.Data, data); err != nil {
+			logger.WithError(err).Errorf("Could not decode get-sli.triggered event %s", event.ID)
+			continue
+		}
+		getSLIEvents[event.ID] = data
+		triggeredAt[event.ID] = event.Time
+	}
+
+	triggered, ok := getSLIEvents[triggeredID]
+	if !ok {
+		return nil, time.Time{}, nil
+	}
+
+	retrieval := &sliRetrieval{
+		keptnContext: keptnContext,
+		template: &keptnv2.GetSLIFinishedEventData{
+			EventData: keptnv2.EventData{
+				Project: triggered.Project,
+				Stage:   triggered.Stage,
+				Service: triggered.Service,
+				Labels:  triggered.Labels,
+			},
+			GetSLI: keptnv2.GetSLIFinished{
+				Start: triggered.GetSLI.Start,
+				End:   triggered.GetSLI.End,
+			},
+		},
+		results: map[string]*keptnv2.GetSLIFinishedEventData{},
+	}
+	earliest := triggeredAt[triggeredID]
+	for id, data := range getSLIEvents {
+		// the get-sli.triggered events of an evaluation share its timeframe
+		if data.GetSLI.Start != triggered.GetSLI.Start || data.GetSLI.End != triggered.GetSLI.End {
+			continue
+		}
+		retrieval.requests = append(retrieval.requests, SLIRequest{TriggeredID: id, Provider: data.GetSLI.SLIProvider, Indicators: data.GetSLI.Indicators})
+		if triggeredAt[id].Before(earliest) {
+			earliest = triggeredAt[id]
+		}
+	}
+	if len(retrieval.requests) < 2 {
+		return nil, time.Time{}, nil
+	}
+	sort.Slice(retrieval.requests, func(i, j int) bool {
+		return retrieval.requests[i].TriggeredID < retrieval.requests[j].TriggeredID
+	})
+	return retrieval, earliest, nil
+}
+
+// getStoredSLIResults returns the get-sli.finished events of the given retrieval that are stored in the datastore
+func getStoredSLIResults(eventStore EventStore, retrieval *sliRetrieval) (map[string]*keptnv2.GetSLIFinishedEventData, error) {
+	finishedEvents, errObj := eventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      retrieval.template.Project,
+		Stage:        retrieval.template.Stage,
+		Service:      retrieval.template.Service,
+		EventType:    keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName),
+		KeptnContext: retrieval.keptnContext,
+	})
+	if errObj != nil {
+		return nil, fmt.Errorf("could not retrieve get-sli.finished events for context %s: %s", retrieval.keptnContext, errObj.GetMessage())
+	}
+
+	results := map[string]*keptnv2.GetSLIFinishedEventData{}
+	for _, event := range finishedEvents {
+		if event == nil || event.Type == nil || *event.Type != keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName) || !retrieval.hasRequest(event.Triggeredid) {
+			continue
+		}
+		data := &keptnv2.GetSLIFinishedEventData{}
+		if err := keptnv2.Decode(event.Data, data); err != nil {
+			logger.WithError(err).Errorf("Could not decode get-sli.finished event %s", event.ID)
+			continue
+		}
+		results[event.Triggeredid] = data
+	}
+	return results, nil
+}
+
+// isEvaluationFinished returns whether an evaluation.finished event has already been sent for the timeframe of the given retrieval
+func isEvaluationFinished(eventStore EventStore, retrieval *sliRetrieval) (bool, error) {
+	finishedEvents, errObj := eventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      retrieval.template.Project,
+		Stage:        retrieval.template.Stage,
+		Service:      retrieval.template.Service,
+		EventType:    keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+		KeptnContext: retrieval.keptnContext,
+	})
+	if errObj != nil {
+		return false, fmt.Errorf("could not retrieve evaluation.finished events for context %s: %s", retrieval.keptnContext, errObj.GetMessage())
+	}
+
+	for _, event := range finishedEvents {
+		if event == nil || event.Type == nil || *event.Type != keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName) {
+			continue
+		}
+		data := &keptnv2.EvaluationFinishedEventData{}
+		if err := keptnv2.Decode(event.Data, data); err != nil {
+			continue
+		}
+		if data.Evaluation.TimeStart == retrieval.template.GetSLI.Start && data.Evaluation.TimeEnd == retrieval.template.GetSLI.End {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *sliRetrieval) hasRequest(triggeredID string) bool {
+	for _, request := range r.requests {
+		if request.TriggeredID == triggeredID {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeSLIResults combines the SLI results of all providers. The SLIs of providers that did not respond, or failed to retrieve them, are marked as failed
+func mergeSLIResults(retrieval *sliRetrieval, timeout time.Duration) *keptnv2.GetSLIFinishedEventData {
+	merged := *retrieval.template
+	merged.Status = keptnv2.StatusSucceeded
+	merged.Result = keptnv2.ResultPass
+	merged.Message = ""
+	merged.GetSLI.IndicatorValues = []*keptnv2.SLIResult{}
+
+	for _, request := range retrieval.requests {
+		result, ok := retrieval.results[request.TriggeredID]
+		if !ok {
+			merged.GetSLI.IndicatorValues = append(merged.GetSLI.IndicatorValues,
+				failedSLIResults(request.Indicators, nil, fmt.Sprintf("SLI provider %s did not respond within %s", request.Provider, timeout))...)
+			continue
+		}
+		merged.GetSLI.IndicatorValues = append(merged.GetSLI.IndicatorValues, result.GetSLI.IndicatorValues...)
+		if result.Result == keptnv2.ResultFailed || result.Status == keptnv2.StatusErrored || result.Status == keptnv2.StatusAborted {
+			merged.GetSLI.IndicatorValues = append(merged.GetSLI.IndicatorValues,
+				failedSLIResults(request.Indicators, result.GetSLI.IndicatorValues, fmt.Sprintf("SLI provider %s failed: %s", request.Provider, result.Message))...)
+		}
+	}
+	return &merged
+}
+
+// failedSLIResults returns failed results for the given indicators that are not contained in the received results
+func failedSLIResults(indicators []string, received []*keptnv2.SLIResult, message string) []*keptnv2.SLIResult {
+	results := []*keptnv2.SLIResult{}
+	for _, indicator := range indicators {
+		found := false
+		for _, result := range received {
+			if result != nil && result.Metric == indicator {
+				found = true
+				break
+			}
+		}
+		if !found {
+			results = append(results, &keptnv2.SLIResult{Metric: indicator, Success: false, Message: message})
+		}
+	}
+	return results
+}
+
+// parseSLIProviders returns the SLI providers that are explicitly set for the objectives of an SLO file
+func parseSLIProviders(input []byte) (map[string]string, error) {
+	providerConfig := struct {
+		Objectives []*struct {
+			SLI      string `yaml:"sli"`
+			Provider string `yaml:"provider"`
+		} `yaml:"objectives"`
+	}{}
+	if err := yaml.Unmarshal(input, &providerConfig); err != nil {
+		return nil, fmt.Errorf("could not parse SLI providers: %w", err)
+	}
+
+	sliProviders := map[string]string{}
+	for _, objective := range providerConfig.Objectives {
+		if objective != nil && objective.Provider != "" {
+			sliProviders[objective.SLI] = objective.Provider
+		}
+	}
+	return sliProviders, nil
+}
+
+// getSLIRequests groups the indicators by the SLI provider they are retrieved from. Indicators without an explicit provider are retrieved from the default provider
+func getSLIRequests(indicators []string, sliProviders map[string]string, defaultProvider string) []SLIRequest {
+	requests := []SLIRequest{}
+	for _, indicator := range indicators {
+		provider := sliProviders[indicator]
+		if provider == "" {
+			provider = defaultProvider
+		}
+		found := false
+		for i := range requests {
+			if requests[i].Provider == provider {
+				requests[i].Indicators = append(requests[i].Indicators, indicator)
+				found = true
+				break
+			}
+		}
+		if !found {
+			requests = append(requests, SLIRequest{Provider: provider, Indicators: []string{indicator}})
+		}
+	}
+	return requests
+}
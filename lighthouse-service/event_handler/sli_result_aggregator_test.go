@@ -0,0 +1,257 @@
+package event_handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/strutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func getSLIRetrievalTemplate() *keptnv2.GetSLIFinishedEventData {
+	return &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI:    keptnv2.GetSLIFinished{Start: "2022-03-01T12:00:00Z", End: "2022-03-01T12:05:00Z"},
+	}
+}
+
+func getSLIRequestsForTest() []SLIRequest {
+	return []SLIRequest{
+		{TriggeredID: "id-1", Provider: "prometheus", Indicators: []string{"response_time_p95", "error_rate"}},
+		{TriggeredID: "id-2", Provider: "business-metrics-provider", Indicators: []string{"conversion_rate"}},
+	}
+}
+
+func TestSLIResultAggregator_Add(t *testing.T) {
+	aggregator := NewSLIResultAggregator(time.Hour)
+	aggregator.Register("my-context", getSLIRequestsForTest(), getSLIRetrievalTemplate(), nil, func(merged *keptnv2.GetSLIFinishedEventData) {
+		t.Error("the timeout should not be reached")
+	})
+
+	merged, tracked := aggregator.Add("unknown-id", &keptnv2.GetSLIFinishedEventData{})
+	require.False(t, tracked)
+	require.Nil(t, merged)
+
+	merged, tracked = aggregator.Add("id-2", &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Status: keptnv2.StatusErrored, Result: keptnv2.ResultFailed, Message: "connection refused"},
+	})
+	require.True(t, tracked)
+	require.Nil(t, merged)
+
+	merged, tracked = aggregator.Add("id-1", &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+		GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{
+			{Metric: "response_time_p95", Value: 500, Success: true},
+			{Metric: "error_rate", Value: 0, Success: true},
+		}},
+	})
+	require.True(t, tracked)
+	require.NotNil(t, merged)
+	require.Equal(t, "sockshop", merged.Project)
+	require.Equal(t, "2022-03-01T12:05:00Z", merged.GetSLI.End)
+	require.Equal(t, keptnv2.StatusSucceeded, merged.Status)
+	require.Equal(t, keptnv2.ResultPass, merged.Result)
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Value: 500, Success: true},
+		{Metric: "error_rate", Value: 0, Success: true},
+		{Metric: "conversion_rate", Success: false, Message: "SLI provider business-metrics-provider failed: connection refused"},
+	}, merged.GetSLI.IndicatorValues)
+
+	// results received after the evaluation are ignored
+	merged, tracked = aggregator.Add("id-1", &keptnv2.GetSLIFinishedEventData{})
+	require.True(t, tracked)
+	require.Nil(t, merged)
+}
+
+func TestSLIResultAggregator_Timeout(t *testing.T) {
+	aggregator := NewSLIResultAggregator(10 * time.Millisecond)
+	mergedCh := make(chan *keptnv2.GetSLIFinishedEventData, 1)
+	aggregator.Register("my-context", getSLIRequestsForTest(), getSLIRetrievalTemplate(), nil, func(merged *keptnv2.GetSLIFinishedEventData) {
+		mergedCh <- merged
+	})
+
+	_, tracked := aggregator.Add("id-2", &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "conversion_rate", Value: 0.2, Success: true}}},
+	})
+	require.True(t, tracked)
+
+	select {
+	case merged := <-mergedCh:
+		require.Equal(t, keptnv2.ResultPass, merged.Result)
+		require.Equal(t, []*keptnv2.SLIResult{
+			{Metric: "response_time_p95", Success: false, Message: "SLI provider prometheus did not respond within 10ms"},
+			{Metric: "error_rate", Success: false, Message: "SLI provider prometheus did not respond within 10ms"},
+			{Metric: "conversion_rate", Value: 0.2, Success: true},
+		}, merged.GetSLI.IndicatorValues)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the timeout has not been reached")
+	}
+
+	// the late result of the provider is ignored
+	merged, tracked := aggregator.Add("id-1", &keptnv2.GetSLIFinishedEventData{})
+	require.True(t, tracked)
+	require.Nil(t, merged)
+}
+
+func getSLIEventStoreForTest(triggeredAt time.Time, storedEvents ...*models.KeptnContextExtendedCE) *event_handler_mock.EventStoreMock {
+	template := getSLIRetrievalTemplate()
+	events := storedEvents
+	for _, request := range getSLIRequestsForTest() {
+		events = append(events, &models.KeptnContextExtendedCE{
+			ID:             request.TriggeredID,
+			Shkeptncontext: "my-context",
+			Time:           triggeredAt,
+			Type:           strutils.Stringp(keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName)),
+			Data: keptnv2.GetSLITriggeredEventData{
+				EventData: template.EventData,
+				GetSLI:    keptnv2.GetSLI{SLIProvider: request.Provider, Start: template.GetSLI.Start, End: template.GetSLI.End, Indicators: request.Indicators},
+			},
+		})
+	}
+	return &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+		result := []*models.KeptnContextExtendedCE{}
+		for _, event := range events {
+			if *event.Type == filter.EventType && event.Shkeptncontext == filter.KeptnContext {
+				result = append(result, event)
+			}
+		}
+		return result, nil
+	}}
+}
+
+func TestSLIResultAggregator_Restore(t *testing.T) {
+	aggregator := NewSLIResultAggregator(time.Hour)
+	eventStore := getSLIEventStoreForTest(time.Now(), &models.KeptnContextExtendedCE{
+		ID:             "finished-id-1",
+		Shkeptncontext: "my-context",
+		Triggeredid:    "id-1",
+		Type:           strutils.Stringp(keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName)),
+		Data: keptnv2.GetSLIFinishedEventData{
+			EventData: keptnv2.EventData{Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+			GetSLI:    keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 200, Success: true}, {Metric: "error_rate", Value: 0, Success: true}}},
+		},
+	})
+	onTimeout := func(merged *keptnv2.GetSLIFinishedEventData) {
+		t.Error("the timeout should not be reached")
+	}
+
+	// results of unknown get-sli.triggered events are evaluated on their own
+	merged, tracked, err := aggregator.Restore(eventStore, "my-context", "unknown-id", &keptnv2.GetSLIFinishedEventData{}, onTimeout)
+	require.Nil(t, err)
+	require.False(t, tracked)
+	require.Nil(t, merged)
+
+	// the result of the second provider is merged with the stored result of the first provider
+	merged, tracked, err = aggregator.Restore(eventStore, "my-context", "id-2", &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass},
+		GetSLI:    keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "conversion_rate", Value: 0.2, Success: true}}},
+	}, onTimeout)
+	require.Nil(t, err)
+	require.True(t, tracked)
+	require.NotNil(t, merged)
+	require.Equal(t, "sockshop", merged.Project)
+	require.Equal(t, "2022-03-01T12:00:00Z", merged.GetSLI.Start)
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Value: 200, Success: true},
+		{Metric: "error_rate", Value: 0, Success: true},
+		{Metric: "conversion_rate", Value: 0.2, Success: true},
+	}, merged.GetSLI.IndicatorValues)
+
+	// results received after the evaluation are ignored
+	merged, tracked = aggregator.Add("id-1", &keptnv2.GetSLIFinishedEventData{})
+	require.True(t, tracked)
+	require.Nil(t, merged)
+}
+
+func TestSLIResultAggregator_RestoreWaitsForMissingProviders(t *testing.T) {
+	aggregator := NewSLIResultAggregator(time.Hour)
+	eventStore := getSLIEventStoreForTest(time.Now())
+
+	merged, tracked, err := aggregator.Restore(eventStore, "my-context", "id-2", &keptnv2.GetSLIFinishedEventData{}, func(merged *keptnv2.GetSLIFinishedEventData) {
+		t.Error("the timeout should not be reached")
+	})
+	require.Nil(t, err)
+	require.True(t, tracked)
+	require.Nil(t, merged)
+
+	// the restored retrieval is completed by the result of the missing provider
+	merged, tracked = aggregator.Add("id-1", &keptnv2.GetSLIFinishedEventData{})
+	require.True(t, tracked)
+	require.NotNil(t, merged)
+}
+
+func TestSLIResultAggregator_RestoreAfterTimeout(t *testing.T) {
+	aggregator := NewSLIResultAggregator(time.Minute)
+	eventStore := getSLIEventStoreForTest(time.Now().Add(-time.Hour))
+
+	// the timeout of the retrieval has passed while no instance tracked it, so the available SLIs are evaluated immediately
+	merged, tracked, err := aggregator.Restore(eventStore, "my-context", "id-2", &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "conversion_rate", Value: 0.2, Success: true}}},
+	}, func(merged *keptnv2.GetSLIFinishedEventData) {
+		t.Error("the timeout should not be awaited")
+	})
+	require.Nil(t, err)
+	require.True(t, tracked)
+	require.NotNil(t, merged)
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "response_time_p95", Success: false, Message: "SLI provider prometheus did not respond within 1m0s"},
+		{Metric: "error_rate", Success: false, Message: "SLI provider prometheus did not respond within 1m0s"},
+		{Metric: "conversion_rate", Value: 0.2, Success: true},
+	}, merged.GetSLI.IndicatorValues)
+}
+
+func TestSLIResultAggregator_RestoreEvaluatedRetrieval(t *testing.T) {
+	aggregator := NewSLIResultAggregator(time.Hour)
+	template := getSLIRetrievalTemplate()
+	eventStore := getSLIEventStoreForTest(time.Now(), &models.KeptnContextExtendedCE{
+		ID:             "evaluation-finished-id",
+		Shkeptncontext: "my-context",
+		Type:           strutils.Stringp(keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)),
+		Data: keptnv2.EvaluationFinishedEventData{
+			Evaluation: keptnv2.EvaluationDetails{TimeStart: template.GetSLI.Start, TimeEnd: template.GetSLI.End},
+		},
+	})
+
+	// late results of an evaluation that has been conducted by another instance are ignored
+	merged, tracked, err := aggregator.Restore(eventStore, "my-context", "id-1", &keptnv2.GetSLIFinishedEventData{}, func(merged *keptnv2.GetSLIFinishedEventData) {
+		t.Error("the timeout should not be reached")
+	})
+	require.Nil(t, err)
+	require.True(t, tracked)
+	require.Nil(t, merged)
+}
+
+func TestParseSLIProviders(t *testing.T) {
+	sliProviders, err := parseSLIProviders([]byte(`objectives:
+  - sli: response_time_p95
+  - sli: conversion_rate
+    provider: business-metrics-provider
+`))
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"conversion_rate": "business-metrics-provider"}, sliProviders)
+
+	_, err = parseSLIProviders([]byte("objectives: invalid"))
+	require.NotNil(t, err)
+}
+
+func TestGetSLIRequests(t *testing.T) {
+	indicators := []string{"response_time_p95", "conversion_rate", "error_rate"}
+
+	requests := getSLIRequests(indicators, map[string]string{"conversion_rate": "business-metrics-provider"}, "prometheus")
+	require.Equal(t, []SLIRequest{
+		{Provider: "prometheus", Indicators: []string{"response_time_p95", "error_rate"}},
+		{Provider: "business-metrics-provider", Indicators: []string{"conversion_rate"}},
+	}, requests)
+
+	// explicitly naming the default provider does not result in an additional request
+	requests = getSLIRequests(indicators, map[string]string{"conversion_rate": "prometheus"}, "prometheus")
+	require.Equal(t, []SLIRequest{{Provider: "prometheus", Indicators: indicators}}, requests)
+
+	require.True(t, allIndicatorsHaveProvider(indicators, map[string]string{"response_time_p95": "a", "conversion_rate": "b", "error_rate": "a"}))
+	require.False(t, allIndicatorsHaveProvider(indicators, map[string]string{"conversion_rate": "b"}))
+	require.False(t, allIndicatorsHaveProvider([]string{}, map[string]string{}))
+}
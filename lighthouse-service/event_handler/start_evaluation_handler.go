@@ -16,10 +16,11 @@ import (
 )
 
 type StartEvaluationHandler struct {
-	Event             cloudevents.Event
-	KeptnHandler      *keptnv2.Keptn
-	SLIProviderConfig SLIProviderConfig
-	SLOFileRetriever  SLOFileRetriever `deep:"-"`
+	Event               cloudevents.Event
+	KeptnHandler        *keptnv2.Keptn
+	SLIProviderConfig   SLIProviderConfig
+	SLOFileRetriever    SLOFileRetriever     `deep:"-"`
+	SLIResultAggregator *SLIResultAggregator `deep:"-"`
 }
 
 func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
@@ -81,14 +82,23 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 
 	indicators := []string{}
 	var filters = []*keptnv2.SLIFilter{}
+	sliProviders := map[string]string{}
 
-	if err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, evaluationStartTimestamp, evaluationEndTimestamp); end {
+	if err2, end := eh.computeObjectives(e, commitID, &indicators, &filters, sliProviders, evaluationStartTimestamp, evaluationEndTimestamp); end {
 		return err2
 	}
 
-	// get the SLI provider that has been configured for the project (e.g. 'dynatrace' or 'prometheus') from the respective configmap
+	// get the SLI provider that has been configured for the service, stage or project (e.g. 'dynatrace' or 'prometheus') from the lighthouse-config.yaml resource or the respective configmap.
+	// It is not needed if all objectives name their SLI provider
 	var sliProvider string
-	sliProvider, err := eh.SLIProviderConfig.GetSLIProvider(e.Project)
+	var err error
+	if !allIndicatorsHaveProvider(indicators, sliProviders) {
+		sliProvider, err = eh.SLIProviderConfig.GetSLIProvider(e.Project, e.Stage, e.Service, commitID)
+	}
+	if errors.Is(err, ErrReadSLIProviderConfig) {
+		logger.Error(err.Error())
+		return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error())
+	}
 	if err != nil {
 		// no provider found - fallback to default SLI provider
 		sliProvider, err = eh.SLIProviderConfig.GetDefaultSLIProvider()
@@ -118,20 +128,86 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 			return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evaluationFinishedData)
 		}
 	}
+	requests := getSLIRequests(indicators, sliProviders, sliProvider)
+	if len(requests) > 1 {
+		return eh.sendGetSLIEventsToProviders(keptnContext, commitID, e, requests, evaluationStartTimestamp, evaluationEndTimestamp, filters)
+	}
+	if len(requests) == 1 {
+		sliProvider = requests[0].Provider
+	}
+
 	// send a new event to trigger the SLI retrieval
 	logger.Debug("SLI provider for project " + e.Project + " is: " + sliProvider)
-	err = eh.sendInternalGetSLIEvent(keptnContext, commitID, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
+	err = eh.sendInternalGetSLIEvent(uuid.New().String(), keptnContext, commitID, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
 	return nil
 }
 
-func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
-	objectives, _, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
+// sendGetSLIEventsToProviders sends a get-sli.triggered event to each SLI provider of the SLO. The get-sli.finished events of the providers are merged
+// by the SLIResultAggregator before the evaluation. If not all providers respond in time, the available SLIs are evaluated
+func (eh *StartEvaluationHandler) sendGetSLIEventsToProviders(keptnContext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, requests []SLIRequest, start string, end string, filters []*keptnv2.SLIFilter) error {
+	for i := range requests {
+		requests[i].TriggeredID = uuid.New().String()
+	}
+
+	template := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: e.Project,
+			Stage:   e.Stage,
+			Service: e.Service,
+			Labels:  e.Labels,
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: start,
+			End:   end,
+		},
+	}
+
+	aggregator := eh.SLIResultAggregator
+	if aggregator == nil {
+		aggregator = GetSLIResultAggregator()
+	}
+	// the retrieval has to be registered before the events are sent, since the providers may respond immediately
+	aggregator.Register(keptnContext, requests, template, eh.KeptnHandler.EventHandler, evaluateMergedSLIResults(eh.Event, eh.KeptnHandler, eh.SLOFileRetriever, keptnContext, commitID))
+
+	for _, request := range requests {
+		logger.Debugf("Requesting SLIs %v from SLI provider %s", request.Indicators, request.Provider)
+		if err := eh.sendInternalGetSLIEvent(request.TriggeredID, keptnContext, commitID, e, request.Provider, request.Indicators, start, end, filters); err != nil {
+			// the SLIs of this provider will be marked as failed once the timeout is reached
+			logger.WithError(err).Errorf("Could not send get-sli.triggered event to SLI provider %s", request.Provider)
+		}
+	}
+	return nil
+}
+
+func allIndicatorsHaveProvider(indicators []string, sliProviders map[string]string) bool {
+	if len(indicators) == 0 {
+		return false
+	}
+	for _, indicator := range indicators {
+		if sliProviders[indicator] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, sliProviders map[string]string, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
+	objectives, sloFileContent, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
 	if err == nil && objectives != nil {
 		logger.Info("SLO file found")
 		for _, objective := range objectives.Objectives {
 			*indicators = append(*indicators, objective.SLI)
 		}
 
+		objectiveProviders, err := parseSLIProviders(sloFileContent)
+		if err != nil {
+			logger.Error(err.Error())
+			return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, err.Error()), true
+		}
+		for sli, provider := range objectiveProviders {
+			sliProviders[sli] = provider
+		}
+
 		if objectives.Filter != nil {
 			for key, value := range objectives.Filter {
 				filter := &keptnv2.SLIFilter{
@@ -203,7 +279,7 @@ func getEvaluationTimestamps(e *keptnv2.EvaluationTriggeredEventData) (string, s
 	return "", "", errors.New("evaluation.triggered event does not contain evaluation timeframe")
 }
 
-func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, sliProvider string, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
+func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(eventID string, shkeptncontext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, sliProvider string, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
 	source, _ := url.Parse("lighthouse-service")
 
 	getSLITriggeredEventData := keptnv2.GetSLITriggeredEventData{
@@ -227,7 +303,7 @@ func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string,
 	}
 
 	event := cloudevents.NewEvent()
-	event.SetID(uuid.New().String())
+	event.SetID(eventID)
 	event.SetType(keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName))
 	event.SetSource(source.String())
 	event.SetDataContentType(cloudevents.ApplicationJSON)
@@ -58,8 +58,8 @@ func (m *MockSLIProviderConfig) GetDefaultSLIProvider() (string, error) {
 	return m.DefaultSLIProvider.val, m.DefaultSLIProvider.err
 }
 
-func (m *MockSLIProviderConfig) GetSLIProvider(project string) (string, error) {
-	return m.ProjectSLIProvider.val, m.DefaultSLIProvider.err
+func (m *MockSLIProviderConfig) GetSLIProvider(project, stage, service, commitID string) (string, error) {
+	return m.ProjectSLIProvider.val, m.ProjectSLIProvider.err
 }
 
 func TestStartEvaluationHandler_HandleEvent(t *testing.T) {
@@ -225,6 +225,37 @@ func TestStartEvaluationHandler_HandleEvent(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name:     "SLI provider configuration cannot be read - return evaluation.finished event",
+			metadata: &keptnapi.Version{},
+			fields: fields{
+				Event: getStartEvaluationEvent(),
+				SLOFileRetriever: SLOFileRetriever{
+					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+						GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*keptnapi.Resource, error) {
+							return nil, nil
+						},
+					},
+				},
+			},
+			sloAvailable:  false,
+			wantEventType: []string{keptnv2.GetStartedEventType(keptnv2.EvaluationTaskName), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)},
+			wantErr:       false,
+			ProjectSLIProvider: struct {
+				val string
+				err error
+			}{
+				val: "",
+				err: fmt.Errorf("%w from /project/sockshop/resource/lighthouse-config.yaml: could not check out branch", ErrReadSLIProviderConfig),
+			},
+			DefaultSLIProvider: struct {
+				val string
+				err error
+			}{
+				val: "default-sli-provider",
+				err: nil,
+			},
+		},
 		{
 			name:     "Retrieve SLO by commitID",
 			metadata: &keptnapi.Version{Version: "myID"},
@@ -492,3 +523,81 @@ func Test_getEvaluationTimestamps(t *testing.T) {
 		})
 	}
 }
+
+func TestStartEvaluationHandler_MultipleSLIProviders(t *testing.T) {
+	type getSLITriggeredEvent struct {
+		ID   string                           `json:"id"`
+		Type string                           `json:"type"`
+		Data keptnv2.GetSLITriggeredEventData `json:"data"`
+	}
+	ch := make(chan getSLITriggeredEvent, 10)
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			event := getSLITriggeredEvent{}
+			_ = json.NewDecoder(r.Body).Decode(&event)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+			w.Write([]byte(`{}`))
+			if event.Type == keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName) {
+				ch <- event
+			}
+		}),
+	)
+	defer ts.Close()
+
+	sloFileContent := `objectives:
+  - sli: response_time_p95
+  - sli: error_rate
+  - sli: conversion_rate
+    provider: business-metrics-provider
+`
+	event := getStartEvaluationEvent()
+	keptnHandler, _ := keptnv2.NewKeptn(&event, keptncommon.KeptnOpts{EventBrokerURL: ts.URL})
+	aggregator := NewSLIResultAggregator(time.Hour)
+	eh := &StartEvaluationHandler{
+		Event:        event,
+		KeptnHandler: keptnHandler,
+		SLIProviderConfig: &MockSLIProviderConfig{
+			ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "prometheus"},
+		},
+		SLOFileRetriever: SLOFileRetriever{
+			ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+				GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*keptnapi.Resource, error) {
+					return &keptnapi.Resource{ResourceContent: sloFileContent}, nil
+				},
+			},
+		},
+		SLIResultAggregator: aggregator,
+	}
+	require.Nil(t, eh.HandleEvent(context.Background()))
+
+	requests := map[string]getSLITriggeredEvent{}
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-ch:
+			requests[event.Data.GetSLI.SLIProvider] = event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected get-sli.triggered events for 2 SLI providers, received %d", len(requests))
+		}
+	}
+	require.Equal(t, []string{"response_time_p95", "error_rate"}, requests["prometheus"].Data.GetSLI.Indicators)
+	require.Equal(t, []string{"conversion_rate"}, requests["business-metrics-provider"].Data.GetSLI.Indicators)
+
+	// the results are merged once both providers have responded
+	merged, tracked := aggregator.Add(requests["business-metrics-provider"].ID, &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "conversion_rate", Value: 0.2, Success: true}}},
+	})
+	require.True(t, tracked)
+	require.Nil(t, merged)
+
+	merged, tracked = aggregator.Add(requests["prometheus"].ID, &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 500, Success: true}, {Metric: "error_rate", Value: 0, Success: true}}},
+	})
+	require.True(t, tracked)
+	require.NotNil(t, merged)
+	require.Equal(t, "sockshop", merged.Project)
+	require.Len(t, merged.GetSLI.IndicatorValues, 3)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/keptn/keptn/cp-connector/pkg/logforwarder"
 	"github.com/keptn/keptn/cp-connector/pkg/subscriptionsource"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -66,6 +67,8 @@ func main() {
 
 	controlPlane := controlplane.New(subscriptionSource, eventSource, logForwarder, controlplane.WithLogger(log))
 
+	// the dry-run endpoint is served by the same server as the health endpoint
+	http.HandleFunc(event_handler.DryRunEvaluationPath, event_handler.DryRunEvaluationHandler)
 	go func() {
 		keptnapi.RunHealthEndpoint("8080", keptnapi.WithReadinessConditionFunc(func() bool {
 			return controlPlane.IsRegistered()
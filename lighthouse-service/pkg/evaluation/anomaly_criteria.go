@@ -0,0 +1,265 @@
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const (
+	// anomalyStdDev checks whether the value is within N standard deviations of the mean of the previous results
+	anomalyStdDev = "stddev"
+	// anomalyMAD checks whether the value is within N (scaled) median absolute deviations of the median of the previous results
+	anomalyMAD = "mad"
+	// anomalyTrend checks the slope of the linear trend of the previous results and the value
+	anomalyTrend = "trend"
+)
+
+// madScaleFactor scales the median absolute deviation, so that it is comparable to the standard deviation of normally distributed values
+const madScaleFactor = 1.4826
+
+// the criteria strings are matched after all whitespaces have been removed, e.g. "within 2 stddev of last 10" -> "within2stddevoflast10"
+var withinCriteriaRegex = regexp.MustCompile(`^within(\d+\.?\d*)(stddev|mad)(oflast(\d+))?$`)
+var trendCriteriaRegex = regexp.MustCompile(`^trend(<=|<|=|>=|>)([+-]?\d+\.?\d*)(%?)(oflast(\d+))?$`)
+
+func isAnomalyCriteria(criteria string) bool {
+	return strings.HasPrefix(criteria, "within") || strings.HasPrefix(criteria, anomalyTrend)
+}
+
+// parseAnomalyCriteriaString parses criteria that evaluate a value based on the distribution of the previous results.
+// Example values: "within 2 stddev", "within 3 mad of last 10", "trend <= +5%", "trend < 20 of last 5"
+func parseAnomalyCriteriaString(criteria string) (*criteriaObject, error) {
+	if matches := withinCriteriaRegex.FindStringSubmatch(criteria); matches != nil {
+		factor, err := strconv.ParseFloat(matches[1], 64)
+		if err != nil {
+			return nil, errors.New("could not parse criteria target value")
+		}
+		numberOfResults, err := parseNumberOfAnomalyResults(matches[4])
+		if err != nil {
+			return nil, err
+		}
+		return &criteriaObject{
+			Operator:        "<=",
+			Value:           factor,
+			IsComparison:    true,
+			AnomalyType:     matches[2],
+			NumberOfResults: numberOfResults,
+		}, nil
+	}
+
+	if matches := trendCriteriaRegex.FindStringSubmatch(criteria); matches != nil {
+		slope, err := strconv.ParseFloat(matches[2], 64)
+		if err != nil {
+			return nil, errors.New("could not parse criteria target value")
+		}
+		numberOfResults, err := parseNumberOfAnomalyResults(matches[5])
+		if err != nil {
+			return nil, err
+		}
+		return &criteriaObject{
+			Operator:        matches[1],
+			Value:           slope,
+			CheckPercentage: matches[3] == "%",
+			IsComparison:    true,
+			CheckIncrease:   slope >= 0,
+			AnomalyType:     anomalyTrend,
+			NumberOfResults: numberOfResults,
+		}, nil
+	}
+
+	return nil, errors.New("invalid criteria string")
+}
+
+func parseNumberOfAnomalyResults(numberOfResults string) (int, error) {
+	if numberOfResults == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(numberOfResults)
+	if err != nil || value <= 0 {
+		return 0, errors.New("could not parse number of previous results")
+	}
+	return value, nil
+}
+
+// getNumberOfComparisonResults returns the number of previous results that are considered by comparisons, based on the comparison settings of the SLO
+func getNumberOfComparisonResults(comparison *keptn.SLOComparison) int {
+	if comparison == nil {
+		return 3
+	}
+	switch comparison.CompareWith {
+	case "single_result":
+		return 1
+	case "several_results":
+		return comparison.NumberOfComparisonResults
+	}
+	return 3
+}
+
+// getMaxNumberOfAnomalyResults returns the highest number of previous results that is explicitly requested by an anomaly criteria of the SLO
+func getMaxNumberOfAnomalyResults(sloConfig *keptn.ServiceLevelObjectives) int {
+	maxNumberOfResults := 0
+	for _, objective := range sloConfig.Objectives {
+		for _, sloCriteria := range append(objective.Pass, objective.Warning...) {
+			if sloCriteria == nil {
+				continue
+			}
+			for _, criteria := range sloCriteria.Criteria {
+				co, err := parseCriteriaString(criteria)
+				if err != nil || co.AnomalyType == "" {
+					continue
+				}
+				if co.NumberOfResults > maxNumberOfResults {
+					maxNumberOfResults = co.NumberOfResults
+				}
+			}
+		}
+	}
+	return maxNumberOfResults
+}
+
+// limitPreviousResults returns the given number of most recent previous results
+func limitPreviousResults(previousResults []*keptnv2.SLIEvaluationResult, numberOfResults int) []*keptnv2.SLIEvaluationResult {
+	if numberOfResults <= 0 || len(previousResults) <= numberOfResults {
+		return previousResults
+	}
+	return previousResults[:numberOfResults]
+}
+
+// getSuccessfulPreviousValues returns the values of the successful previous results in chronological order, i.e. the most recent value is the last one
+func getSuccessfulPreviousValues(previousResults []*keptnv2.SLIEvaluationResult) []float64 {
+	values := []float64{}
+	// previous results are ordered from the most recent to the oldest one
+	for i := len(previousResults) - 1; i >= 0; i-- {
+		if previousResults[i].Value != nil && previousResults[i].Value.Success {
+			values = append(values, previousResults[i].Value.Value)
+		}
+	}
+	return values
+}
+
+// evaluateAnomaly evaluates the value against the distribution of the previous results. The computed bounds are appended to the criteria of the violation,
+// and the bound the value is closest to is used as its target value. If not enough previous results are available, the evaluation passes
+func evaluateAnomaly(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, violation *keptnv2.SLITarget) (bool, error) {
+	numberOfResults := co.NumberOfResults
+	if numberOfResults == 0 {
+		numberOfResults = getNumberOfComparisonResults(comparison)
+	}
+	values := getSuccessfulPreviousValues(limitPreviousResults(previousResults, numberOfResults))
+
+	switch co.AnomalyType {
+	case anomalyStdDev:
+		if len(values) < 2 {
+			return true, nil
+		}
+		mean := calculateAverage(values)
+		sliResult.ComparedValue = mean
+		return evaluateWithinBounds(sliResult.Value, mean, co.Value*calculateStandardDeviation(values, mean), violation), nil
+	case anomalyMAD:
+		if len(values) < 2 {
+			return true, nil
+		}
+		median := calculateMedian(values)
+		sliResult.ComparedValue = median
+		return evaluateWithinBounds(sliResult.Value, median, co.Value*madScaleFactor*calculateMedianAbsoluteDeviation(values, median), violation), nil
+	case anomalyTrend:
+		return evaluateTrend(sliResult, co, values, violation)
+	}
+	return false, fmt.Errorf("unknown anomaly criteria type %s", co.AnomalyType)
+}
+
+func evaluateWithinBounds(value float64, center float64, deviation float64, violation *keptnv2.SLITarget) bool {
+	lowerBound := center - deviation
+	upperBound := center + deviation
+
+	violation.Criteria = fmt.Sprintf("%s [%s, %s]", violation.Criteria, formatBound(lowerBound), formatBound(upperBound))
+	if value >= center {
+		violation.TargetValue = upperBound
+	} else {
+		violation.TargetValue = lowerBound
+	}
+	return value >= lowerBound && value <= upperBound
+}
+
+// evaluateTrend fits a linear trend through the previous values and the current value, and compares its slope (i.e. the change per evaluation) with the criteria.
+// For percentage criteria, the slope is relative to the average of the previous values. The target value is the value at which the slope would match the criteria
+func evaluateTrend(sliResult *keptnv2.SLIResult, co *criteriaObject, previousValues []float64, violation *keptnv2.SLITarget) (bool, error) {
+	if len(previousValues) < 2 {
+		return true, nil
+	}
+	average := calculateAverage(previousValues)
+	sliResult.ComparedValue = average
+	if co.CheckPercentage && average == 0 {
+		// a relative change cannot be calculated
+		return true, nil
+	}
+
+	values := append(append([]float64{}, previousValues...), sliResult.Value)
+	slope, slopeSensitivity := calculateLinearTrend(values)
+
+	targetSlope := co.Value
+	if co.CheckPercentage {
+		targetSlope = co.Value * math.Abs(average) / 100.0
+		violation.Criteria = fmt.Sprintf("%s [slope: %s%%]", violation.Criteria, formatBound(100.0*slope/math.Abs(average)))
+	} else {
+		violation.Criteria = fmt.Sprintf("%s [slope: %s]", violation.Criteria, formatBound(slope))
+	}
+	// the value that would result in the target slope, given all previous values
+	violation.TargetValue = sliResult.Value + (targetSlope-slope)/slopeSensitivity
+
+	return evaluateValue(slope, targetSlope, co.Operator)
+}
+
+// calculateLinearTrend returns the slope of the least squares regression line through the given values, which are assumed to be equidistant.
+// Additionally, it returns the change of the slope per unit change of the last value
+func calculateLinearTrend(values []float64) (float64, float64) {
+	n := float64(len(values))
+	meanX := (n - 1) / 2
+	meanY := calculateAverage(values)
+
+	sxx := 0.0
+	sxy := 0.0
+	for i, value := range values {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (value - meanY)
+	}
+	return sxy / sxx, (n - 1 - meanX) / sxx
+}
+
+func calculateStandardDeviation(values []float64, mean float64) float64 {
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	// sample standard deviation, since the previous results are only a sample of the expected distribution
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+func calculateMedian(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func calculateMedianAbsoluteDeviation(values []float64, median float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - median)
+	}
+	return calculateMedian(deviations)
+}
+
+func formatBound(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
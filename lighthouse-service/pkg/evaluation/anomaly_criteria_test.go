@@ -0,0 +1,187 @@
+package evaluation
+
+import (
+	"testing"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnomalyCriteriaString(t *testing.T) {
+	tests := []struct {
+		criteria string
+		want     *criteriaObject
+		wantErr  bool
+	}{
+		{
+			criteria: "within 2 stddev",
+			want:     &criteriaObject{Operator: "<=", Value: 2, IsComparison: true, AnomalyType: anomalyStdDev},
+		},
+		{
+			criteria: "within 2.5 stddev of last 10",
+			want:     &criteriaObject{Operator: "<=", Value: 2.5, IsComparison: true, AnomalyType: anomalyStdDev, NumberOfResults: 10},
+		},
+		{
+			criteria: "within 3 mad of last 5",
+			want:     &criteriaObject{Operator: "<=", Value: 3, IsComparison: true, AnomalyType: anomalyMAD, NumberOfResults: 5},
+		},
+		{
+			criteria: "trend <= +5%",
+			want:     &criteriaObject{Operator: "<=", Value: 5, CheckPercentage: true, IsComparison: true, CheckIncrease: true, AnomalyType: anomalyTrend},
+		},
+		{
+			criteria: "trend > -20 of last 4",
+			want:     &criteriaObject{Operator: ">", Value: -20, IsComparison: true, AnomalyType: anomalyTrend, NumberOfResults: 4},
+		},
+		{
+			criteria: "within two stddev",
+			wantErr:  true,
+		},
+		{
+			criteria: "within 2 stddev of last 0",
+			wantErr:  true,
+		},
+		{
+			criteria: "trend 5%",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.criteria, func(t *testing.T) {
+			co, err := parseCriteriaString(tt.criteria)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, co)
+		})
+	}
+}
+
+func TestEvaluateAnomaly(t *testing.T) {
+	// most recent result first
+	previousResults := func(values ...float64) []*keptnv2.SLIEvaluationResult {
+		results := []*keptnv2.SLIEvaluationResult{}
+		for _, value := range values {
+			results = append(results, &keptnv2.SLIEvaluationResult{Value: &keptnv2.SLIResult{Metric: "response_time_p95", Value: value, Success: true}})
+		}
+		return results
+	}
+	comparison := &keptn.SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 4, AggregateFunction: "avg"}
+
+	tests := []struct {
+		name              string
+		criteria          string
+		value             float64
+		previousResults   []*keptnv2.SLIEvaluationResult
+		wantSatisfied     bool
+		wantCriteria      string
+		wantTargetValue   float64
+		wantComparedValue float64
+	}{
+		{
+			name:              "within standard deviations",
+			criteria:          "within 2 stddev",
+			value:             104,
+			previousResults:   previousResults(98, 102, 98, 102),
+			wantSatisfied:     true,
+			wantCriteria:      "within 2 stddev [95.38, 104.62]",
+			wantTargetValue:   104.61880215351701,
+			wantComparedValue: 100,
+		},
+		{
+			name:              "outside of standard deviations",
+			criteria:          "within 2 stddev",
+			value:             90,
+			previousResults:   previousResults(98, 102, 98, 102),
+			wantSatisfied:     false,
+			wantCriteria:      "within 2 stddev [95.38, 104.62]",
+			wantTargetValue:   95.38119784648299,
+			wantComparedValue: 100,
+		},
+		{
+			name:              "only the given number of previous results is considered",
+			criteria:          "within 1 stddev of last 2",
+			value:             100,
+			previousResults:   previousResults(200, 202, 98, 102),
+			wantSatisfied:     false,
+			wantCriteria:      "within 1 stddev of last 2 [199.59, 202.41]",
+			wantTargetValue:   199.5857864376269,
+			wantComparedValue: 201,
+		},
+		{
+			name:              "outlier in previous results does not affect median absolute deviation",
+			criteria:          "within 3 mad",
+			value:             103,
+			previousResults:   previousResults(100, 101, 99, 500),
+			wantSatisfied:     true,
+			wantCriteria:      "within 3 mad [96.05, 104.95]",
+			wantTargetValue:   104.9478,
+			wantComparedValue: 100.5,
+		},
+		{
+			name:              "increasing trend",
+			criteria:          "trend <= +5%",
+			value:             130,
+			previousResults:   previousResults(120, 110, 100),
+			wantSatisfied:     false,
+			wantCriteria:      "trend <= +5% [slope: 9.09%]",
+			wantTargetValue:   115,
+			wantComparedValue: 110,
+		},
+		{
+			name:              "stable trend",
+			criteria:          "trend < 5",
+			value:             100,
+			previousResults:   previousResults(101, 99, 100),
+			wantSatisfied:     true,
+			wantCriteria:      "trend < 5 [slope: 0.2]",
+			wantTargetValue:   116,
+			wantComparedValue: 100,
+		},
+		{
+			name:            "not enough previous results",
+			criteria:        "within 2 stddev",
+			value:           1000,
+			previousResults: previousResults(100),
+			wantSatisfied:   true,
+			wantCriteria:    "within 2 stddev",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sliResult := &keptnv2.SLIResult{Metric: "response_time_p95", Value: tt.value, Success: true}
+			target := &keptnv2.SLITarget{Criteria: tt.criteria}
+
+			satisfied, err := evaluateSingleCriteria(sliResult, tt.criteria, tt.previousResults, comparison, target)
+
+			require.Nil(t, err)
+			require.Equal(t, tt.wantSatisfied, satisfied)
+			require.Equal(t, tt.wantCriteria, target.Criteria)
+			require.InDelta(t, tt.wantTargetValue, target.TargetValue, 0.0001)
+			require.InDelta(t, tt.wantComparedValue, sliResult.ComparedValue, 0.0001)
+		})
+	}
+}
+
+func TestGetMaxNumberOfAnomalyResults(t *testing.T) {
+	sloConfig := &keptn.ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{
+			{
+				SLI:  "response_time_p95",
+				Pass: []*keptn.SLOCriteria{{Criteria: []string{"<=+10%", "within 2 stddev of last 10"}}},
+			},
+			{
+				SLI:     "throughput",
+				Warning: []*keptn.SLOCriteria{{Criteria: []string{"trend > -5% of last 20"}}},
+			},
+			{
+				SLI: "error_rate",
+			},
+		},
+	}
+	require.Equal(t, 20, getMaxNumberOfAnomalyResults(sloConfig))
+	require.Equal(t, 0, getMaxNumberOfAnomalyResults(&keptn.ServiceLevelObjectives{}))
+}
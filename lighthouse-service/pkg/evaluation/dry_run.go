@@ -0,0 +1,82 @@
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// DryRunRequest contains everything that is needed to evaluate SLI results against an SLO
+type DryRunRequest struct {
+	// SLO is the content of an slo.yaml file
+	SLO string `json:"slo"`
+	// IndicatorValues are the SLI results, as they would be provided by an SLI provider
+	IndicatorValues []*keptnv2.SLIResult `json:"indicatorValues"`
+	// PreviousEvaluations are the results of previous evaluations, ordered from the most recent to the oldest one
+	PreviousEvaluations []*keptnv2.EvaluationFinishedEventData `json:"previousEvaluations,omitempty"`
+	Project             string                                 `json:"project,omitempty"`
+	Stage               string                                 `json:"stage,omitempty"`
+	Service             string                                 `json:"service,omitempty"`
+	Start               string                                 `json:"start,omitempty"`
+	End                 string                                 `json:"end,omitempty"`
+	Labels              map[string]string                      `json:"labels,omitempty"`
+}
+
+// EvaluateDryRun evaluates the SLI results of the request the same way an evaluation sequence would, but without retrieving or emitting any events
+func EvaluateDryRun(request DryRunRequest) (*EvaluationFinishedEventData, error) {
+	if strings.TrimSpace(request.SLO) == "" {
+		return nil, errors.New("no SLO provided")
+	}
+	sloConfig, err := ParseSLO([]byte(request.SLO))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SLO: %w", err)
+	}
+
+	e := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: request.Project,
+			Stage:   request.Stage,
+			Service: request.Service,
+			Labels:  request.Labels,
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: request.Start,
+			End:   request.End,
+		},
+	}
+	for _, indicatorValue := range request.IndicatorValues {
+		if indicatorValue != nil {
+			e.GetSLI.IndicatorValues = append(e.GetSLI.IndicatorValues, indicatorValue)
+		}
+	}
+
+	previousEvaluations := filterPreviousEvaluations(request.PreviousEvaluations, GetNumberOfPreviousResults(sloConfig), sloConfig.Comparison.IncludeResultWithScore)
+	return EvaluateSLIs(e, sloConfig, []byte(request.SLO), previousEvaluations)
+}
+
+// filterPreviousEvaluations applies the same filters to the given previous evaluations that are used when fetching them from the datastore
+func filterPreviousEvaluations(previousEvaluations []*keptnv2.EvaluationFinishedEventData, numberOfPreviousResults int, includeResult string) []*keptnv2.EvaluationFinishedEventData {
+	var filtered []*keptnv2.EvaluationFinishedEventData
+	for _, previousEvaluation := range previousEvaluations {
+		if previousEvaluation == nil {
+			continue
+		}
+		switch strings.ToLower(includeResult) {
+		case "pass":
+			if previousEvaluation.Result != keptnv2.ResultPass {
+				continue
+			}
+		case "pass_or_warn":
+			if previousEvaluation.Result != keptnv2.ResultPass && previousEvaluation.Result != keptnv2.ResultWarning {
+				continue
+			}
+		}
+		filtered = append(filtered, previousEvaluation)
+		if len(filtered) == numberOfPreviousResults {
+			break
+		}
+	}
+	return filtered
+}
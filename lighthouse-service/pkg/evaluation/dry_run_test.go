@@ -0,0 +1,157 @@
+package evaluation
+
+import (
+	"strings"
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const dryRunTestSLO = `---
+spec_version: "1.0"
+comparison:
+  compare_with: "single_result"
+  include_result_with_score: "pass"
+  aggregate_function: avg
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+    warning:
+      - criteria:
+          - "<=800"
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "<1"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+func previousEvaluation(result keptnv2.ResultType, responseTime float64) *keptnv2.EvaluationFinishedEventData {
+	return &keptnv2.EvaluationFinishedEventData{
+		EventData: keptnv2.EventData{Result: result},
+		Evaluation: keptnv2.EvaluationDetails{
+			IndicatorResults: []*keptnv2.SLIEvaluationResult{
+				{Value: &keptnv2.SLIResult{Metric: "response_time_p95", Value: responseTime, Success: true}},
+			},
+		},
+	}
+}
+
+func TestEvaluateDryRun(t *testing.T) {
+	tests := []struct {
+		name                string
+		request             DryRunRequest
+		wantResult          keptnv2.ResultType
+		wantScore           float64
+		wantComparedValue   float64
+		wantErr             bool
+		wantLeftoverMessage bool
+	}{
+		{
+			name: "pass without previous evaluations",
+			request: DryRunRequest{
+				SLO: dryRunTestSLO,
+				IndicatorValues: []*keptnv2.SLIResult{
+					{Metric: "response_time_p95", Value: 500, Success: true},
+					{Metric: "error_rate", Value: 0, Success: true},
+					{Metric: "throughput", Value: 100, Success: true},
+				},
+			},
+			wantResult:          keptnv2.ResultPass,
+			wantScore:           100,
+			wantLeftoverMessage: true,
+		},
+		{
+			name: "warning based on the last passed evaluation",
+			request: DryRunRequest{
+				SLO: dryRunTestSLO,
+				IndicatorValues: []*keptnv2.SLIResult{
+					{Metric: "response_time_p95", Value: 500, Success: true},
+					{Metric: "error_rate", Value: 0, Success: true},
+				},
+				PreviousEvaluations: []*keptnv2.EvaluationFinishedEventData{
+					previousEvaluation(keptnv2.ResultFailed, 1000),
+					previousEvaluation(keptnv2.ResultPass, 400),
+				},
+			},
+			wantResult:        keptnv2.ResultWarning,
+			wantScore:         75,
+			wantComparedValue: 400,
+		},
+		{
+			name: "fail if an SLI is missing",
+			request: DryRunRequest{
+				SLO: dryRunTestSLO,
+				IndicatorValues: []*keptnv2.SLIResult{
+					{Metric: "response_time_p95", Value: 500, Success: true},
+				},
+			},
+			wantResult: keptnv2.ResultFailed,
+			wantScore:  50,
+		},
+		{
+			name:    "no SLO",
+			request: DryRunRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid SLO",
+			request: DryRunRequest{SLO: "objectives: invalid"},
+			wantErr: true,
+		},
+		{
+			name: "no total score",
+			request: DryRunRequest{
+				SLO:             "objectives:\n  - sli: error_rate\n    pass:\n      - criteria:\n          - \"<1\"",
+				IndicatorValues: []*keptnv2.SLIResult{{Metric: "error_rate", Value: 0, Success: true}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := EvaluateDryRun(tt.request)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResult, result.Result)
+			require.Equal(t, string(tt.wantResult), result.Evaluation.Result)
+			require.Equal(t, tt.wantScore, result.Evaluation.Score)
+			require.NotEmpty(t, result.Evaluation.SLOFileContent)
+			require.Equal(t, tt.wantLeftoverMessage, strings.Contains(result.Message, "throughput"))
+			if tt.wantComparedValue != 0 {
+				require.Equal(t, tt.wantComparedValue, result.Evaluation.IndicatorResults[0].Value.ComparedValue)
+			}
+		})
+	}
+}
+
+func TestFilterPreviousEvaluations(t *testing.T) {
+	previousEvaluations := []*keptnv2.EvaluationFinishedEventData{
+		previousEvaluation(keptnv2.ResultFailed, 1),
+		nil,
+		previousEvaluation(keptnv2.ResultWarning, 2),
+		previousEvaluation(keptnv2.ResultPass, 3),
+		previousEvaluation(keptnv2.ResultPass, 4),
+	}
+
+	values := func(evaluations []*keptnv2.EvaluationFinishedEventData) []float64 {
+		result := []float64{}
+		for _, evaluation := range evaluations {
+			result = append(result, evaluation.Evaluation.IndicatorResults[0].Value.Value)
+		}
+		return result
+	}
+
+	require.Equal(t, []float64{1, 2, 3}, values(filterPreviousEvaluations(previousEvaluations, 3, "all")))
+	require.Equal(t, []float64{2, 3}, values(filterPreviousEvaluations(previousEvaluations, 2, "pass_or_warn")))
+	require.Equal(t, []float64{3, 4}, values(filterPreviousEvaluations(previousEvaluations, 5, "pass")))
+}
@@ -0,0 +1,198 @@
+package evaluation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// SLOGroup combines objectives that are scored together, e.g. all latency related objectives
+type SLOGroup struct {
+	Name   string `yaml:"name"`
+	Weight int    `yaml:"weight"`
+	// TotalScore contains the pass and warning targets of the group. If it is not set, the total score of the SLO is used
+	TotalScore *keptn.SLOScore `yaml:"total_score"`
+}
+
+// sloGroupConfig contains the parts of an SLO file that define SLO groups, which are not covered by the go-utils SLO types
+type sloGroupConfig struct {
+	Groups     []*SLOGroup `yaml:"groups"`
+	Objectives []*struct {
+		SLI   string `yaml:"sli"`
+		Group string `yaml:"group"`
+	} `yaml:"objectives"`
+	// objectiveGroups maps the SLIs of the objectives to the names of their groups
+	objectiveGroups map[string]string
+}
+
+// SLOGroupResult contains the score and the result of an SLO group
+type SLOGroupResult struct {
+	Name       string   `json:"name"`
+	Weight     int      `json:"weight"`
+	Score      float64  `json:"score"`
+	Result     string   `json:"result"`
+	Objectives []string `json:"objectives"`
+}
+
+// EvaluationFinishedEventData extends the evaluation.finished event data with the results of the SLO groups
+type EvaluationFinishedEventData struct {
+	keptnv2.EventData
+	Evaluation EvaluationDetails `json:"evaluation,omitempty"`
+}
+
+// EvaluationDetails extends the evaluation details with the results of the SLO groups
+type EvaluationDetails struct {
+	keptnv2.EvaluationDetails
+	Groups []*SLOGroupResult `json:"groups,omitempty"`
+}
+
+func newEvaluationFinishedEventData(evaluationResult *keptnv2.EvaluationFinishedEventData, groupResults []*SLOGroupResult) *EvaluationFinishedEventData {
+	return &EvaluationFinishedEventData{
+		EventData: evaluationResult.EventData,
+		Evaluation: EvaluationDetails{
+			EvaluationDetails: evaluationResult.Evaluation,
+			Groups:            groupResults,
+		},
+	}
+}
+
+// parseSLOGroups parses the SLO groups of an SLO file. If the SLO file does not define any groups, nil is returned
+func parseSLOGroups(input []byte) (*sloGroupConfig, error) {
+	groupConfig := &sloGroupConfig{}
+	if err := yaml.Unmarshal(input, groupConfig); err != nil {
+		return nil, fmt.Errorf("could not parse SLO groups: %w", err)
+	}
+	if len(groupConfig.Groups) == 0 {
+		return nil, nil
+	}
+
+	groupNames := map[string]bool{}
+	for _, group := range groupConfig.Groups {
+		if group == nil || group.Name == "" {
+			return nil, errors.New("SLO groups must have a name")
+		}
+		if groupNames[group.Name] {
+			return nil, fmt.Errorf("SLO group %s is defined more than once", group.Name)
+		}
+		if group.Weight < 0 {
+			return nil, fmt.Errorf("SLO group %s has a negative weight", group.Name)
+		}
+		if group.Weight == 0 {
+			group.Weight = 1
+		}
+		groupNames[group.Name] = true
+	}
+
+	groupConfig.objectiveGroups = map[string]string{}
+	for _, objective := range groupConfig.Objectives {
+		if objective == nil || objective.Group == "" {
+			continue
+		}
+		if !groupNames[objective.Group] {
+			return nil, fmt.Errorf("objective %s refers to the unknown SLO group %s", objective.SLI, objective.Group)
+		}
+		groupConfig.objectiveGroups[objective.SLI] = objective.Group
+	}
+	return groupConfig, nil
+}
+
+// calculateGroupScores calculates the score of each SLO group based on the objectives of the group, and the total score as the weighted average of the scores of the groups with scored objectives.
+// The evaluation fails if the total score or any group fails, and returns a warning if the total score or any group returns a warning
+func calculateGroupScores(evaluationResult *keptnv2.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, groupConfig *sloGroupConfig) ([]*SLOGroupResult, error) {
+	maximumAchievableScores := map[string]float64{}
+	groupObjectives := map[string][]string{}
+	for _, objective := range sloConfig.Objectives {
+		group, ok := groupConfig.objectiveGroups[objective.SLI]
+		if !ok {
+			// objectives without pass criteria are only informative, and therefore do not need to be part of a group
+			if len(objective.Pass) > 0 {
+				return nil, fmt.Errorf("objective %s is not assigned to an SLO group", objective.SLI)
+			}
+			continue
+		}
+		groupObjectives[group] = append(groupObjectives[group], objective.SLI)
+		// only consider the SLI for the group score if pass criteria have been included
+		if len(objective.Pass) > 0 {
+			maximumAchievableScores[group] += float64(objective.Weight)
+		}
+	}
+
+	achievedScores := map[string]float64{}
+	keySLIFailed := map[string]bool{}
+	for _, indicatorResult := range evaluationResult.Evaluation.IndicatorResults {
+		group := groupConfig.objectiveGroups[indicatorResult.Value.Metric]
+		achievedScores[group] += indicatorResult.Score
+		if indicatorResult.KeySLI && indicatorResult.Status == string(keptnv2.ResultFailed) {
+			keySLIFailed[group] = true
+		}
+	}
+
+	groupResults := []*SLOGroupResult{}
+	var failedGroups, warningGroups []string
+	totalScore := 0.0
+	totalWeight := 0
+	for _, group := range groupConfig.Groups {
+		groupResult := &SLOGroupResult{
+			Name:       group.Name,
+			Weight:     group.Weight,
+			Score:      100.0,
+			Result:     string(keptnv2.ResultPass),
+			Objectives: groupObjectives[group.Name],
+		}
+		if maximumAchievableScores[group.Name] > 0 {
+			groupResult.Score = 100.0 * (achievedScores[group.Name] / maximumAchievableScores[group.Name])
+			targetScore := group.TotalScore
+			if targetScore == nil {
+				targetScore = sloConfig.TotalScore
+			}
+			result, _, err := evaluateScore(groupResult.Score, targetScore, keySLIFailed[group.Name])
+			if err != nil {
+				return nil, fmt.Errorf("could not evaluate SLO group %s: %w", group.Name, err)
+			}
+			groupResult.Result = string(result)
+		}
+
+		switch keptnv2.ResultType(groupResult.Result) {
+		case keptnv2.ResultFailed:
+			failedGroups = append(failedGroups, group.Name)
+		case keptnv2.ResultWarning:
+			warningGroups = append(warningGroups, group.Name)
+		}
+		groupResults = append(groupResults, groupResult)
+		// groups without scored objectives have nothing to contribute to the total score, and must not dilute the scores of the other groups
+		if maximumAchievableScores[group.Name] <= 0 {
+			continue
+		}
+		totalScore += float64(group.Weight) * groupResult.Score
+		totalWeight += group.Weight
+	}
+
+	evaluationResult.Evaluation.Score = 100.0
+	if totalWeight > 0 {
+		evaluationResult.Evaluation.Score = totalScore / float64(totalWeight)
+	}
+
+	result := keptnv2.ResultPass
+	message := ""
+	if sloConfig.TotalScore != nil {
+		var err error
+		result, message, err = evaluateScore(evaluationResult.Evaluation.Score, sloConfig.TotalScore, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(failedGroups) > 0 {
+		result = keptnv2.ResultFailed
+		message = fmt.Sprintf("Evaluation failed since the SLO groups %s failed", strings.Join(failedGroups, ", "))
+	} else if len(warningGroups) > 0 && result == keptnv2.ResultPass {
+		result = keptnv2.ResultWarning
+		message = fmt.Sprintf("Evaluation returned a warning since the SLO groups %s returned a warning", strings.Join(warningGroups, ", "))
+	}
+	setEvaluationResult(evaluationResult, result, message)
+
+	return groupResults, nil
+}
@@ -0,0 +1,203 @@
+package evaluation
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+const sloGroupsTestSLO = `---
+spec_version: "1.0"
+groups:
+  - name: latency
+    weight: 2
+    total_score:
+      pass: "100%"
+      warning: "50%"
+  - name: errors
+objectives:
+  - sli: response_time_p95
+    group: latency
+    pass:
+      - criteria:
+          - "<600"
+  - sli: response_time_p50
+    group: latency
+    pass:
+      - criteria:
+          - "<200"
+  - sli: error_rate
+    group: errors
+    key_sli: true
+    pass:
+      - criteria:
+          - "<1"
+  - sli: throughput
+total_score:
+  pass: "90%"
+  warning: "60%"
+`
+
+func TestParseSLOGroups(t *testing.T) {
+	groupConfig, err := parseSLOGroups([]byte(sloGroupsTestSLO))
+	require.Nil(t, err)
+	require.Len(t, groupConfig.Groups, 2)
+	require.Equal(t, 2, groupConfig.Groups[0].Weight)
+	require.Equal(t, "100%", groupConfig.Groups[0].TotalScore.Pass)
+	require.Equal(t, 1, groupConfig.Groups[1].Weight)
+	require.Nil(t, groupConfig.Groups[1].TotalScore)
+	require.Equal(t, map[string]string{"response_time_p95": "latency", "response_time_p50": "latency", "error_rate": "errors"}, groupConfig.objectiveGroups)
+
+	groupConfig, err = parseSLOGroups([]byte("objectives:\n  - sli: error_rate\n"))
+	require.Nil(t, err)
+	require.Nil(t, groupConfig)
+
+	invalidSLOs := []string{
+		"groups:\n  - weight: 1\n",
+		"groups:\n  - name: latency\n  - name: latency\n",
+		"groups:\n  - name: latency\n    weight: -1\n",
+		"groups:\n  - name: latency\nobjectives:\n  - sli: error_rate\n    group: errors\n",
+	}
+	for _, slo := range invalidSLOs {
+		_, err = parseSLOGroups([]byte(slo))
+		require.NotNil(t, err, slo)
+	}
+}
+
+func TestEvaluateSLIsWithGroups(t *testing.T) {
+	tests := []struct {
+		name             string
+		responseTimeP50  float64
+		errorRate        float64
+		wantResult       keptnv2.ResultType
+		wantScore        float64
+		wantGroupScores  []float64
+		wantGroupResults []string
+		wantMessage      string
+	}{
+		{
+			name:             "all groups pass",
+			responseTimeP50:  100,
+			errorRate:        0,
+			wantResult:       keptnv2.ResultPass,
+			wantScore:        100,
+			wantGroupScores:  []float64{100, 100},
+			wantGroupResults: []string{"pass", "pass"},
+		},
+		{
+			name:             "group warning",
+			responseTimeP50:  300,
+			errorRate:        0,
+			wantResult:       keptnv2.ResultWarning,
+			wantScore:        200.0 / 3,
+			wantGroupScores:  []float64{50, 100},
+			wantGroupResults: []string{"warning", "pass"},
+		},
+		{
+			name:             "failed key SLI fails its group and the evaluation",
+			responseTimeP50:  100,
+			errorRate:        2,
+			wantResult:       keptnv2.ResultFailed,
+			wantScore:        200.0 / 3,
+			wantGroupScores:  []float64{100, 0},
+			wantGroupResults: []string{"pass", "fail"},
+			wantMessage:      "Evaluation failed since the SLO groups errors failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sloConfig, err := ParseSLO([]byte(sloGroupsTestSLO))
+			require.Nil(t, err)
+			e := &keptnv2.GetSLIFinishedEventData{
+				GetSLI: keptnv2.GetSLIFinished{
+					IndicatorValues: []*keptnv2.SLIResult{
+						{Metric: "response_time_p95", Value: 500, Success: true},
+						{Metric: "response_time_p50", Value: tt.responseTimeP50, Success: true},
+						{Metric: "error_rate", Value: tt.errorRate, Success: true},
+						{Metric: "throughput", Value: 1000, Success: true},
+					},
+				},
+			}
+
+			result, err := EvaluateSLIs(e, sloConfig, []byte(sloGroupsTestSLO), nil)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResult, result.Result)
+			require.Equal(t, string(tt.wantResult), result.Evaluation.Result)
+			require.InDelta(t, tt.wantScore, result.Evaluation.Score, 0.001)
+			require.Len(t, result.Evaluation.Groups, 2)
+			for i, group := range result.Evaluation.Groups {
+				require.Equal(t, tt.wantGroupScores[i], group.Score)
+				require.Equal(t, tt.wantGroupResults[i], group.Result)
+			}
+			require.Equal(t, []string{"response_time_p95", "response_time_p50"}, result.Evaluation.Groups[0].Objectives)
+			if tt.wantMessage != "" {
+				require.Equal(t, tt.wantMessage, result.Message)
+			}
+		})
+	}
+}
+
+func TestEvaluateSLIsWithUnscoredGroup(t *testing.T) {
+	slo := `---
+spec_version: "1.0"
+groups:
+  - name: latency
+  - name: saturation
+    weight: 3
+objectives:
+  - sli: response_time_p95
+    group: latency
+    pass:
+      - criteria:
+          - "<600"
+  - sli: response_time_p50
+    group: latency
+    pass:
+      - criteria:
+          - "<200"
+  - sli: cpu_usage
+    group: saturation
+total_score:
+  pass: "90%"
+  warning: "40%"
+`
+	sloConfig, err := ParseSLO([]byte(slo))
+	require.Nil(t, err)
+	e := &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "response_time_p95", Value: 500, Success: true},
+				{Metric: "response_time_p50", Value: 300, Success: true},
+				{Metric: "cpu_usage", Value: 80, Success: true},
+			},
+		},
+	}
+
+	result, err := EvaluateSLIs(e, sloConfig, []byte(slo), nil)
+	require.Nil(t, err)
+	// the saturation group has no objective with pass criteria, hence only the score of the latency group counts
+	require.Equal(t, 50.0, result.Evaluation.Score)
+	require.Equal(t, keptnv2.ResultWarning, result.Result)
+	require.Len(t, result.Evaluation.Groups, 2)
+	require.Equal(t, 50.0, result.Evaluation.Groups[0].Score)
+	require.Equal(t, []string{"cpu_usage"}, result.Evaluation.Groups[1].Objectives)
+}
+
+func TestEvaluateSLIsWithInvalidGroups(t *testing.T) {
+	slos := []string{
+		// the objective with pass criteria is not assigned to a group
+		"groups:\n  - name: latency\nobjectives:\n  - sli: error_rate\n    pass:\n      - criteria:\n          - \"<1\"\ntotal_score:\n  pass: \"90%\"\n",
+		// neither the group nor the SLO define a total score
+		"groups:\n  - name: errors\nobjectives:\n  - sli: error_rate\n    group: errors\n    pass:\n      - criteria:\n          - \"<1\"\n",
+	}
+	for _, slo := range slos {
+		sloConfig, err := ParseSLO([]byte(slo))
+		require.Nil(t, err)
+		e := &keptnv2.GetSLIFinishedEventData{
+			GetSLI: keptnv2.GetSLIFinished{IndicatorValues: []*keptnv2.SLIResult{{Metric: "error_rate", Value: 0, Success: true}}},
+		}
+		_, err = EvaluateSLIs(e, sloConfig, []byte(slo), nil)
+		require.NotNil(t, err, slo)
+	}
+}
@@ -0,0 +1,385 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+)
+
+// IProjectCache provides read access to a cached clone of the upstream repository of a project.
+// Reading from the cache does not require the project to be locked, and the upstream is only contacted if the cache is outdated.
+// The content of files is returned as stored in the repository, i.e. LFS pointer files are not resolved
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/project_cache_mock.go . IProjectCache
+type IProjectCache interface {
+	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
+	GetRevision(gitContext common_models.GitContext, branch string) (string, error)
+	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+	OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error)
+	PathExists(gitContext common_models.GitContext, revision string, path string) (bool, error)
+	Invalidate(project string)
+	Remove(project string) error
+}
+
+// ProjectCache keeps a bare clone of the upstream repository of each project. The clone is fetched again before it is read when it
+// has been invalidated, e.g. after a push to the upstream, or when it is older than the configured maximum age.
+// Clones that are read shortly before reaching the maximum age are fetched in the background, so that frequently read projects do not wait for the upstream
+type ProjectCache struct {
+	git    Gogit
+	maxAge time.Duration
+	// refreshAfter is the age after which a clone is fetched in the background when it is read
+	refreshAfter time.Duration
+	mutex        sync.Mutex
+	entries      map[string]*projectCacheEntry
+}
+
+type projectCacheEntry struct {
+	// lock protects the cached repository on disk. Reads hold the read lock, fetches hold the write lock
+	lock sync.RWMutex
+	// the following fields are protected by the mutex of the cache
+	lastFetch time.Time
+	stale     bool
+	fetching  bool
+	remoteURI string
+}
+
+func NewProjectCache(git Gogit, maxAge time.Duration) *ProjectCache {
+	return &ProjectCache{
+		git:          git,
+		maxAge:       maxAge,
+		refreshAfter: maxAge / 2,
+		entries:      map[string]*projectCacheEntry{},
+	}
+}
+
+// Invalidate marks the cached clone of the given project as outdated, which causes the next read to fetch the upstream first
+func (c *ProjectCache) Invalidate(project string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if entry, ok := c.entries[project]; ok {
+		entry.stale = true
+	}
+}
+
+// Remove deletes the cached clone of the given project, e.g. after the project has been deleted
+func (c *ProjectCache) Remove(project string) error {
+	c.mutex.Lock()
+	entry, ok := c.entries[project]
+	delete(c.entries, project)
+	c.mutex.Unlock()
+
+	if ok {
+		// wait for ongoing reads and fetches of the clone
+		entry.lock.Lock()
+		defer entry.lock.Unlock()
+	}
+	if err := os.RemoveAll(GetProjectCachePath(project)); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete the cache of the", project, err)
+	}
+	return nil
+}
+
+func (c *ProjectCache) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
+	var defaultBranch string
+	err := c.read(gitContext, func(r *git.Repository) error {
+		var err error
+		defaultBranch, err = getDefaultBranch(r)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGetDefBranch, gitContext.Project, err)
+	}
+	return defaultBranch, nil
+}
+
+// GetRevision returns the id of the latest commit of the given branch
+func (c *ProjectCache) GetRevision(gitContext common_models.GitContext, branch string) (string, error) {
+	var revision string
+	err := c.read(gitContext, func(r *git.Repository) error {
+		ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				return fmt.Errorf("could not find branch %s of project %s: %w", branch, gitContext.Project, kerrors.ErrReferenceNotFound)
+			}
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+		}
+		revision = ref.Hash().String()
+		return nil
+	})
+	return revision, err
+}
+
+func (c *ProjectCache) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+	content, _, err := c.OpenFileRevision(gitContext, revision, file)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return ioutil.ReadAll(content)
+}
+
+// OpenFileRevision opens the file at the given revision for reading. The cached clone cannot be updated until the returned reader is closed
+func (c *ProjectCache) OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+	r, release, err := c.open(gitContext)
+	if err != nil {
+		return nil, 0, err
+	}
+	tree, err := getRevisionTree(r, gitContext, revision)
+	if err != nil {
+		release()
+		return nil, 0, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+	}
+	f, err := tree.File(file)
+	if err != nil {
+		release()
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, 0, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, kerrors.ErrResourceNotFound)
+		}
+		return nil, 0, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+	}
+	content, err := f.Reader()
+	if err != nil {
+		release()
+		return nil, 0, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+	}
+	return &lockedReadCloser{ReadCloser: content, release: release}, f.Size, nil
+}
+
+// PathExists checks whether the file or directory at the given path exists at the given revision. An empty path refers to the root of the repository
+func (c *ProjectCache) PathExists(gitContext common_models.GitContext, revision string, path string) (bool, error) {
+	exists := false
+	err := c.read(gitContext, func(r *git.Repository) error {
+		tree, err := getRevisionTree(r, gitContext, revision)
+		if err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+		}
+		if path == "" {
+			exists = true
+			return nil
+		}
+		if _, err := tree.FindEntry(path); err != nil {
+			if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+				return nil
+			}
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, err)
+		}
+		exists = true
+		return nil
+	})
+	return exists, err
+}
+
+// read calls the given function with the cached repository of the project, while holding the read lock of the cache entry
+func (c *ProjectCache) read(gitContext common_models.GitContext, readFunc func(r *git.Repository) error) error {
+	r, release, err := c.open(gitContext)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return readFunc(r)
+}
+
+// open makes sure the cached repository of the project is available and acquires the read lock of the cache entry,
+// which has to be released by calling the returned function.
+// Each caller gets its own instance of the repository, since a repository must not be used concurrently
+func (c *ProjectCache) open(gitContext common_models.GitContext) (*git.Repository, func(), error) {
+	if gitContext.Credentials == nil {
+		return nil, nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	entry, err := c.prepare(gitContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry.lock.RLock()
+	r, err := c.git.PlainOpen(GetProjectCachePath(gitContext.Project))
+	if err != nil {
+		entry.lock.RUnlock()
+		return nil, nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	return r, entry.lock.RUnlock, nil
+}
+
+// prepare returns the cache entry of the project. If the entry has not been fetched yet, has been invalidated, refers to
+// a different upstream, or is older than the maximum age, it is updated before returning. If it is only older than the refresh age,
+// it is updated in the background
+func (c *ProjectCache) prepare(gitContext common_models.GitContext) (*projectCacheEntry, error) {
+	c.mutex.Lock()
+	entry, ok := c.entries[gitContext.Project]
+	if !ok {
+		entry = &projectCacheEntry{}
+		c.entries[gitContext.Project] = entry
+	}
+	update := !c.isUpToDate(entry, gitContext, c.maxAge)
+	backgroundUpdate := !update && !entry.fetching && !c.isUpToDate(entry, gitContext, c.refreshAfter)
+	if backgroundUpdate {
+		entry.fetching = true
+	}
+	c.mutex.Unlock()
+
+	if update {
+		if err := c.update(gitContext, entry, c.maxAge); err != nil {
+			return nil, err
+		}
+	} else if backgroundUpdate {
+		go func() {
+			if err := c.update(gitContext, entry, c.refreshAfter); err != nil {
+				logger.WithError(err).Warnf("Could not update cache of project %s", gitContext.Project)
+			}
+			c.mutex.Lock()
+			entry.fetching = false
+			c.mutex.Unlock()
+		}()
+	}
+	return entry, nil
+}
+
+// isUpToDate checks whether the entry has been fetched from the upstream of the git context within the given age.
+// It must be called while holding the mutex of the cache
+func (c *ProjectCache) isUpToDate(entry *projectCacheEntry, gitContext common_models.GitContext, maxAge time.Duration) bool {
+	return !entry.stale && !entry.lastFetch.IsZero() && entry.remoteURI == gitContext.Credentials.RemoteURI && time.Since(entry.lastFetch) <= maxAge
+}
+
+// update fetches the upstream into the cached repository of the project, or clones it if it is not available yet.
+// The entry is not fetched again if it has been fetched within the given age while waiting for the lock
+func (c *ProjectCache) update(gitContext common_models.GitContext, entry *projectCacheEntry, maxAge time.Duration) error {
+	entry.lock.Lock()
+	defer entry.lock.Unlock()
+
+	c.mutex.Lock()
+	// another reader might have updated the entry while we were waiting for the lock
+	if c.isUpToDate(entry, gitContext, maxAge) {
+		c.mutex.Unlock()
+		return nil
+	}
+	// reset the flag before fetching, so that invalidations during the fetch are not lost
+	wasStale := entry.stale
+	entry.stale = false
+	remoteURI := entry.remoteURI
+	c.mutex.Unlock()
+
+	started := time.Now()
+	err := c.fetch(gitContext, remoteURI != gitContext.Credentials.RemoteURI)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err != nil {
+		entry.stale = entry.stale || wasStale
+		return err
+	}
+	entry.lastFetch = started
+	entry.remoteURI = gitContext.Credentials.RemoteURI
+	return nil
+}
+
+func (c *ProjectCache) fetch(gitContext common_models.GitContext, remoteChanged bool) error {
+	path := GetProjectCachePath(gitContext.Project)
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.git.PlainOpen(path)
+	if err != nil || remoteChanged {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "clone", gitContext.Project, err)
+		}
+		if err := ensureDirectoryExists(path); err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotCreatePath, path, err)
+		}
+		r, err = c.git.PlainClone(path, true, &git.CloneOptions{
+			URL:             gitContext.Credentials.RemoteURI,
+			Auth:            auth,
+			InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+		})
+		if err != nil {
+			if kerrors.ErrEmptyRemoteRepository.Is(err) {
+				return kerrors.ErrProjectNotFound
+			}
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "clone", gitContext.Project, err)
+		}
+	}
+
+	// fetch all branches of the upstream into the local branches, since there is no worktree that could contain local changes
+	err = r.Fetch(&git.FetchOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{"+refs/heads/*:refs/heads/*"},
+		Force:           true,
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "fetch", gitContext.Project, err)
+	}
+	if err := pruneBranches(r, auth, gitContext); err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "prune", gitContext.Project, err)
+	}
+	return nil
+}
+
+// pruneBranches removes the branches that do not exist in the upstream anymore from the cached repository.
+// This is done explicitly, since go-git does not support pruning when fetching
+func pruneBranches(r *git.Repository, auth transport.AuthMethod, gitContext common_models.GitContext) error {
+	remote, err := r.Remote("origin")
+	if err != nil {
+		return err
+	}
+	remoteRefs, err := remote.List(&git.ListOptions{
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil {
+		return err
+	}
+	remoteBranches := map[plumbing.ReferenceName]bool{}
+	for _, ref := range remoteRefs {
+		if ref.Name().IsBranch() {
+			remoteBranches[ref.Name()] = true
+		}
+	}
+
+	branches, err := r.Branches()
+	if err != nil {
+		return err
+	}
+	prunedBranches := []plumbing.ReferenceName{}
+	err = branches.ForEach(func(ref *plumbing.Reference) error {
+		if !remoteBranches[ref.Name()] {
+			prunedBranches = append(prunedBranches, ref.Name())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, branch := range prunedBranches {
+		if err := r.Storer.RemoveReference(branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockedReadCloser releases the lock of a cache entry once the reader is closed
+type lockedReadCloser struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (l *lockedReadCloser) Close() error {
+	err := l.ReadCloser.Close()
+	l.once.Do(l.release)
+	return err
+}
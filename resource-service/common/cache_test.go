@@ -0,0 +1,213 @@
+package common
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheTestRepo(t *testing.T) (common_models.GitContext, *git.Repository, *git.Worktree) {
+	remotePath := t.TempDir()
+	_, err := git.PlainInit(remotePath, true)
+	require.Nil(t, err)
+
+	gitContext, w := newHistoryTestRepo(t)
+	gitContext.Credentials.RemoteURI = remotePath
+	r, err := git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	require.Nil(t, err)
+	require.Nil(t, r.DeleteRemote("origin"))
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	require.Nil(t, err)
+	return gitContext, r, w
+}
+
+func TestProjectCache(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	first := commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	cache := NewProjectCache(GogitReal{}, time.Hour)
+
+	defaultBranch, err := cache.GetDefaultBranch(gitContext)
+	require.Nil(t, err)
+	require.Equal(t, "master", defaultBranch)
+
+	revision, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.Equal(t, first, revision)
+
+	content, err := cache.GetFileRevision(gitContext, "master", "my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 1\n", string(content))
+
+	exists, err := cache.PathExists(gitContext, revision, "my-service")
+	require.Nil(t, err)
+	require.True(t, exists)
+
+	exists, err = cache.PathExists(gitContext, revision, "other-service")
+	require.Nil(t, err)
+	require.False(t, exists)
+
+	_, err = cache.GetFileRevision(gitContext, revision, "my-service/other.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+
+	_, err = cache.GetFileRevision(gitContext, "0000000000000000000000000000000000000000", "my-service/values.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+
+	_, err = cache.GetRevision(gitContext, "prod")
+	require.ErrorIs(t, err, kerrors.ErrReferenceNotFound)
+
+	// changes of the upstream are not visible before the cache is outdated
+	second := commitHistoryTestFiles(t, w, "Updated resource", map[string]string{"my-service/values.yaml": "replicas: 2\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	revision, err = cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.Equal(t, first, revision)
+
+	cache.Invalidate(gitContext.Project)
+
+	revision, err = cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.Equal(t, second, revision)
+
+	// pushing via Git invalidates the cache
+	g := NewGit(GogitReal{}, WithProjectCache(cache))
+	commitHistoryTestFiles(t, w, "Updated resource", map[string]string{"my-service/values.yaml": "replicas: 3\n"})
+	require.Nil(t, g.Push(gitContext))
+
+	content, err = cache.GetFileRevision(gitContext, "master", "my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 3\n", string(content))
+}
+
+func TestProjectCache_BackgroundUpdate(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	cache := NewProjectCache(GogitReal{}, time.Hour)
+	// refresh the entry in the background on every read
+	cache.refreshAfter = 0
+	_, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+
+	second := commitHistoryTestFiles(t, w, "Updated resource", map[string]string{"my-service/values.yaml": "replicas: 2\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	// entries that are about to become outdated are still served while they are fetched in the background
+	require.Eventually(t, func() bool {
+		revision, err := cache.GetRevision(gitContext, "master")
+		require.Nil(t, err)
+		return revision == second
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProjectCache_ReadAfterIdlePeriod(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	cache := NewProjectCache(GogitReal{}, 50*time.Millisecond)
+	_, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+
+	second := commitHistoryTestFiles(t, w, "Updated resource", map[string]string{"my-service/values.yaml": "replicas: 2\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	// entries older than the maximum age are fetched before they are read
+	time.Sleep(100 * time.Millisecond)
+
+	revision, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.Equal(t, second, revision)
+
+	content, err := cache.GetFileRevision(gitContext, "master", "my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 2\n", string(content))
+}
+
+func TestProjectCache_PruneDeletedBranches(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("dev"), Create: true}))
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin", RefSpecs: []config.RefSpec{"refs/heads/*:refs/heads/*"}}))
+
+	cache := NewProjectCache(GogitReal{}, time.Hour)
+	_, err := cache.GetRevision(gitContext, "dev")
+	require.Nil(t, err)
+
+	// delete the branch in the upstream
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin", RefSpecs: []config.RefSpec{":refs/heads/dev"}}))
+	cache.Invalidate(gitContext.Project)
+
+	_, err = cache.GetRevision(gitContext, "dev")
+	require.ErrorIs(t, err, kerrors.ErrReferenceNotFound)
+
+	_, err = cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+}
+
+func TestProjectCache_Remove(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	first := commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	cache := NewProjectCache(GogitReal{}, time.Hour)
+	_, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.DirExists(t, GetProjectCachePath(gitContext.Project))
+
+	require.Nil(t, cache.Remove(gitContext.Project))
+	require.NoDirExists(t, GetProjectCachePath(gitContext.Project))
+
+	// the project is cloned again when it is read after being removed
+	revision, err := cache.GetRevision(gitContext, "master")
+	require.Nil(t, err)
+	require.Equal(t, first, revision)
+
+	// removing a project that has not been cached is not an error
+	require.Nil(t, cache.Remove("other-project"))
+}
+
+func TestProjectCache_ConcurrentReads(t *testing.T) {
+	gitContext, r, w := newCacheTestRepo(t)
+	commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	cache := NewProjectCache(GogitReal{}, time.Hour)
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%5 == 0 {
+				cache.Invalidate(gitContext.Project)
+			}
+			content, _, err := cache.OpenFileRevision(gitContext, "master", "my-service/values.yaml")
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer content.Close()
+			if _, err := ioutil.ReadAll(content); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Nil(t, err)
+	}
+}
@@ -4,47 +4,60 @@
 package common_mock
 
 import (
+	"io"
 	"path/filepath"
 	"sync"
 )
 
 // IFileSystemMock is a mock implementation of common.IFileSystem.
 //
-// 	func TestSomethingThatUsesIFileSystem(t *testing.T) {
+//	func TestSomethingThatUsesIFileSystem(t *testing.T) {
 //
-// 		// make and configure a mocked common.IFileSystem
-// 		mockedIFileSystem := &IFileSystemMock{
-// 			DeleteFileFunc: func(path string) error {
-// 				panic("mock out the DeleteFile method")
-// 			},
-// 			FileExistsFunc: func(path string) bool {
-// 				panic("mock out the FileExists method")
-// 			},
-// 			MakeDirFunc: func(path string) error {
-// 				panic("mock out the MakeDir method")
-// 			},
-// 			ReadFileFunc: func(filename string) ([]byte, error) {
-// 				panic("mock out the ReadFile method")
-// 			},
-// 			WalkPathFunc: func(path string, walkFunc filepath.WalkFunc) error {
-// 				panic("mock out the WalkPath method")
-// 			},
-// 			WriteBase64EncodedFileFunc: func(path string, content string) error {
-// 				panic("mock out the WriteBase64EncodedFile method")
-// 			},
-// 			WriteFileFunc: func(path string, content []byte) error {
-// 				panic("mock out the WriteFile method")
-// 			},
-// 			WriteHelmChartFunc: func(path string) error {
-// 				panic("mock out the WriteHelmChart method")
-// 			},
-// 		}
+//		// make and configure a mocked common.IFileSystem
+//		mockedIFileSystem := &IFileSystemMock{
+//			CopyFileFunc: func(sourcePath string, path string) error {
+//				panic("mock out the CopyFile method")
+//			},
+//			DeleteFileFunc: func(path string) error {
+//				panic("mock out the DeleteFile method")
+//			},
+//			FileExistsFunc: func(path string) bool {
+//				panic("mock out the FileExists method")
+//			},
+//			MakeDirFunc: func(path string) error {
+//				panic("mock out the MakeDir method")
+//			},
+//			OpenFileFunc: func(filename string) (io.ReadCloser, int64, error) {
+//				panic("mock out the OpenFile method")
+//			},
+//			ReadFileFunc: func(filename string) ([]byte, error) {
+//				panic("mock out the ReadFile method")
+//			},
+//			WalkPathFunc: func(path string, walkFunc filepath.WalkFunc) error {
+//				panic("mock out the WalkPath method")
+//			},
+//			WriteBase64EncodedFileFunc: func(path string, content string) error {
+//				panic("mock out the WriteBase64EncodedFile method")
+//			},
+//			WriteFileFunc: func(path string, content []byte) error {
+//				panic("mock out the WriteFile method")
+//			},
+//			WriteHelmChartFunc: func(path string) error {
+//				panic("mock out the WriteHelmChart method")
+//			},
+//			WriteTempFileFunc: func(content io.Reader) (string, error) {
+//				panic("mock out the WriteTempFile method")
+//			},
+//		}
 //
-// 		// use mockedIFileSystem in code that requires common.IFileSystem
-// 		// and then make assertions.
+//		// use mockedIFileSystem in code that requires common.IFileSystem
+//		// and then make assertions.
 //
-// 	}
+//	}
 type IFileSystemMock struct {
+	// CopyFileFunc mocks the CopyFile method.
+	CopyFileFunc func(sourcePath string, path string) error
+
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(path string) error
 
@@ -54,6 +67,9 @@ type IFileSystemMock struct {
 	// MakeDirFunc mocks the MakeDir method.
 	MakeDirFunc func(path string) error
 
+	// OpenFileFunc mocks the OpenFile method.
+	OpenFileFunc func(filename string) (io.ReadCloser, int64, error)
+
 	// ReadFileFunc mocks the ReadFile method.
 	ReadFileFunc func(filename string) ([]byte, error)
 
@@ -69,8 +85,18 @@ type IFileSystemMock struct {
 	// WriteHelmChartFunc mocks the WriteHelmChart method.
 	WriteHelmChartFunc func(path string) error
 
+	// WriteTempFileFunc mocks the WriteTempFile method.
+	WriteTempFileFunc func(content io.Reader) (string, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CopyFile holds details about calls to the CopyFile method.
+		CopyFile []struct {
+			// SourcePath is the sourcePath argument value.
+			SourcePath string
+			// Path is the path argument value.
+			Path string
+		}
 		// DeleteFile holds details about calls to the DeleteFile method.
 		DeleteFile []struct {
 			// Path is the path argument value.
@@ -86,6 +112,11 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// OpenFile holds details about calls to the OpenFile method.
+		OpenFile []struct {
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// ReadFile holds details about calls to the ReadFile method.
 		ReadFile []struct {
 			// Filename is the filename argument value.
@@ -117,15 +148,59 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// WriteTempFile holds details about calls to the WriteTempFile method.
+		WriteTempFile []struct {
+			// Content is the content argument value.
+			Content io.Reader
+		}
 	}
+	lockCopyFile               sync.RWMutex
 	lockDeleteFile             sync.RWMutex
 	lockFileExists             sync.RWMutex
 	lockMakeDir                sync.RWMutex
+	lockOpenFile               sync.RWMutex
 	lockReadFile               sync.RWMutex
 	lockWalkPath               sync.RWMutex
 	lockWriteBase64EncodedFile sync.RWMutex
 	lockWriteFile              sync.RWMutex
 	lockWriteHelmChart         sync.RWMutex
+	lockWriteTempFile          sync.RWMutex
+}
+
+// CopyFile calls CopyFileFunc.
+func (mock *IFileSystemMock) CopyFile(sourcePath string, path string) error {
+	if mock.CopyFileFunc == nil {
+		panic("IFileSystemMock.CopyFileFunc: method is nil but IFileSystem.CopyFile was just called")
+	}
+	callInfo := struct {
+		SourcePath string
+		Path       string
+	}{
+		SourcePath: sourcePath,
+		Path:       path,
+	}
+	mock.lockCopyFile.Lock()
+	mock.calls.CopyFile = append(mock.calls.CopyFile, callInfo)
+	mock.lockCopyFile.Unlock()
+	return mock.CopyFileFunc(sourcePath, path)
+}
+
+// CopyFileCalls gets all the calls that were made to CopyFile.
+// Check the length with:
+//
+//	len(mockedIFileSystem.CopyFileCalls())
+func (mock *IFileSystemMock) CopyFileCalls() []struct {
+	SourcePath string
+	Path       string
+} {
+	var calls []struct {
+		SourcePath string
+		Path       string
+	}
+	mock.lockCopyFile.RLock()
+	calls = mock.calls.CopyFile
+	mock.lockCopyFile.RUnlock()
+	return calls
 }
 
 // DeleteFile calls DeleteFileFunc.
@@ -146,7 +221,8 @@ func (mock *IFileSystemMock) DeleteFile(path string) error {
 
 // DeleteFileCalls gets all the calls that were made to DeleteFile.
 // Check the length with:
-//     len(mockedIFileSystem.DeleteFileCalls())
+//
+//	len(mockedIFileSystem.DeleteFileCalls())
 func (mock *IFileSystemMock) DeleteFileCalls() []struct {
 	Path string
 } {
@@ -177,7 +253,8 @@ func (mock *IFileSystemMock) FileExists(path string) bool {
 
 // FileExistsCalls gets all the calls that were made to FileExists.
 // Check the length with:
-//     len(mockedIFileSystem.FileExistsCalls())
+//
+//	len(mockedIFileSystem.FileExistsCalls())
 func (mock *IFileSystemMock) FileExistsCalls() []struct {
 	Path string
 } {
@@ -208,7 +285,8 @@ func (mock *IFileSystemMock) MakeDir(path string) error {
 
 // MakeDirCalls gets all the calls that were made to MakeDir.
 // Check the length with:
-//     len(mockedIFileSystem.MakeDirCalls())
+//
+//	len(mockedIFileSystem.MakeDirCalls())
 func (mock *IFileSystemMock) MakeDirCalls() []struct {
 	Path string
 } {
@@ -221,6 +299,38 @@ func (mock *IFileSystemMock) MakeDirCalls() []struct {
 	return calls
 }
 
+// OpenFile calls OpenFileFunc.
+func (mock *IFileSystemMock) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	if mock.OpenFileFunc == nil {
+		panic("IFileSystemMock.OpenFileFunc: method is nil but IFileSystem.OpenFile was just called")
+	}
+	callInfo := struct {
+		Filename string
+	}{
+		Filename: filename,
+	}
+	mock.lockOpenFile.Lock()
+	mock.calls.OpenFile = append(mock.calls.OpenFile, callInfo)
+	mock.lockOpenFile.Unlock()
+	return mock.OpenFileFunc(filename)
+}
+
+// OpenFileCalls gets all the calls that were made to OpenFile.
+// Check the length with:
+//
+//	len(mockedIFileSystem.OpenFileCalls())
+func (mock *IFileSystemMock) OpenFileCalls() []struct {
+	Filename string
+} {
+	var calls []struct {
+		Filename string
+	}
+	mock.lockOpenFile.RLock()
+	calls = mock.calls.OpenFile
+	mock.lockOpenFile.RUnlock()
+	return calls
+}
+
 // ReadFile calls ReadFileFunc.
 func (mock *IFileSystemMock) ReadFile(filename string) ([]byte, error) {
 	if mock.ReadFileFunc == nil {
@@ -239,7 +349,8 @@ func (mock *IFileSystemMock) ReadFile(filename string) ([]byte, error) {
 
 // ReadFileCalls gets all the calls that were made to ReadFile.
 // Check the length with:
-//     len(mockedIFileSystem.ReadFileCalls())
+//
+//	len(mockedIFileSystem.ReadFileCalls())
 func (mock *IFileSystemMock) ReadFileCalls() []struct {
 	Filename string
 } {
@@ -272,7 +383,8 @@ func (mock *IFileSystemMock) WalkPath(path string, walkFunc filepath.WalkFunc) e
 
 // WalkPathCalls gets all the calls that were made to WalkPath.
 // Check the length with:
-//     len(mockedIFileSystem.WalkPathCalls())
+//
+//	len(mockedIFileSystem.WalkPathCalls())
 func (mock *IFileSystemMock) WalkPathCalls() []struct {
 	Path     string
 	WalkFunc filepath.WalkFunc
@@ -307,7 +419,8 @@ func (mock *IFileSystemMock) WriteBase64EncodedFile(path string, content string)
 
 // WriteBase64EncodedFileCalls gets all the calls that were made to WriteBase64EncodedFile.
 // Check the length with:
-//     len(mockedIFileSystem.WriteBase64EncodedFileCalls())
+//
+//	len(mockedIFileSystem.WriteBase64EncodedFileCalls())
 func (mock *IFileSystemMock) WriteBase64EncodedFileCalls() []struct {
 	Path    string
 	Content string
@@ -342,7 +455,8 @@ func (mock *IFileSystemMock) WriteFile(path string, content []byte) error {
 
 // WriteFileCalls gets all the calls that were made to WriteFile.
 // Check the length with:
-//     len(mockedIFileSystem.WriteFileCalls())
+//
+//	len(mockedIFileSystem.WriteFileCalls())
 func (mock *IFileSystemMock) WriteFileCalls() []struct {
 	Path    string
 	Content []byte
@@ -375,7 +489,8 @@ func (mock *IFileSystemMock) WriteHelmChart(path string) error {
 
 // WriteHelmChartCalls gets all the calls that were made to WriteHelmChart.
 // Check the length with:
-//     len(mockedIFileSystem.WriteHelmChartCalls())
+//
+//	len(mockedIFileSystem.WriteHelmChartCalls())
 func (mock *IFileSystemMock) WriteHelmChartCalls() []struct {
 	Path string
 } {
@@ -387,3 +502,35 @@ func (mock *IFileSystemMock) WriteHelmChartCalls() []struct {
 	mock.lockWriteHelmChart.RUnlock()
 	return calls
 }
+
+// WriteTempFile calls WriteTempFileFunc.
+func (mock *IFileSystemMock) WriteTempFile(content io.Reader) (string, error) {
+	if mock.WriteTempFileFunc == nil {
+		panic("IFileSystemMock.WriteTempFileFunc: method is nil but IFileSystem.WriteTempFile was just called")
+	}
+	callInfo := struct {
+		Content io.Reader
+	}{
+		Content: content,
+	}
+	mock.lockWriteTempFile.Lock()
+	mock.calls.WriteTempFile = append(mock.calls.WriteTempFile, callInfo)
+	mock.lockWriteTempFile.Unlock()
+	return mock.WriteTempFileFunc(content)
+}
+
+// WriteTempFileCalls gets all the calls that were made to WriteTempFile.
+// Check the length with:
+//
+//	len(mockedIFileSystem.WriteTempFileCalls())
+func (mock *IFileSystemMock) WriteTempFileCalls() []struct {
+	Content io.Reader
+} {
+	var calls []struct {
+		Content io.Reader
+	}
+	mock.lockWriteTempFile.RLock()
+	calls = mock.calls.WriteTempFile
+	mock.lockWriteTempFile.RUnlock()
+	return calls
+}
@@ -5,60 +5,82 @@ package common_mock
 
 import (
 	"github.com/keptn/keptn/resource-service/common_models"
+	"io"
 	"sync"
 )
 
 // IGitMock is a mock implementation of common.IGit.
 //
-// 	func TestSomethingThatUsesIGit(t *testing.T) {
+//	func TestSomethingThatUsesIGit(t *testing.T) {
 //
-// 		// make and configure a mocked common.IGit
-// 		mockedIGit := &IGitMock{
-// 			CheckoutBranchFunc: func(gitContext common_models.GitContext, branch string) error {
-// 				panic("mock out the CheckoutBranch method")
-// 			},
-// 			CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
-// 				panic("mock out the CloneRepo method")
-// 			},
-// 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
-// 				panic("mock out the CreateBranch method")
-// 			},
-// 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
-// 				panic("mock out the GetCurrentRevision method")
-// 			},
-// 			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
-// 				panic("mock out the GetDefaultBranch method")
-// 			},
-// 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
-// 				panic("mock out the GetFileRevision method")
-// 			},
-// 			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
-// 				panic("mock out the MigrateProject method")
-// 			},
-// 			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
-// 				panic("mock out the ProjectExists method")
-// 			},
-// 			ProjectRepoExistsFunc: func(projectName string) bool {
-// 				panic("mock out the ProjectRepoExists method")
-// 			},
-// 			PullFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the Pull method")
-// 			},
-// 			PushFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the Push method")
-// 			},
-// 			ResetHardFunc: func(gitContext common_models.GitContext) error {
-// 				panic("mock out the ResetHard method")
-// 			},
-// 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
-// 				panic("mock out the StageAndCommitAll method")
-// 			},
-// 		}
+//		// make and configure a mocked common.IGit
+//		mockedIGit := &IGitMock{
+//			CheckoutBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+//				panic("mock out the CheckoutBranch method")
+//			},
+//			CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
+//				panic("mock out the CloneRepo method")
+//			},
+//			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
+//				panic("mock out the CreateBranch method")
+//			},
+//			DeleteRemoteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+//				panic("mock out the DeleteRemoteBranch method")
+//			},
+//			GetCurrentBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetCurrentBranch method")
+//			},
+//			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetCurrentRevision method")
+//			},
+//			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetDefaultBranch method")
+//			},
+//			GetFileDiffFunc: func(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error) {
+//				panic("mock out the GetFileDiff method")
+//			},
+//			GetFileHistoryFunc: func(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error) {
+//				panic("mock out the GetFileHistory method")
+//			},
+//			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+//				panic("mock out the GetFileRevision method")
+//			},
+//			MigrateProjectFunc: func(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error {
+//				panic("mock out the MigrateProject method")
+//			},
+//			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
+//				panic("mock out the ProjectExists method")
+//			},
+//			ProjectRepoExistsFunc: func(projectName string) bool {
+//				panic("mock out the ProjectRepoExists method")
+//			},
+//			PullFunc: func(gitContext common_models.GitContext) error {
+//				panic("mock out the Pull method")
+//			},
+//			PushFunc: func(gitContext common_models.GitContext) error {
+//				panic("mock out the Push method")
+//			},
+//			ResetHardFunc: func(gitContext common_models.GitContext, revision string) error {
+//				panic("mock out the ResetHard method")
+//			},
+//			ResolveLFSPointerFunc: func(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+//				panic("mock out the ResolveLFSPointer method")
+//			},
+//			RestoreFileRevisionFunc: func(gitContext common_models.GitContext, revision string, path string) error {
+//				panic("mock out the RestoreFileRevision method")
+//			},
+//			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
+//				panic("mock out the StageAndCommitAll method")
+//			},
+//			StageAndCommitToBranchFunc: func(gitContext common_models.GitContext, branch string, message string) (string, error) {
+//				panic("mock out the StageAndCommitToBranch method")
+//			},
+//		}
 //
-// 		// use mockedIGit in code that requires common.IGit
-// 		// and then make assertions.
+//		// use mockedIGit in code that requires common.IGit
+//		// and then make assertions.
 //
-// 	}
+//	}
 type IGitMock struct {
 	// CheckoutBranchFunc mocks the CheckoutBranch method.
 	CheckoutBranchFunc func(gitContext common_models.GitContext, branch string) error
@@ -69,17 +91,29 @@ type IGitMock struct {
 	// CreateBranchFunc mocks the CreateBranch method.
 	CreateBranchFunc func(gitContext common_models.GitContext, branch string, sourceBranch string) error
 
+	// DeleteRemoteBranchFunc mocks the DeleteRemoteBranch method.
+	DeleteRemoteBranchFunc func(gitContext common_models.GitContext, branch string) error
+
+	// GetCurrentBranchFunc mocks the GetCurrentBranch method.
+	GetCurrentBranchFunc func(gitContext common_models.GitContext) (string, error)
+
 	// GetCurrentRevisionFunc mocks the GetCurrentRevision method.
 	GetCurrentRevisionFunc func(gitContext common_models.GitContext) (string, error)
 
 	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
 	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
 
+	// GetFileDiffFunc mocks the GetFileDiff method.
+	GetFileDiffFunc func(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error)
+
+	// GetFileHistoryFunc mocks the GetFileHistory method.
+	GetFileHistoryFunc func(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error)
+
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
 	// MigrateProjectFunc mocks the MigrateProject method.
-	MigrateProjectFunc func(gitContext common_models.GitContext, newMetadatacontent []byte) error
+	MigrateProjectFunc func(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error
 
 	// ProjectExistsFunc mocks the ProjectExists method.
 	ProjectExistsFunc func(gitContext common_models.GitContext) bool
@@ -94,11 +128,20 @@ type IGitMock struct {
 	PushFunc func(gitContext common_models.GitContext) error
 
 	// ResetHardFunc mocks the ResetHard method.
-	ResetHardFunc func(gitContext common_models.GitContext) error
+	ResetHardFunc func(gitContext common_models.GitContext, revision string) error
+
+	// ResolveLFSPointerFunc mocks the ResolveLFSPointer method.
+	ResolveLFSPointerFunc func(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error)
+
+	// RestoreFileRevisionFunc mocks the RestoreFileRevision method.
+	RestoreFileRevisionFunc func(gitContext common_models.GitContext, revision string, path string) error
 
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
 
+	// StageAndCommitToBranchFunc mocks the StageAndCommitToBranch method.
+	StageAndCommitToBranchFunc func(gitContext common_models.GitContext, branch string, message string) (string, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckoutBranch holds details about calls to the CheckoutBranch method.
@@ -122,6 +165,18 @@ type IGitMock struct {
 			// SourceBranch is the sourceBranch argument value.
 			SourceBranch string
 		}
+		// DeleteRemoteBranch holds details about calls to the DeleteRemoteBranch method.
+		DeleteRemoteBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// GetCurrentBranch holds details about calls to the GetCurrentBranch method.
+		GetCurrentBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// GetCurrentRevision holds details about calls to the GetCurrentRevision method.
 		GetCurrentRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -132,6 +187,24 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// GetFileDiff holds details about calls to the GetFileDiff method.
+		GetFileDiff []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Path is the path argument value.
+			Path string
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+		}
+		// GetFileHistory holds details about calls to the GetFileHistory method.
+		GetFileHistory []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Path is the path argument value.
+			Path string
+		}
 		// GetFileRevision holds details about calls to the GetFileRevision method.
 		GetFileRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -145,8 +218,10 @@ type IGitMock struct {
 		MigrateProject []struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
-			// NewMetadatacontent is the newMetadatacontent argument value.
-			NewMetadatacontent []byte
+			// StageLayout is the stageLayout argument value.
+			StageLayout string
+			// NewMetadataContent is the newMetadataContent argument value.
+			NewMetadataContent []byte
 		}
 		// ProjectExists holds details about calls to the ProjectExists method.
 		ProjectExists []struct {
@@ -172,6 +247,26 @@ type IGitMock struct {
 		ResetHard []struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+		}
+		// ResolveLFSPointer holds details about calls to the ResolveLFSPointer method.
+		ResolveLFSPointer []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Content is the content argument value.
+			Content io.ReadCloser
+			// Size is the size argument value.
+			Size int64
+		}
+		// RestoreFileRevision holds details about calls to the RestoreFileRevision method.
+		RestoreFileRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// Path is the path argument value.
+			Path string
 		}
 		// StageAndCommitAll holds details about calls to the StageAndCommitAll method.
 		StageAndCommitAll []struct {
@@ -180,20 +275,36 @@ type IGitMock struct {
 			// Message is the message argument value.
 			Message string
 		}
+		// StageAndCommitToBranch holds details about calls to the StageAndCommitToBranch method.
+		StageAndCommitToBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+			// Message is the message argument value.
+			Message string
+		}
 	}
-	lockCheckoutBranch     sync.RWMutex
-	lockCloneRepo          sync.RWMutex
-	lockCreateBranch       sync.RWMutex
-	lockGetCurrentRevision sync.RWMutex
-	lockGetDefaultBranch   sync.RWMutex
-	lockGetFileRevision    sync.RWMutex
-	lockMigrateProject     sync.RWMutex
-	lockProjectExists      sync.RWMutex
-	lockProjectRepoExists  sync.RWMutex
-	lockPull               sync.RWMutex
-	lockPush               sync.RWMutex
-	lockResetHard          sync.RWMutex
-	lockStageAndCommitAll  sync.RWMutex
+	lockCheckoutBranch         sync.RWMutex
+	lockCloneRepo              sync.RWMutex
+	lockCreateBranch           sync.RWMutex
+	lockDeleteRemoteBranch     sync.RWMutex
+	lockGetCurrentBranch       sync.RWMutex
+	lockGetCurrentRevision     sync.RWMutex
+	lockGetDefaultBranch       sync.RWMutex
+	lockGetFileDiff            sync.RWMutex
+	lockGetFileHistory         sync.RWMutex
+	lockGetFileRevision        sync.RWMutex
+	lockMigrateProject         sync.RWMutex
+	lockProjectExists          sync.RWMutex
+	lockProjectRepoExists      sync.RWMutex
+	lockPull                   sync.RWMutex
+	lockPush                   sync.RWMutex
+	lockResetHard              sync.RWMutex
+	lockResolveLFSPointer      sync.RWMutex
+	lockRestoreFileRevision    sync.RWMutex
+	lockStageAndCommitAll      sync.RWMutex
+	lockStageAndCommitToBranch sync.RWMutex
 }
 
 // CheckoutBranch calls CheckoutBranchFunc.
@@ -216,7 +327,8 @@ func (mock *IGitMock) CheckoutBranch(gitContext common_models.GitContext, branch
 
 // CheckoutBranchCalls gets all the calls that were made to CheckoutBranch.
 // Check the length with:
-//     len(mockedIGit.CheckoutBranchCalls())
+//
+//	len(mockedIGit.CheckoutBranchCalls())
 func (mock *IGitMock) CheckoutBranchCalls() []struct {
 	GitContext common_models.GitContext
 	Branch     string
@@ -249,7 +361,8 @@ func (mock *IGitMock) CloneRepo(gitContext common_models.GitContext) (bool, erro
 
 // CloneRepoCalls gets all the calls that were made to CloneRepo.
 // Check the length with:
-//     len(mockedIGit.CloneRepoCalls())
+//
+//	len(mockedIGit.CloneRepoCalls())
 func (mock *IGitMock) CloneRepoCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -284,7 +397,8 @@ func (mock *IGitMock) CreateBranch(gitContext common_models.GitContext, branch s
 
 // CreateBranchCalls gets all the calls that were made to CreateBranch.
 // Check the length with:
-//     len(mockedIGit.CreateBranchCalls())
+//
+//	len(mockedIGit.CreateBranchCalls())
 func (mock *IGitMock) CreateBranchCalls() []struct {
 	GitContext   common_models.GitContext
 	Branch       string
@@ -301,6 +415,74 @@ func (mock *IGitMock) CreateBranchCalls() []struct {
 	return calls
 }
 
+// DeleteRemoteBranch calls DeleteRemoteBranchFunc.
+func (mock *IGitMock) DeleteRemoteBranch(gitContext common_models.GitContext, branch string) error {
+	if mock.DeleteRemoteBranchFunc == nil {
+		panic("IGitMock.DeleteRemoteBranchFunc: method is nil but IGit.DeleteRemoteBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockDeleteRemoteBranch.Lock()
+	mock.calls.DeleteRemoteBranch = append(mock.calls.DeleteRemoteBranch, callInfo)
+	mock.lockDeleteRemoteBranch.Unlock()
+	return mock.DeleteRemoteBranchFunc(gitContext, branch)
+}
+
+// DeleteRemoteBranchCalls gets all the calls that were made to DeleteRemoteBranch.
+// Check the length with:
+//
+//	len(mockedIGit.DeleteRemoteBranchCalls())
+func (mock *IGitMock) DeleteRemoteBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockDeleteRemoteBranch.RLock()
+	calls = mock.calls.DeleteRemoteBranch
+	mock.lockDeleteRemoteBranch.RUnlock()
+	return calls
+}
+
+// GetCurrentBranch calls GetCurrentBranchFunc.
+func (mock *IGitMock) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	if mock.GetCurrentBranchFunc == nil {
+		panic("IGitMock.GetCurrentBranchFunc: method is nil but IGit.GetCurrentBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetCurrentBranch.Lock()
+	mock.calls.GetCurrentBranch = append(mock.calls.GetCurrentBranch, callInfo)
+	mock.lockGetCurrentBranch.Unlock()
+	return mock.GetCurrentBranchFunc(gitContext)
+}
+
+// GetCurrentBranchCalls gets all the calls that were made to GetCurrentBranch.
+// Check the length with:
+//
+//	len(mockedIGit.GetCurrentBranchCalls())
+func (mock *IGitMock) GetCurrentBranchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetCurrentBranch.RLock()
+	calls = mock.calls.GetCurrentBranch
+	mock.lockGetCurrentBranch.RUnlock()
+	return calls
+}
+
 // GetCurrentRevision calls GetCurrentRevisionFunc.
 func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (string, error) {
 	if mock.GetCurrentRevisionFunc == nil {
@@ -319,7 +501,8 @@ func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (s
 
 // GetCurrentRevisionCalls gets all the calls that were made to GetCurrentRevision.
 // Check the length with:
-//     len(mockedIGit.GetCurrentRevisionCalls())
+//
+//	len(mockedIGit.GetCurrentRevisionCalls())
 func (mock *IGitMock) GetCurrentRevisionCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -350,7 +533,8 @@ func (mock *IGitMock) GetDefaultBranch(gitContext common_models.GitContext) (str
 
 // GetDefaultBranchCalls gets all the calls that were made to GetDefaultBranch.
 // Check the length with:
-//     len(mockedIGit.GetDefaultBranchCalls())
+//
+//	len(mockedIGit.GetDefaultBranchCalls())
 func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -363,6 +547,86 @@ func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	return calls
 }
 
+// GetFileDiff calls GetFileDiffFunc.
+func (mock *IGitMock) GetFileDiff(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error) {
+	if mock.GetFileDiffFunc == nil {
+		panic("IGitMock.GetFileDiffFunc: method is nil but IGit.GetFileDiff was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		Path         string
+		FromRevision string
+		ToRevision   string
+	}{
+		GitContext:   gitContext,
+		Path:         path,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+	}
+	mock.lockGetFileDiff.Lock()
+	mock.calls.GetFileDiff = append(mock.calls.GetFileDiff, callInfo)
+	mock.lockGetFileDiff.Unlock()
+	return mock.GetFileDiffFunc(gitContext, path, fromRevision, toRevision)
+}
+
+// GetFileDiffCalls gets all the calls that were made to GetFileDiff.
+// Check the length with:
+//
+//	len(mockedIGit.GetFileDiffCalls())
+func (mock *IGitMock) GetFileDiffCalls() []struct {
+	GitContext   common_models.GitContext
+	Path         string
+	FromRevision string
+	ToRevision   string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		Path         string
+		FromRevision string
+		ToRevision   string
+	}
+	mock.lockGetFileDiff.RLock()
+	calls = mock.calls.GetFileDiff
+	mock.lockGetFileDiff.RUnlock()
+	return calls
+}
+
+// GetFileHistory calls GetFileHistoryFunc.
+func (mock *IGitMock) GetFileHistory(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error) {
+	if mock.GetFileHistoryFunc == nil {
+		panic("IGitMock.GetFileHistoryFunc: method is nil but IGit.GetFileHistory was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Path       string
+	}{
+		GitContext: gitContext,
+		Path:       path,
+	}
+	mock.lockGetFileHistory.Lock()
+	mock.calls.GetFileHistory = append(mock.calls.GetFileHistory, callInfo)
+	mock.lockGetFileHistory.Unlock()
+	return mock.GetFileHistoryFunc(gitContext, path)
+}
+
+// GetFileHistoryCalls gets all the calls that were made to GetFileHistory.
+// Check the length with:
+//
+//	len(mockedIGit.GetFileHistoryCalls())
+func (mock *IGitMock) GetFileHistoryCalls() []struct {
+	GitContext common_models.GitContext
+	Path       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Path       string
+	}
+	mock.lockGetFileHistory.RLock()
+	calls = mock.calls.GetFileHistory
+	mock.lockGetFileHistory.RUnlock()
+	return calls
+}
+
 // GetFileRevision calls GetFileRevisionFunc.
 func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	if mock.GetFileRevisionFunc == nil {
@@ -385,7 +649,8 @@ func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revis
 
 // GetFileRevisionCalls gets all the calls that were made to GetFileRevision.
 // Check the length with:
-//     len(mockedIGit.GetFileRevisionCalls())
+//
+//	len(mockedIGit.GetFileRevisionCalls())
 func (mock *IGitMock) GetFileRevisionCalls() []struct {
 	GitContext common_models.GitContext
 	Revision   string
@@ -403,33 +668,38 @@ func (mock *IGitMock) GetFileRevisionCalls() []struct {
 }
 
 // MigrateProject calls MigrateProjectFunc.
-func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error {
+func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error {
 	if mock.MigrateProjectFunc == nil {
 		panic("IGitMock.MigrateProjectFunc: method is nil but IGit.MigrateProject was just called")
 	}
 	callInfo := struct {
 		GitContext         common_models.GitContext
-		NewMetadatacontent []byte
+		StageLayout        string
+		NewMetadataContent []byte
 	}{
 		GitContext:         gitContext,
-		NewMetadatacontent: newMetadatacontent,
+		StageLayout:        stageLayout,
+		NewMetadataContent: newMetadataContent,
 	}
 	mock.lockMigrateProject.Lock()
 	mock.calls.MigrateProject = append(mock.calls.MigrateProject, callInfo)
 	mock.lockMigrateProject.Unlock()
-	return mock.MigrateProjectFunc(gitContext, newMetadatacontent)
+	return mock.MigrateProjectFunc(gitContext, stageLayout, newMetadataContent)
 }
 
 // MigrateProjectCalls gets all the calls that were made to MigrateProject.
 // Check the length with:
-//     len(mockedIGit.MigrateProjectCalls())
+//
+//	len(mockedIGit.MigrateProjectCalls())
 func (mock *IGitMock) MigrateProjectCalls() []struct {
 	GitContext         common_models.GitContext
-	NewMetadatacontent []byte
+	StageLayout        string
+	NewMetadataContent []byte
 } {
 	var calls []struct {
 		GitContext         common_models.GitContext
-		NewMetadatacontent []byte
+		StageLayout        string
+		NewMetadataContent []byte
 	}
 	mock.lockMigrateProject.RLock()
 	calls = mock.calls.MigrateProject
@@ -455,7 +725,8 @@ func (mock *IGitMock) ProjectExists(gitContext common_models.GitContext) bool {
 
 // ProjectExistsCalls gets all the calls that were made to ProjectExists.
 // Check the length with:
-//     len(mockedIGit.ProjectExistsCalls())
+//
+//	len(mockedIGit.ProjectExistsCalls())
 func (mock *IGitMock) ProjectExistsCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -486,7 +757,8 @@ func (mock *IGitMock) ProjectRepoExists(projectName string) bool {
 
 // ProjectRepoExistsCalls gets all the calls that were made to ProjectRepoExists.
 // Check the length with:
-//     len(mockedIGit.ProjectRepoExistsCalls())
+//
+//	len(mockedIGit.ProjectRepoExistsCalls())
 func (mock *IGitMock) ProjectRepoExistsCalls() []struct {
 	ProjectName string
 } {
@@ -517,7 +789,8 @@ func (mock *IGitMock) Pull(gitContext common_models.GitContext) error {
 
 // PullCalls gets all the calls that were made to Pull.
 // Check the length with:
-//     len(mockedIGit.PullCalls())
+//
+//	len(mockedIGit.PullCalls())
 func (mock *IGitMock) PullCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -548,7 +821,8 @@ func (mock *IGitMock) Push(gitContext common_models.GitContext) error {
 
 // PushCalls gets all the calls that were made to Push.
 // Check the length with:
-//     len(mockedIGit.PushCalls())
+//
+//	len(mockedIGit.PushCalls())
 func (mock *IGitMock) PushCalls() []struct {
 	GitContext common_models.GitContext
 } {
@@ -568,23 +842,28 @@ func (mock *IGitMock) ResetHard(gitContext common_models.GitContext, revision st
 	}
 	callInfo := struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}{
 		GitContext: gitContext,
+		Revision:   revision,
 	}
 	mock.lockResetHard.Lock()
 	mock.calls.ResetHard = append(mock.calls.ResetHard, callInfo)
 	mock.lockResetHard.Unlock()
-	return mock.ResetHardFunc(gitContext)
+	return mock.ResetHardFunc(gitContext, revision)
 }
 
 // ResetHardCalls gets all the calls that were made to ResetHard.
 // Check the length with:
-//     len(mockedIGit.ResetHardCalls())
+//
+//	len(mockedIGit.ResetHardCalls())
 func (mock *IGitMock) ResetHardCalls() []struct {
 	GitContext common_models.GitContext
+	Revision   string
 } {
 	var calls []struct {
 		GitContext common_models.GitContext
+		Revision   string
 	}
 	mock.lockResetHard.RLock()
 	calls = mock.calls.ResetHard
@@ -592,6 +871,86 @@ func (mock *IGitMock) ResetHardCalls() []struct {
 	return calls
 }
 
+// ResolveLFSPointer calls ResolveLFSPointerFunc.
+func (mock *IGitMock) ResolveLFSPointer(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+	if mock.ResolveLFSPointerFunc == nil {
+		panic("IGitMock.ResolveLFSPointerFunc: method is nil but IGit.ResolveLFSPointer was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Content    io.ReadCloser
+		Size       int64
+	}{
+		GitContext: gitContext,
+		Content:    content,
+		Size:       size,
+	}
+	mock.lockResolveLFSPointer.Lock()
+	mock.calls.ResolveLFSPointer = append(mock.calls.ResolveLFSPointer, callInfo)
+	mock.lockResolveLFSPointer.Unlock()
+	return mock.ResolveLFSPointerFunc(gitContext, content, size)
+}
+
+// ResolveLFSPointerCalls gets all the calls that were made to ResolveLFSPointer.
+// Check the length with:
+//
+//	len(mockedIGit.ResolveLFSPointerCalls())
+func (mock *IGitMock) ResolveLFSPointerCalls() []struct {
+	GitContext common_models.GitContext
+	Content    io.ReadCloser
+	Size       int64
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Content    io.ReadCloser
+		Size       int64
+	}
+	mock.lockResolveLFSPointer.RLock()
+	calls = mock.calls.ResolveLFSPointer
+	mock.lockResolveLFSPointer.RUnlock()
+	return calls
+}
+
+// RestoreFileRevision calls RestoreFileRevisionFunc.
+func (mock *IGitMock) RestoreFileRevision(gitContext common_models.GitContext, revision string, path string) error {
+	if mock.RestoreFileRevisionFunc == nil {
+		panic("IGitMock.RestoreFileRevisionFunc: method is nil but IGit.RestoreFileRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		Path:       path,
+	}
+	mock.lockRestoreFileRevision.Lock()
+	mock.calls.RestoreFileRevision = append(mock.calls.RestoreFileRevision, callInfo)
+	mock.lockRestoreFileRevision.Unlock()
+	return mock.RestoreFileRevisionFunc(gitContext, revision, path)
+}
+
+// RestoreFileRevisionCalls gets all the calls that were made to RestoreFileRevision.
+// Check the length with:
+//
+//	len(mockedIGit.RestoreFileRevisionCalls())
+func (mock *IGitMock) RestoreFileRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	Path       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}
+	mock.lockRestoreFileRevision.RLock()
+	calls = mock.calls.RestoreFileRevision
+	mock.lockRestoreFileRevision.RUnlock()
+	return calls
+}
+
 // StageAndCommitAll calls StageAndCommitAllFunc.
 func (mock *IGitMock) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
 	if mock.StageAndCommitAllFunc == nil {
@@ -612,7 +971,8 @@ func (mock *IGitMock) StageAndCommitAll(gitContext common_models.GitContext, mes
 
 // StageAndCommitAllCalls gets all the calls that were made to StageAndCommitAll.
 // Check the length with:
-//     len(mockedIGit.StageAndCommitAllCalls())
+//
+//	len(mockedIGit.StageAndCommitAllCalls())
 func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	GitContext common_models.GitContext
 	Message    string
@@ -626,3 +986,43 @@ func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	mock.lockStageAndCommitAll.RUnlock()
 	return calls
 }
+
+// StageAndCommitToBranch calls StageAndCommitToBranchFunc.
+func (mock *IGitMock) StageAndCommitToBranch(gitContext common_models.GitContext, branch string, message string) (string, error) {
+	if mock.StageAndCommitToBranchFunc == nil {
+		panic("IGitMock.StageAndCommitToBranchFunc: method is nil but IGit.StageAndCommitToBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+		Message    string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+		Message:    message,
+	}
+	mock.lockStageAndCommitToBranch.Lock()
+	mock.calls.StageAndCommitToBranch = append(mock.calls.StageAndCommitToBranch, callInfo)
+	mock.lockStageAndCommitToBranch.Unlock()
+	return mock.StageAndCommitToBranchFunc(gitContext, branch, message)
+}
+
+// StageAndCommitToBranchCalls gets all the calls that were made to StageAndCommitToBranch.
+// Check the length with:
+//
+//	len(mockedIGit.StageAndCommitToBranchCalls())
+func (mock *IGitMock) StageAndCommitToBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+	Message    string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+		Message    string
+	}
+	mock.lockStageAndCommitToBranch.RLock()
+	calls = mock.calls.StageAndCommitToBranch
+	mock.lockStageAndCommitToBranch.RUnlock()
+	return calls
+}
@@ -0,0 +1,378 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package common_mock
+
+import (
+	"github.com/keptn/keptn/resource-service/common_models"
+	"io"
+	"sync"
+)
+
+// IProjectCacheMock is a mock implementation of common.IProjectCache.
+//
+//	func TestSomethingThatUsesIProjectCache(t *testing.T) {
+//
+//		// make and configure a mocked common.IProjectCache
+//		mockedIProjectCache := &IProjectCacheMock{
+//			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+//				panic("mock out the GetDefaultBranch method")
+//			},
+//			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+//				panic("mock out the GetFileRevision method")
+//			},
+//			GetRevisionFunc: func(gitContext common_models.GitContext, branch string) (string, error) {
+//				panic("mock out the GetRevision method")
+//			},
+//			InvalidateFunc: func(project string)  {
+//				panic("mock out the Invalidate method")
+//			},
+//			OpenFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+//				panic("mock out the OpenFileRevision method")
+//			},
+//			PathExistsFunc: func(gitContext common_models.GitContext, revision string, path string) (bool, error) {
+//				panic("mock out the PathExists method")
+//			},
+//			RemoveFunc: func(project string) error {
+//				panic("mock out the Remove method")
+//			},
+//		}
+//
+//		// use mockedIProjectCache in code that requires common.IProjectCache
+//		// and then make assertions.
+//
+//	}
+type IProjectCacheMock struct {
+	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
+	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
+
+	// GetFileRevisionFunc mocks the GetFileRevision method.
+	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+
+	// GetRevisionFunc mocks the GetRevision method.
+	GetRevisionFunc func(gitContext common_models.GitContext, branch string) (string, error)
+
+	// InvalidateFunc mocks the Invalidate method.
+	InvalidateFunc func(project string)
+
+	// OpenFileRevisionFunc mocks the OpenFileRevision method.
+	OpenFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error)
+
+	// PathExistsFunc mocks the PathExists method.
+	PathExistsFunc func(gitContext common_models.GitContext, revision string, path string) (bool, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(project string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// GetDefaultBranch holds details about calls to the GetDefaultBranch method.
+		GetDefaultBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
+		// GetFileRevision holds details about calls to the GetFileRevision method.
+		GetFileRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// File is the file argument value.
+			File string
+		}
+		// GetRevision holds details about calls to the GetRevision method.
+		GetRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// Invalidate holds details about calls to the Invalidate method.
+		Invalidate []struct {
+			// Project is the project argument value.
+			Project string
+		}
+		// OpenFileRevision holds details about calls to the OpenFileRevision method.
+		OpenFileRevision []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// File is the file argument value.
+			File string
+		}
+		// PathExists holds details about calls to the PathExists method.
+		PathExists []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// Path is the path argument value.
+			Path string
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// Project is the project argument value.
+			Project string
+		}
+	}
+	lockGetDefaultBranch sync.RWMutex
+	lockGetFileRevision  sync.RWMutex
+	lockGetRevision      sync.RWMutex
+	lockInvalidate       sync.RWMutex
+	lockOpenFileRevision sync.RWMutex
+	lockPathExists       sync.RWMutex
+	lockRemove           sync.RWMutex
+}
+
+// GetDefaultBranch calls GetDefaultBranchFunc.
+func (mock *IProjectCacheMock) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
+	if mock.GetDefaultBranchFunc == nil {
+		panic("IProjectCacheMock.GetDefaultBranchFunc: method is nil but IProjectCache.GetDefaultBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetDefaultBranch.Lock()
+	mock.calls.GetDefaultBranch = append(mock.calls.GetDefaultBranch, callInfo)
+	mock.lockGetDefaultBranch.Unlock()
+	return mock.GetDefaultBranchFunc(gitContext)
+}
+
+// GetDefaultBranchCalls gets all the calls that were made to GetDefaultBranch.
+// Check the length with:
+//
+//	len(mockedIProjectCache.GetDefaultBranchCalls())
+func (mock *IProjectCacheMock) GetDefaultBranchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetDefaultBranch.RLock()
+	calls = mock.calls.GetDefaultBranch
+	mock.lockGetDefaultBranch.RUnlock()
+	return calls
+}
+
+// GetFileRevision calls GetFileRevisionFunc.
+func (mock *IProjectCacheMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+	if mock.GetFileRevisionFunc == nil {
+		panic("IProjectCacheMock.GetFileRevisionFunc: method is nil but IProjectCache.GetFileRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		File:       file,
+	}
+	mock.lockGetFileRevision.Lock()
+	mock.calls.GetFileRevision = append(mock.calls.GetFileRevision, callInfo)
+	mock.lockGetFileRevision.Unlock()
+	return mock.GetFileRevisionFunc(gitContext, revision, file)
+}
+
+// GetFileRevisionCalls gets all the calls that were made to GetFileRevision.
+// Check the length with:
+//
+//	len(mockedIProjectCache.GetFileRevisionCalls())
+func (mock *IProjectCacheMock) GetFileRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}
+	mock.lockGetFileRevision.RLock()
+	calls = mock.calls.GetFileRevision
+	mock.lockGetFileRevision.RUnlock()
+	return calls
+}
+
+// GetRevision calls GetRevisionFunc.
+func (mock *IProjectCacheMock) GetRevision(gitContext common_models.GitContext, branch string) (string, error) {
+	if mock.GetRevisionFunc == nil {
+		panic("IProjectCacheMock.GetRevisionFunc: method is nil but IProjectCache.GetRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockGetRevision.Lock()
+	mock.calls.GetRevision = append(mock.calls.GetRevision, callInfo)
+	mock.lockGetRevision.Unlock()
+	return mock.GetRevisionFunc(gitContext, branch)
+}
+
+// GetRevisionCalls gets all the calls that were made to GetRevision.
+// Check the length with:
+//
+//	len(mockedIProjectCache.GetRevisionCalls())
+func (mock *IProjectCacheMock) GetRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockGetRevision.RLock()
+	calls = mock.calls.GetRevision
+	mock.lockGetRevision.RUnlock()
+	return calls
+}
+
+// Invalidate calls InvalidateFunc.
+func (mock *IProjectCacheMock) Invalidate(project string) {
+	if mock.InvalidateFunc == nil {
+		panic("IProjectCacheMock.InvalidateFunc: method is nil but IProjectCache.Invalidate was just called")
+	}
+	callInfo := struct {
+		Project string
+	}{
+		Project: project,
+	}
+	mock.lockInvalidate.Lock()
+	mock.calls.Invalidate = append(mock.calls.Invalidate, callInfo)
+	mock.lockInvalidate.Unlock()
+	mock.InvalidateFunc(project)
+}
+
+// InvalidateCalls gets all the calls that were made to Invalidate.
+// Check the length with:
+//
+//	len(mockedIProjectCache.InvalidateCalls())
+func (mock *IProjectCacheMock) InvalidateCalls() []struct {
+	Project string
+} {
+	var calls []struct {
+		Project string
+	}
+	mock.lockInvalidate.RLock()
+	calls = mock.calls.Invalidate
+	mock.lockInvalidate.RUnlock()
+	return calls
+}
+
+// OpenFileRevision calls OpenFileRevisionFunc.
+func (mock *IProjectCacheMock) OpenFileRevision(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+	if mock.OpenFileRevisionFunc == nil {
+		panic("IProjectCacheMock.OpenFileRevisionFunc: method is nil but IProjectCache.OpenFileRevision was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		File:       file,
+	}
+	mock.lockOpenFileRevision.Lock()
+	mock.calls.OpenFileRevision = append(mock.calls.OpenFileRevision, callInfo)
+	mock.lockOpenFileRevision.Unlock()
+	return mock.OpenFileRevisionFunc(gitContext, revision, file)
+}
+
+// OpenFileRevisionCalls gets all the calls that were made to OpenFileRevision.
+// Check the length with:
+//
+//	len(mockedIProjectCache.OpenFileRevisionCalls())
+func (mock *IProjectCacheMock) OpenFileRevisionCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}
+	mock.lockOpenFileRevision.RLock()
+	calls = mock.calls.OpenFileRevision
+	mock.lockOpenFileRevision.RUnlock()
+	return calls
+}
+
+// PathExists calls PathExistsFunc.
+func (mock *IProjectCacheMock) PathExists(gitContext common_models.GitContext, revision string, path string) (bool, error) {
+	if mock.PathExistsFunc == nil {
+		panic("IProjectCacheMock.PathExistsFunc: method is nil but IProjectCache.PathExists was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		Path:       path,
+	}
+	mock.lockPathExists.Lock()
+	mock.calls.PathExists = append(mock.calls.PathExists, callInfo)
+	mock.lockPathExists.Unlock()
+	return mock.PathExistsFunc(gitContext, revision, path)
+}
+
+// PathExistsCalls gets all the calls that were made to PathExists.
+// Check the length with:
+//
+//	len(mockedIProjectCache.PathExistsCalls())
+func (mock *IProjectCacheMock) PathExistsCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	Path       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}
+	mock.lockPathExists.RLock()
+	calls = mock.calls.PathExists
+	mock.lockPathExists.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *IProjectCacheMock) Remove(project string) error {
+	if mock.RemoveFunc == nil {
+		panic("IProjectCacheMock.RemoveFunc: method is nil but IProjectCache.Remove was just called")
+	}
+	callInfo := struct {
+		Project string
+	}{
+		Project: project,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	return mock.RemoveFunc(project)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//	len(mockedIProjectCache.RemoveCalls())
+func (mock *IProjectCacheMock) RemoveCalls() []struct {
+	Project string
+} {
+	var calls []struct {
+		Project string
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
@@ -0,0 +1,77 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package common_mock
+
+import (
+	"github.com/keptn/keptn/resource-service/common_models"
+	"sync"
+)
+
+// IPullRequestProviderMock is a mock implementation of common.IPullRequestProvider.
+//
+//	func TestSomethingThatUsesIPullRequestProvider(t *testing.T) {
+//
+//		// make and configure a mocked common.IPullRequestProvider
+//		mockedIPullRequestProvider := &IPullRequestProviderMock{
+//			CreatePullRequestFunc: func(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+//				panic("mock out the CreatePullRequest method")
+//			},
+//		}
+//
+//		// use mockedIPullRequestProvider in code that requires common.IPullRequestProvider
+//		// and then make assertions.
+//
+//	}
+type IPullRequestProviderMock struct {
+	// CreatePullRequestFunc mocks the CreatePullRequest method.
+	CreatePullRequestFunc func(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreatePullRequest holds details about calls to the CreatePullRequest method.
+		CreatePullRequest []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// PullRequest is the pullRequest argument value.
+			PullRequest common_models.PullRequest
+		}
+	}
+	lockCreatePullRequest sync.RWMutex
+}
+
+// CreatePullRequest calls CreatePullRequestFunc.
+func (mock *IPullRequestProviderMock) CreatePullRequest(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+	if mock.CreatePullRequestFunc == nil {
+		panic("IPullRequestProviderMock.CreatePullRequestFunc: method is nil but IPullRequestProvider.CreatePullRequest was just called")
+	}
+	callInfo := struct {
+		GitContext  common_models.GitContext
+		PullRequest common_models.PullRequest
+	}{
+		GitContext:  gitContext,
+		PullRequest: pullRequest,
+	}
+	mock.lockCreatePullRequest.Lock()
+	mock.calls.CreatePullRequest = append(mock.calls.CreatePullRequest, callInfo)
+	mock.lockCreatePullRequest.Unlock()
+	return mock.CreatePullRequestFunc(gitContext, pullRequest)
+}
+
+// CreatePullRequestCalls gets all the calls that were made to CreatePullRequest.
+// Check the length with:
+//
+//	len(mockedIPullRequestProvider.CreatePullRequestCalls())
+func (mock *IPullRequestProviderMock) CreatePullRequestCalls() []struct {
+	GitContext  common_models.GitContext
+	PullRequest common_models.PullRequest
+} {
+	var calls []struct {
+		GitContext  common_models.GitContext
+		PullRequest common_models.PullRequest
+	}
+	mock.lockCreatePullRequest.RLock()
+	calls = mock.calls.CreatePullRequest
+	mock.lockCreatePullRequest.RUnlock()
+	return calls
+}
@@ -21,7 +21,10 @@ type IFileSystem interface {
 	WriteBase64EncodedFile(path string, content string) error
 	WriteHelmChart(path string) error
 	WriteFile(path string, content []byte) error
+	WriteTempFile(content io.Reader) (string, error)
+	CopyFile(sourcePath string, path string) error
 	ReadFile(filename string) ([]byte, error)
+	OpenFile(filename string) (io.ReadCloser, int64, error)
 	DeleteFile(path string) error
 	FileExists(path string) bool
 	MakeDir(path string) error
@@ -89,6 +92,52 @@ func (fw FileSystem) WriteFile(path string, content []byte) error {
 	return err
 }
 
+// WriteTempFile streams the content into a new temporary file and returns its path. The caller is responsible for deleting the file
+func (fw FileSystem) WriteTempFile(content io.Reader) (string, error) {
+	file, err := ioutil.TempFile(fw.tmpDirLocation, "resource-*")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, content); err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Sync(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// CopyFile copies the file at sourcePath to path, without reading the whole content into memory
+func (fw FileSystem) CopyFile(sourcePath string, path string) error {
+	source, err := os.Open(filepath.Clean(sourcePath))
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	// the file is re-created instead of truncated, so that readers that still have the previous version opened are not affected
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, source); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
 func (fw FileSystem) WriteHelmChart(path string) error {
 	// remove previous helm/resourceURI folder
 	targetFolderPath := strings.TrimSuffix(path, ".tgz")
@@ -132,6 +181,82 @@ func (fw FileSystem) ReadFile(filename string) ([]byte, error) {
 	return ioutil.ReadFile(filename)
 }
 
+// OpenFile opens the file for streaming its content, and returns its size. Helm charts are packaged into a temporary archive,
+// which is deleted when the returned reader is closed
+func (fw FileSystem) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	filename = filepath.Clean(filename)
+	if IsHelmChartPath(filename) {
+		return fw.openHelmChart(filename)
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, errors2.ErrResourceNotFound
+		}
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+	return file, info.Size(), nil
+}
+
+func (fw FileSystem) openHelmChart(filename string) (io.ReadCloser, int64, error) {
+	chartDir := strings.TrimSuffix(filename, ".tgz")
+	if !fw.FileExists(chartDir) {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+	isEmpty, err := IsEmpty(chartDir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not check directory content: %w", err)
+	}
+	if isEmpty {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+
+	tmpDir, err := ioutil.TempDir(fw.tmpDirLocation, "*")
+	if err != nil {
+		return nil, 0, err
+	}
+	archivePath := filepath.Join(tmpDir, filepath.Base(filename))
+	if err := archive.Archive([]string{chartDir}, archivePath); err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, 0, err
+	}
+	file, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		_ = os.RemoveAll(tmpDir)
+		return nil, 0, err
+	}
+	return &tempFileReader{File: file, dir: tmpDir}, info.Size(), nil
+}
+
+// tempFileReader removes the temporary directory of the file when it is closed
+type tempFileReader struct {
+	*os.File
+	dir string
+}
+
+func (r *tempFileReader) Close() error {
+	err := r.File.Close()
+	if removeErr := os.RemoveAll(r.dir); removeErr != nil {
+		logger.Errorf("Could not delete temporary directory %s: %v", r.dir, removeErr)
+	}
+	return err
+}
+
 func (FileSystem) DeleteFile(path string) error {
 	var err = os.RemoveAll(path)
 	if err != nil {
@@ -1,8 +1,11 @@
 package common
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
 
+	errors2 "github.com/keptn/keptn/resource-service/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -102,3 +105,74 @@ func TestFileSystem_WriteHelmChart(t *testing.T) {
 
 	require.Equal(t, "test\n", string(res))
 }
+
+func TestFileSystem_WriteTempFileAndCopyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	tmpFile, err := fs.WriteTempFile(strings.NewReader("streamed content"))
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(tmpFile, dir))
+
+	err = fs.CopyFile(tmpFile, dir+"/my-service/my-file")
+	require.Nil(t, err)
+
+	// copying a file again replaces its content
+	err = fs.WriteFile(dir+"/my-service/other-file", []byte("other content"))
+	require.Nil(t, err)
+	err = fs.CopyFile(dir+"/my-service/other-file", dir+"/my-service/my-file")
+	require.Nil(t, err)
+
+	res, err := fs.ReadFile(dir + "/my-service/my-file")
+	require.Nil(t, err)
+	require.Equal(t, "other content", string(res))
+	require.True(t, fs.FileExists(tmpFile))
+}
+
+func TestFileSystem_OpenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	err := fs.WriteFile(dir+"/my-file", []byte("content"))
+	require.Nil(t, err)
+
+	content, size, err := fs.OpenFile(dir + "/my-file")
+	require.Nil(t, err)
+	res, err := ioutil.ReadAll(content)
+	require.Nil(t, err)
+	require.Nil(t, content.Close())
+	require.Equal(t, "content", string(res))
+	require.Equal(t, int64(7), size)
+
+	_, _, err = fs.OpenFile(dir + "/not-existing")
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+}
+
+func TestFileSystem_OpenFile_HelmChart(t *testing.T) {
+	dir := t.TempDir()
+	tmpDir := t.TempDir()
+
+	fs := NewFileSystem(tmpDir)
+
+	filePath := dir + "/helm/my-chart.tgz"
+	err := fs.WriteBase64EncodedFile(filePath, testTgzContent)
+	require.Nil(t, err)
+	err = fs.WriteHelmChart(filePath)
+	require.Nil(t, err)
+
+	content, size, err := fs.OpenFile(filePath)
+	require.Nil(t, err)
+	res, err := ioutil.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, int64(len(res)), size)
+	require.NotZero(t, size)
+
+	// the temporary archive is removed when the content is closed
+	require.Nil(t, content.Close())
+	isEmpty, err := IsEmpty(tmpDir)
+	require.Nil(t, err)
+	require.True(t, isEmpty)
+	require.False(t, fs.FileExists(filePath))
+}
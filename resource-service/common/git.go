@@ -9,23 +9,31 @@ import (
 	nethttp "net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/client"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
 	logger "github.com/sirupsen/logrus"
 	ssh2 "golang.org/x/crypto/ssh"
 )
 
+// PullRequestBranchPrefix is the prefix of the branches to which changes are pushed if they are proposed via pull requests
+const PullRequestBranchPrefix = "keptn/resource-update-"
+
 // IGit provides functions to interact with the git repository of a project
 //go:generate moq -pkg common_mock -skip-ensure -out ./fake/git_mock.go . IGit
 type IGit interface {
@@ -33,6 +41,8 @@ type IGit interface {
 	ProjectRepoExists(projectName string) bool
 	CloneRepo(gitContext common_models.GitContext) (bool, error)
 	StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error)
+	StageAndCommitToBranch(gitContext common_models.GitContext, branch string, message string) (string, error)
+	DeleteRemoteBranch(gitContext common_models.GitContext, branch string) error
 	Push(gitContext common_models.GitContext) error
 	Pull(gitContext common_models.GitContext) error
 	CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error
@@ -40,16 +50,44 @@ type IGit interface {
 	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 	GetCurrentRevision(gitContext common_models.GitContext) (string, error)
 	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
-	MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error
+	GetCurrentBranch(gitContext common_models.GitContext) (string, error)
+	MigrateProject(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error
 	ResetHard(gitContext common_models.GitContext, revision string) error
+	ResolveLFSPointer(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error)
+	GetFileHistory(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error)
+	GetFileDiff(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error)
+	RestoreFileRevision(gitContext common_models.GitContext, revision string, path string) error
 }
 
 type Git struct {
-	git Gogit
+	git   Gogit
+	lfs   *LFS
+	cache IProjectCache
+}
+
+// GitOption can be used to configure the Git implementation
+type GitOption func(*Git)
+
+// WithLFS enables storing files that are larger than the threshold (in bytes) in the Git LFS storage of the upstream
+func WithLFS(threshold int64) GitOption {
+	return func(g *Git) {
+		g.lfs = NewLFS(threshold)
+	}
 }
 
-func NewGit(git Gogit) *Git {
-	return &Git{git: git}
+// WithProjectCache invalidates the cached clone of a project whenever changes have been pushed to its upstream
+func WithProjectCache(cache IProjectCache) GitOption {
+	return func(g *Git) {
+		g.cache = cache
+	}
+}
+
+func NewGit(git Gogit, opts ...GitOption) *Git {
+	g := &Git{git: git, lfs: NewLFS(0)}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
 func configureGitUser(repository *git.Repository) error {
@@ -234,6 +272,15 @@ func (g Git) commitAll(gitContext common_models.GitContext, message string) (str
 		message = "commit changes"
 	}
 
+	// only the files that are committed are stored in LFS, the rest of the worktree has already been cleaned by previous commits
+	changedPaths, err := getChangedPaths(w)
+	if err != nil {
+		return "", err
+	}
+	if err := g.lfs.Clean(gitContext, changedPaths); err != nil {
+		return "", err
+	}
+
 	err = w.AddWithOptions(&git.AddOptions{All: true})
 	if err != nil {
 		return "", err
@@ -250,6 +297,23 @@ func (g Git) commitAll(gitContext common_models.GitContext, message string) (str
 	return id.String(), err
 }
 
+// getChangedPaths returns the paths of the worktree that have been added or modified since the last commit
+func getChangedPaths(w *git.Worktree) ([]string, error) {
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Deleted || (fileStatus.Worktree == git.Unmodified && fileStatus.Staging == git.Unmodified) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
 func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
 
 	id, err := g.commitAll(gitContext, message)
@@ -285,6 +349,69 @@ func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message stri
 	return id, nil
 }
 
+// StageAndCommitToBranch commits all changes and pushes them to a new branch of the upstream. The current branch is not changed,
+// i.e. the changes are only available in the current branch once the new branch has been merged
+func (g Git) StageAndCommitToBranch(gitContext common_models.GitContext, branch string, message string) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+
+	id, err := g.commitAll(gitContext, message)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, err)
+	}
+	defer func() {
+		if err := g.ResetHard(gitContext, head.Hash().String()); err != nil {
+			logger.WithError(err).Warn("could not reset")
+		}
+	}()
+
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return "", err
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(head.Name().String() + ":" + plumbing.NewBranchReferenceName(branch).String())},
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	return id, nil
+}
+
+// DeleteRemoteBranch deletes a branch of the upstream, e.g. a branch that has been pushed by StageAndCommitToBranch
+func (g Git) DeleteRemoteBranch(gitContext common_models.GitContext, branch string) error {
+	if gitContext.Credentials == nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch of", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch of", gitContext.Project, err)
+	}
+	auth, err := getAuthMethod(gitContext)
+	if err != nil {
+		return err
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(":" + plumbing.NewBranchReferenceName(branch).String())},
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch of", gitContext.Project, err)
+	}
+	return nil
+}
+
 func (g Git) Push(gitContext common_models.GitContext) error {
 	var err error
 	if gitContext.Credentials == nil {
@@ -309,6 +436,9 @@ func (g Git) Push(gitContext common_models.GitContext) error {
 		}
 		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
 	}
+	if g.cache != nil {
+		g.cache.Invalidate(gitContext.Project)
+	}
 	return nil
 }
 
@@ -520,6 +650,11 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 	if obj == nil {
 		return []byte{}, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
 	}
+	if commit, ok := obj.(*object.Commit); ok {
+		if err := ensureCommitMerged(r, gitContext, commit); err != nil {
+			return []byte{}, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+		}
+	}
 	blob, err := resolve(obj, file)
 
 	if err != nil {
@@ -531,7 +666,7 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
 
-	var re (io.Reader)
+	var re (io.ReadCloser)
 	re, err = blob.Reader()
 
 	if err != nil {
@@ -539,23 +674,252 @@ func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision stri
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
 
+	re, _, err = g.lfs.Resolve(gitContext, re, blob.Size)
+	if err != nil {
+		return []byte{},
+			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	defer re.Close()
+
 	return ioutil.ReadAll(re)
 }
 
+// ResolveLFSPointer returns the content of the file referenced by an LFS pointer file. The content of other files is returned unchanged
+func (g *Git) ResolveLFSPointer(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+	return g.lfs.Resolve(gitContext, content, size)
+}
+
+// GetFileHistory returns the commits of the current branch that changed the file or directory at the given path, starting with the most recent one.
+// The path is relative to the project directory
+func (g *Git) GetFileHistory(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	commitIter, err := r.Log(&git.LogOptions{
+		From:       head.Hash(),
+		PathFilter: pathMatcher(path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	defer commitIter.Close()
+
+	commits := []common_models.GitCommit{}
+	err = commitIter.ForEach(func(commit *object.Commit) error {
+		commits = append(commits, common_models.GitCommit{
+			ID:        commit.Hash.String(),
+			Author:    commit.Author.Name,
+			Message:   strings.TrimSpace(commit.Message),
+			Timestamp: commit.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "read history of", gitContext.Project, err)
+	}
+	if len(commits) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return commits, nil
+}
+
+// GetFileDiff returns the changes of the file or directory at the given path between two revisions as unified diff. The path is relative to the project directory
+func (g *Git) GetFileDiff(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	fromTree, err := getRevisionTree(r, gitContext, fromRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	toTree, err := getRevisionTree(r, gitContext, toRevision)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+
+	matches := pathMatcher(path)
+	fileChanges := object.Changes{}
+	for _, change := range changes {
+		if matches(change.From.Name) || matches(change.To.Name) {
+			fileChanges = append(fileChanges, change)
+		}
+	}
+	patch, err := fileChanges.Patch()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "diff", gitContext.Project, err)
+	}
+	return patch.String(), nil
+}
+
+// RestoreFileRevision restores the file or directory at the given path in the worktree to its state at the given revision, without committing the changes.
+// The path is relative to the project directory
+func (g *Git) RestoreFileRevision(gitContext common_models.GitContext, revision string, path string) error {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "restore file in", gitContext.Project, err)
+	}
+	tree, err := getRevisionTree(r, gitContext, revision)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "restore file in", gitContext.Project, err)
+	}
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "restore file in", gitContext.Project, kerrors.ErrResourceNotFound)
+	}
+
+	targetPath := GetProjectConfigPath(gitContext.Project) + "/" + path
+	if entry.Mode != filemode.Dir {
+		file, err := tree.TreeEntryFile(entry)
+		if err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "restore file in", gitContext.Project, err)
+		}
+		return writeGitFile(file, targetPath)
+	}
+
+	// directories, e.g. Helm charts, are restored completely, i.e. files that have been added afterwards are removed
+	subtree, err := tree.Tree(path)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "restore file in", gitContext.Project, err)
+	}
+	if err := os.RemoveAll(targetPath); err != nil {
+		return err
+	}
+	return subtree.Files().ForEach(func(file *object.File) error {
+		return writeGitFile(file, targetPath+"/"+file.Name)
+	})
+}
+
+// getRevisionTree returns the tree of the given revision. If the changes of the project are proposed via pull requests, only revisions that
+// have been merged into one of the stage or default branches can be read, i.e. not the commits of pull requests that have not been merged
+func getRevisionTree(r *git.Repository, gitContext common_models.GitContext, revision string) (*object.Tree, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", revision, kerrors.ErrResolveRevision)
+	}
+	commit, err := r.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", revision, kerrors.ErrResolveRevision)
+	}
+	if err := ensureCommitMerged(r, gitContext, commit); err != nil {
+		return nil, fmt.Errorf("%s: %w", revision, err)
+	}
+	return commit.Tree()
+}
+
+// ensureCommitMerged returns ErrRevisionNotMerged if the changes of the project are proposed via pull requests, and the commit is not reachable
+// from any branch except the branches of pull requests
+func ensureCommitMerged(r *git.Repository, gitContext common_models.GitContext, commit *object.Commit) error {
+	if gitContext.Credentials == nil || !gitContext.Credentials.PullRequestsEnabled() {
+		return nil
+	}
+	branches, err := r.Branches()
+	if err != nil {
+		return err
+	}
+	heads := []*object.Commit{}
+	merged := false
+	err = branches.ForEach(func(ref *plumbing.Reference) error {
+		if strings.HasPrefix(ref.Name().Short(), PullRequestBranchPrefix) {
+			return nil
+		}
+		if ref.Hash() == commit.Hash {
+			merged = true
+			return storer.ErrStop
+		}
+		head, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			return err
+		}
+		heads = append(heads, head)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, head := range heads {
+		if merged {
+			break
+		}
+		if merged, err = commit.IsAncestor(head); err != nil {
+			return err
+		}
+	}
+	if !merged {
+		return kerrors.ErrRevisionNotMerged
+	}
+	return nil
+}
+
+// pathMatcher returns a function that checks whether a path of the repository belongs to the file or directory at the given path
+func pathMatcher(path string) func(string) bool {
+	return func(p string) bool {
+		return p != "" && (p == path || strings.HasPrefix(p, path+"/"))
+	}
+}
+
+func writeGitFile(file *object.File, path string) error {
+	if err := ensureDirectoryExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	target, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	_, err = io.Copy(target, reader)
+	return err
+}
+
 func (g *Git) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
 	r, _, err := g.getWorkTree(gitContext)
 	if err != nil {
 		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGetDefBranch, gitContext.Project, err)
 	}
-	repoConfig, err := r.Config()
+	def, err := getDefaultBranch(r)
 	if err != nil {
 		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGetDefBranch, gitContext.Project, err)
 	}
+	return def, nil
+}
+
+func getDefaultBranch(r *git.Repository) (string, error) {
+	repoConfig, err := r.Config()
+	if err != nil {
+		return "", err
+	}
 	def := repoConfig.Init.DefaultBranch
 	if def == "" {
 		def = "master"
 	}
-	return def, err
+	return def, nil
+}
+
+// GetCurrentBranch returns the name of the branch that is currently checked out
+func (g *Git) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, err)
+	}
+	return head.Name().Short(), nil
 }
 
 func (g *Git) ProjectExists(gitContext common_models.GitContext) bool {
@@ -579,7 +943,10 @@ func (g *Git) ProjectRepoExists(project string) bool {
 	return false
 }
 
-func (g *Git) MigrateProject(gitContext common_models.GitContext, newMetadataContent []byte) error {
+// MigrateProject migrates the stages of the project to the given stage layout and updates the upstream. When migrating to the directory layout,
+// the content of each stage branch is moved to a directory within the default branch. When migrating to the branch layout, the content of each
+// stage directory is committed to the branch of the respective stage, on top of the history the branch already has
+func (g *Git) MigrateProject(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error {
 	if err := g.Pull(gitContext); err != nil {
 		return err
 	}
@@ -595,11 +962,19 @@ func (g *Git) MigrateProject(gitContext common_models.GitContext, newMetadataCon
 		return err
 	}
 
+	// the clone of a previous attempt must not be reused, since its branches may have been modified
+	if err := os.RemoveAll(tmpProjectPath); err != nil {
+		return err
+	}
 	if _, err := g.CloneRepo(tmpGitContext); err != nil {
 		return err
 	}
+	defer func() {
+		if err := os.RemoveAll(tmpProjectPath); err != nil {
+			logger.WithError(err).Warnf("could not delete temporary clone of project %s", gitContext.Project)
+		}
+	}()
 
-	// check out branches of the tmp remote and store the content in the master branch of the repo
 	oldRepo, oldRepoWorktree, err := g.getWorkTree(tmpGitContext)
 	if err != nil {
 		return err
@@ -608,31 +983,204 @@ func (g *Git) MigrateProject(gitContext common_models.GitContext, newMetadataCon
 	if err := g.fetch(tmpGitContext, oldRepo); err != nil {
 		return err
 	}
+
+	if stageLayout == models.StageLayoutBranch {
+		return g.migrateStageDirectories(gitContext, tmpGitContext, oldRepo, oldRepoWorktree, defaultBranch, newMetadataContent)
+	}
+
+	if err := g.migrateBranches(oldRepo, oldRepoWorktree, defaultBranch, projectPath, tmpProjectPath); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(GetProjectMetadataFilePath(gitContext.Project), newMetadataContent, os.ModePerm); err != nil {
+		return err
+	}
+
+	_, err = g.StageAndCommitAll(gitContext, "migrated project structure")
+	return err
+}
+
+// migrateBranches checks out the branches of the tmp clone and stores their content in the stage directories of the default branch of the project
+func (g *Git) migrateBranches(oldRepo *git.Repository, oldRepoWorktree *git.Worktree, defaultBranch string, projectPath string, tmpProjectPath string) error {
 	branches, err := oldRepo.Branches()
-	err = branches.ForEach(func(branch *plumbing.Reference) error {
-		if branch.Name().Short() != defaultBranch {
+	if err != nil {
+		return err
+	}
+	return branches.ForEach(func(branch *plumbing.Reference) error {
+		// stage names cannot contain '/', hence such branches, e.g. the ones created for pull requests, do not represent a stage
+		if branch.Name().Short() != defaultBranch && !strings.Contains(branch.Name().Short(), "/") {
 			return g.migrateBranch(branch, oldRepoWorktree, projectPath, tmpProjectPath)
 		}
 		return nil
 	})
+}
+
+// migrateStageDirectories commits the content of the stage directories of the project to the respective branches of the tmp clone, and removes
+// the stage directories from its default branch. All branches are pushed at once, and the stage branches are reset to their previous revision if
+// the upstream does not accept all of them, so that the upstream never contains a partially migrated project. Afterwards, the project is reset
+// to the migrated default branch
+func (g *Git) migrateStageDirectories(gitContext common_models.GitContext, tmpGitContext common_models.GitContext, tmpRepo *git.Repository, tmpWorktree *git.Worktree, defaultBranch string, newMetadataContent []byte) error {
+	stagesPath := GetProjectConfigPath(gitContext.Project) + "/" + StageDirectoryName
+	stages, err := ioutil.ReadDir(stagesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	defaultBranchRef, err := tmpRepo.Reference(plumbing.NewBranchReferenceName(defaultBranch), true)
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(GetProjectMetadataFilePath(gitContext.Project), newMetadataContent, os.ModePerm); err != nil {
+	refSpecs := []config.RefSpec{}
+	previousRevisions := map[plumbing.ReferenceName]plumbing.Hash{}
+	for _, stage := range stages {
+		if !stage.IsDir() {
+			continue
+		}
+		branch := plumbing.NewBranchReferenceName(stage.Name())
+		previousRevision, err := g.migrateStageDirectory(tmpGitContext, tmpRepo, tmpWorktree, branch, stagesPath+"/"+stage.Name(), defaultBranchRef.Hash(), newMetadataContent)
+		if err != nil {
+			return err
+		}
+		previousRevisions[branch] = previousRevision
+		refSpecs = append(refSpecs, config.RefSpec(branch.String()+":"+branch.String()))
+	}
+
+	// the stage directories are removed from the default branch of the tmp clone, so that it can be pushed together with the stage branches
+	if err := tmpWorktree.Checkout(&git.CheckoutOptions{Branch: defaultBranchRef.Name(), Force: true}); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(GetProjectConfigPath(tmpGitContext.Project) + "/" + StageDirectoryName); err != nil {
+		return err
+	}
+	if err := os.WriteFile(GetProjectMetadataFilePath(tmpGitContext.Project), newMetadataContent, os.ModePerm); err != nil {
+		return err
+	}
+	migratedRevision, err := g.commitAll(tmpGitContext, "migrated project structure")
+	if err != nil {
 		return err
 	}
+	refSpecs = append(refSpecs, config.RefSpec(defaultBranchRef.Name().String()+":"+defaultBranchRef.Name().String()))
 
-	_, err = g.StageAndCommitAll(gitContext, "migrated project structure")
+	auth, err := getAuthMethod(gitContext)
 	if err != nil {
 		return err
 	}
+	if err := tmpRepo.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        refSpecs,
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	}); err != nil {
+		// the upstream updates each branch on its own, hence the stage branches may have been updated even if the default branch has been rejected
+		g.rollbackStageBranches(tmpGitContext, tmpRepo, previousRevisions)
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	if g.cache != nil {
+		g.cache.Invalidate(gitContext.Project)
+	}
 
-	if err := os.RemoveAll(tmpProjectPath); err != nil {
+	// the local branches of the project are pushed together with the default branch, hence they need to be updated as well.
+	// This includes the remote-tracking branches, since pulling the default branch would be rejected otherwise
+	repo, worktree, err := g.getWorkTree(gitContext)
+	if err != nil {
+		return err
+	}
+	if err := repo.Fetch(&git.FetchOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{"+refs/heads/*:refs/heads/*", "+refs/heads/*:refs/remotes/origin/*"},
+		Force:           true,
+		Auth:            auth,
+		InsecureSkipTLS: gitContext.Credentials.InsecureSkipTLS,
+	}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	if err := worktree.Reset(&git.ResetOptions{Commit: plumbing.NewHash(migratedRevision), Mode: git.HardReset}); err != nil {
 		return err
 	}
 
-	return nil
+	return os.RemoveAll(stagesPath)
+}
+
+// migrateStageDirectory commits the content of the stage directory to the branch of the stage in the tmp clone, and returns the previous
+// revision of the branch, or the zero hash if the branch has been created
+func (g *Git) migrateStageDirectory(tmpGitContext common_models.GitContext, tmpRepo *git.Repository, tmpWorktree *git.Worktree, branch plumbing.ReferenceName, stagePath string, defaultBranchHash plumbing.Hash, newMetadataContent []byte) (plumbing.Hash, error) {
+	previousRevision := plumbing.ZeroHash
+	checkoutOptions := &git.CheckoutOptions{Branch: branch, Force: true}
+	if ref, err := tmpRepo.Reference(branch, true); err == nil {
+		previousRevision = ref.Hash()
+	} else if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// stages that have been created after migrating the project to the directory layout do not have a branch yet
+		checkoutOptions.Create = true
+		checkoutOptions.Hash = defaultBranchHash
+	} else {
+		return plumbing.ZeroHash, err
+	}
+	if err := tmpWorktree.Checkout(checkoutOptions); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	tmpProjectPath := GetProjectConfigPath(tmpGitContext.Project)
+	files, err := ioutil.ReadDir(tmpProjectPath)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	for _, file := range files {
+		if file.Name() == ".git" {
+			continue
+		}
+		if err := os.RemoveAll(tmpProjectPath + "/" + file.Name()); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	if err := copyDirectory(stagePath, tmpProjectPath); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	// in the branch layout, each stage branch contains the metadata of the project
+	if err := os.WriteFile(tmpProjectPath+"/metadata.yaml", newMetadataContent, os.ModePerm); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if _, err := g.commitAll(tmpGitContext, "migrated stage "+branch.Short()+" to branch"); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return previousRevision, nil
+}
+
+// rollbackStageBranches resets the stage branches of the upstream that have been updated by migrateStageDirectories to their previous revision,
+// and deletes the ones that have been created. Branches that have not been updated, or have been updated by someone else in the meantime, are not changed
+func (g *Git) rollbackStageBranches(tmpGitContext common_models.GitContext, tmpRepo *git.Repository, previousRevisions map[plumbing.ReferenceName]plumbing.Hash) {
+	auth, err := getAuthMethod(tmpGitContext)
+	if err != nil {
+		logger.WithError(err).Errorf("could not roll back the stage branches of project %s", tmpGitContext.Project)
+		return
+	}
+	for branch, previousRevision := range previousRevisions {
+		migratedRef, err := tmpRepo.Reference(branch, true)
+		if err != nil {
+			logger.WithError(err).Errorf("could not roll back branch %s", branch.Short())
+			continue
+		}
+		refSpec := config.RefSpec(":" + branch.String())
+		if !previousRevision.IsZero() {
+			if err := tmpRepo.Storer.SetReference(plumbing.NewHashReference(branch, previousRevision)); err != nil {
+				logger.WithError(err).Errorf("could not roll back branch %s", branch.Short())
+				continue
+			}
+			refSpec = config.RefSpec("+" + branch.String() + ":" + branch.String())
+		}
+		err = tmpRepo.Push(&git.PushOptions{
+			RemoteName:        "origin",
+			RefSpecs:          []config.RefSpec{refSpec},
+			RequireRemoteRefs: []config.RefSpec{config.RefSpec(migratedRef.Hash().String() + ":" + branch.String())},
+			Auth:              auth,
+			InsecureSkipTLS:   tmpGitContext.Credentials.InsecureSkipTLS,
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			logger.WithError(err).Warnf("could not roll back branch %s", branch.Short())
+		}
+	}
 }
 
 func (g *Git) migrateBranch(branch *plumbing.Reference, oldRepoWorktree *git.Worktree, projectPath string, tmpProjectPath string) error {
@@ -0,0 +1,146 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryTestRepo creates a local repository for the project. The upstream is never contacted
+func newHistoryTestRepo(t *testing.T) (common_models.GitContext, *git.Worktree) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	gitContext := common_models.GitContext{
+		Project:     "my-project",
+		Credentials: &common_models.GitCredentials{User: "my-user", Token: "my-token", RemoteURI: "https://github.com/my-org/my-repo"},
+	}
+	r, err := git.PlainInit(GetProjectConfigPath(gitContext.Project), false)
+	require.Nil(t, err)
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{gitContext.Credentials.RemoteURI}})
+	require.Nil(t, err)
+	w, err := r.Worktree()
+	require.Nil(t, err)
+	return gitContext, w
+}
+
+func commitHistoryTestFiles(t *testing.T, w *git.Worktree, message string, files map[string]string) string {
+	fs := NewFileSystem(t.TempDir())
+	for path, content := range files {
+		if content == "" {
+			require.Nil(t, os.RemoveAll(w.Filesystem.Root()+"/"+path))
+			continue
+		}
+		require.Nil(t, fs.WriteFile(w.Filesystem.Root()+"/"+path, []byte(content)))
+	}
+	require.Nil(t, w.AddWithOptions(&git.AddOptions{All: true}))
+	id, err := w.Commit(message, &git.CommitOptions{
+		All:    true,
+		Author: &object.Signature{Name: "my-user", Email: "my-user@keptn.sh", When: time.Now()},
+	})
+	require.Nil(t, err)
+	return id.String()
+}
+
+func TestGit_GetFileHistory(t *testing.T) {
+	gitContext, w := newHistoryTestRepo(t)
+	first := commitHistoryTestFiles(t, w, "Added resources", map[string]string{
+		"my-service/values.yaml":             "replicas: 1\n",
+		"my-service/helm/chart/Chart.yaml":   "name: chart\n",
+		"my-service/values.yaml.bak":         "replicas: 0\n",
+		"my-service/helm/chart-2/Chart.yaml": "name: chart-2\n",
+	})
+	commitHistoryTestFiles(t, w, "Updated other resource", map[string]string{"my-service/values.yaml.bak": "replicas: 2\n"})
+	second := commitHistoryTestFiles(t, w, "Updated chart", map[string]string{"my-service/helm/chart/templates/service.yaml": "kind: Service\n"})
+	third := commitHistoryTestFiles(t, w, "Updated resource\n", map[string]string{"my-service/values.yaml": "replicas: 3\n"})
+
+	g := NewGit(GogitReal{})
+
+	commits, err := g.GetFileHistory(gitContext, "my-service/values.yaml")
+	require.Nil(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, third, commits[0].ID)
+	require.Equal(t, "Updated resource", commits[0].Message)
+	require.Equal(t, "my-user", commits[0].Author)
+	require.Equal(t, first, commits[1].ID)
+
+	// all files of a directory belong to its history
+	commits, err = g.GetFileHistory(gitContext, "my-service/helm/chart")
+	require.Nil(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, second, commits[0].ID)
+	require.Equal(t, first, commits[1].ID)
+
+	_, err = g.GetFileHistory(gitContext, "my-service/unknown.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+}
+
+func TestGit_GetFileDiff(t *testing.T) {
+	gitContext, w := newHistoryTestRepo(t)
+	first := commitHistoryTestFiles(t, w, "Added resources", map[string]string{
+		"my-service/values.yaml": "replicas: 1\n",
+		"my-service/other.yaml":  "replicas: 1\n",
+	})
+	second := commitHistoryTestFiles(t, w, "Updated resources", map[string]string{
+		"my-service/values.yaml": "replicas: 2\n",
+		"my-service/other.yaml":  "replicas: 2\n",
+	})
+
+	g := NewGit(GogitReal{})
+
+	diff, err := g.GetFileDiff(gitContext, "my-service/values.yaml", first, second)
+	require.Nil(t, err)
+	require.Contains(t, diff, "--- a/my-service/values.yaml")
+	require.Contains(t, diff, "-replicas: 1")
+	require.Contains(t, diff, "+replicas: 2")
+	require.NotContains(t, diff, "other.yaml")
+
+	diff, err = g.GetFileDiff(gitContext, "my-service/values.yaml", second, second)
+	require.Nil(t, err)
+	require.Empty(t, diff)
+
+	_, err = g.GetFileDiff(gitContext, "my-service/values.yaml", "unknown", second)
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+}
+
+func TestGit_RestoreFileRevision(t *testing.T) {
+	gitContext, w := newHistoryTestRepo(t)
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	first := commitHistoryTestFiles(t, w, "Added resources", map[string]string{
+		"my-service/values.yaml":           "replicas: 1\n",
+		"my-service/helm/chart/Chart.yaml": "name: chart\n",
+	})
+	commitHistoryTestFiles(t, w, "Updated resources", map[string]string{
+		"my-service/values.yaml":                       "replicas: 2\n",
+		"my-service/helm/chart/Chart.yaml":             "name: chart\nversion: 2\n",
+		"my-service/helm/chart/templates/service.yaml": "kind: Service\n",
+		"my-service/new.yaml":                          "kind: New\n",
+	})
+
+	g := NewGit(GogitReal{})
+
+	require.Nil(t, g.RestoreFileRevision(gitContext, first, "my-service/values.yaml"))
+	content, err := ioutil.ReadFile(projectPath + "/my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 1\n", string(content))
+
+	// files that have been added to a directory afterwards are removed
+	require.Nil(t, g.RestoreFileRevision(gitContext, first, "my-service/helm/chart"))
+	content, err = ioutil.ReadFile(projectPath + "/my-service/helm/chart/Chart.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "name: chart\n", string(content))
+	require.NoFileExists(t, projectPath+"/my-service/helm/chart/templates/service.yaml")
+
+	err = g.RestoreFileRevision(gitContext, first, "my-service/new.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResourceNotFound)
+	require.FileExists(t, projectPath+"/my-service/new.yaml")
+
+	err = g.RestoreFileRevision(gitContext, "unknown", "my-service/values.yaml")
+	require.ErrorIs(t, err, kerrors.ErrResolveRevision)
+}
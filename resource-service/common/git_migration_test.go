@@ -0,0 +1,153 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func readBranchFile(t *testing.T, r *git.Repository, branch string, path string) string {
+	ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	require.Nil(t, err)
+	commit, err := r.CommitObject(ref.Hash())
+	require.Nil(t, err)
+	file, err := commit.File(path)
+	require.Nil(t, err)
+	content, err := file.Contents()
+	require.Nil(t, err)
+	return content
+}
+
+func TestGit_MigrateProject_DirectoryLayoutAndBack(t *testing.T) {
+	remotePath := t.TempDir()
+	remote, err := git.PlainInit(remotePath, true)
+	require.Nil(t, err)
+
+	gitContext, w := newHistoryTestRepo(t)
+	gitContext.Credentials.RemoteURI = remotePath
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	r, err := git.PlainOpen(projectPath)
+	require.Nil(t, err)
+	require.Nil(t, r.DeleteRemote("origin"))
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	require.Nil(t, err)
+
+	initial := commitHistoryTestFiles(t, w, "initialized project", map[string]string{"metadata.yaml": "projectName: my-project\n"})
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("dev"), Create: true}))
+	devRevision := commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	// branches that cannot represent a stage are not migrated
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("keptn/resource-update-1"), Create: true}))
+	commitHistoryTestFiles(t, w, "Proposed resource", map[string]string{"my-service/values.yaml": "replicas: 5\n"})
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")}))
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	g := NewGit(GogitReal{})
+
+	// migrate to the directory layout
+	require.Nil(t, g.MigrateProject(gitContext, models.StageLayoutDirectory, []byte("stageLayout: directory\n")))
+
+	require.Equal(t, "replicas: 1\n", readBranchFile(t, remote, "master", ".keptn-stages/dev/my-service/values.yaml"))
+	require.Equal(t, "stageLayout: directory\n", readBranchFile(t, remote, "master", "metadata.yaml"))
+	require.NoDirExists(t, projectPath+"/.keptn-stages/keptn")
+	require.NoDirExists(t, GetProjectConfigPath("_keptn-tmp_"+gitContext.Project))
+
+	// change a stage and add a new one while using the directory layout
+	fs := NewFileSystem(t.TempDir())
+	require.Nil(t, fs.WriteFile(projectPath+"/.keptn-stages/dev/my-service/values.yaml", []byte("replicas: 2\n")))
+	require.Nil(t, fs.WriteFile(projectPath+"/.keptn-stages/prod/my-service/values.yaml", []byte("replicas: 3\n")))
+	_, err = g.StageAndCommitAll(gitContext, "Updated resources")
+	require.Nil(t, err)
+
+	// migrate back to the branch layout
+	require.Nil(t, g.MigrateProject(gitContext, models.StageLayoutBranch, []byte("stageLayout: branch\n")))
+
+	require.Equal(t, "replicas: 2\n", readBranchFile(t, remote, "dev", "my-service/values.yaml"))
+	require.Equal(t, "stageLayout: branch\n", readBranchFile(t, remote, "dev", "metadata.yaml"))
+	require.Equal(t, "replicas: 3\n", readBranchFile(t, remote, "prod", "my-service/values.yaml"))
+	require.Equal(t, "stageLayout: branch\n", readBranchFile(t, remote, "master", "metadata.yaml"))
+	require.Equal(t, "replicas: 5\n", readBranchFile(t, remote, "keptn/resource-update-1", "my-service/values.yaml"))
+
+	// the history of the existing stage branch is preserved
+	devRef, err := remote.Reference(plumbing.NewBranchReferenceName("dev"), true)
+	require.Nil(t, err)
+	devCommit, err := remote.CommitObject(devRef.Hash())
+	require.Nil(t, err)
+	require.Equal(t, []plumbing.Hash{plumbing.NewHash(devRevision)}, devCommit.ParentHashes)
+
+	// new stage branches are based on the default branch
+	prodRef, err := remote.Reference(plumbing.NewBranchReferenceName("prod"), true)
+	require.Nil(t, err)
+	prodCommit, err := remote.CommitObject(prodRef.Hash())
+	require.Nil(t, err)
+	isBasedOnDefaultBranch, err := remote.CommitObject(plumbing.NewHash(initial))
+	require.Nil(t, err)
+	isAncestor, err := isBasedOnDefaultBranch.IsAncestor(prodCommit)
+	require.Nil(t, err)
+	require.True(t, isAncestor)
+
+	masterRef, err := remote.Reference(plumbing.NewBranchReferenceName("master"), true)
+	require.Nil(t, err)
+	masterCommit, err := remote.CommitObject(masterRef.Hash())
+	require.Nil(t, err)
+	_, err = masterCommit.File(".keptn-stages/dev/my-service/values.yaml")
+	require.ErrorIs(t, err, object.ErrFileNotFound)
+	require.NoDirExists(t, projectPath+"/.keptn-stages")
+
+	content, err := ioutil.ReadFile(projectPath + "/metadata.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "stageLayout: branch\n", string(content))
+}
+
+func TestGit_MigrateProject_BranchLayoutRollsBackStageBranches(t *testing.T) {
+	remotePath := t.TempDir()
+	remote, err := git.PlainInit(remotePath, true)
+	require.Nil(t, err)
+
+	gitContext, w := newHistoryTestRepo(t)
+	gitContext.Credentials.RemoteURI = remotePath
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	r, err := git.PlainOpen(projectPath)
+	require.Nil(t, err)
+	require.Nil(t, r.DeleteRemote("origin"))
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	require.Nil(t, err)
+
+	commitHistoryTestFiles(t, w, "initialized project", map[string]string{"metadata.yaml": "projectName: my-project\n"})
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("dev"), Create: true}))
+	devRevision := commitHistoryTestFiles(t, w, "Added resource", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")}))
+	masterRevision := commitHistoryTestFiles(t, w, "migrated project structure", map[string]string{
+		"metadata.yaml": "stageLayout: directory\n",
+		".keptn-stages/dev/my-service/values.yaml":  "replicas: 2\n",
+		".keptn-stages/prod/my-service/values.yaml": "replicas: 3\n",
+	})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	// the upstream accepts the stage branches, but rejects the default branch
+	require.Nil(t, os.MkdirAll(remotePath+"/hooks", 0755))
+	require.Nil(t, ioutil.WriteFile(remotePath+"/hooks/update", []byte("#!/bin/sh\n[ \"$1\" != \"refs/heads/master\" ]\n"), 0755))
+
+	g := NewGit(GogitReal{})
+	require.NotNil(t, g.MigrateProject(gitContext, models.StageLayoutBranch, []byte("stageLayout: branch\n")))
+
+	// the existing stage branch is reset, and the new one is deleted
+	devRef, err := remote.Reference(plumbing.NewBranchReferenceName("dev"), true)
+	require.Nil(t, err)
+	require.Equal(t, devRevision, devRef.Hash().String())
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("prod"), true)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	masterRef, err := remote.Reference(plumbing.NewBranchReferenceName("master"), true)
+	require.Nil(t, err)
+	require.Equal(t, masterRevision, masterRef.Hash().String())
+
+	// the project keeps the directory layout
+	require.Equal(t, "replicas: 3\n", readBranchFile(t, r, "master", ".keptn-stages/prod/my-service/values.yaml"))
+	require.DirExists(t, projectPath+"/.keptn-stages/prod")
+}
@@ -24,6 +24,7 @@ import (
 	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
 	. "gopkg.in/check.v1"
 )
 
@@ -853,7 +854,7 @@ func (s *BaseSuite) TestGit_MigrateProject(c *C) {
 	err = g.CheckoutBranch(gitContext, "master")
 	c.Assert(err, IsNil)
 
-	err = g.MigrateProject(gitContext, []byte("new-metadata-content"))
+	err = g.MigrateProject(gitContext, models.StageLayoutDirectory, []byte("new-metadata-content"))
 	c.Assert(err, IsNil)
 
 	revision, err := g.GetCurrentRevision(gitContext)
@@ -0,0 +1,495 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const lfsPointerVersion = "https://git-lfs.github.com/spec/v1"
+
+// lfsMaxPointerSize is the maximum size of an LFS pointer file, see https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+const lfsMaxPointerSize = 1024
+const lfsMediaType = "application/vnd.git-lfs+json"
+const lfsAttributes = "filter=lfs diff=lfs merge=lfs -text"
+const gitAttributesFileName = ".gitattributes"
+
+var lfsOidRegex = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// LFSPointer references a file that is stored in the LFS storage of the upstream repository
+type LFSPointer struct {
+	Oid  string
+	Size int64
+}
+
+func (p LFSPointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", lfsPointerVersion, p.Oid, p.Size)
+}
+
+// ParseLFSPointer parses the content of an LFS pointer file. If the content is not a valid pointer file, false is returned
+func ParseLFSPointer(content []byte) (*LFSPointer, bool) {
+	if len(content) > lfsMaxPointerSize || !bytes.HasPrefix(content, []byte("version "+lfsPointerVersion+"\n")) {
+		return nil, false
+	}
+	pointer := &LFSPointer{Size: -1}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			pointer.Size = size
+		}
+	}
+	if !lfsOidRegex.MatchString(pointer.Oid) || pointer.Size < 0 {
+		return nil, false
+	}
+	return pointer, true
+}
+
+// LFS stores large files in the Git LFS storage of the upstream repository.
+// Before changes are committed, files exceeding the threshold are replaced by LFS pointer files, and their content is uploaded to the upstream.
+// When a resource is read, LFS pointer files are resolved using the local LFS storage of the repository, or by downloading the content from the upstream
+type LFS struct {
+	threshold int64
+}
+
+// NewLFS creates a new LFS handler. Files larger than the threshold (in bytes) are stored in LFS. If the threshold is 0, no new files are stored in LFS,
+// but existing LFS pointer files can still be resolved
+func NewLFS(threshold int64) *LFS {
+	if threshold > 0 && threshold <= lfsMaxPointerSize {
+		// smaller files could be mistaken for pointer files
+		threshold = lfsMaxPointerSize + 1
+	}
+	return &LFS{threshold: threshold}
+}
+
+// Clean replaces the given files of the project that exceed the threshold, or that are already tracked in LFS, with LFS pointer files
+// and uploads their content to the upstream. The paths are relative to the project directory, e.g. the paths that are about to be committed.
+// New LFS files are added to the .gitattributes file of the repository, so that they can be checked out with the git-lfs client
+func (l *LFS) Clean(gitContext common_models.GitContext, paths []string) error {
+	if l.threshold <= 0 || len(paths) == 0 {
+		return nil
+	}
+	if !supportsLFS(gitContext) {
+		logger.Debugf("Upstream of project %s does not support LFS, storing large files in git", gitContext.Project)
+		return nil
+	}
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	tracked, err := readLFSTrackedPaths(projectPath)
+	if err != nil {
+		return fmt.Errorf("could not read tracked LFS files of project %s: %w", gitContext.Project, err)
+	}
+
+	newlyTracked := []string{}
+	for _, relativePath := range paths {
+		relativePath = filepath.ToSlash(relativePath)
+		isTracked := tracked[relativePath]
+		cleaned, err := l.cleanPath(gitContext, projectPath, relativePath, isTracked)
+		if err != nil {
+			return fmt.Errorf("could not store %s in LFS: %w", relativePath, err)
+		}
+		if cleaned && !isTracked {
+			newlyTracked = append(newlyTracked, relativePath)
+		}
+	}
+	return trackLFSPaths(projectPath, newlyTracked)
+}
+
+// cleanPath replaces the file with an LFS pointer file if it exceeds the threshold or is already tracked in LFS, and returns whether it has been replaced.
+// Deleted files, files of the .git directory and files that already are pointer files are skipped
+func (l *LFS) cleanPath(gitContext common_models.GitContext, projectPath string, relativePath string, isTracked bool) (bool, error) {
+	if relativePath == gitAttributesFileName || relativePath == ".git" || strings.HasPrefix(relativePath, ".git/") {
+		return false, nil
+	}
+	path := filepath.Join(projectPath, filepath.FromSlash(relativePath))
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !info.Mode().IsRegular() || (info.Size() <= l.threshold && !isTracked) {
+		return false, nil
+	}
+	if info.Size() <= lfsMaxPointerSize {
+		content, err := ioutil.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return false, err
+		}
+		if _, isPointer := ParseLFSPointer(content); isPointer {
+			return false, nil
+		}
+	}
+	if err := l.cleanFile(gitContext, projectPath, path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Resolve returns the content of the file referenced by an LFS pointer file. If the content is not a pointer file, it is returned unchanged
+func (l *LFS) Resolve(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+	if size > lfsMaxPointerSize {
+		return content, size, nil
+	}
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, 0, err
+	}
+	pointer, isPointer := ParseLFSPointer(data)
+	if !isPointer {
+		return ioutil.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+	}
+	object, err := l.open(gitContext, pointer)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not retrieve LFS object %s of project %s: %w", pointer.Oid, gitContext.Project, err)
+	}
+	return object, pointer.Size, nil
+}
+
+// cleanFile moves the content of the file into the local LFS storage, uploads it to the upstream and replaces the file with a pointer file
+func (l *LFS) cleanFile(gitContext common_models.GitContext, projectPath string, path string) error {
+	pointer, err := storeLFSObject(projectPath, path)
+	if err != nil {
+		return err
+	}
+	client := newLFSClient(gitContext)
+	if err := client.upload(pointer, getLFSObjectPath(projectPath, pointer.Oid)); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(pointer.String()), 0600)
+}
+
+func (l *LFS) open(gitContext common_models.GitContext, pointer *LFSPointer) (io.ReadCloser, error) {
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	objectPath := getLFSObjectPath(projectPath, pointer.Oid)
+	if _, err := os.Stat(objectPath); err != nil {
+		if !supportsLFS(gitContext) {
+			return nil, errors.New("upstream does not support LFS")
+		}
+		if err := newLFSClient(gitContext).download(pointer, projectPath); err != nil {
+			return nil, err
+		}
+	}
+	return os.Open(objectPath)
+}
+
+func getLFSObjectPath(projectPath string, oid string) string {
+	return filepath.Join(projectPath, ".git", "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// storeLFSObject copies the file into the local LFS storage of the repository, and returns its pointer
+func storeLFSObject(projectPath string, path string) (*LFSPointer, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return writeLFSObject(projectPath, file, "")
+}
+
+// writeLFSObject writes the content into the local LFS storage of the repository. If an oid is expected, the content is verified against it
+func writeLFSObject(projectPath string, content io.Reader, expectedOid string) (*LFSPointer, error) {
+	tmpDir := filepath.Join(projectPath, ".git", "lfs", "tmp")
+	if err := ensureDirectoryExists(tmpDir); err != nil {
+		return nil, err
+	}
+	tmpFile, err := ioutil.TempFile(tmpDir, "object-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	pointer := &LFSPointer{Oid: hex.EncodeToString(hash.Sum(nil)), Size: size}
+	if expectedOid != "" && pointer.Oid != expectedOid {
+		return nil, fmt.Errorf("content of LFS object %s does not match its oid", expectedOid)
+	}
+
+	objectPath := getLFSObjectPath(projectPath, pointer.Oid)
+	if err := ensureDirectoryExists(filepath.Dir(objectPath)); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpFile.Name(), objectPath); err != nil {
+		return nil, err
+	}
+	return pointer, nil
+}
+
+// readLFSTrackedPaths returns the paths that are tracked in LFS according to the .gitattributes file of the repository
+func readLFSTrackedPaths(projectPath string) (map[string]bool, error) {
+	tracked := map[string]bool{}
+	content, err := ioutil.ReadFile(filepath.Join(projectPath, gitAttributesFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return tracked, nil
+		}
+		return nil, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasSuffix(line, " "+lfsAttributes) {
+			continue
+		}
+		pattern := strings.TrimSuffix(line, " "+lfsAttributes)
+		tracked[strings.ReplaceAll(strings.TrimPrefix(pattern, "/"), "[[:space:]]", " ")] = true
+	}
+	return tracked, scanner.Err()
+}
+
+func trackLFSPaths(projectPath string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(filepath.Join(projectPath, gitAttributesFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, path := range paths {
+		// patterns are anchored to the root of the repository, and must not contain spaces
+		if _, err := fmt.Fprintf(file, "/%s %s\n", strings.ReplaceAll(path, " ", "[[:space:]]"), lfsAttributes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supportsLFS returns true if the LFS batch API of the upstream can be used, which is only the case for http(s) upstreams
+func supportsLFS(gitContext common_models.GitContext) bool {
+	return gitContext.Credentials != nil && strings.HasPrefix(gitContext.Credentials.RemoteURI, "http")
+}
+
+type lfsBatchRequest struct {
+	Operation string      `json:"operation"`
+	Transfers []string    `json:"transfers"`
+	Objects   []lfsObject `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsObject `json:"objects"`
+}
+
+type lfsObject struct {
+	Oid     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsObjectError      `json:"error,omitempty"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// lfsClient implements the basic transfer mode of the Git LFS batch API, see https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md
+type lfsClient struct {
+	endpoint    string
+	credentials *common_models.GitCredentials
+	httpClient  *nethttp.Client
+}
+
+func newLFSClient(gitContext common_models.GitContext) *lfsClient {
+	return &lfsClient{
+		endpoint:    getLFSEndpoint(gitContext.Credentials.RemoteURI),
+		credentials: gitContext.Credentials,
+		httpClient:  newUpstreamHTTPClient(gitContext.Credentials),
+	}
+}
+
+// newUpstreamHTTPClient creates an HTTP client for the APIs of the upstream, which uses the same TLS and proxy settings as the git operations
+func newUpstreamHTTPClient(credentials *common_models.GitCredentials) *nethttp.Client {
+	transport := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: credentials.InsecureSkipTLS}
+	if credentials.GitProxyURL != "" {
+		transport.Proxy = nethttp.ProxyURL(&url.URL{
+			Scheme: credentials.GitProxyScheme,
+			User:   url.UserPassword(credentials.GitProxyUser, credentials.GitProxyPassword),
+			Host:   credentials.GitProxyURL,
+		})
+	}
+	return &nethttp.Client{Transport: transport}
+}
+
+// getLFSEndpoint returns the URL of the LFS API of the upstream, which is <remote>.git/info/lfs
+func getLFSEndpoint(remoteURI string) string {
+	endpoint := strings.TrimSuffix(remoteURI, "/")
+	if !strings.HasSuffix(endpoint, ".git") {
+		endpoint += ".git"
+	}
+	return endpoint + "/info/lfs"
+}
+
+func (c *lfsClient) upload(pointer *LFSPointer, objectPath string) error {
+	object, err := c.batch("upload", pointer)
+	if err != nil {
+		return err
+	}
+	upload, ok := object.Actions["upload"]
+	if !ok {
+		// the upstream already has the object
+		return nil
+	}
+	file, err := os.Open(filepath.Clean(objectPath))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	req, err := c.newActionRequest(nethttp.MethodPut, upload, file)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = pointer.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if err := c.do(req, nil); err != nil {
+		return err
+	}
+
+	if verify, ok := object.Actions["verify"]; ok {
+		payload, err := json.Marshal(lfsObject{Oid: pointer.Oid, Size: pointer.Size})
+		if err != nil {
+			return err
+		}
+		req, err := c.newActionRequest(nethttp.MethodPost, verify, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", lfsMediaType)
+		return c.do(req, nil)
+	}
+	return nil
+}
+
+func (c *lfsClient) download(pointer *LFSPointer, projectPath string) error {
+	object, err := c.batch("download", pointer)
+	if err != nil {
+		return err
+	}
+	download, ok := object.Actions["download"]
+	if !ok {
+		return fmt.Errorf("no download action provided for LFS object %s", pointer.Oid)
+	}
+	req, err := c.newActionRequest(nethttp.MethodGet, download, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, func(body io.Reader) error {
+		_, err := writeLFSObject(projectPath, body, pointer.Oid)
+		return err
+	})
+}
+
+func (c *lfsClient) batch(operation string, pointer *LFSPointer) (*lfsObject, error) {
+	payload, err := json.Marshal(lfsBatchRequest{
+		Operation: operation,
+		Transfers: []string{"basic"},
+		Objects:   []lfsObject{{Oid: pointer.Oid, Size: pointer.Size}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := nethttp.NewRequest(nethttp.MethodPost, c.endpoint+"/objects/batch", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	c.setAuth(req)
+
+	response := &lfsBatchResponse{}
+	err = c.do(req, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range response.Objects {
+		if object.Oid != pointer.Oid {
+			continue
+		}
+		if object.Error != nil {
+			return nil, fmt.Errorf("LFS %s of object %s failed with code %d: %s", operation, pointer.Oid, object.Error.Code, object.Error.Message)
+		}
+		return &object, nil
+	}
+	return nil, fmt.Errorf("LFS batch response does not contain object %s", pointer.Oid)
+}
+
+func (c *lfsClient) newActionRequest(method string, action lfsAction, body io.Reader) (*nethttp.Request, error) {
+	req, err := nethttp.NewRequest(method, action.Href, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range action.Header {
+		req.Header.Set(key, value)
+	}
+	// actions may provide their own authorization, otherwise the credentials of the upstream are used for the LFS server
+	if req.Header.Get("Authorization") == "" && strings.HasPrefix(action.Href, strings.TrimSuffix(c.endpoint, "/info/lfs")) {
+		c.setAuth(req)
+	}
+	return req, nil
+}
+
+func (c *lfsClient) setAuth(req *nethttp.Request) {
+	if c.credentials.Token == "" {
+		return
+	}
+	user := c.credentials.User
+	if user == "" {
+		// same fallback as for the git operations
+		user = "keptnuser"
+	}
+	req.SetBasicAuth(user, c.credentials.Token)
+}
+
+func (c *lfsClient) do(req *nethttp.Request, handleBody func(body io.Reader) error) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("LFS request %s %s failed with status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if handleBody != nil {
+		return handleBody(resp.Body)
+	}
+	return nil
+}
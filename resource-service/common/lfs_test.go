@@ -0,0 +1,233 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLFSServer implements the basic transfer mode of the LFS batch API and keeps the objects in memory
+type fakeLFSServer struct {
+	*httptest.Server
+	objects map[string][]byte
+	mutex   sync.Mutex
+}
+
+func newFakeLFSServer(t *testing.T) *fakeLFSServer {
+	s := &fakeLFSServer{objects: map[string][]byte{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "my-user" || token != "my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		switch {
+		case r.URL.Path == "/my-repo.git/info/lfs/objects/batch":
+			request := &lfsBatchRequest{}
+			require.Nil(t, json.NewDecoder(r.Body).Decode(request))
+			response := lfsBatchResponse{}
+			for _, object := range request.Objects {
+				_, exists := s.objects[object.Oid]
+				href := s.URL + "/my-repo.git/info/lfs/objects/" + object.Oid
+				if request.Operation == "upload" && !exists {
+					object.Actions = map[string]lfsAction{"upload": {Href: href}}
+				} else if request.Operation == "download" && exists {
+					object.Actions = map[string]lfsAction{"download": {Href: href}}
+				} else if request.Operation == "download" {
+					object.Error = &lfsObjectError{Code: 404, Message: "object not found"}
+				}
+				response.Objects = append(response.Objects, object)
+			}
+			w.Header().Set("Content-Type", lfsMediaType)
+			require.Nil(t, json.NewEncoder(w).Encode(response))
+		case strings.HasPrefix(r.URL.Path, "/my-repo.git/info/lfs/objects/") && r.Method == http.MethodPut:
+			content, err := ioutil.ReadAll(r.Body)
+			require.Nil(t, err)
+			s.objects[strings.TrimPrefix(r.URL.Path, "/my-repo.git/info/lfs/objects/")] = content
+		case strings.HasPrefix(r.URL.Path, "/my-repo.git/info/lfs/objects/") && r.Method == http.MethodGet:
+			_, _ = w.Write(s.objects[strings.TrimPrefix(r.URL.Path, "/my-repo.git/info/lfs/objects/")])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeLFSServer) getObjects() map[string][]byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.objects
+}
+
+func newLFSTestGitContext(remoteURI string) common_models.GitContext {
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "my-user",
+			Token:     "my-token",
+			RemoteURI: remoteURI,
+		},
+	}
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	pointer := LFSPointer{Oid: strings.Repeat("ab", 32), Size: 2048}
+
+	parsed, ok := ParseLFSPointer([]byte(pointer.String()))
+	require.True(t, ok)
+	require.Equal(t, pointer, *parsed)
+
+	_, ok = ParseLFSPointer([]byte("apiVersion: v1\nkind: ConfigMap"))
+	require.False(t, ok)
+	_, ok = ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:invalid\nsize 12\n"))
+	require.False(t, ok)
+	_, ok = ParseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:" + pointer.Oid + "\n"))
+	require.False(t, ok)
+}
+
+func TestLFS_CleanAndResolve(t *testing.T) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	server := newFakeLFSServer(t)
+	gitContext := newLFSTestGitContext(server.URL + "/my-repo")
+	projectPath := GetProjectConfigPath(gitContext.Project)
+
+	largeContent := strings.Repeat("large file ", 500)
+	fs := NewFileSystem(t.TempDir())
+	require.Nil(t, fs.WriteFile(projectPath+"/my-service/test data/large.bin", []byte(largeContent)))
+	require.Nil(t, fs.WriteFile(projectPath+"/my-service/small.yaml", []byte("small file")))
+	require.Nil(t, fs.WriteFile(projectPath+"/.git/config", []byte(largeContent)))
+	require.Nil(t, fs.WriteFile(projectPath+"/my-service/unchanged.bin", []byte(largeContent)))
+
+	lfs := NewLFS(2048)
+	require.Nil(t, lfs.Clean(gitContext, []string{"my-service/test data/large.bin", "my-service/small.yaml", ".git/config", "my-service/deleted.bin"}))
+
+	// the large file has been replaced by a pointer file and uploaded
+	content, err := ioutil.ReadFile(projectPath + "/my-service/test data/large.bin")
+	require.Nil(t, err)
+	pointer, ok := ParseLFSPointer(content)
+	require.True(t, ok)
+	require.Equal(t, int64(len(largeContent)), pointer.Size)
+	require.Equal(t, largeContent, string(server.getObjects()[pointer.Oid]))
+
+	content, err = ioutil.ReadFile(projectPath + "/my-service/small.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "small file", string(content))
+	content, err = ioutil.ReadFile(projectPath + "/.git/config")
+	require.Nil(t, err)
+	require.Equal(t, largeContent, string(content))
+	// only the given paths are stored in LFS
+	content, err = ioutil.ReadFile(projectPath + "/my-service/unchanged.bin")
+	require.Nil(t, err)
+	require.Equal(t, largeContent, string(content))
+
+	attributes, err := ioutil.ReadFile(projectPath + "/" + gitAttributesFileName)
+	require.Nil(t, err)
+	require.Equal(t, "/my-service/test[[:space:]]data/large.bin "+lfsAttributes+"\n", string(attributes))
+
+	// files that are tracked in LFS are stored in LFS regardless of their size
+	require.Nil(t, fs.WriteFile(projectPath+"/my-service/test data/large.bin", []byte("not so large anymore")))
+	require.Nil(t, lfs.Clean(gitContext, []string{"my-service/test data/large.bin"}))
+	content, err = ioutil.ReadFile(projectPath + "/my-service/test data/large.bin")
+	require.Nil(t, err)
+	_, ok = ParseLFSPointer(content)
+	require.True(t, ok)
+	attributes, err = ioutil.ReadFile(projectPath + "/" + gitAttributesFileName)
+	require.Nil(t, err)
+	require.Equal(t, 1, strings.Count(string(attributes), lfsAttributes))
+
+	// pointers are resolved from the local LFS storage, or downloaded from the upstream
+	require.Nil(t, os.RemoveAll(filepath.Join(projectPath, ".git", "lfs")))
+	reader, size, err := lfs.Resolve(gitContext, ioutil.NopCloser(strings.NewReader(pointer.String())), int64(len(pointer.String())))
+	require.Nil(t, err)
+	resolved, err := ioutil.ReadAll(reader)
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	require.Equal(t, largeContent, string(resolved))
+	require.Equal(t, int64(len(largeContent)), size)
+	require.FileExists(t, getLFSObjectPath(projectPath, pointer.Oid))
+
+	// other files are returned unchanged
+	reader, size, err = lfs.Resolve(gitContext, ioutil.NopCloser(strings.NewReader("small file")), 10)
+	require.Nil(t, err)
+	resolved, err = ioutil.ReadAll(reader)
+	require.Nil(t, err)
+	require.Equal(t, "small file", string(resolved))
+	require.Equal(t, int64(10), size)
+}
+
+func TestLFS_CleanNotSupported(t *testing.T) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	gitContext := newLFSTestGitContext("ssh://git@github.com/my-org/my-repo.git")
+	projectPath := GetProjectConfigPath(gitContext.Project)
+
+	largeContent := strings.Repeat("large file ", 500)
+	fs := NewFileSystem(t.TempDir())
+	require.Nil(t, fs.WriteFile(projectPath+"/large.bin", []byte(largeContent)))
+
+	// LFS is disabled
+	require.Nil(t, NewLFS(0).Clean(newLFSTestGitContext("https://github.com/my-org/my-repo"), []string{"large.bin"}))
+	// LFS is not supported for ssh upstreams
+	require.Nil(t, NewLFS(2048).Clean(gitContext, []string{"large.bin"}))
+
+	content, err := ioutil.ReadFile(projectPath + "/large.bin")
+	require.Nil(t, err)
+	require.Equal(t, largeContent, string(content))
+	require.NoFileExists(t, projectPath+"/"+gitAttributesFileName)
+}
+
+func TestLFS_UploadFails(t *testing.T) {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	server := newFakeLFSServer(t)
+	gitContext := newLFSTestGitContext(server.URL + "/my-repo")
+	gitContext.Credentials.Token = "invalid-token"
+	projectPath := GetProjectConfigPath(gitContext.Project)
+
+	largeContent := strings.Repeat("large file ", 500)
+	fs := NewFileSystem(t.TempDir())
+	require.Nil(t, fs.WriteFile(projectPath+"/large.bin", []byte(largeContent)))
+
+	err := NewLFS(2048).Clean(gitContext, []string{"large.bin"})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "status 401")
+
+	// the file is not replaced if it could not be uploaded
+	content, err := ioutil.ReadFile(projectPath + "/large.bin")
+	require.Nil(t, err)
+	require.Equal(t, largeContent, string(content))
+}
+
+func Test_getChangedPaths(t *testing.T) {
+	_, w := newHistoryTestRepo(t)
+	commitHistoryTestFiles(t, w, "Added resources", map[string]string{
+		"my-service/values.yaml":  "replicas: 1\n",
+		"my-service/removed.yaml": "replicas: 1\n",
+		"unchanged.yaml":          "replicas: 1\n",
+	})
+
+	fs := NewFileSystem(t.TempDir())
+	root := w.Filesystem.Root()
+	require.Nil(t, fs.WriteFile(root+"/my-service/values.yaml", []byte("replicas: 2\n")))
+	require.Nil(t, fs.WriteFile(root+"/my-service/large.bin", []byte(strings.Repeat("large file ", 500))))
+	require.Nil(t, os.Remove(root+"/my-service/removed.yaml"))
+
+	paths, err := getChangedPaths(w)
+	require.Nil(t, err)
+	require.Equal(t, []string{"my-service/large.bin", "my-service/values.yaml"}, paths)
+}
+
+func Test_getLFSEndpoint(t *testing.T) {
+	require.Equal(t, "https://github.com/my-org/my-repo.git/info/lfs", getLFSEndpoint("https://github.com/my-org/my-repo"))
+	require.Equal(t, "https://github.com/my-org/my-repo.git/info/lfs", getLFSEndpoint("https://github.com/my-org/my-repo.git"))
+	require.Equal(t, "https://github.com/my-org/my-repo.git/info/lfs", getLFSEndpoint("https://github.com/my-org/my-repo/"))
+}
@@ -1,9 +1,30 @@
 package common
 
+import "github.com/keptn/keptn/resource-service/models"
+
 type ProjectMetadata struct {
 	ProjectName               string `yaml:"projectName"`
 	CreationTimestamp         string `yaml:"creationTimestamp"`
 	IsUsingDirectoryStructure bool   `yaml:"isUsingDirectoryStructure"`
+	StageLayout               string `yaml:"stageLayout,omitempty"`
+}
+
+// GetStageLayout returns the stage layout of the project. Projects that have been created before the layout has been stored in the metadata
+// use the given default layout, unless they have been migrated to the directory layout
+func (m ProjectMetadata) GetStageLayout(defaultLayout string) string {
+	if m.StageLayout != "" {
+		return m.StageLayout
+	}
+	if m.IsUsingDirectoryStructure {
+		return models.StageLayoutDirectory
+	}
+	return defaultLayout
+}
+
+// SetStageLayout sets the stage layout of the project
+func (m *ProjectMetadata) SetStageLayout(layout string) {
+	m.StageLayout = layout
+	m.IsUsingDirectoryStructure = layout == models.StageLayoutDirectory
 }
 
 type StageMetadata struct {
@@ -4,7 +4,7 @@ import "sync"
 
 var mutex = &sync.Mutex{}
 
-var projectLocks = map[string]*sync.Mutex{}
+var projectLocks = map[string]*sync.RWMutex{}
 
 // Lock locks the mutex
 func Lock() {
@@ -16,21 +16,32 @@ func Unlock() {
 	mutex.Unlock()
 }
 
-// LockProject locks the given project
+// LockProject locks the given project for exclusive access, i.e. for operations that modify the local repository of the project
 func LockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
-	}
-	projectLocks[project].Lock()
+	getProjectLock(project).Lock()
 }
 
+// UnlockProject releases the exclusive lock of the given project
 func UnlockProject(project string) {
+	getProjectLock(project).Unlock()
+}
+
+// RLockProject locks the given project for shared access. Multiple readers can hold the lock at the same time,
+// but they are blocked as long as the project is locked via LockProject
+func RLockProject(project string) {
+	getProjectLock(project).RLock()
+}
+
+// RUnlockProject releases the shared lock of the given project
+func RUnlockProject(project string) {
+	getProjectLock(project).RUnlock()
+}
+
+func getProjectLock(project string) *sync.RWMutex {
+	Lock()
+	defer Unlock()
 	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
+		projectLocks[project] = &sync.RWMutex{}
 	}
-	projectLocks[project].Unlock()
+	return projectLocks[project]
 }
@@ -3,10 +3,40 @@ package common
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestLockProject(t *testing.T) {
 	LockProject("my-project")
-	require.NotNil(t, projectLocks["my-project"])
+	require.NotNil(t, getProjectLock("my-project"))
 	UnlockProject("my-project")
 }
+
+func TestRLockProject(t *testing.T) {
+	// multiple readers can hold the lock at the same time
+	RLockProject("my-read-project")
+	RLockProject("my-read-project")
+
+	locked := make(chan struct{})
+	go func() {
+		LockProject("my-read-project")
+		close(locked)
+		UnlockProject("my-read-project")
+	}()
+
+	// a writer has to wait until all readers are done
+	select {
+	case <-locked:
+		t.Fatal("project has been locked while being read")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	RUnlockProject("my-read-project")
+	RUnlockProject("my-read-project")
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("project could not be locked after readers were done")
+	}
+}
@@ -0,0 +1,182 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	nethttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+)
+
+// IPullRequestProvider creates pull requests using the API of the Git hosting service of the upstream repository
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/pull_request_provider_mock.go . IPullRequestProvider
+type IPullRequestProvider interface {
+	CreatePullRequest(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error)
+}
+
+// PullRequestProvider creates pull requests via the APIs of GitHub, GitLab, and Gitea. The provider is selected by the pull request settings of the project
+type PullRequestProvider struct{}
+
+func NewPullRequestProvider() *PullRequestProvider {
+	return &PullRequestProvider{}
+}
+
+func (p PullRequestProvider) CreatePullRequest(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+	if gitContext.Credentials == nil || gitContext.Credentials.PullRequest == nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotCreatePullRequest, gitContext.Project, kerrors.ErrPullRequestInvalidProvider)
+	}
+	host, repository, err := parseRemoteURI(gitContext.Credentials.RemoteURI)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotCreatePullRequest, gitContext.Project, err)
+	}
+	client := &pullRequestClient{
+		token:      gitContext.Credentials.Token,
+		httpClient: newUpstreamHTTPClient(gitContext.Credentials),
+	}
+	client.httpClient.Timeout = 30 * time.Second
+
+	apiURL := strings.TrimSuffix(gitContext.Credentials.PullRequest.APIURL, "/")
+	var result *common_models.PullRequestResult
+	switch gitContext.Credentials.PullRequest.Provider {
+	case common_models.PullRequestProviderGitHub:
+		if apiURL == "" {
+			apiURL = getGitHubAPIURL(host)
+		}
+		result, err = client.createGitHubPullRequest(apiURL, repository, pullRequest)
+	case common_models.PullRequestProviderGitLab:
+		if apiURL == "" {
+			apiURL = host + "/api/v4"
+		}
+		result, err = client.createGitLabMergeRequest(apiURL, repository, pullRequest)
+	case common_models.PullRequestProviderGitea:
+		if apiURL == "" {
+			apiURL = host + "/api/v1"
+		}
+		// Gitea implements the same API for pull requests as GitHub
+		result, err = client.createGitHubPullRequest(apiURL, repository, pullRequest)
+	default:
+		err = kerrors.ErrPullRequestInvalidProvider
+	}
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotCreatePullRequest, gitContext.Project, err)
+	}
+	return result, nil
+}
+
+// parseRemoteURI returns the base URL of the Git hosting service and the path of the repository, e.g. https://github.com and my-org/my-repo.
+// For ssh upstreams, the API is assumed to be reachable via https on the same host
+func parseRemoteURI(remoteURI string) (string, string, error) {
+	u, err := url.Parse(remoteURI)
+	if err != nil || u.Host == "" {
+		return "", "", kerrors.ErrCredentialsInvalidRemoteURI
+	}
+	repository := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if repository == "" {
+		return "", "", kerrors.ErrCredentialsInvalidRemoteURI
+	}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return u.Scheme + "://" + u.Host, repository, nil
+	}
+	return "https://" + u.Hostname(), repository, nil
+}
+
+func getGitHubAPIURL(host string) string {
+	if host == "https://github.com" {
+		return "https://api.github.com"
+	}
+	// GitHub Enterprise Server
+	return host + "/api/v3"
+}
+
+type gitHubPullRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+type gitHubPullRequestResponse struct {
+	Number  int64  `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+type gitLabMergeRequest struct {
+	Title              string `json:"title"`
+	Description        string `json:"description"`
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	RemoveSourceBranch bool   `json:"remove_source_branch"`
+}
+
+type gitLabMergeRequestResponse struct {
+	IID    int64  `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+type pullRequestClient struct {
+	token      string
+	httpClient *nethttp.Client
+}
+
+func (c *pullRequestClient) createGitHubPullRequest(apiURL string, repository string, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+	response := &gitHubPullRequestResponse{}
+	err := c.post(apiURL+"/repos/"+repository+"/pulls", map[string]string{"Authorization": "token " + c.token}, gitHubPullRequest{
+		Title: pullRequest.Title,
+		Body:  pullRequest.Description,
+		Head:  pullRequest.SourceBranch,
+		Base:  pullRequest.TargetBranch,
+	}, response)
+	if err != nil {
+		return nil, err
+	}
+	return &common_models.PullRequestResult{ID: response.Number, URL: response.HTMLURL}, nil
+}
+
+func (c *pullRequestClient) createGitLabMergeRequest(apiURL string, repository string, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+	response := &gitLabMergeRequestResponse{}
+	err := c.post(apiURL+"/projects/"+url.PathEscape(repository)+"/merge_requests", map[string]string{"PRIVATE-TOKEN": c.token}, gitLabMergeRequest{
+		Title:              pullRequest.Title,
+		Description:        pullRequest.Description,
+		SourceBranch:       pullRequest.SourceBranch,
+		TargetBranch:       pullRequest.TargetBranch,
+		RemoveSourceBranch: true,
+	}, response)
+	if err != nil {
+		return nil, err
+	}
+	return &common_models.PullRequestResult{ID: response.IID, URL: response.WebURL}, nil
+}
+
+func (c *pullRequestClient) post(endpoint string, headers map[string]string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := nethttp.NewRequest(nethttp.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request %s %s failed with status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
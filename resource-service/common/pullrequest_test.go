@@ -0,0 +1,197 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newPullRequestTestGitContext(provider string, apiURL string) common_models.GitContext {
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:        "my-user",
+			Token:       "my-token",
+			RemoteURI:   "https://git.example.com/my-group/my-repo.git",
+			PullRequest: &common_models.PullRequestSettings{Enabled: true, Provider: provider, APIURL: apiURL},
+		},
+	}
+}
+
+var testPullRequest = common_models.PullRequest{
+	Title:        "Updated resource in dev",
+	Description:  "description",
+	SourceBranch: "keptn/resource-update-1",
+	TargetBranch: "dev",
+}
+
+func TestPullRequestProvider_CreatePullRequest_GitHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v3/repos/my-group/my-repo/pulls", r.URL.Path)
+		require.Equal(t, "token my-token", r.Header.Get("Authorization"))
+
+		payload := &gitHubPullRequest{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(payload))
+		require.Equal(t, gitHubPullRequest{Title: testPullRequest.Title, Body: "description", Head: "keptn/resource-update-1", Base: "dev"}, *payload)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 42, "html_url": "https://git.example.com/my-group/my-repo/pull/42"}`))
+	}))
+	defer server.Close()
+
+	result, err := NewPullRequestProvider().CreatePullRequest(newPullRequestTestGitContext(common_models.PullRequestProviderGitHub, server.URL+"/api/v3"), testPullRequest)
+	require.Nil(t, err)
+	require.Equal(t, &common_models.PullRequestResult{ID: 42, URL: "https://git.example.com/my-group/my-repo/pull/42"}, result)
+}
+
+func TestPullRequestProvider_CreatePullRequest_GitLab(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v4/projects/my-group%2Fmy-repo/merge_requests", r.URL.EscapedPath())
+		require.Equal(t, "my-token", r.Header.Get("PRIVATE-TOKEN"))
+
+		payload := &gitLabMergeRequest{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(payload))
+		require.Equal(t, "keptn/resource-update-1", payload.SourceBranch)
+		require.Equal(t, "dev", payload.TargetBranch)
+		require.True(t, payload.RemoveSourceBranch)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"iid": 7, "web_url": "https://git.example.com/my-group/my-repo/-/merge_requests/7"}`))
+	}))
+	defer server.Close()
+
+	result, err := NewPullRequestProvider().CreatePullRequest(newPullRequestTestGitContext(common_models.PullRequestProviderGitLab, server.URL+"/api/v4/"), testPullRequest)
+	require.Nil(t, err)
+	require.Equal(t, &common_models.PullRequestResult{ID: 7, URL: "https://git.example.com/my-group/my-repo/-/merge_requests/7"}, result)
+}
+
+func TestPullRequestProvider_CreatePullRequest_Gitea(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repos/my-group/my-repo/pulls", r.URL.Path)
+		require.Equal(t, "token my-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 3, "html_url": "https://git.example.com/my-group/my-repo/pulls/3"}`))
+	}))
+	defer server.Close()
+
+	result, err := NewPullRequestProvider().CreatePullRequest(newPullRequestTestGitContext(common_models.PullRequestProviderGitea, server.URL+"/api/v1"), testPullRequest)
+	require.Nil(t, err)
+	require.Equal(t, &common_models.PullRequestResult{ID: 3, URL: "https://git.example.com/my-group/my-repo/pulls/3"}, result)
+}
+
+func TestPullRequestProvider_CreatePullRequest_Fails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "A pull request already exists"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewPullRequestProvider().CreatePullRequest(newPullRequestTestGitContext(common_models.PullRequestProviderGitHub, server.URL), testPullRequest)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "status 422")
+	require.Contains(t, err.Error(), "A pull request already exists")
+
+	_, err = NewPullRequestProvider().CreatePullRequest(newPullRequestTestGitContext("bitbucket", server.URL), testPullRequest)
+	require.ErrorIs(t, err, kerrors.ErrPullRequestInvalidProvider)
+}
+
+func Test_parseRemoteURI(t *testing.T) {
+	tests := []struct {
+		remoteURI      string
+		wantHost       string
+		wantRepository string
+		wantErr        bool
+	}{
+		{remoteURI: "https://github.com/my-org/my-repo", wantHost: "https://github.com", wantRepository: "my-org/my-repo"},
+		{remoteURI: "https://gitlab.example.com:8443/my-group/sub-group/my-repo.git", wantHost: "https://gitlab.example.com:8443", wantRepository: "my-group/sub-group/my-repo"},
+		{remoteURI: "http://gitea:3000/my-org/my-repo/", wantHost: "http://gitea:3000", wantRepository: "my-org/my-repo"},
+		{remoteURI: "ssh://git@github.com:22/my-org/my-repo.git", wantHost: "https://github.com", wantRepository: "my-org/my-repo"},
+		{remoteURI: "https://github.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remoteURI, func(t *testing.T) {
+			host, repository, err := parseRemoteURI(tt.remoteURI)
+			if tt.wantErr {
+				require.ErrorIs(t, err, kerrors.ErrCredentialsInvalidRemoteURI)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantHost, host)
+			require.Equal(t, tt.wantRepository, repository)
+		})
+	}
+	require.Equal(t, "https://api.github.com", getGitHubAPIURL("https://github.com"))
+	require.Equal(t, "https://github.example.com/api/v3", getGitHubAPIURL("https://github.example.com"))
+}
+
+func TestGit_StageAndCommitToBranch(t *testing.T) {
+	remotePath := t.TempDir()
+	_, err := git.PlainInit(remotePath, true)
+	require.Nil(t, err)
+
+	gitContext, w := newHistoryTestRepo(t)
+	gitContext.Credentials.RemoteURI = remotePath
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	r, err := git.PlainOpen(projectPath)
+	require.Nil(t, err)
+	require.Nil(t, r.DeleteRemote("origin"))
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	require.Nil(t, err)
+
+	initial := commitHistoryTestFiles(t, w, "Added resources", map[string]string{"my-service/values.yaml": "replicas: 1\n"})
+	require.Nil(t, r.Push(&git.PushOptions{RemoteName: "origin"}))
+
+	fs := NewFileSystem(t.TempDir())
+	require.Nil(t, fs.WriteFile(projectPath+"/my-service/values.yaml", []byte("replicas: 2\n")))
+
+	g := NewGit(GogitReal{})
+	id, err := g.StageAndCommitToBranch(gitContext, "keptn/resource-update-1", "Updated resource")
+	require.Nil(t, err)
+
+	// the change has been pushed to the new branch only
+	remote, err := git.PlainOpen(remotePath)
+	require.Nil(t, err)
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("keptn/resource-update-1"), true)
+	require.Nil(t, err)
+	require.Equal(t, id, ref.Hash().String())
+	head, err := remote.Head()
+	require.Nil(t, err)
+	require.Equal(t, initial, head.Hash().String())
+
+	// the current branch is not changed
+	branch, err := g.GetCurrentBranch(gitContext)
+	require.Nil(t, err)
+	require.Equal(t, "master", branch)
+	revision, err := g.GetCurrentRevision(gitContext)
+	require.Nil(t, err)
+	require.Equal(t, initial, revision)
+	content, err := ioutil.ReadFile(projectPath + "/my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 1\n", string(content))
+
+	// the commit of the pull request cannot be read before it has been merged, even if its branch has been fetched
+	gitContext.Credentials.PullRequest = &common_models.PullRequestSettings{Enabled: true, Provider: common_models.PullRequestProviderGitHub}
+	require.Nil(t, r.Fetch(&git.FetchOptions{RemoteName: "origin", RefSpecs: []config.RefSpec{"+refs/heads/*:refs/heads/*"}}))
+	_, err = g.GetFileRevision(gitContext, id, "my-service/values.yaml")
+	require.ErrorIs(t, err, kerrors.ErrRevisionNotMerged)
+	require.ErrorIs(t, g.RestoreFileRevision(gitContext, id, "my-service/values.yaml"), kerrors.ErrRevisionNotMerged)
+	content, err = g.GetFileRevision(gitContext, initial, "my-service/values.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 1\n", string(content))
+
+	// the branch of the pull request can be deleted, e.g. if the pull request cannot be created
+	require.Nil(t, g.DeleteRemoteBranch(gitContext, "keptn/resource-update-1"))
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("keptn/resource-update-1"), true)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
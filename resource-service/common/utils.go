@@ -3,10 +3,13 @@ package common
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 )
 
 const StageDirectoryName = ".keptn-stages"
 
+const ProjectCacheDirectoryName = ".keptn-cache"
+
 func GetProjectConfigPath(project string) string {
 	return fmt.Sprintf("%s/%s", GetConfigDir(), project)
 }
@@ -15,6 +18,11 @@ func GetTmpProjectConfigPath(project string) string {
 	return fmt.Sprintf("%s/tmp_projects_migration/%s", GetConfigDir(), project)
 }
 
+// GetProjectCachePath returns the path of the cached clone of the given project, which is used for reading resources
+func GetProjectCachePath(project string) string {
+	return fmt.Sprintf("%s/%s/%s", GetConfigDir(), ProjectCacheDirectoryName, project)
+}
+
 func GetProjectMetadataFilePath(project string) string {
 	return fmt.Sprintf("%s/%s", GetProjectConfigPath(project), "metadata.yaml")
 }
@@ -30,3 +38,25 @@ func ensureDirectoryExists(path string) error {
 	}
 	return nil
 }
+
+// copyDirectory copies the content of the source directory to the target directory
+func copyDirectory(source string, target string) error {
+	return filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(target, relativePath)
+		if info.IsDir() {
+			return ensureDirectoryExists(targetPath)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(targetPath, content, info.Mode())
+	})
+}
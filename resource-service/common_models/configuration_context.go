@@ -9,3 +9,11 @@ type ConfigurationContextParams struct {
 	GitContext              GitContext
 	CheckConfigDirAvailable bool
 }
+
+// ConfigurationLocation describes where the configuration of a project, stage, or service is stored within the repository of the project
+type ConfigurationLocation struct {
+	// Revision is the commit in which the configuration has been located
+	Revision string
+	// Path is the directory containing the configuration, relative to the root of the repository
+	Path string
+}
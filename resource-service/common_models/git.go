@@ -3,6 +3,7 @@ package common_models
 import (
 	"net/url"
 	"strings"
+	"time"
 
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 )
@@ -23,13 +24,51 @@ type GitCredentials struct {
 	// parameter to "undefined" when marshalling/unmarshalling data
 	// when "false" value is present
 	InsecureSkipTLS bool `json:"insecureSkipTLS"`
+	// PullRequest enables the pull request based write mode for the project
+	PullRequest *PullRequestSettings `json:"pullRequest,omitempty"`
 }
 
+// PullRequestSettings configures the pull request based write mode of a project. If it is enabled, changes of resources are
+// pushed to a new branch and proposed via a pull request, instead of being pushed to the branch of the stage directly
+type PullRequestSettings struct {
+	Enabled bool `json:"enabled"`
+	// Provider is the type of the Git hosting service, one of github, gitlab, or gitea
+	Provider string `json:"provider"`
+	// APIURL is the base URL of the API of the Git hosting service. If it is not set, it is derived from the remote URI
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// PullRequest contains the information for proposing the changes of a branch via a pull request
+type PullRequest struct {
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+}
+
+// PullRequestResult identifies a pull request that has been created
+type PullRequestResult struct {
+	ID  int64
+	URL string
+}
+
+const PullRequestProviderGitHub = "github"
+const PullRequestProviderGitLab = "gitlab"
+const PullRequestProviderGitea = "gitea"
+
 type GitContext struct {
 	Project     string
 	Credentials *GitCredentials
 }
 
+// GitCommit contains the metadata of a commit
+type GitCommit struct {
+	ID        string
+	Author    string
+	Message   string
+	Timestamp time.Time
+}
+
 func (g GitCredentials) Validate() error {
 	if strings.HasPrefix(g.RemoteURI, "https://") || strings.HasPrefix(g.RemoteURI, "http://") {
 		if err := g.validateRemoteURIAndToken(); err != nil {
@@ -45,6 +84,34 @@ func (g GitCredentials) Validate() error {
 	} else {
 		return kerrors.ErrCredentialsInvalidRemoteURI
 	}
+	if g.PullRequestsEnabled() {
+		if err := g.validatePullRequestSettings(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PullRequestsEnabled returns true if changes of resources shall be proposed via pull requests
+func (g GitCredentials) PullRequestsEnabled() bool {
+	return g.PullRequest != nil && g.PullRequest.Enabled
+}
+
+func (g GitCredentials) validatePullRequestSettings() error {
+	switch g.PullRequest.Provider {
+	case PullRequestProviderGitHub, PullRequestProviderGitLab, PullRequestProviderGitea:
+	default:
+		return kerrors.ErrPullRequestInvalidProvider
+	}
+	// the token is needed for the API of the provider, also for ssh upstreams
+	if g.Token == "" {
+		return kerrors.ErrCredentialsTokenMustNotBeEmpty
+	}
+	if g.PullRequest.APIURL != "" {
+		if _, err := url.Parse(g.PullRequest.APIURL); err != nil {
+			return kerrors.ErrPullRequestInvalidAPIURL
+		}
+	}
 	return nil
 }
 
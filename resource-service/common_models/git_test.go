@@ -10,6 +10,7 @@ func TestGitCredentials_Validate(t *testing.T) {
 		RemoteURI      string
 		GitProxyURL    string
 		GitProxyScheme string
+		PullRequest    *PullRequestSettings
 	}
 	tests := []struct {
 		name    string
@@ -123,6 +124,46 @@ func TestGitCredentials_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "pull requests enabled",
+			fields: fields{
+				User:        "my-user",
+				Token:       "token",
+				RemoteURI:   "https://my-repo",
+				PullRequest: &PullRequestSettings{Enabled: true, Provider: PullRequestProviderGitLab},
+			},
+			wantErr: false,
+		},
+		{
+			name: "pull requests with invalid provider",
+			fields: fields{
+				User:        "my-user",
+				Token:       "token",
+				RemoteURI:   "https://my-repo",
+				PullRequest: &PullRequestSettings{Enabled: true, Provider: "bitbucket"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pull requests without token",
+			fields: fields{
+				User:        "my-user",
+				PrivateKey:  "privatekey",
+				RemoteURI:   "ssh://my-repo",
+				PullRequest: &PullRequestSettings{Enabled: true, Provider: PullRequestProviderGitHub},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pull requests disabled",
+			fields: fields{
+				User:        "my-user",
+				Token:       "token",
+				RemoteURI:   "https://my-repo",
+				PullRequest: &PullRequestSettings{Enabled: false, Provider: "bitbucket"},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -133,6 +174,7 @@ func TestGitCredentials_Validate(t *testing.T) {
 				RemoteURI:      tt.fields.RemoteURI,
 				GitProxyURL:    tt.fields.GitProxyURL,
 				GitProxyScheme: tt.fields.GitProxyScheme,
+				PullRequest:    tt.fields.PullRequest,
 			}
 			if err := g.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
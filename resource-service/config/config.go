@@ -1,8 +1,14 @@
 package config
 
+import "time"
+
 var Global EnvConfig
 
 type EnvConfig struct {
-	LogLevel                string `envconfig:"LOG_LEVEL" default:"info"`
-	DirectoryStageStructure bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	LogLevel                string        `envconfig:"LOG_LEVEL" default:"info"`
+	DirectoryStageStructure bool          `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
+	LFSEnabled              bool          `envconfig:"LFS_ENABLED" default:"false"`
+	LFSThreshold            int64         `envconfig:"LFS_THRESHOLD" default:"1048576"`
+	ProjectCacheEnabled     bool          `envconfig:"PROJECT_CACHE_ENABLED" default:"true"`
+	ProjectCacheMaxAge      time.Duration `envconfig:"PROJECT_CACHE_MAX_AGE" default:"30s"`
 }
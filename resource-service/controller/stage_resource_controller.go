@@ -20,4 +20,9 @@ func (controller StageResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.GetStageResource)
 	apiGroup.PUT("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.UpdateStageResource)
 	apiGroup.DELETE("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.DeleteStageResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/content", controller.StageResourceHandler.GetStageResourceContent)
+	apiGroup.PUT("/project/:projectName/stage/:stageName/resource/:resourceURI/content", controller.StageResourceHandler.UpdateStageResourceContent)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/history", controller.StageResourceHandler.GetStageResourceHistory)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/diff", controller.StageResourceHandler.GetStageResourceDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/resource/:resourceURI/revert", controller.StageResourceHandler.RevertStageResource)
 }
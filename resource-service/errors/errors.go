@@ -40,6 +40,7 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrResourceRevisionMustNotBeEmpty = New("revision must not be empty")
 
 // Git specific errors
 
@@ -48,6 +49,7 @@ var ErrRepositoryNotFound = New("repository not found")
 var ErrInvalidGitContext = New("invalid git context")
 var ErrResolvedNilHash = New("resolved nil hash")
 var ErrResolveRevision = New("revision does not exist")
+var ErrRevisionNotMerged = New("revision has not been merged into a branch of the project")
 var ErrBranchExists = New("branch already exists")
 var ErrBranchNotFound = New("branch not found")
 var ErrTagExists = New("tag already exists")
@@ -93,6 +95,8 @@ var ErrCredentialsTokenMustNotBeEmpty = New("token must not be empty")
 var ErrCredentialsPrivateKeyMustNotBeEmpty = New("private key must not be empty")
 var ErrProxyInvalidScheme = New("proxy scheme must be http or https")
 var ErrProxyInvalidURL = New("proxy URL must contain IP address and port (<ip-address>:<port>)")
+var ErrPullRequestInvalidProvider = New("pull request provider must be github, gitlab, or gitea")
+var ErrPullRequestInvalidAPIURL = New("invalid pull request API URL")
 
 // Error messages
 
@@ -106,3 +110,4 @@ const ErrMsgCouldNotGetRevision = "could not get current revision for project %s
 const ErrMsgCouldNotGetDefBranch = "could not get default branch for project %s: %w"
 const ErrMsgCouldNotCheckout = "could not checkout branch %s: %w"
 const ErrMsgCouldNotCreate = "could not create branch %s for project %s: %w"
+const ErrMsgCouldNotCreatePullRequest = "could not create pull request for project %s: %w"
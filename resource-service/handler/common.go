@@ -14,6 +14,28 @@ const pathParamStageName = "stageName"
 const pathParamServiceName = "serviceName"
 const pathParamResourceURI = "resourceURI"
 
+// headerResourceVersion contains the commit ID of the resource when its content is streamed
+const headerResourceVersion = "X-Keptn-Resource-Version"
+
+// headerRequestOrigin names the sender of a request. The API gateway sets it to api-gateway for all requests it forwards to the resource-service,
+// hence it cannot be set by users
+const headerRequestOrigin = "X-Keptn-Request-Origin"
+
+// pullRequestExemptOrigins are the only senders whose changes are pushed directly, even if the changes of the project are proposed via pull requests.
+// Their changes need to become effective immediately, and are derived from reviewed resources or are required to use the project at all
+var pullRequestExemptOrigins = map[string]bool{
+	// the helm-service writes the generated Helm charts and the updated charts during deployments, which continue with the commit of the written charts
+	"helm-service": true,
+	// the shipyard-controller marks only the upload of the initial shipyard of a new project, since a project cannot be used without a shipyard.
+	// Updates of the shipyard, e.g. via the project or freeze window API, are proposed via pull requests
+	"shipyard-controller": true,
+}
+
+// isExemptFromPullRequests checks whether a request has been sent by one of the pullRequestExemptOrigins
+func isExemptFromPullRequests(c *gin.Context) bool {
+	return pullRequestExemptOrigins[c.GetHeader(headerRequestOrigin)]
+}
+
 func OnAPIError(c *gin.Context, err error) {
 	logger.Infof("Could not complete request %s %s: %v", c.Request.Method, c.Request.RequestURI, err)
 
@@ -25,6 +47,8 @@ func OnAPIError(c *gin.Context, err error) {
 		SetNotFoundErrorResponse(c, "Could not find credentials for upstream repository")
 	} else if errors.Is(err, errors2.ErrMalformedCredentials) {
 		SetFailedDependencyErrorResponse(c, "Could not decode credentials for upstream repository")
+	} else if errors.Is(err, errors2.ErrPullRequestInvalidProvider) || errors.Is(err, errors2.ErrPullRequestInvalidAPIURL) {
+		SetFailedDependencyErrorResponse(c, "Invalid pull request settings for upstream repository")
 	} else if errors.Is(err, errors2.ErrCredentialsInvalidRemoteURI) || errors.Is(err, errors2.ErrCredentialsTokenMustNotBeEmpty) {
 		SetBadRequestErrorResponse(c, "Upstream repository not found")
 	} else if errors.Is(err, errors2.ErrRepositoryNotFound) {
@@ -57,10 +81,20 @@ func resourceNotFound(err error) (bool, string) {
 		return true, "Service"
 	} else if errors.Is(err, errors2.ErrResourceNotFound) {
 		return true, "Resource"
+	} else if errors.Is(err, errors2.ErrResolveRevision) || errors.Is(err, errors2.ErrRevisionNotMerged) {
+		return true, "Revision"
 	}
 	return false, ""
 }
 
+// SetResourceContentResponse streams the content of a resource to the client and closes it afterwards
+func SetResourceContentResponse(c *gin.Context, result *models.GetResourceContentResponse) {
+	defer result.Content.Close()
+	c.DataFromReader(http.StatusOK, result.Size, "application/octet-stream", result.Content, map[string]string{
+		headerResourceVersion: result.Metadata.Version,
+	})
+}
+
 func SetFailedDependencyErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusFailedDependency, models.Error{
 		Code:    http.StatusFailedDependency,
@@ -4,29 +4,36 @@
 package handler_mock
 
 import (
+	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
 	"sync"
 )
 
 // IConfigurationContextMock is a mock implementation of handler.IConfigurationContext.
 //
-// 	func TestSomethingThatUsesIConfigurationContext(t *testing.T) {
+//	func TestSomethingThatUsesIConfigurationContext(t *testing.T) {
 //
-// 		// make and configure a mocked handler.IConfigurationContext
-// 		mockedIConfigurationContext := &IConfigurationContextMock{
-// 			EstablishFunc: func(params handler.ConfigurationContextParams) (string, error) {
-// 				panic("mock out the Establish method")
-// 			},
-// 		}
+//		// make and configure a mocked handler.IConfigurationContext
+//		mockedIConfigurationContext := &IConfigurationContextMock{
+//			EstablishFunc: func(params common_models.ConfigurationContextParams) (string, error) {
+//				panic("mock out the Establish method")
+//			},
+//			LocateFunc: func(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+//				panic("mock out the Locate method")
+//			},
+//		}
 //
-// 		// use mockedIConfigurationContext in code that requires handler.IConfigurationContext
-// 		// and then make assertions.
+//		// use mockedIConfigurationContext in code that requires handler.IConfigurationContext
+//		// and then make assertions.
 //
-// 	}
+//	}
 type IConfigurationContextMock struct {
 	// EstablishFunc mocks the Establish method.
 	EstablishFunc func(params common_models.ConfigurationContextParams) (string, error)
 
+	// LocateFunc mocks the Locate method.
+	LocateFunc func(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Establish holds details about calls to the Establish method.
@@ -34,8 +41,16 @@ type IConfigurationContextMock struct {
 			// Params is the params argument value.
 			Params common_models.ConfigurationContextParams
 		}
+		// Locate holds details about calls to the Locate method.
+		Locate []struct {
+			// Params is the params argument value.
+			Params common_models.ConfigurationContextParams
+			// Cache is the cache argument value.
+			Cache common.IProjectCache
+		}
 	}
 	lockEstablish sync.RWMutex
+	lockLocate    sync.RWMutex
 }
 
 // Establish calls EstablishFunc.
@@ -56,7 +71,8 @@ func (mock *IConfigurationContextMock) Establish(params common_models.Configurat
 
 // EstablishCalls gets all the calls that were made to Establish.
 // Check the length with:
-//     len(mockedIConfigurationContext.EstablishCalls())
+//
+//	len(mockedIConfigurationContext.EstablishCalls())
 func (mock *IConfigurationContextMock) EstablishCalls() []struct {
 	Params common_models.ConfigurationContextParams
 } {
@@ -68,3 +84,39 @@ func (mock *IConfigurationContextMock) EstablishCalls() []struct {
 	mock.lockEstablish.RUnlock()
 	return calls
 }
+
+// Locate calls LocateFunc.
+func (mock *IConfigurationContextMock) Locate(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+	if mock.LocateFunc == nil {
+		panic("IConfigurationContextMock.LocateFunc: method is nil but IConfigurationContext.Locate was just called")
+	}
+	callInfo := struct {
+		Params common_models.ConfigurationContextParams
+		Cache  common.IProjectCache
+	}{
+		Params: params,
+		Cache:  cache,
+	}
+	mock.lockLocate.Lock()
+	mock.calls.Locate = append(mock.calls.Locate, callInfo)
+	mock.lockLocate.Unlock()
+	return mock.LocateFunc(params, cache)
+}
+
+// LocateCalls gets all the calls that were made to Locate.
+// Check the length with:
+//
+//	len(mockedIConfigurationContext.LocateCalls())
+func (mock *IConfigurationContextMock) LocateCalls() []struct {
+	Params common_models.ConfigurationContextParams
+	Cache  common.IProjectCache
+} {
+	var calls []struct {
+		Params common_models.ConfigurationContextParams
+		Cache  common.IProjectCache
+	}
+	mock.lockLocate.RLock()
+	calls = mock.calls.Locate
+	mock.lockLocate.RUnlock()
+	return calls
+}
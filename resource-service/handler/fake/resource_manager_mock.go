@@ -10,34 +10,49 @@ import (
 
 // IResourceManagerMock is a mock implementation of handler.IResourceManager.
 //
-// 	func TestSomethingThatUsesIResourceManager(t *testing.T) {
+//	func TestSomethingThatUsesIResourceManager(t *testing.T) {
 //
-// 		// make and configure a mocked handler.IResourceManager
-// 		mockedIResourceManager := &IResourceManagerMock{
-// 			CreateResourcesFunc: func(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
-// 				panic("mock out the CreateResources method")
-// 			},
-// 			DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
-// 				panic("mock out the DeleteResource method")
-// 			},
-// 			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
-// 				panic("mock out the GetResource method")
-// 			},
-// 			GetResourcesFunc: func(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
-// 				panic("mock out the GetResources method")
-// 			},
-// 			UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
-// 				panic("mock out the UpdateResource method")
-// 			},
-// 			UpdateResourcesFunc: func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
-// 				panic("mock out the UpdateResources method")
-// 			},
-// 		}
+//		// make and configure a mocked handler.IResourceManager
+//		mockedIResourceManager := &IResourceManagerMock{
+//			CreateResourcesFunc: func(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the CreateResources method")
+//			},
+//			DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the DeleteResource method")
+//			},
+//			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+//				panic("mock out the GetResource method")
+//			},
+//			GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+//				panic("mock out the GetResourceContent method")
+//			},
+//			GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+//				panic("mock out the GetResourceDiff method")
+//			},
+//			GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+//				panic("mock out the GetResourceHistory method")
+//			},
+//			GetResourcesFunc: func(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
+//				panic("mock out the GetResources method")
+//			},
+//			RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the RevertResource method")
+//			},
+//			UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the UpdateResource method")
+//			},
+//			UpdateResourceContentFunc: func(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the UpdateResourceContent method")
+//			},
+//			UpdateResourcesFunc: func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+//				panic("mock out the UpdateResources method")
+//			},
+//		}
 //
-// 		// use mockedIResourceManager in code that requires handler.IResourceManager
-// 		// and then make assertions.
+//		// use mockedIResourceManager in code that requires handler.IResourceManager
+//		// and then make assertions.
 //
-// 	}
+//	}
 type IResourceManagerMock struct {
 	// CreateResourcesFunc mocks the CreateResources method.
 	CreateResourcesFunc func(params models.CreateResourcesParams) (*models.WriteResourceResponse, error)
@@ -48,12 +63,27 @@ type IResourceManagerMock struct {
 	// GetResourceFunc mocks the GetResource method.
 	GetResourceFunc func(params models.GetResourceParams) (*models.GetResourceResponse, error)
 
+	// GetResourceContentFunc mocks the GetResourceContent method.
+	GetResourceContentFunc func(params models.GetResourceParams) (*models.GetResourceContentResponse, error)
+
+	// GetResourceDiffFunc mocks the GetResourceDiff method.
+	GetResourceDiffFunc func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+
+	// GetResourceHistoryFunc mocks the GetResourceHistory method.
+	GetResourceHistoryFunc func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+
 	// GetResourcesFunc mocks the GetResources method.
 	GetResourcesFunc func(params models.GetResourcesParams) (*models.GetResourcesResponse, error)
 
+	// RevertResourceFunc mocks the RevertResource method.
+	RevertResourceFunc func(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+
 	// UpdateResourceFunc mocks the UpdateResource method.
 	UpdateResourceFunc func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 
+	// UpdateResourceContentFunc mocks the UpdateResourceContent method.
+	UpdateResourceContentFunc func(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error)
+
 	// UpdateResourcesFunc mocks the UpdateResources method.
 	UpdateResourcesFunc func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error)
 
@@ -74,28 +104,58 @@ type IResourceManagerMock struct {
 			// Params is the params argument value.
 			Params models.GetResourceParams
 		}
+		// GetResourceContent holds details about calls to the GetResourceContent method.
+		GetResourceContent []struct {
+			// Params is the params argument value.
+			Params models.GetResourceParams
+		}
+		// GetResourceDiff holds details about calls to the GetResourceDiff method.
+		GetResourceDiff []struct {
+			// Params is the params argument value.
+			Params models.GetResourceDiffParams
+		}
+		// GetResourceHistory holds details about calls to the GetResourceHistory method.
+		GetResourceHistory []struct {
+			// Params is the params argument value.
+			Params models.GetResourceHistoryParams
+		}
 		// GetResources holds details about calls to the GetResources method.
 		GetResources []struct {
 			// Params is the params argument value.
 			Params models.GetResourcesParams
 		}
+		// RevertResource holds details about calls to the RevertResource method.
+		RevertResource []struct {
+			// Params is the params argument value.
+			Params models.RevertResourceParams
+		}
 		// UpdateResource holds details about calls to the UpdateResource method.
 		UpdateResource []struct {
 			// Params is the params argument value.
 			Params models.UpdateResourceParams
 		}
+		// UpdateResourceContent holds details about calls to the UpdateResourceContent method.
+		UpdateResourceContent []struct {
+			// Params is the params argument value.
+			Params models.UpdateResourceContentParams
+		}
 		// UpdateResources holds details about calls to the UpdateResources method.
 		UpdateResources []struct {
 			// Params is the params argument value.
 			Params models.UpdateResourcesParams
 		}
 	}
-	lockCreateResources sync.RWMutex
-	lockDeleteResource  sync.RWMutex
-	lockGetResource     sync.RWMutex
-	lockGetResources    sync.RWMutex
-	lockUpdateResource  sync.RWMutex
-	lockUpdateResources sync.RWMutex
+	lockCreateResources       sync.RWMutex
+	lockDeleteResource        sync.RWMutex
+	lockGetResource           sync.RWMutex
+	lockGetResourceContent    sync.RWMutex
+	lockGetResourceDiff       sync.RWMutex
+	lockGetResourceHistory    sync.RWMutex
+	lockGetResources          sync.RWMutex
+	lockRevertResource        sync.RWMutex
+	lockUpdateResource        sync.RWMutex
+	lockUpdateResourceContent sync.RWMutex
+	lockUpdateResources       sync.RWMutex
 }
 
 // CreateResources calls CreateResourcesFunc.
@@ -116,7 +176,8 @@ func (mock *IResourceManagerMock) CreateResources(params models.CreateResourcesP
 
 // CreateResourcesCalls gets all the calls that were made to CreateResources.
 // Check the length with:
-//     len(mockedIResourceManager.CreateResourcesCalls())
+//
+//	len(mockedIResourceManager.CreateResourcesCalls())
 func (mock *IResourceManagerMock) CreateResourcesCalls() []struct {
 	Params models.CreateResourcesParams
 } {
@@ -147,7 +208,8 @@ func (mock *IResourceManagerMock) DeleteResource(params models.DeleteResourcePar
 
 // DeleteResourceCalls gets all the calls that were made to DeleteResource.
 // Check the length with:
-//     len(mockedIResourceManager.DeleteResourceCalls())
+//
+//	len(mockedIResourceManager.DeleteResourceCalls())
 func (mock *IResourceManagerMock) DeleteResourceCalls() []struct {
 	Params models.DeleteResourceParams
 } {
@@ -178,7 +240,8 @@ func (mock *IResourceManagerMock) GetResource(params models.GetResourceParams) (
 
 // GetResourceCalls gets all the calls that were made to GetResource.
 // Check the length with:
-//     len(mockedIResourceManager.GetResourceCalls())
+//
+//	len(mockedIResourceManager.GetResourceCalls())
 func (mock *IResourceManagerMock) GetResourceCalls() []struct {
 	Params models.GetResourceParams
 } {
@@ -191,6 +254,102 @@ func (mock *IResourceManagerMock) GetResourceCalls() []struct {
 	return calls
 }
 
+// GetResourceContent calls GetResourceContentFunc.
+func (mock *IResourceManagerMock) GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+	if mock.GetResourceContentFunc == nil {
+		panic("IResourceManagerMock.GetResourceContentFunc: method is nil but IResourceManager.GetResourceContent was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceContent.Lock()
+	mock.calls.GetResourceContent = append(mock.calls.GetResourceContent, callInfo)
+	mock.lockGetResourceContent.Unlock()
+	return mock.GetResourceContentFunc(params)
+}
+
+// GetResourceContentCalls gets all the calls that were made to GetResourceContent.
+// Check the length with:
+//
+//	len(mockedIResourceManager.GetResourceContentCalls())
+func (mock *IResourceManagerMock) GetResourceContentCalls() []struct {
+	Params models.GetResourceParams
+} {
+	var calls []struct {
+		Params models.GetResourceParams
+	}
+	mock.lockGetResourceContent.RLock()
+	calls = mock.calls.GetResourceContent
+	mock.lockGetResourceContent.RUnlock()
+	return calls
+}
+
+// GetResourceDiff calls GetResourceDiffFunc.
+func (mock *IResourceManagerMock) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if mock.GetResourceDiffFunc == nil {
+		panic("IResourceManagerMock.GetResourceDiffFunc: method is nil but IResourceManager.GetResourceDiff was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceDiffParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceDiff.Lock()
+	mock.calls.GetResourceDiff = append(mock.calls.GetResourceDiff, callInfo)
+	mock.lockGetResourceDiff.Unlock()
+	return mock.GetResourceDiffFunc(params)
+}
+
+// GetResourceDiffCalls gets all the calls that were made to GetResourceDiff.
+// Check the length with:
+//
+//	len(mockedIResourceManager.GetResourceDiffCalls())
+func (mock *IResourceManagerMock) GetResourceDiffCalls() []struct {
+	Params models.GetResourceDiffParams
+} {
+	var calls []struct {
+		Params models.GetResourceDiffParams
+	}
+	mock.lockGetResourceDiff.RLock()
+	calls = mock.calls.GetResourceDiff
+	mock.lockGetResourceDiff.RUnlock()
+	return calls
+}
+
+// GetResourceHistory calls GetResourceHistoryFunc.
+func (mock *IResourceManagerMock) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if mock.GetResourceHistoryFunc == nil {
+		panic("IResourceManagerMock.GetResourceHistoryFunc: method is nil but IResourceManager.GetResourceHistory was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceHistoryParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceHistory.Lock()
+	mock.calls.GetResourceHistory = append(mock.calls.GetResourceHistory, callInfo)
+	mock.lockGetResourceHistory.Unlock()
+	return mock.GetResourceHistoryFunc(params)
+}
+
+// GetResourceHistoryCalls gets all the calls that were made to GetResourceHistory.
+// Check the length with:
+//
+//	len(mockedIResourceManager.GetResourceHistoryCalls())
+func (mock *IResourceManagerMock) GetResourceHistoryCalls() []struct {
+	Params models.GetResourceHistoryParams
+} {
+	var calls []struct {
+		Params models.GetResourceHistoryParams
+	}
+	mock.lockGetResourceHistory.RLock()
+	calls = mock.calls.GetResourceHistory
+	mock.lockGetResourceHistory.RUnlock()
+	return calls
+}
+
 // GetResources calls GetResourcesFunc.
 func (mock *IResourceManagerMock) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
 	if mock.GetResourcesFunc == nil {
@@ -209,7 +368,8 @@ func (mock *IResourceManagerMock) GetResources(params models.GetResourcesParams)
 
 // GetResourcesCalls gets all the calls that were made to GetResources.
 // Check the length with:
-//     len(mockedIResourceManager.GetResourcesCalls())
+//
+//	len(mockedIResourceManager.GetResourcesCalls())
 func (mock *IResourceManagerMock) GetResourcesCalls() []struct {
 	Params models.GetResourcesParams
 } {
@@ -222,6 +382,38 @@ func (mock *IResourceManagerMock) GetResourcesCalls() []struct {
 	return calls
 }
 
+// RevertResource calls RevertResourceFunc.
+func (mock *IResourceManagerMock) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.RevertResourceFunc == nil {
+		panic("IResourceManagerMock.RevertResourceFunc: method is nil but IResourceManager.RevertResource was just called")
+	}
+	callInfo := struct {
+		Params models.RevertResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockRevertResource.Lock()
+	mock.calls.RevertResource = append(mock.calls.RevertResource, callInfo)
+	mock.lockRevertResource.Unlock()
+	return mock.RevertResourceFunc(params)
+}
+
+// RevertResourceCalls gets all the calls that were made to RevertResource.
+// Check the length with:
+//
+//	len(mockedIResourceManager.RevertResourceCalls())
+func (mock *IResourceManagerMock) RevertResourceCalls() []struct {
+	Params models.RevertResourceParams
+} {
+	var calls []struct {
+		Params models.RevertResourceParams
+	}
+	mock.lockRevertResource.RLock()
+	calls = mock.calls.RevertResource
+	mock.lockRevertResource.RUnlock()
+	return calls
+}
+
 // UpdateResource calls UpdateResourceFunc.
 func (mock *IResourceManagerMock) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
 	if mock.UpdateResourceFunc == nil {
@@ -240,7 +432,8 @@ func (mock *IResourceManagerMock) UpdateResource(params models.UpdateResourcePar
 
 // UpdateResourceCalls gets all the calls that were made to UpdateResource.
 // Check the length with:
-//     len(mockedIResourceManager.UpdateResourceCalls())
+//
+//	len(mockedIResourceManager.UpdateResourceCalls())
 func (mock *IResourceManagerMock) UpdateResourceCalls() []struct {
 	Params models.UpdateResourceParams
 } {
@@ -253,6 +446,38 @@ func (mock *IResourceManagerMock) UpdateResourceCalls() []struct {
 	return calls
 }
 
+// UpdateResourceContent calls UpdateResourceContentFunc.
+func (mock *IResourceManagerMock) UpdateResourceContent(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error) {
+	if mock.UpdateResourceContentFunc == nil {
+		panic("IResourceManagerMock.UpdateResourceContentFunc: method is nil but IResourceManager.UpdateResourceContent was just called")
+	}
+	callInfo := struct {
+		Params models.UpdateResourceContentParams
+	}{
+		Params: params,
+	}
+	mock.lockUpdateResourceContent.Lock()
+	mock.calls.UpdateResourceContent = append(mock.calls.UpdateResourceContent, callInfo)
+	mock.lockUpdateResourceContent.Unlock()
+	return mock.UpdateResourceContentFunc(params)
+}
+
+// UpdateResourceContentCalls gets all the calls that were made to UpdateResourceContent.
+// Check the length with:
+//
+//	len(mockedIResourceManager.UpdateResourceContentCalls())
+func (mock *IResourceManagerMock) UpdateResourceContentCalls() []struct {
+	Params models.UpdateResourceContentParams
+} {
+	var calls []struct {
+		Params models.UpdateResourceContentParams
+	}
+	mock.lockUpdateResourceContent.RLock()
+	calls = mock.calls.UpdateResourceContent
+	mock.lockUpdateResourceContent.RUnlock()
+	return calls
+}
+
 // UpdateResources calls UpdateResourcesFunc.
 func (mock *IResourceManagerMock) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
 	if mock.UpdateResourcesFunc == nil {
@@ -271,7 +496,8 @@ func (mock *IResourceManagerMock) UpdateResources(params models.UpdateResourcesP
 
 // UpdateResourcesCalls gets all the calls that were made to UpdateResources.
 // Check the length with:
-//     len(mockedIResourceManager.UpdateResourcesCalls())
+//
+//	len(mockedIResourceManager.UpdateResourcesCalls())
 func (mock *IResourceManagerMock) UpdateResourcesCalls() []struct {
 	Params models.UpdateResourcesParams
 } {
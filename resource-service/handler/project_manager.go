@@ -22,16 +22,21 @@ type IProjectManager interface {
 }
 
 type ProjectManager struct {
-	git              common.IGit
-	credentialReader common.CredentialReader
-	fileSystem       common.IFileSystem
+	git                common.IGit
+	credentialReader   common.CredentialReader
+	fileSystem         common.IFileSystem
+	defaultStageLayout string
+	projectCache       common.IProjectCache
 }
 
-func NewProjectManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem) *ProjectManager {
+// NewProjectManager creates a new ProjectManager. The projectCache is optional, if it is set, the cached clone of a project is removed when the project is deleted
+func NewProjectManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, defaultStageLayout string, projectCache common.IProjectCache) *ProjectManager {
 	projectManager := &ProjectManager{
-		git:              git,
-		credentialReader: credentialReader,
-		fileSystem:       fileWriter,
+		git:                git,
+		credentialReader:   credentialReader,
+		fileSystem:         fileWriter,
+		defaultStageLayout: defaultStageLayout,
+		projectCache:       projectCache,
 	}
 	return projectManager
 }
@@ -81,9 +86,13 @@ func (p ProjectManager) CreateProject(project models.CreateProjectParams) error
 	}
 
 	newProjectMetadata := &common.ProjectMetadata{
-		ProjectName:               project.ProjectName,
-		CreationTimestamp:         time.Now().UTC().String(),
-		IsUsingDirectoryStructure: false,
+		ProjectName:       project.ProjectName,
+		CreationTimestamp: time.Now().UTC().String(),
+	}
+	if project.StageLayout != "" {
+		newProjectMetadata.SetStageLayout(project.StageLayout)
+	} else {
+		newProjectMetadata.SetStageLayout(p.defaultStageLayout)
 	}
 
 	metadataString, err := yaml.Marshal(newProjectMetadata)
@@ -145,8 +154,13 @@ func (p ProjectManager) UpdateProject(project models.UpdateProjectParams) error
 		return fmt.Errorf("could not check out branch %s of project %s: %w", defaultBranch, project.ProjectName, err)
 	}
 
-	if project.Migrate {
-		if err := p.migrateProject(project, gitContext); err != nil {
+	stageLayout := project.StageLayout
+	if stageLayout == "" && project.Migrate {
+		stageLayout = models.StageLayoutDirectory
+	}
+
+	if stageLayout != "" {
+		if err := p.migrateProject(project, gitContext, stageLayout); err != nil {
 			return err
 		}
 	}
@@ -154,16 +168,16 @@ func (p ProjectManager) UpdateProject(project models.UpdateProjectParams) error
 	return nil
 }
 
-// MigrateProject migrates the branch-based structure for representing stages to the new directory-based format,
-// where each stage is represented as a directory within the main branch
-func (p ProjectManager) migrateProject(project models.UpdateProjectParams, gitContext common_models.GitContext) error {
+// migrateProject migrates the project to the given stage layout, i.e. either from the branch-based structure for representing stages
+// to the directory-based format, where each stage is represented as a directory within the main branch, or back
+func (p ProjectManager) migrateProject(project models.UpdateProjectParams, gitContext common_models.GitContext, stageLayout string) error {
 	metadata, err := p.getProjectMetadate(project.ProjectName)
 	if err != nil {
 		return err
 	}
 
-	// if the project already has the new structure, there is no need to migrate it anymore
-	if metadata.IsUsingDirectoryStructure {
+	// if the project already has the requested structure, there is no need to migrate it anymore
+	if metadata.GetStageLayout(p.defaultStageLayout) == stageLayout {
 		return nil
 	}
 
@@ -171,10 +185,10 @@ func (p ProjectManager) migrateProject(project models.UpdateProjectParams, gitCo
 		if err := p.git.Pull(gitContext); err != nil {
 			return err
 		}
-		metadata.IsUsingDirectoryStructure = true
+		metadata.SetStageLayout(stageLayout)
 		marshal, _ := yaml.Marshal(metadata)
 
-		if err := p.git.MigrateProject(gitContext, marshal); err != nil {
+		if err := p.git.MigrateProject(gitContext, stageLayout, marshal); err != nil {
 			return err
 		}
 
@@ -195,6 +209,12 @@ func (p ProjectManager) DeleteProject(projectName string) error {
 		return fmt.Errorf("could not delete project %s: %w", projectName, err)
 	}
 
+	if p.projectCache != nil {
+		if err := p.projectCache.Remove(projectName); err != nil {
+			return fmt.Errorf("could not delete project %s: %w", projectName, err)
+		}
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@ type projectManagerTestFields struct {
 	git              *common_mock.IGitMock
 	credentialReader *common_mock.CredentialReaderMock
 	fileWriter       *common_mock.IFileSystemMock
+	projectCache     *common_mock.IProjectCacheMock
 }
 
 func TestProjectManager_CreateProject(t *testing.T) {
@@ -34,7 +35,7 @@ func TestProjectManager_CreateProject(t *testing.T) {
 	}
 
 	fields := getTestProjectManagerFields()
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.Nil(t, err)
@@ -64,6 +65,46 @@ func TestProjectManager_CreateProject(t *testing.T) {
 	require.Equal(t, pmd.ProjectName, project.ProjectName)
 }
 
+func TestProjectManager_CreateProject_WithStageLayout(t *testing.T) {
+	project := models.CreateProjectParams{
+		Project:     models.Project{ProjectName: "my-project"},
+		StageLayout: models.StageLayoutDirectory,
+	}
+
+	fields := getTestProjectManagerFields()
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
+	err := p.CreateProject(project)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.fileWriter.WriteFileCalls(), 1)
+	pmd := &common.ProjectMetadata{}
+	err = yaml.Unmarshal(fields.fileWriter.WriteFileCalls()[0].Content, pmd)
+
+	require.Nil(t, err)
+	require.Equal(t, models.StageLayoutDirectory, pmd.StageLayout)
+	require.True(t, pmd.IsUsingDirectoryStructure)
+}
+
+func TestProjectManager_CreateProject_WithDefaultStageLayout(t *testing.T) {
+	project := models.CreateProjectParams{
+		Project: models.Project{ProjectName: "my-project"},
+	}
+
+	fields := getTestProjectManagerFields()
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutDirectory, fields.projectCache)
+	err := p.CreateProject(project)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.fileWriter.WriteFileCalls(), 1)
+	pmd := &common.ProjectMetadata{}
+	err = yaml.Unmarshal(fields.fileWriter.WriteFileCalls()[0].Content, pmd)
+
+	require.Nil(t, err)
+	require.Equal(t, models.StageLayoutDirectory, pmd.StageLayout)
+}
+
 func TestProjectManager_CreateProject_ProjectAlreadyExists(t *testing.T) {
 	project := models.CreateProjectParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -86,7 +127,7 @@ func TestProjectManager_CreateProject_ProjectAlreadyExists(t *testing.T) {
 		}
 		return false
 	}
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.Equal(t, errors2.ErrProjectAlreadyExists, err)
@@ -119,7 +160,7 @@ func TestProjectManager_CreateProject_CannotReadCredentials(t *testing.T) {
 		return nil, errors2.ErrMalformedCredentials
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrMalformedCredentials)
@@ -157,7 +198,7 @@ func TestProjectManager_CreateProject_ProjectRepoDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.Equal(t, errors2.ErrRepositoryNotFound, err)
@@ -196,7 +237,7 @@ func TestProjectManager_CreateProject_WritingFileFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.NotNil(t, err)
@@ -238,7 +279,7 @@ func TestProjectManager_CreateProject_CommitFails(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.CreateProject(project)
 
 	require.NotNil(t, err)
@@ -283,7 +324,7 @@ func TestProjectManager_UpdateProject(t *testing.T) {
 		return true
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -336,7 +377,7 @@ func TestProjectManager_UpdateProject_WithMigration(t *testing.T) {
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -395,7 +436,7 @@ func TestProjectManager_UpdateProject_WithMigration_CannotPull(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -451,7 +492,7 @@ func TestProjectManager_UpdateProject_WithMigration_MigrationFailsOnFirstTry(t *
 	}
 
 	nrTries := 0
-	fields.git.MigrateProjectFunc = func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
+	fields.git.MigrateProjectFunc = func(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error {
 		if nrTries == 0 {
 			nrTries++
 			return errors.New("oops")
@@ -459,7 +500,7 @@ func TestProjectManager_UpdateProject_WithMigration_MigrationFailsOnFirstTry(t *
 		return nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -515,7 +556,7 @@ isUsingDirectoryStructure: true`), nil
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.Nil(t, err)
@@ -539,6 +580,72 @@ isUsingDirectoryStructure: true`), nil
 	require.Len(t, fields.git.MigrateProjectCalls(), 0)
 }
 
+func TestProjectManager_UpdateProject_MigrateToBranchLayout(t *testing.T) {
+	project := models.UpdateProjectParams{
+		Project:     models.Project{ProjectName: "my-project"},
+		StageLayout: models.StageLayoutBranch,
+	}
+
+	fields := getTestProjectManagerFields()
+
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return true
+	}
+
+	fields.fileWriter.ReadFileFunc = func(filename string) ([]byte, error) {
+		if strings.HasSuffix(filename, "metadata.yaml") {
+			return []byte(`projectName: "my-project"
+isUsingDirectoryStructure: true`), nil
+		}
+		return []byte("content"), nil
+	}
+
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
+	err := p.UpdateProject(project)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.MigrateProjectCalls(), 1)
+	require.Equal(t, models.StageLayoutBranch, fields.git.MigrateProjectCalls()[0].StageLayout)
+
+	pmd := &common.ProjectMetadata{}
+	err = yaml.Unmarshal(fields.git.MigrateProjectCalls()[0].NewMetadataContent, pmd)
+
+	require.Nil(t, err)
+	require.Equal(t, "my-project", pmd.ProjectName)
+	require.Equal(t, models.StageLayoutBranch, pmd.StageLayout)
+	require.False(t, pmd.IsUsingDirectoryStructure)
+}
+
+func TestProjectManager_UpdateProject_StageLayoutOfDefault(t *testing.T) {
+	project := models.UpdateProjectParams{
+		Project:     models.Project{ProjectName: "my-project"},
+		StageLayout: models.StageLayoutDirectory,
+	}
+
+	fields := getTestProjectManagerFields()
+
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return true
+	}
+
+	// projects created before the stage layout has been stored in the metadata use the default layout
+	fields.fileWriter.ReadFileFunc = func(filename string) ([]byte, error) {
+		if strings.HasSuffix(filename, "metadata.yaml") {
+			return []byte(`projectName: "my-project"
+isUsingDirectoryStructure: false`), nil
+		}
+		return []byte("content"), nil
+	}
+
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutDirectory, fields.projectCache)
+	err := p.UpdateProject(project)
+
+	require.Nil(t, err)
+
+	require.Len(t, fields.git.MigrateProjectCalls(), 0)
+}
+
 func TestProjectManager_UpdateProject_WithMigration_InvalidMetadata(t *testing.T) {
 	project := models.UpdateProjectParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -572,7 +679,7 @@ func TestProjectManager_UpdateProject_WithMigration_InvalidMetadata(t *testing.T
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -633,7 +740,7 @@ func TestProjectManager_UpdateProject_WithMigration_NoMetadata(t *testing.T) {
 		return []byte("content"), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -668,7 +775,7 @@ func TestProjectManager_UpdateProject_CannotReadCredentials(t *testing.T) {
 		return nil, errors2.ErrMalformedCredentials
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrMalformedCredentials)
@@ -702,7 +809,7 @@ func TestProjectManager_UpdateProject_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -741,7 +848,7 @@ func TestProjectManager_UpdateProject_ProjectNotInitialized(t *testing.T) {
 		return nil, errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -780,7 +887,7 @@ func TestProjectManager_UpdateProject_ProjectNotInitializedEmptyMetadataFile(t *
 		return []byte(""), nil
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -822,7 +929,7 @@ func TestProjectManager_UpdateProject_CannotGetDefaultBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -864,7 +971,7 @@ func TestProjectManager_UpdateProject_CheckoutBranchFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.UpdateProject(project)
 
 	require.NotNil(t, err)
@@ -895,13 +1002,31 @@ func TestProjectManager_DeleteProject(t *testing.T) {
 		return true
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.DeleteProject(project)
 
 	require.Nil(t, err)
 
 	require.Len(t, fields.fileWriter.DeleteFileCalls(), 1)
 	require.Equal(t, fields.fileWriter.DeleteFileCalls()[0].Path, common.GetProjectConfigPath(project))
+
+	require.Len(t, fields.projectCache.RemoveCalls(), 1)
+	require.Equal(t, project, fields.projectCache.RemoveCalls()[0].Project)
+}
+
+func TestProjectManager_DeleteProject_CannotRemoveCache(t *testing.T) {
+	project := "my-project"
+
+	fields := getTestProjectManagerFields()
+	fields.projectCache.RemoveFunc = func(project string) error {
+		return errors.New("oops")
+	}
+
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
+	err := p.DeleteProject(project)
+
+	require.NotNil(t, err)
+	require.Len(t, fields.fileWriter.DeleteFileCalls(), 1)
 }
 
 func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
@@ -922,7 +1047,7 @@ func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter)
+	p := NewProjectManager(fields.git, fields.credentialReader, fields.fileWriter, models.StageLayoutBranch, fields.projectCache)
 	err := p.DeleteProject(project)
 
 	require.NotNil(t, err)
@@ -933,14 +1058,14 @@ func TestProjectManager_DeleteProject_CannotDeleteDirectory(t *testing.T) {
 func getTestProjectManagerFields() projectManagerTestFields {
 	return projectManagerTestFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:         func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:         func(gitContext common_models.GitContext, revision string) error { return nil },
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			CloneRepoFunc:         func(gitContext common_models.GitContext) (bool, error) { return true, nil },
 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "", nil },
 			GetDefaultBranchFunc:  func(gitContext common_models.GitContext) (string, error) { return "main", nil },
 			CheckoutBranchFunc:    func(gitContext common_models.GitContext, branch string) error { return nil },
-			MigrateProjectFunc:    func(gitContext common_models.GitContext, newMetadatacontent []byte) error { return nil },
+			MigrateProjectFunc:    func(gitContext common_models.GitContext, stageLayout string, newMetadataContent []byte) error { return nil },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
@@ -969,5 +1094,10 @@ func getTestProjectManagerFields() projectManagerTestFields {
 				return []byte("content"), nil
 			},
 		},
+		projectCache: &common_mock.IProjectCacheMock{
+			RemoveFunc: func(project string) error {
+				return nil
+			},
+		},
 	}
 }
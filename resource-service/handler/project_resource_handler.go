@@ -14,6 +14,11 @@ type IProjectResourceHandler interface {
 	GetProjectResource(context *gin.Context)
 	UpdateProjectResource(context *gin.Context)
 	DeleteProjectResource(context *gin.Context)
+	GetProjectResourceContent(context *gin.Context)
+	UpdateProjectResourceContent(context *gin.Context)
+	GetProjectResourceHistory(context *gin.Context)
+	GetProjectResourceDiff(context *gin.Context)
+	RevertProjectResource(context *gin.Context)
 }
 
 type ProjectResourceHandler struct {
@@ -42,7 +47,8 @@ func NewProjectResourceHandler(projectResourceManager IResourceManager) *Project
 func (ph *ProjectResourceHandler) CreateProjectResources(c *gin.Context) {
 	params := &models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -127,7 +133,8 @@ func (ph *ProjectResourceHandler) GetProjectResources(c *gin.Context) {
 func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 	params := &models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -213,7 +220,8 @@ func (ph *ProjectResourceHandler) GetProjectResource(c *gin.Context) {
 func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 	params := &models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -255,7 +263,8 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 	params := &models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -273,3 +282,221 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetProjectResourceContent godoc
+// @Summary      Get the content of a project resource
+// @Description  Get the raw content of a resource for the project. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/content [get]
+func (ph *ProjectResourceHandler) GetProjectResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	SetResourceContentResponse(c, result)
+}
+
+// UpdateProjectResourceContent godoc
+// @Summary      Updates the content of a project resource
+// @Description  Creates or updates a resource for the project with the raw content of the request body. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        content      body      string  true   "The content of the resource"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/content [put]
+func (ph *ProjectResourceHandler) UpdateProjectResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceContentParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+		Content:     c.Request.Body,
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.UpdateResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetProjectResourceHistory godoc
+// @Summary      Get the history of a project resource
+// @Description  Get the commits that changed a resource of the project, starting with the most recent one
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/history [get]
+func (ph *ProjectResourceHandler) GetProjectResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetProjectResourceDiff godoc
+// @Summary      Get the changes of a project resource
+// @Description  Get the changes of a resource of the project between two revisions in unified diff format. If no target revision is set, the changes up to the current revision are returned
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the old revision"
+// @Param        to           query     string  false  "The commit ID of the new revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevertProjectResource godoc
+// @Summary      Reverts a project resource
+// @Description  Restores the content a resource of the project had at the given revision, and commits it as a new revision
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true  "The commit ID to revert the resource to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/revert [post]
+func (ph *ProjectResourceHandler) RevertProjectResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
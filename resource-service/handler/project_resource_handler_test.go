@@ -9,8 +9,10 @@ import (
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
 	"github.com/stretchr/testify/require"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -827,3 +829,444 @@ func TestProjectResourceHandler_DeleteProjectResource(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectResourceHandler_GetProjectResourceContent(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *http.Request
+		wantParams  *models.GetResourceParams
+		wantContent string
+		wantStatus  int
+	}{
+		{
+			name: "get resource content",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return &models.GetResourceContentResponse{
+							Content:  ioutil.NopCloser(strings.NewReader("binary-content")),
+							Size:     14,
+							Metadata: models.Version{Version: "commit-id"},
+						}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/chart.tgz/content?gitCommitID=commit-id", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "chart.tgz",
+				GetResourceQuery: models.GetResourceQuery{
+					GitCommitID: "commit-id",
+				},
+			},
+			wantContent: "binary-content",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name: "get resource in parent directory - should return error",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/..chart.tgz/content", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceContentFunc: func(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/chart.tgz/content", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "chart.tgz",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/content", ph.GetProjectResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceContentCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceContentCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceContentCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantContent != "" {
+				require.Equal(t, tt.wantContent, resp.Body.String())
+				require.Equal(t, "application/octet-stream", resp.Header().Get("Content-Type"))
+				require.Equal(t, "commit-id", resp.Header().Get(headerResourceVersion))
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_UpdateProjectResourceContent(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *http.Request
+		wantContent string
+		wantStatus  int
+	}{
+		{
+			name: "update resource content successful",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceContentFunc: func(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error) {
+					content, err := ioutil.ReadAll(params.Content)
+					require.Nil(t, err)
+					require.Equal(t, "binary-content", string(content))
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/project/my-project/resource/chart.tgz/content", strings.NewReader("binary-content")),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "resourceUri contains invalid string",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/project/my-project/resource/..chart.tgz/content", strings.NewReader("binary-content")),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceContentFunc: func(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error) {
+					return nil, errors2.ErrProjectNotFound
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPut, "/project/my-project/resource/chart.tgz/content", strings.NewReader("binary-content")),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.PUT("/project/:projectName/resource/:resourceURI/content", ph.UpdateProjectResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantStatus != http.StatusBadRequest {
+				require.Len(t, tt.fields.ProjectResourceManager.UpdateResourceContentCalls(), 1)
+				require.Equal(t, "my-project", tt.fields.ProjectResourceManager.UpdateResourceContentCalls()[0].Params.ProjectName)
+				require.Equal(t, "chart.tgz", tt.fields.ProjectResourceManager.UpdateResourceContentCalls()[0].Params.ResourceURI)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.UpdateResourceContentCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceHistory(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceHistoryParams
+		wantStatus int
+	}{
+		{
+			name: "get resource history",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &models.GetResourceHistoryResponse{Commits: []models.ResourceCommit{{CommitID: "commit-id"}}}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/history?pageSize=5", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 5,
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "default page size",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return &models.GetResourceHistoryResponse{}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid page size",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/history?pageSize=invalid", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/history", nil),
+			wantParams: &models.GetResourceHistoryParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+					PageSize: 20,
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/history", ph.GetProjectResourceHistory)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceHistoryCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceHistoryCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceDiff(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantStatus int
+	}{
+		{
+			name: "get resource diff",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &models.GetResourceDiffResponse{From: "commit-1", To: "commit-2", Diff: "diff"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/diff?from=commit-1&to=commit-2", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "commit-1",
+					To:   "commit-2",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "source revision not set",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/diff?to=commit-2", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{
+					From: "unknown",
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/diff", ph.GetProjectResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_RevertProjectResource(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RevertResourceParams
+		wantStatus int
+	}{
+		{
+			name: "revert resource",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource/resource.yaml/revert", bytes.NewBuffer([]byte(`{"gitCommitID": "commit-1"}`))),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				RevertResourcePayload: models.RevertResourcePayload{
+					GitCommitID: "commit-1",
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "commit ID not set",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/resource/resource.yaml/revert", bytes.NewBuffer([]byte(`{}`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payload",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/resource/resource.yaml/revert", bytes.NewBuffer([]byte(`invalid`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "resource did not exist in revision",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+						return nil, errors2.ErrResourceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/resource/resource.yaml/revert", bytes.NewBuffer([]byte(`{"gitCommitID": "commit-1"}`))),
+			wantParams: &models.RevertResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+				RevertResourcePayload: models.RevertResourcePayload{
+					GitCommitID: "commit-1",
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/resource/:resourceURI/revert", ph.RevertProjectResource)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.RevertResourceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.RevertResourceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.RevertResourceCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"strings"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
 )
 
 //IResourceManager provides an interface for resource CRUD operations
@@ -24,6 +27,11 @@ type IResourceManager interface {
 	GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error)
 	UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 	DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+	GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error)
+	UpdateResourceContent(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error)
+	GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+	GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+	RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
 }
 
 type ResourceManager struct {
@@ -31,14 +39,20 @@ type ResourceManager struct {
 	credentialReader     common.CredentialReader
 	fileSystem           common.IFileSystem
 	configurationContext IConfigurationContext
+	pullRequestProvider  common.IPullRequestProvider
+	projectCache         common.IProjectCache
 }
 
-func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
+// NewResourceManager creates a new ResourceManager. If a project cache is provided, single resources are read from the cached clone
+// of the project, without locking the project, and without pulling the upstream for each request
+func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, pullRequestProvider common.IPullRequestProvider, projectCache common.IProjectCache) *ResourceManager {
 	projectResourceManager := &ResourceManager{
 		git:                  git,
 		credentialReader:     credentialReader,
 		fileSystem:           fileWriter,
 		configurationContext: stageContext,
+		pullRequestProvider:  pullRequestProvider,
+		projectCache:         projectCache,
 	}
 	return projectResourceManager
 }
@@ -52,7 +66,7 @@ func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	return p.writeAndCommitResources(gitContext, params.ExemptFromPullRequests, params.Resources, configPath)
 }
 
 func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
@@ -89,10 +103,14 @@ func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*
 		return nil, err
 	}
 
-	return p.writeAndCommitResources(gitContext, params.Resources, configPath)
+	return p.writeAndCommitResources(gitContext, params.ExemptFromPullRequests, params.Resources, configPath)
 }
 
 func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	if p.isCachedResource(params) {
+		return p.readCachedResource(params)
+	}
+
 	common.LockProject(params.ProjectName)
 	defer common.UnlockProject(params.ProjectName)
 
@@ -120,7 +138,168 @@ func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*mo
 
 	resourcePath := configPath + "/" + params.ResourceURI
 
-	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent))
+	return p.writeAndCommitResource(gitContext, params.ExemptFromPullRequests, resourcePath, string(params.ResourceContent))
+}
+
+// GetResourceContent returns the raw content of a resource, which can be streamed to the client. The caller is responsible for closing the content
+func (p ResourceManager) GetResourceContent(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+	if p.isCachedResource(params) {
+		return p.openCachedResource(params)
+	}
+
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	return p.openResource(gitContext, params, configPath, unescapedResourceName)
+}
+
+// UpdateResourceContent stores the raw content of a resource, without requiring it to be base64 encoded
+func (p ResourceManager) UpdateResourceContent(params models.UpdateResourceContentParams) (*models.WriteResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// the content is streamed into a temporary file before the project is locked, so that slow uploads do not block other requests
+	tmpFile, err := p.fileSystem.WriteTempFile(params.Content)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := p.fileSystem.DeleteFile(tmpFile); err != nil {
+			logger.Errorf("Could not delete temporary file %s: %v", tmpFile, err)
+		}
+	}()
+
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := configPath + "/" + unescapedResourceName
+
+	return p.commitWithRetry(gitContext, params.ExemptFromPullRequests, "Updated resource", func() error {
+		return p.storeResourceFromFile(resourcePath, tmpFile)
+	})
+}
+
+// GetResourceHistory returns the commits that changed a resource, starting with the most recent one
+func (p ResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	commits, err := p.git.GetFileHistory(*gitContext, getRepositoryPath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetResourceHistoryResponse{
+		Commits: []models.ResourceCommit{},
+	}
+	paginationInfo := Paginate(len(commits), params.PageSize, params.NextPageKey)
+	if paginationInfo.NextPageKey < int64(len(commits)) {
+		for _, commit := range commits[paginationInfo.NextPageKey:paginationInfo.EndIndex] {
+			result.Commits = append(result.Commits, models.ResourceCommit{
+				CommitID:  commit.ID,
+				Author:    commit.Author,
+				Message:   commit.Message,
+				Timestamp: commit.Timestamp,
+			})
+		}
+	}
+	result.PageSize = float64(len(result.Commits))
+	result.TotalCount = float64(len(commits))
+	result.NextPageKey = paginationInfo.NewNextPageKey
+	return result, nil
+}
+
+// GetResourceDiff returns the changes of a resource between two revisions. If no target revision is set, the changes up to the current revision are returned
+func (p ResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	to := params.To
+	if to == "" {
+		to, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := p.git.GetFileDiff(*gitContext, getRepositoryPath(params.ProjectName, configPath, unescapedResourceName), params.From, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		From: params.From,
+		To:   to,
+		Diff: diff,
+	}, nil
+}
+
+// RevertResource restores the content a resource had at the given revision, and commits it as a new revision
+func (p ResourceManager) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	common.LockProject(params.ProjectName)
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	repositoryPath := getRepositoryPath(params.ProjectName, configPath, unescapedResourceName)
+	message := fmt.Sprintf("Reverted resource %s to %s", unescapedResourceName, params.GitCommitID)
+
+	return p.commitWithRetry(gitContext, params.ExemptFromPullRequests, message, func() error {
+		return p.git.RestoreFileRevision(*gitContext, params.GitCommitID, repositoryPath)
+	})
 }
 
 func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
@@ -147,7 +326,7 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 			resultErr = err
 			return nil
 		}
-		response, err := p.deleteResource(gitContext, resourcePath)
+		response, err := p.deleteResource(gitContext, params.ExemptFromPullRequests, resourcePath)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -195,12 +374,8 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 	var revision string
 	var err error
 
-	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
-		// if commit ID is set, path needs to be relative to the project directory
-		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
-		// resource path must not start with "/", otherwise git is not able to resolve the revision
-		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
-		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
+	if hasGitCommitID(params) {
+		fileContent, err = p.getFileRevision(gitContext, params, configPath, resourceName)
 		revision = params.GitCommitID
 	} else {
 		resourcePath := configPath + "/" + resourceName
@@ -211,6 +386,10 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 		if err != nil {
 			return nil, err
 		}
+		fileContent, err = p.resolveLFSPointer(gitContext, fileContent)
+		if err != nil {
+			return nil, err
+		}
 		revision, err = p.git.GetCurrentRevision(*gitContext)
 	}
 	if err != nil {
@@ -231,36 +410,204 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 	}, nil
 }
 
-func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
-
-	var resultErr error
-	var resultCommit *models.WriteResourceResponse
-	_ = retry.Retry(func() error {
-		err := p.git.Pull(*gitContext)
+// openResource opens the content of a resource for streaming it to the client
+func (p ResourceManager) openResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) (*models.GetResourceContentResponse, error) {
+	if hasGitCommitID(params) {
+		fileContent, err := p.getFileRevision(gitContext, params, configPath, resourceName)
 		if err != nil {
-			resultErr = err
-			return nil
-		}
-		if err := p.storeResource(resourcePath, resourceContent); err != nil {
-			resultErr = err
-			return nil
+			return nil, err
 		}
+		return &models.GetResourceContentResponse{
+			Content: ioutil.NopCloser(bytes.NewReader(fileContent)),
+			Size:    int64(len(fileContent)),
+			Metadata: models.Version{
+				UpstreamURL: gitContext.Credentials.RemoteURI,
+				Version:     params.GitCommitID,
+			},
+		}, nil
+	}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
-		if err != nil {
-			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	content, size, err := p.fileSystem.OpenFile(configPath + "/" + resourceName)
+	if err != nil {
+		return nil, err
+	}
+	content, size, err = p.git.ResolveLFSPointer(*gitContext, content, size)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := p.git.GetCurrentRevision(*gitContext)
+	if err != nil {
+		content.Close()
+		return nil, err
+	}
+	return &models.GetResourceContentResponse{
+		Content: content,
+		Size:    size,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURI,
+			Version:     revision,
+		},
+	}, nil
+}
+
+// isCachedResource checks whether the resource can be read from the project cache. Helm charts are archived from their directory in the
+// local repository when being read, hence they always have to be read via the local repository
+func (p ResourceManager) isCachedResource(params models.GetResourceParams) bool {
+	if p.projectCache == nil {
+		return false
+	}
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	return err == nil && !common.IsHelmChartPath(unescapedResourceName)
+}
+
+// locateCachedResource determines the revision and the path of a resource within the cached clone of the project
+func (p ResourceManager) locateCachedResource(params models.GetResourceParams) (*common_models.GitContext, string, string, error) {
+	credentials, err := p.credentialReader.GetCredentials(params.ProjectName)
+	if err != nil {
+		return nil, "", "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     params.ProjectName,
+		Credentials: credentials,
+	}
+
+	location, err := p.configurationContext.Locate(common_models.ConfigurationContextParams{
+		Project:                 params.Project,
+		Stage:                   params.Stage,
+		Service:                 params.Service,
+		GitContext:              gitContext,
+		CheckConfigDirAvailable: true,
+	}, p.projectCache)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, "", "", kerrors.ErrResourceInvalidResourceURI
+	}
+
+	revision := location.Revision
+	if hasGitCommitID(params) {
+		revision = params.GitCommitID
+	}
+	// resource path must not start with "/", otherwise git is not able to resolve the revision
+	resourcePath := strings.TrimPrefix(location.Path+"/"+unescapedResourceName, "/")
+	return &gitContext, revision, resourcePath, nil
+}
+
+func (p ResourceManager) readCachedResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+	gitContext, revision, resourcePath, err := p.locateCachedResource(params)
+	if err != nil {
+		return nil, err
+	}
+	fileContent, err := p.projectCache.GetFileRevision(*gitContext, revision, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+	// LFS objects are downloaded into the local repository, which must not be removed or replaced meanwhile
+	common.RLockProject(params.ProjectName)
+	fileContent, err = p.resolveLFSPointer(gitContext, fileContent)
+	common.RUnlockProject(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceURI:     params.ResourceURI,
+			ResourceContent: models.ResourceContent(base64.StdEncoding.EncodeToString(fileContent)),
+		},
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURI,
+			Version:     revision,
+		},
+	}, nil
+}
+
+func (p ResourceManager) openCachedResource(params models.GetResourceParams) (*models.GetResourceContentResponse, error) {
+	gitContext, revision, resourcePath, err := p.locateCachedResource(params)
+	if err != nil {
+		return nil, err
+	}
+	content, size, err := p.projectCache.OpenFileRevision(*gitContext, revision, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+	// LFS objects are downloaded into the local repository, which must not be removed or replaced meanwhile
+	common.RLockProject(params.ProjectName)
+	content, size, err = p.git.ResolveLFSPointer(*gitContext, content, size)
+	common.RUnlockProject(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceContentResponse{
+		Content: content,
+		Size:    size,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURI,
+			Version:     revision,
+		},
+	}, nil
+}
+
+func hasGitCommitID(params models.GetResourceParams) bool {
+	return params.GitCommitID != "" && params.GitCommitID != "\"\""
+}
+
+func (p ResourceManager) getFileRevision(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) ([]byte, error) {
+	// if commit ID is set, path needs to be relative to the project directory
+	configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
+	// resource path must not start with "/", otherwise git is not able to resolve the revision
+	resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
+	return p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
+}
+
+// getRepositoryPath returns the path of a resource relative to the project directory, as it is stored in the repository.
+// Helm charts are stored as extracted directory, therefore the path of the chart directory is returned for them
+func getRepositoryPath(projectName string, configPath string, resourceName string) string {
+	configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(projectName))
+	resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
+	if common.IsHelmChartPath(resourcePath) {
+		return strings.TrimSuffix(resourcePath, ".tgz")
+	}
+	return resourcePath
+}
+
+func (p ResourceManager) resolveLFSPointer(gitContext *common_models.GitContext, fileContent []byte) ([]byte, error) {
+	content, _, err := p.git.ResolveLFSPointer(*gitContext, ioutil.NopCloser(bytes.NewReader(fileContent)), int64(len(fileContent)))
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return ioutil.ReadAll(content)
+}
+
+func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, exemptFromPullRequests bool, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
+	return p.commitWithRetry(gitContext, exemptFromPullRequests, "Updated resource", func() error {
+		return p.storeResource(resourcePath, resourceContent)
+	})
+}
+
+func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, exemptFromPullRequests bool, resources []models.Resource, directory string) (*models.WriteResourceResponse, error) {
+	return p.commitWithRetry(gitContext, exemptFromPullRequests, "Updated resource", func() error {
+		for _, res := range resources {
+			filePath := directory + "/" + res.ResourceURI
+			if err := p.storeResource(filePath, string(res.ResourceContent)); err != nil {
 				return err
 			}
-			resultErr = err
-			return nil
 		}
-		resultCommit = commit
 		return nil
-	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
-	return resultCommit, resultErr
+	})
 }
 
-func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitContext, resources []models.Resource, directory string) (*models.WriteResourceResponse, error) {
+// commitWithRetry pulls the latest changes, writes the resources and commits them. If the upstream has been updated in the meantime, the whole process is retried
+func (p ResourceManager) commitWithRetry(gitContext *common_models.GitContext, exemptFromPullRequests bool, message string, writeResources func() error) (*models.WriteResourceResponse, error) {
 
 	var resultErr error
 	var resultCommit *models.WriteResourceResponse
@@ -270,15 +617,12 @@ func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitCo
 			resultErr = err
 			return nil
 		}
-		for _, res := range resources {
-			filePath := directory + "/" + res.ResourceURI
-			if err := p.storeResource(filePath, string(res.ResourceContent)); err != nil {
-				resultErr = err
-				return nil
-			}
+		if err := writeResources(); err != nil {
+			resultErr = err
+			return nil
 		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.stageAndCommit(gitContext, exemptFromPullRequests, message)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -304,7 +648,24 @@ func (p ResourceManager) storeResource(resourcePath, resourceContent string) err
 	return nil
 }
 
-func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+func (p ResourceManager) storeResourceFromFile(resourcePath, sourcePath string) error {
+	if err := p.fileSystem.CopyFile(sourcePath, resourcePath); err != nil {
+		return err
+	}
+	if common.IsHelmChartPath(resourcePath) {
+		if err := p.fileSystem.WriteHelmChart(resourcePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stageAndCommit commits the changes and pushes them to the upstream. If the changes of the project are proposed via pull requests,
+// the changes are pushed to a new branch instead, unless the request is exempt from pull requests
+func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, exemptFromPullRequests bool, message string) (*models.WriteResourceResponse, error) {
+	if !exemptFromPullRequests && gitContext.Credentials.PullRequestsEnabled() {
+		return p.proposeChanges(gitContext, message)
+	}
 	commitID, err := p.git.StageAndCommitAll(*gitContext, message)
 	if err != nil {
 		return nil, err
@@ -320,7 +681,48 @@ func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, me
 	return result, nil
 }
 
-func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, resourcePath string) (*models.WriteResourceResponse, error) {
+// proposeChanges pushes the changes to a new branch and creates a pull request for merging them into the current branch.
+// The changes only become effective once the pull request has been merged, hence no commit ID is returned for them
+func (p ResourceManager) proposeChanges(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	targetBranch, err := p.git.GetCurrentBranch(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	sourceBranch := fmt.Sprintf("%s%d", common.PullRequestBranchPrefix, time.Now().UnixNano())
+
+	if _, err := p.git.StageAndCommitToBranch(*gitContext, sourceBranch, message); err != nil {
+		return nil, err
+	}
+
+	pullRequest, err := p.pullRequestProvider.CreatePullRequest(*gitContext, common_models.PullRequest{
+		Title:        fmt.Sprintf("%s in %s", message, targetBranch),
+		Description:  fmt.Sprintf("This change of the resources of project %s has been proposed by Keptn. It becomes effective once it has been merged into %s.", gitContext.Project, targetBranch),
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+	})
+	if err != nil {
+		logger.Errorf("Could not create pull request for branch %s of project %s: %v", sourceBranch, gitContext.Project, err)
+		if err := p.git.DeleteRemoteBranch(*gitContext, sourceBranch); err != nil {
+			logger.Errorf("Could not delete branch %s of project %s: %v", sourceBranch, gitContext.Project, err)
+		}
+		return nil, err
+	}
+
+	return &models.WriteResourceResponse{
+		Metadata: models.Version{
+			Branch:      sourceBranch,
+			UpstreamURL: gitContext.Credentials.RemoteURI,
+		},
+		PullRequest: &models.PullRequest{
+			ID:           pullRequest.ID,
+			URL:          pullRequest.URL,
+			SourceBranch: sourceBranch,
+			TargetBranch: targetBranch,
+		},
+	}, nil
+}
+
+func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, exemptFromPullRequests bool, resourcePath string) (*models.WriteResourceResponse, error) {
 	if !p.fileSystem.FileExists(resourcePath) {
 		return nil, kerrors.ErrResourceNotFound
 	}
@@ -328,5 +730,5 @@ func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, re
 		return nil, err
 	}
 
-	return p.stageAndCommit(gitContext, "Deleted resources")
+	return p.stageAndCommit(gitContext, exemptFromPullRequests, "Deleted resources")
 }
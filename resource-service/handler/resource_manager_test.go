@@ -9,7 +9,9 @@ import (
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
 	"github.com/stretchr/testify/require"
+	"io"
 	"io/fs"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,16 +23,17 @@ const testConfigDir = "/data/config/my-project"
 const testServiceConfigDir = "/data/config/my-project/my-service"
 
 type testResourceManagerFields struct {
-	git              *common_mock.IGitMock
-	credentialReader *common_mock.CredentialReaderMock
-	fileSystem       *common_mock.IFileSystemMock
-	stageContext     *handler_mock.IConfigurationContextMock
+	git                 *common_mock.IGitMock
+	credentialReader    *common_mock.CredentialReaderMock
+	fileSystem          *common_mock.IFileSystemMock
+	stageContext        *handler_mock.IConfigurationContextMock
+	pullRequestProvider *common_mock.IPullRequestProviderMock
 }
 
 func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -69,7 +72,7 @@ func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -109,7 +112,7 @@ func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -150,7 +153,7 @@ func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource_HelmChart(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -188,7 +191,7 @@ func TestResourceManager_CreateResources_ServiceResource_HelmChartWriteFails(t *
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -225,7 +228,7 @@ func TestResourceManager_CreateResources_ProjectResource_ProjectNotFound(t *test
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -262,7 +265,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotReadCredentials(t
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrMalformedCredentials
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -299,7 +302,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -331,7 +334,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 func TestResourceManager_UpdateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -369,7 +372,7 @@ func TestResourceManager_UpdateResources_ProjectResource_ProjectNotFound(t *test
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -407,7 +410,7 @@ func TestResourceManager_UpdateResources_ProjectResource_WritingFileFails(t *tes
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -444,7 +447,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFails(t *testing.
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -482,7 +485,7 @@ func TestResourceManager_UpdateResources_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -524,7 +527,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -558,7 +561,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -587,7 +590,7 @@ func TestResourceManager_UpdateResource_ProjectResource_ProjectNotFound(t *testi
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -615,7 +618,7 @@ func TestResourceManager_UpdateResource_ProjectResource_WritingFileFails(t *test
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -644,7 +647,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFails(t *testing.T
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -673,7 +676,7 @@ func TestResourceManager_UpdateResource_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -707,7 +710,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -732,7 +735,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_DeleteResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -757,7 +760,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ProjectNotFound(t *testi
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -784,7 +787,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ResourceNotFound(t *test
 		}
 		return true
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -808,7 +811,7 @@ func TestResourceManager_DeleteResource_ProjectResource_DeleteFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -843,7 +846,7 @@ func TestResourceManager_DeleteResource_Git_ThrowsNonFastForward_Or_ThrowsForceN
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -870,7 +873,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -894,7 +897,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -927,7 +930,7 @@ func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -966,7 +969,7 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 		return testConfigDir + "/my-service", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1006,7 +1009,7 @@ func TestResourceManager_GetResource_ProjectResource_PullFails(t *testing.T) {
 	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1033,7 +1036,7 @@ func TestResourceManager_GetResource_ProjectResource_ProjectNotFound(t *testing.
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1058,7 +1061,7 @@ func TestResourceManager_GetResource_ServiceResource_ServiceNotFound(t *testing.
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors2.ErrServiceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1089,7 +1092,7 @@ func TestResourceManager_GetResource_ProjectResource_ResourceNotFound(t *testing
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors2.ErrResourceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1115,7 +1118,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1138,7 +1141,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1159,10 +1162,616 @@ func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *test
 	require.Empty(t, fields.git.GetFileRevisionCalls())
 }
 
+func TestResourceManager_GetResourceContent_ProjectResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.ResolveLFSPointerFunc = func(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+		return ioutil.NopCloser(strings.NewReader("lfs-content")), 11, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "helm%2Fchart.tgz",
+	})
+
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Equal(t, "lfs-content", string(content))
+	require.Equal(t, int64(11), result.Size)
+	require.Equal(t, models.Version{UpstreamURL: "remote-url", Version: "my-revision"}, result.Metadata)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.fileSystem.OpenFileCalls(), 1)
+	require.Equal(t, testConfigDir+"/helm/chart.tgz", fields.fileSystem.OpenFileCalls()[0].Filename)
+	require.Len(t, fields.git.ResolveLFSPointerCalls(), 1)
+	require.Equal(t, int64(12), fields.git.ResolveLFSPointerCalls()[0].Size)
+}
+
+func TestResourceManager_GetResourceContent_ProvideGitCommitID(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	})
+
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(content))
+	require.Equal(t, int64(12), result.Size)
+	require.Equal(t, "my-commit-id", result.Metadata.Version)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "file1", fields.git.GetFileRevisionCalls()[0].File)
+	require.Empty(t, fields.fileSystem.OpenFileCalls())
+}
+
+func TestResourceManager_GetResourceContent_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.fileSystem.OpenFileFunc = func(filename string) (io.ReadCloser, int64, error) {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.ResolveLFSPointerCalls())
+}
+
+func TestResourceManager_GetResource_FromProjectCache(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	cache := getTestProjectCache()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, cache)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.Nil(t, err)
+
+	require.Equal(t, &models.GetResourceResponse{
+		Resource: models.Resource{
+			ResourceContent: "Y2FjaGVkLWNvbnRlbnQ=",
+			ResourceURI:     "file1",
+		},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-cached-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.stageContext.LocateCalls(), 1)
+	require.True(t, fields.stageContext.LocateCalls()[0].Params.CheckConfigDirAvailable)
+	require.Len(t, cache.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-cached-revision", cache.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "file1", cache.GetFileRevisionCalls()[0].File)
+	require.Len(t, fields.git.ResolveLFSPointerCalls(), 1)
+
+	// the local repository of the project is neither used nor updated
+	require.Empty(t, fields.stageContext.EstablishCalls())
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.fileSystem.ReadFileCalls())
+}
+
+func TestResourceManager_GetResource_FromProjectCache_ProvideGitCommitID(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	cache := getTestProjectCache()
+
+	fields.stageContext.LocateFunc = func(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+		return &common_models.ConfigurationLocation{Revision: "my-cached-revision", Path: "my-service"}, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, cache)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-commit-id", result.Metadata.Version)
+
+	require.Len(t, cache.GetFileRevisionCalls(), 1)
+	require.Equal(t, "my-commit-id", cache.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/file1", cache.GetFileRevisionCalls()[0].File)
+}
+
+func TestResourceManager_GetResource_FromProjectCache_ServiceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	cache := getTestProjectCache()
+
+	fields.stageContext.LocateFunc = func(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+		return nil, errors2.ErrServiceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, cache)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, cache.GetFileRevisionCalls())
+}
+
+func TestResourceManager_GetResourceContent_FromProjectCache(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	cache := getTestProjectCache()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, cache)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Nil(t, result.Content.Close())
+	require.Equal(t, "cached-content", string(content))
+	require.Equal(t, int64(14), result.Size)
+	require.Equal(t, models.Version{UpstreamURL: "remote-url", Version: "my-cached-revision"}, result.Metadata)
+
+	require.Len(t, cache.OpenFileRevisionCalls(), 1)
+	require.Equal(t, "file1", cache.OpenFileRevisionCalls()[0].File)
+	require.Empty(t, fields.stageContext.EstablishCalls())
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.fileSystem.OpenFileCalls())
+}
+
+func TestResourceManager_GetResourceContent_FromProjectCache_HelmChart(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	cache := getTestProjectCache()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, cache)
+
+	result, err := rm.GetResourceContent(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "helm%2Fchart.tgz",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-revision", result.Metadata.Version)
+
+	// helm charts are archived from the local repository
+	require.Empty(t, fields.stageContext.LocateCalls())
+	require.Empty(t, cache.OpenFileRevisionCalls())
+	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+	require.Len(t, fields.fileSystem.OpenFileCalls(), 1)
+}
+
+func TestResourceManager_UpdateResourceContent_ServiceResource_HelmChart(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.UpdateResourceContent(models.UpdateResourceContentParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fchart.tgz",
+		Content:     strings.NewReader("chart-content"),
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	require.Len(t, fields.fileSystem.WriteTempFileCalls(), 1)
+	require.Len(t, fields.fileSystem.CopyFileCalls(), 1)
+	require.Equal(t, "/tmp/resource-123", fields.fileSystem.CopyFileCalls()[0].SourcePath)
+	require.Equal(t, testServiceConfigDir+"/helm/chart.tgz", fields.fileSystem.CopyFileCalls()[0].Path)
+	require.Len(t, fields.fileSystem.WriteHelmChartCalls(), 1)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+
+	// the temporary file is removed
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, "/tmp/resource-123", fields.fileSystem.DeleteFileCalls()[0].Path)
+}
+
+func TestResourceManager_UpdateResourceContent_ProjectNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.UpdateResourceContent(models.UpdateResourceContentParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		Content:     strings.NewReader("content"),
+	})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.fileSystem.CopyFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+}
+
+func TestResourceManager_GetResourceHistory(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fchart.tgz",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize: 2,
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.GetResourceHistoryResponse{
+		NextPageKey: "2",
+		PageSize:    2,
+		TotalCount:  3,
+		Commits: []models.ResourceCommit{
+			{CommitID: "commit-3", Author: "keptn", Message: "Updated resource", Timestamp: time.Unix(3, 0)},
+			{CommitID: "commit-2", Author: "keptn", Message: "Updated resource", Timestamp: time.Unix(2, 0)},
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileHistoryCalls(), 1)
+	// helm charts are stored as extracted directory
+	require.Equal(t, "my-service/helm/chart", fields.git.GetFileHistoryCalls()[0].Path)
+
+	result, err = rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fchart.tgz",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize:    2,
+			NextPageKey: "2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "0", result.NextPageKey)
+	require.Len(t, result.Commits, 1)
+	require.Equal(t, "commit-1", result.Commits[0].CommitID)
+}
+
+func TestResourceManager_GetResourceHistory_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileHistoryFunc = func(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{
+			PageSize: 20,
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Equal(t, "file1", fields.git.GetFileHistoryCalls()[0].Path)
+}
+
+func TestResourceManager_GetResourceDiff(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+		},
+	})
+
+	require.Nil(t, err)
+	// the current revision is used if no target revision is set
+	require.Equal(t, &models.GetResourceDiffResponse{From: "commit-1", To: "my-revision", Diff: "my-diff"}, result)
+
+	require.Len(t, fields.git.GetFileDiffCalls(), 1)
+	require.Equal(t, "file1", fields.git.GetFileDiffCalls()[0].Path)
+	require.Equal(t, "commit-1", fields.git.GetFileDiffCalls()[0].FromRevision)
+	require.Equal(t, "my-revision", fields.git.GetFileDiffCalls()[0].ToRevision)
+
+	result, err = rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "commit-1",
+			To:   "commit-2",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "commit-2", result.To)
+	require.Equal(t, "commit-2", fields.git.GetFileDiffCalls()[1].ToRevision)
+}
+
+func TestResourceManager_GetResourceDiff_RevisionNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileDiffFunc = func(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error) {
+		return "", errors2.ErrResolveRevision
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{
+			From: "unknown",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResolveRevision)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_RevertResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "file1",
+		RevertResourcePayload: models.RevertResourcePayload{
+			GitCommitID: "commit-1",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	require.Len(t, fields.git.RestoreFileRevisionCalls(), 1)
+	require.Equal(t, "commit-1", fields.git.RestoreFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/file1", fields.git.RestoreFileRevisionCalls()[0].Path)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Reverted resource file1 to commit-1", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_RevertResource_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.RestoreFileRevisionFunc = func(gitContext common_models.GitContext, revision string, path string) error {
+		return errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		RevertResourcePayload: models.RevertResourcePayload{
+			GitCommitID: "commit-1",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_UpdateResource_PullRequestsEnabled(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
+		return &common_models.GitCredentials{
+			User:        "user",
+			Token:       "token",
+			RemoteURI:   "remote-url",
+			PullRequest: &common_models.PullRequestSettings{Enabled: true, Provider: common_models.PullRequestProviderGitHub},
+		}, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.Nil(t, err)
+
+	// the change is pushed to a new branch instead of the branch of the stage
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+	sourceBranch := fields.git.StageAndCommitToBranchCalls()[0].Branch
+	require.True(t, strings.HasPrefix(sourceBranch, "keptn/resource-update-"))
+
+	require.Len(t, fields.pullRequestProvider.CreatePullRequestCalls(), 1)
+	pullRequest := fields.pullRequestProvider.CreatePullRequestCalls()[0].PullRequest
+	require.Equal(t, sourceBranch, pullRequest.SourceBranch)
+	require.Equal(t, "my-stage", pullRequest.TargetBranch)
+	require.Equal(t, "Updated resource in my-stage", pullRequest.Title)
+
+	// the commit of the pull request is not returned, since it has not been merged
+	require.Equal(t, &models.WriteResourceResponse{
+		Metadata: models.Version{
+			Branch:      sourceBranch,
+			UpstreamURL: "remote-url",
+		},
+		PullRequest: &models.PullRequest{
+			ID:           42,
+			URL:          "https://github.com/my-org/my-repo/pull/42",
+			SourceBranch: sourceBranch,
+			TargetBranch: "my-stage",
+		},
+	}, result)
+}
+
+func TestResourceManager_UpdateResource_PullRequestCannotBeCreated(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
+		return &common_models.GitCredentials{
+			User:        "user",
+			Token:       "token",
+			RemoteURI:   "remote-url",
+			PullRequest: &common_models.PullRequestSettings{Enabled: true, Provider: common_models.PullRequestProviderGitLab},
+		}, nil
+	}
+	fields.pullRequestProvider.CreatePullRequestFunc = func(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+		return nil, errors.New("oops")
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+
+	// the branch of the pull request is removed again
+	require.Len(t, fields.git.DeleteRemoteBranchCalls(), 1)
+	require.Equal(t, fields.git.StageAndCommitToBranchCalls()[0].Branch, fields.git.DeleteRemoteBranchCalls()[0].Branch)
+}
+
+func TestResourceManager_UpdateResource_PullRequestsEnabled_ExemptWrite(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
+		return &common_models.GitCredentials{
+			User:        "user",
+			Token:       "token",
+			RemoteURI:   "remote-url",
+			PullRequest: &common_models.PullRequestSettings{Enabled: true, Provider: common_models.PullRequestProviderGitHub},
+		}, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
+
+	// changes requested by the Keptn services that are exempt from pull requests, e.g. the generated Helm charts of the helm-service, are committed directly
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: "my-project"},
+			Stage:                  &models.Stage{StageName: "my-stage"},
+			ExemptFromPullRequests: true,
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-revision", result.CommitID)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Empty(t, fields.git.StageAndCommitToBranchCalls())
+	require.Empty(t, fields.pullRequestProvider.CreatePullRequestCalls())
+}
+
 func TestResourceManager_GetResources(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.pullRequestProvider, nil)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1259,7 +1868,7 @@ func (fakeFileInfo) Sys() interface{} {
 func getTestResourceManagerFields() testResourceManagerFields {
 	return testResourceManagerFields{
 		git: &common_mock.IGitMock{
-			ResetHardFunc:          func(gitContext common_models.GitContext) error { return nil },
+			ResetHardFunc:          func(gitContext common_models.GitContext, revision string) error { return nil },
 			CheckoutBranchFunc:     func(gitContext common_models.GitContext, branch string) error { return nil },
 			CloneRepoFunc:          func(gitContext common_models.GitContext) (bool, error) { return true, nil },
 			CreateBranchFunc:       func(gitContext common_models.GitContext, branch string, sourceBranch string) error { return nil },
@@ -1273,6 +1882,27 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
 			PushFunc:              func(gitContext common_models.GitContext) error { return nil },
 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) { return "my-revision", nil },
+			ResolveLFSPointerFunc: func(gitContext common_models.GitContext, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+				return content, size, nil
+			},
+			GetFileHistoryFunc: func(gitContext common_models.GitContext, path string) ([]common_models.GitCommit, error) {
+				return []common_models.GitCommit{
+					{ID: "commit-3", Author: "keptn", Message: "Updated resource", Timestamp: time.Unix(3, 0)},
+					{ID: "commit-2", Author: "keptn", Message: "Updated resource", Timestamp: time.Unix(2, 0)},
+					{ID: "commit-1", Author: "keptn", Message: "Added resource", Timestamp: time.Unix(1, 0)},
+				}, nil
+			},
+			GetFileDiffFunc: func(gitContext common_models.GitContext, path string, fromRevision string, toRevision string) (string, error) {
+				return "my-diff", nil
+			},
+			RestoreFileRevisionFunc: func(gitContext common_models.GitContext, revision string, path string) error { return nil },
+			GetCurrentBranchFunc:    func(gitContext common_models.GitContext) (string, error) { return "my-stage", nil },
+			DeleteRemoteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+				return nil
+			},
+			StageAndCommitToBranchFunc: func(gitContext common_models.GitContext, branch string, message string) (string, error) {
+				return "my-proposed-revision", nil
+			},
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
@@ -1296,6 +1926,15 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			ReadFileFunc: func(filename string) ([]byte, error) {
 				return []byte("file-content"), nil
 			},
+			OpenFileFunc: func(filename string) (io.ReadCloser, int64, error) {
+				return ioutil.NopCloser(strings.NewReader("file-content")), 12, nil
+			},
+			WriteTempFileFunc: func(content io.Reader) (string, error) {
+				return "/tmp/resource-123", nil
+			},
+			CopyFileFunc: func(sourcePath string, path string) error {
+				return nil
+			},
 			WalkPathFunc: func(path string, walkFunc filepath.WalkFunc) error {
 
 				_ = walkFunc(path+"/file1", newFakeFileInfo("file1", false), nil)
@@ -1318,6 +1957,25 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			EstablishFunc: func(params common_models.ConfigurationContextParams) (string, error) {
 				return testConfigDir, nil
 			},
+			LocateFunc: func(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+				return &common_models.ConfigurationLocation{Revision: "my-cached-revision"}, nil
+			},
+		},
+		pullRequestProvider: &common_mock.IPullRequestProviderMock{
+			CreatePullRequestFunc: func(gitContext common_models.GitContext, pullRequest common_models.PullRequest) (*common_models.PullRequestResult, error) {
+				return &common_models.PullRequestResult{ID: 42, URL: "https://github.com/my-org/my-repo/pull/42"}, nil
+			},
+		},
+	}
+}
+
+func getTestProjectCache() *common_mock.IProjectCacheMock {
+	return &common_mock.IProjectCacheMock{
+		GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+			return []byte("cached-content"), nil
+		},
+		OpenFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+			return ioutil.NopCloser(strings.NewReader("cached-content")), 14, nil
 		},
 	}
 }
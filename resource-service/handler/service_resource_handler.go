@@ -15,6 +15,11 @@ type IServiceResourceHandler interface {
 	GetServiceResource(context *gin.Context)
 	UpdateServiceResource(context *gin.Context)
 	DeleteServiceResource(context *gin.Context)
+	GetServiceResourceContent(context *gin.Context)
+	UpdateServiceResourceContent(context *gin.Context)
+	GetServiceResourceHistory(context *gin.Context)
+	GetServiceResourceDiff(context *gin.Context)
+	RevertServiceResource(context *gin.Context)
 }
 
 type ServiceResourceHandler struct {
@@ -45,9 +50,10 @@ func NewServiceResourceHandler(serviceResourceManager IResourceManager) *Service
 func (ph *ServiceResourceHandler) CreateServiceResources(c *gin.Context) {
 	params := &models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
-			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -138,9 +144,10 @@ func (ph *ServiceResourceHandler) GetServiceResources(c *gin.Context) {
 func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 	params := &models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
-			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -231,9 +238,10 @@ func (ph *ServiceResourceHandler) GetServiceResource(c *gin.Context) {
 func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 	params := &models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
-			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -276,6 +284,47 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 // @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI} [delete]
 func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 	params := &models.DeleteResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.DeleteResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetServiceResourceContent godoc
+// @Summary      Get the content of a service resource
+// @Description  Get the raw content of a resource for the service in the given stage of a project. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/content [get]
+func (ph *ServiceResourceHandler) GetServiceResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
@@ -283,13 +332,211 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
 	}
 
-	result, err := ph.ServiceResourceManager.DeleteResource(*params)
+	result, err := ph.ServiceResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	SetResourceContentResponse(c, result)
+}
+
+// UpdateServiceResourceContent godoc
+// @Summary      Updates the content of a service resource
+// @Description  Creates or updates a resource for the service in the given stage of a project with the raw content of the request body. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        content      body      string  true   "The content of the resource"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/content [put]
+func (ph *ServiceResourceHandler) UpdateServiceResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceContentParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+		Content:     c.Request.Body,
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.UpdateResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetServiceResourceHistory godoc
+// @Summary      Get the history of a service resource
+// @Description  Get the commits that changed a resource of the service in the given stage of a project, starting with the most recent one
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history [get]
+func (ph *ServiceResourceHandler) GetServiceResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetServiceResourceDiff godoc
+// @Summary      Get the changes of a service resource
+// @Description  Get the changes of a resource of the service in the given stage of a project between two revisions in unified diff format. If no target revision is set, the changes up to the current revision are returned
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the old revision"
+// @Param        to           query     string  false  "The commit ID of the new revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevertServiceResource godoc
+// @Summary      Reverts a service resource
+// @Description  Restores the content a resource of the service in the given stage of a project had at the given revision, and commits it as a new revision
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        serviceName  path      string  true   "The name of the service"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true  "The commit ID to revert the resource to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/revert [post]
+func (ph *ServiceResourceHandler) RevertServiceResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service:                &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.RevertResource(*params)
 	if err != nil {
 		OnAPIError(c, err)
 		return
@@ -722,6 +722,53 @@ func TestServiceResourceHandler_UpdateServiceResource(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "update resource by a Keptn service that is exempt from pull requests",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodPut, "/project/my-project/stage/my-stage/service/my-service/resource/resource.yaml", bytes.NewBuffer([]byte(updateResourceTestPayload)))
+				request.Header.Set("X-Keptn-Request-Origin", "helm-service")
+				return request
+			}(),
+			wantParams: &models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project:                models.Project{ProjectName: "my-project"},
+					Stage:                  &models.Stage{StageName: "my-stage"},
+					Service:                &models.Service{ServiceName: "my-service"},
+					ExemptFromPullRequests: true,
+				},
+				ResourceURI:           "resource.yaml",
+				UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: "c3RyaW5n"},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "update resource via API gateway",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			request: func() *http.Request {
+				request := httptest.NewRequest(http.MethodPut, "/project/my-project/stage/my-stage/service/my-service/resource/resource.yaml", bytes.NewBuffer([]byte(updateResourceTestPayload)))
+				request.Header.Set("X-Keptn-Request-Origin", "api-gateway")
+				return request
+			}(),
+			wantParams: &models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI:           "resource.yaml",
+				UpdateResourcePayload: models.UpdateResourcePayload{ResourceContent: "c3RyaW5n"},
+			},
+			wantStatus: http.StatusOK,
+		},
 		{
 			name: "resource content not base64 encoded",
 			fields: fields{
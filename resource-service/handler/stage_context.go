@@ -5,11 +5,16 @@ import (
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"gopkg.in/yaml.v3"
 )
 
 //go:generate moq -pkg handler_mock -skip-ensure -out ./fake/configuration_context_mock.go . IConfigurationContext
 type IConfigurationContext interface {
 	Establish(params common_models.ConfigurationContextParams) (string, error)
+	// Locate determines the revision and the directory containing the configuration of the given context, based on the cached clone of the project.
+	// In contrast to Establish, the local repository of the project is not modified, hence the project does not need to be locked
+	Locate(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error)
 }
 
 type BranchConfigurationContext struct {
@@ -52,6 +57,35 @@ func (bs BranchConfigurationContext) Establish(params common_models.Configuratio
 	return configPath, nil
 }
 
+func (bs BranchConfigurationContext) Locate(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+	var branch string
+	var err error
+	if params.Stage == nil {
+		branch, err = cache.GetDefaultBranch(params.GitContext)
+		if err != nil {
+			return nil, fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
+		}
+	} else {
+		branch = params.Stage.StageName
+	}
+
+	revision, err := cache.GetRevision(params.GitContext, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	location := &common_models.ConfigurationLocation{Revision: revision}
+	notFoundErr := kerrors.ErrProjectNotFound
+	if params.Service != nil {
+		location.Path = params.Service.ServiceName
+		notFoundErr = kerrors.ErrServiceNotFound
+	}
+	if err := checkLocationAvailable(params, cache, location, notFoundErr); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
 type DirectoryConfigurationContext struct {
 	git        common.IGit
 	fileSystem common.IFileSystem
@@ -91,6 +125,31 @@ func (ds DirectoryConfigurationContext) Establish(params common_models.Configura
 	return configPath, nil
 }
 
+func (ds DirectoryConfigurationContext) Locate(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+	branch, err := cache.GetDefaultBranch(params.GitContext)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine default branch of project %s: %w", params.Project.ProjectName, err)
+	}
+	revision, err := cache.GetRevision(params.GitContext, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	location := &common_models.ConfigurationLocation{Revision: revision}
+	notFoundErr := kerrors.ErrProjectNotFound
+	if params.Stage != nil && params.Service != nil {
+		location.Path = fmt.Sprintf("%s/%s/%s", common.StageDirectoryName, params.Stage.StageName, params.Service.ServiceName)
+		notFoundErr = kerrors.ErrServiceNotFound
+	} else if params.Stage != nil {
+		location.Path = fmt.Sprintf("%s/%s", common.StageDirectoryName, params.Stage.StageName)
+		notFoundErr = kerrors.ErrStageNotFound
+	}
+	if err := checkLocationAvailable(params, cache, location, notFoundErr); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
 func (ds DirectoryConfigurationContext) GetProjectConfigPath(project string) string {
 	return fmt.Sprintf("%s/%s", common.GetConfigDir(), project)
 }
@@ -102,3 +161,73 @@ func (ds DirectoryConfigurationContext) GetStageConfigPath(project, stage string
 func (ds DirectoryConfigurationContext) GetServiceConfigPath(project, stage, service string) string {
 	return fmt.Sprintf("%s/%s", ds.GetStageConfigPath(project, stage), service)
 }
+
+// StageLayoutConfigurationContext establishes the configuration context according to the stage layout stored in the metadata of the respective project
+type StageLayoutConfigurationContext struct {
+	git              common.IGit
+	defaultLayout    string
+	branchContext    IConfigurationContext
+	directoryContext IConfigurationContext
+}
+
+func NewStageLayoutConfigurationContext(git common.IGit, fileSystem common.IFileSystem, defaultLayout string) *StageLayoutConfigurationContext {
+	return &StageLayoutConfigurationContext{
+		git:              git,
+		defaultLayout:    defaultLayout,
+		branchContext:    NewBranchConfigurationContext(git, fileSystem),
+		directoryContext: NewDirectoryConfigurationContext(git, fileSystem),
+	}
+}
+
+func (sc StageLayoutConfigurationContext) Establish(params common_models.ConfigurationContextParams) (string, error) {
+	if getStageLayout(sc.git, params.GitContext, sc.defaultLayout) == models.StageLayoutDirectory {
+		return sc.directoryContext.Establish(params)
+	}
+	return sc.branchContext.Establish(params)
+}
+
+func (sc StageLayoutConfigurationContext) Locate(params common_models.ConfigurationContextParams, cache common.IProjectCache) (*common_models.ConfigurationLocation, error) {
+	if getStageLayout(cache, params.GitContext, sc.defaultLayout) == models.StageLayoutDirectory {
+		return sc.directoryContext.Locate(params, cache)
+	}
+	return sc.branchContext.Locate(params, cache)
+}
+
+// checkLocationAvailable returns the given error if the configuration directory is required to exist, but is not available at the located revision
+func checkLocationAvailable(params common_models.ConfigurationContextParams, cache common.IProjectCache, location *common_models.ConfigurationLocation, notFoundErr error) error {
+	if !params.CheckConfigDirAvailable {
+		return nil
+	}
+	exists, err := cache.PathExists(params.GitContext, location.Revision, location.Path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return notFoundErr
+	}
+	return nil
+}
+
+// revisionReader provides access to the files of a project at a given revision, either via the local repository or the cached clone of the project
+type revisionReader interface {
+	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
+	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+}
+
+// getStageLayout returns the stage layout stored in the metadata on the default branch of the project.
+// If the metadata cannot be read, e.g. because the project has not been initialized yet, the default layout is returned
+func getStageLayout(git revisionReader, gitContext common_models.GitContext, defaultLayout string) string {
+	defaultBranch, err := git.GetDefaultBranch(gitContext)
+	if err != nil {
+		return defaultLayout
+	}
+	metadataContent, err := git.GetFileRevision(gitContext, defaultBranch, "metadata.yaml")
+	if err != nil {
+		return defaultLayout
+	}
+	metadata := &common.ProjectMetadata{}
+	if err := yaml.Unmarshal(metadataContent, metadata); err != nil {
+		return defaultLayout
+	}
+	return metadata.GetStageLayout(defaultLayout)
+}
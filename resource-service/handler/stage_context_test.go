@@ -406,3 +406,197 @@ func TestDirectoryConfigurationContext_Establish_CannotCheckoutDefaultBranch(t *
 
 	require.Equal(t, "", configDir)
 }
+
+func TestStageLayoutConfigurationContext_Establish_DirectoryLayout(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte(`projectName: my-project
+stageLayout: directory`), nil
+	}
+
+	sc := NewStageLayoutConfigurationContext(fields.git, fields.fileSystem, models.StageLayoutBranch)
+
+	params := common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		GitContext:              common_models.GitContext{},
+		CheckConfigDirAvailable: false,
+	}
+	configDir, err := sc.Establish(params)
+
+	require.Nil(t, err)
+
+	require.Equal(t, common.GetProjectConfigPath("my-project")+"/.keptn-stages/my-stage/my-service", configDir)
+
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "main", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "metadata.yaml", fields.git.GetFileRevisionCalls()[0].File)
+
+	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
+	require.Equal(t, "main", fields.git.CheckoutBranchCalls()[0].Branch)
+}
+
+func TestStageLayoutConfigurationContext_Establish_BranchLayout(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte(`projectName: my-project
+stageLayout: branch`), nil
+	}
+
+	sc := NewStageLayoutConfigurationContext(fields.git, fields.fileSystem, models.StageLayoutDirectory)
+
+	params := common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		GitContext:              common_models.GitContext{},
+		CheckConfigDirAvailable: false,
+	}
+	configDir, err := sc.Establish(params)
+
+	require.Nil(t, err)
+
+	require.Equal(t, common.GetServiceConfigPath("my-project", "my-service"), configDir)
+
+	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
+	require.Equal(t, "my-stage", fields.git.CheckoutBranchCalls()[0].Branch)
+}
+
+func TestStageLayoutConfigurationContext_Establish_CannotReadMetadata(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, errors.New("oops")
+	}
+
+	sc := NewStageLayoutConfigurationContext(fields.git, fields.fileSystem, models.StageLayoutDirectory)
+
+	params := common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		GitContext:              common_models.GitContext{},
+		CheckConfigDirAvailable: false,
+	}
+	configDir, err := sc.Establish(params)
+
+	require.Nil(t, err)
+
+	// the default layout is used
+	require.Equal(t, common.GetProjectConfigPath("my-project")+"/.keptn-stages/my-stage", configDir)
+}
+
+func getTestLocateProjectCache() *common_mock.IProjectCacheMock {
+	return &common_mock.IProjectCacheMock{
+		GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) { return "main", nil },
+		GetRevisionFunc: func(gitContext common_models.GitContext, branch string) (string, error) {
+			return branch + "-revision", nil
+		},
+		GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+			return []byte("projectName: my-project\nstageLayout: directory"), nil
+		},
+		PathExistsFunc: func(gitContext common_models.GitContext, revision string, path string) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+func TestBranchStageContext_Locate_ServiceContext(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+	cache := getTestLocateProjectCache()
+
+	sc := NewBranchConfigurationContext(fields.git, fields.fileSystem)
+
+	location, err := sc.Locate(common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		CheckConfigDirAvailable: true,
+	}, cache)
+
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ConfigurationLocation{Revision: "my-stage-revision", Path: "my-service"}, location)
+
+	require.Len(t, cache.PathExistsCalls(), 1)
+	require.Equal(t, "my-service", cache.PathExistsCalls()[0].Path)
+
+	// the local repository is not modified
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+}
+
+func TestBranchStageContext_Locate_StageNotFound(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+	cache := getTestLocateProjectCache()
+	cache.GetRevisionFunc = func(gitContext common_models.GitContext, branch string) (string, error) {
+		return "", kerrors.ErrReferenceNotFound
+	}
+
+	sc := NewBranchConfigurationContext(fields.git, fields.fileSystem)
+
+	location, err := sc.Locate(common_models.ConfigurationContextParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   &models.Stage{StageName: "my-stage"},
+	}, cache)
+
+	require.ErrorIs(t, err, kerrors.ErrReferenceNotFound)
+	require.Nil(t, location)
+}
+
+func TestDirectoryConfigurationContext_Locate_ServiceContext(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+	cache := getTestLocateProjectCache()
+
+	sc := NewDirectoryConfigurationContext(fields.git, fields.fileSystem)
+
+	location, err := sc.Locate(common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		Service:                 &models.Service{ServiceName: "my-service"},
+		CheckConfigDirAvailable: true,
+	}, cache)
+
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ConfigurationLocation{Revision: "main-revision", Path: ".keptn-stages/my-stage/my-service"}, location)
+	require.Empty(t, fields.git.CheckoutBranchCalls())
+}
+
+func TestDirectoryConfigurationContext_Locate_StageContext_StageNotFound(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+	cache := getTestLocateProjectCache()
+	cache.PathExistsFunc = func(gitContext common_models.GitContext, revision string, path string) (bool, error) {
+		return false, nil
+	}
+
+	sc := NewDirectoryConfigurationContext(fields.git, fields.fileSystem)
+
+	location, err := sc.Locate(common_models.ConfigurationContextParams{
+		Project:                 models.Project{ProjectName: "my-project"},
+		Stage:                   &models.Stage{StageName: "my-stage"},
+		CheckConfigDirAvailable: true,
+	}, cache)
+
+	require.ErrorIs(t, err, kerrors.ErrStageNotFound)
+	require.Nil(t, location)
+}
+
+func TestStageLayoutConfigurationContext_Locate_DirectoryLayout(t *testing.T) {
+	fields := getTestBranchStageContextFields()
+	cache := getTestLocateProjectCache()
+
+	sc := NewStageLayoutConfigurationContext(fields.git, fields.fileSystem, models.StageLayoutBranch)
+
+	location, err := sc.Locate(common_models.ConfigurationContextParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   &models.Stage{StageName: "my-stage"},
+	}, cache)
+
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ConfigurationLocation{Revision: "main-revision", Path: ".keptn-stages/my-stage"}, location)
+
+	// the stage layout is read from the cache as well
+	require.Len(t, cache.GetFileRevisionCalls(), 1)
+	require.Equal(t, "metadata.yaml", cache.GetFileRevisionCalls()[0].File)
+	require.Empty(t, fields.git.GetFileRevisionCalls())
+}
@@ -164,3 +164,59 @@ func (dm DirectoryStageManager) establishStageContext(project models.Project, st
 
 	return &gitContext, configPath, nil
 }
+
+// StageLayoutStageManager delegates to the stage manager that corresponds to the stage layout of the respective project
+type StageLayoutStageManager struct {
+	git                   common.IGit
+	credentialReader      common.CredentialReader
+	branchStageManager    IStageManager
+	directoryStageManager IStageManager
+	defaultLayout         string
+}
+
+func NewStageLayoutStageManager(git common.IGit, credentialReader common.CredentialReader, branchStageManager IStageManager, directoryStageManager IStageManager, defaultLayout string) *StageLayoutStageManager {
+	return &StageLayoutStageManager{
+		git:                   git,
+		credentialReader:      credentialReader,
+		branchStageManager:    branchStageManager,
+		directoryStageManager: directoryStageManager,
+		defaultLayout:         defaultLayout,
+	}
+}
+
+func (sm StageLayoutStageManager) CreateStage(params models.CreateStageParams) error {
+	stageManager, err := sm.getStageManager(params.Project)
+	if err != nil {
+		return err
+	}
+	return stageManager.CreateStage(params)
+}
+
+func (sm StageLayoutStageManager) DeleteStage(params models.DeleteStageParams) error {
+	stageManager, err := sm.getStageManager(params.Project)
+	if err != nil {
+		return err
+	}
+	return stageManager.DeleteStage(params)
+}
+
+func (sm StageLayoutStageManager) getStageManager(project models.Project) (IStageManager, error) {
+	credentials, err := sm.credentialReader.GetCredentials(project.ProjectName)
+	if err != nil {
+		return nil, fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     project.ProjectName,
+		Credentials: credentials,
+	}
+
+	if !sm.git.ProjectExists(gitContext) {
+		return nil, errors.ErrProjectNotFound
+	}
+
+	if getStageLayout(sm.git, gitContext, sm.defaultLayout) == models.StageLayoutDirectory {
+		return sm.directoryStageManager, nil
+	}
+	return sm.branchStageManager, nil
+}
@@ -539,3 +539,79 @@ func TestDirectoryStageManager_DeleteStage_CannotCommitChanges(t *testing.T) {
 
 	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
 }
+
+func TestStageLayoutStageManager_CreateStage(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte(`projectName: my-project
+isUsingDirectoryStructure: true`), nil
+	}
+
+	branchStageManager := &handler_mock.IStageManagerMock{CreateStageFunc: func(params models.CreateStageParams) error { return nil }}
+	directoryStageManager := &handler_mock.IStageManagerMock{CreateStageFunc: func(params models.CreateStageParams) error { return nil }}
+
+	sm := NewStageLayoutStageManager(fields.git, fields.credentialReader, branchStageManager, directoryStageManager, models.StageLayoutBranch)
+
+	params := models.CreateStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateStagePayload: models.CreateStagePayload{
+			Stage: models.Stage{StageName: "my-stage"},
+		},
+	}
+	err := sm.CreateStage(params)
+
+	require.Nil(t, err)
+
+	require.Empty(t, branchStageManager.CreateStageCalls())
+	require.Len(t, directoryStageManager.CreateStageCalls(), 1)
+	require.Equal(t, params, directoryStageManager.CreateStageCalls()[0].Params)
+}
+
+func TestStageLayoutStageManager_DeleteStage_DefaultLayout(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte(`projectName: my-project`), nil
+	}
+
+	branchStageManager := &handler_mock.IStageManagerMock{DeleteStageFunc: func(params models.DeleteStageParams) error { return nil }}
+	directoryStageManager := &handler_mock.IStageManagerMock{DeleteStageFunc: func(params models.DeleteStageParams) error { return nil }}
+
+	sm := NewStageLayoutStageManager(fields.git, fields.credentialReader, branchStageManager, directoryStageManager, models.StageLayoutDirectory)
+
+	err := sm.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+
+	require.Nil(t, err)
+
+	require.Empty(t, branchStageManager.DeleteStageCalls())
+	require.Len(t, directoryStageManager.DeleteStageCalls(), 1)
+}
+
+func TestStageLayoutStageManager_CreateStage_ProjectNotFound(t *testing.T) {
+	fields := getTestStageManagerFields()
+
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	branchStageManager := &handler_mock.IStageManagerMock{}
+	directoryStageManager := &handler_mock.IStageManagerMock{}
+
+	sm := NewStageLayoutStageManager(fields.git, fields.credentialReader, branchStageManager, directoryStageManager, models.StageLayoutBranch)
+
+	err := sm.CreateStage(models.CreateStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		CreateStagePayload: models.CreateStagePayload{
+			Stage: models.Stage{StageName: "my-stage"},
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+
+	require.Empty(t, branchStageManager.CreateStageCalls())
+	require.Empty(t, directoryStageManager.CreateStageCalls())
+}
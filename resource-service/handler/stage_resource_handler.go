@@ -15,6 +15,11 @@ type IStageResourceHandler interface {
 	GetStageResource(context *gin.Context)
 	UpdateStageResource(context *gin.Context)
 	DeleteStageResource(context *gin.Context)
+	GetStageResourceContent(context *gin.Context)
+	UpdateStageResourceContent(context *gin.Context)
+	GetStageResourceHistory(context *gin.Context)
+	GetStageResourceDiff(context *gin.Context)
+	RevertStageResource(context *gin.Context)
 }
 
 type StageResourceHandler struct {
@@ -44,8 +49,9 @@ func NewStageResourceHandler(stageResourceManager IResourceManager) *StageResour
 func (ph *StageResourceHandler) CreateStageResources(c *gin.Context) {
 	params := &models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -133,8 +139,9 @@ func (ph *StageResourceHandler) GetStageResources(c *gin.Context) {
 func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 	params := &models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 	}
 
@@ -223,8 +230,9 @@ func (ph *StageResourceHandler) GetStageResource(c *gin.Context) {
 func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 	params := &models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
-			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
-			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -266,19 +274,248 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 // @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI} [delete]
 func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 	params := &models.DeleteResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.DeleteResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetStageResourceContent godoc
+// @Summary      Get the content of a stage resource
+// @Description  Get the raw content of a resource for the stage of a project. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Produce      octet-stream
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/content [get]
+func (ph *StageResourceHandler) GetStageResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
 
 	if err := params.Validate(); err != nil {
 		SetBadRequestErrorResponse(c, err.Error())
 		return
 	}
 
-	result, err := ph.StageResourceManager.DeleteResource(*params)
+	result, err := ph.StageResourceManager.GetResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	SetResourceContentResponse(c, result)
+}
+
+// UpdateStageResourceContent godoc
+// @Summary      Updates the content of a stage resource
+// @Description  Creates or updates a resource for the stage of a project with the raw content of the request body. In contrast to the JSON API, the content is streamed and not base64 encoded
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        content      body      string  true   "The content of the resource"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/content [put]
+func (ph *StageResourceHandler) UpdateStageResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceContentParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+		Content:     c.Request.Body,
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.UpdateResourceContent(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetStageResourceHistory godoc
+// @Summary      Get the history of a stage resource
+// @Description  Get the commits that changed a resource of the stage of a project, starting with the most recent one
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        pageSize     query     int     false  "The number of items to return"
+// @Param        nextPageKey  query     string  false  "Pointer to the next set of items"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/history [get]
+func (ph *StageResourceHandler) GetStageResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getHistory := &models.GetResourceHistoryQuery{PageSize: 20}
+	if err := c.ShouldBindQuery(getHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetStageResourceDiff godoc
+// @Summary      Get the changes of a stage resource
+// @Description  Get the changes of a resource of the stage of a project between two revisions in unified diff format. If no target revision is set, the changes up to the current revision are returned
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        from         query     string  true   "The commit ID of the old revision"
+// @Param        to           query     string  false  "The commit ID of the new revision"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff [get]
+func (ph *StageResourceHandler) GetStageResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevertStageResource godoc
+// @Summary      Reverts a stage resource
+// @Description  Restores the content a resource of the stage of a project had at the given revision, and commits it as a new revision
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        stageName    path      string  true   "The name of the stage"
+// @Param        resourceURI  path      string  true   "The path of the resource file"
+// @Param        revision     body      models.RevertResourcePayload  true  "The commit ID to revert the resource to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/revert [post]
+func (ph *StageResourceHandler) RevertStageResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project:                models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:                  &models.Stage{StageName: c.Param(pathParamStageName)},
+			ExemptFromPullRequests: isExemptFromPullRequests(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.RevertResource(*params)
 	if err != nil {
 		OnAPIError(c, err)
 		return
@@ -18,6 +18,7 @@ import (
 	"github.com/keptn/keptn/resource-service/config"
 	"github.com/keptn/keptn/resource-service/controller"
 	"github.com/keptn/keptn/resource-service/handler"
+	"github.com/keptn/keptn/resource-service/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -82,15 +83,23 @@ func main() {
 	credentialReader := common.NewK8sCredentialReader(kubeAPI)
 	fileSystem := common.NewFileSystem(common.GetConfigDir())
 
-	git := common.NewGit(&common.GogitReal{})
-	configurationContext := createConfigurationContext(git, fileSystem)
+	projectCache := createProjectCache()
+	git := createGit(projectCache)
+	defaultStageLayout := getDefaultStageLayout()
+	configurationContext := handler.NewStageLayoutConfigurationContext(git, fileSystem, defaultStageLayout)
 
-	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
+	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem, defaultStageLayout, projectCache)
 	projectHandler := handler.NewProjectHandler(projectManager)
 	projectController := controller.NewProjectController(projectHandler)
 	projectController.Inject(apiV1)
 
-	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader)
+	stageManager := handler.NewStageLayoutStageManager(
+		git,
+		credentialReader,
+		handler.NewStageManager(git, credentialReader),
+		handler.NewDirectoryStageManager(handler.NewDirectoryConfigurationContext(git, fileSystem), fileSystem, credentialReader, git),
+		defaultStageLayout,
+	)
 	stageHandler := handler.NewStageHandler(stageManager)
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
@@ -100,17 +109,19 @@ func main() {
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	pullRequestProvider := common.NewPullRequestProvider()
+
+	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, pullRequestProvider, projectCache)
 	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
-	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, pullRequestProvider, projectCache)
 	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
-	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, pullRequestProvider, projectCache)
 	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
@@ -137,24 +148,33 @@ func main() {
 
 }
 
-func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem) handler.IConfigurationContext {
-	var configContext handler.IConfigurationContext
-	if config.Global.DirectoryStageStructure {
-		configContext = handler.NewDirectoryConfigurationContext(git, fileSystem)
-	} else {
-		configContext = handler.NewBranchConfigurationContext(git, fileSystem)
+func createGit(projectCache common.IProjectCache) *common.Git {
+	var opts []common.GitOption
+	if config.Global.LFSEnabled {
+		log.Infof("Storing files larger than %d bytes in Git LFS", config.Global.LFSThreshold)
+		opts = append(opts, common.WithLFS(config.Global.LFSThreshold))
+	}
+	if projectCache != nil {
+		opts = append(opts, common.WithProjectCache(projectCache))
+	}
+	return common.NewGit(&common.GogitReal{}, opts...)
+}
+
+// createProjectCache returns the cache used for reading resources without locking the project, or nil if the cache is disabled
+func createProjectCache() common.IProjectCache {
+	if !config.Global.ProjectCacheEnabled {
+		return nil
 	}
-	return configContext
+	log.Infof("Reading resources from cached project clones, which are refreshed after %s", config.Global.ProjectCacheMaxAge)
+	return common.NewProjectCache(&common.GogitReal{}, config.Global.ProjectCacheMaxAge)
 }
 
-func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader) handler.IStageManager {
-	var stageManager handler.IStageManager
+// getDefaultStageLayout returns the stage layout of projects for which no layout has been specified at creation
+func getDefaultStageLayout() string {
 	if config.Global.DirectoryStageStructure {
-		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git)
-	} else {
-		stageManager = handler.NewStageManager(git, credentialReader)
+		return models.StageLayoutDirectory
 	}
-	return stageManager
+	return models.StageLayoutBranch
 }
 
 func gracefulShutdown(ctx context.Context, wg *sync.WaitGroup, srv *http.Server) {
@@ -2,9 +2,17 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 )
 
+const (
+	// StageLayoutBranch represents each stage of a project as a branch of its Git repository
+	StageLayoutBranch = "branch"
+	// StageLayoutDirectory represents each stage of a project as a directory within the default branch of its Git repository
+	StageLayoutDirectory = "directory"
+)
+
 func validateEntityName(s string) error {
 	if strings.Contains(s, " ") {
 		return errors.New("name must not contain whitespaces")
@@ -17,3 +25,10 @@ func validateEntityName(s string) error {
 	}
 	return nil
 }
+
+func validateStageLayout(layout string) error {
+	if layout != "" && layout != StageLayoutBranch && layout != StageLayoutDirectory {
+		return fmt.Errorf("stage layout must be either '%s' or '%s'", StageLayoutBranch, StageLayoutDirectory)
+	}
+	return nil
+}
@@ -14,10 +14,16 @@ func (p Project) Validate() error {
 // swagger:model CreateProjectParams
 type CreateProjectParams struct {
 	Project
+	// StageLayout defines how the stages of the project are represented in its Git repository, i.e. either as branches ("branch") or as directories within the default branch ("directory").
+	// If not set, the default layout of the resource-service is used
+	StageLayout string `json:"stageLayout,omitempty"`
 }
 
 func (p CreateProjectParams) Validate() error {
-	return p.Project.Validate()
+	if err := p.Project.Validate(); err != nil {
+		return err
+	}
+	return validateStageLayout(p.StageLayout)
 }
 
 // UpdateProjectParams contains information about the project to be updated
@@ -25,11 +31,17 @@ func (p CreateProjectParams) Validate() error {
 // swagger:model UpdateProjectParams
 type UpdateProjectParams struct {
 	Project
+	// Migrate migrates the project to the directory stage layout. Deprecated: use StageLayout instead
 	Migrate bool `json:"migrate"`
+	// StageLayout is the stage layout the project should be migrated to, i.e. either "branch" or "directory"
+	StageLayout string `json:"stageLayout,omitempty"`
 }
 
 func (p UpdateProjectParams) Validate() error {
-	return p.Project.Validate()
+	if err := p.Project.Validate(); err != nil {
+		return err
+	}
+	return validateStageLayout(p.StageLayout)
 }
 
 // DeleteProjectPathParams contains path parameters for the delete project endpoint
@@ -44,7 +44,8 @@ func TestDeleteProjectPathParams_Validate(t *testing.T) {
 
 func TestUpdateProjectParams_Validate(t *testing.T) {
 	type fields struct {
-		Project Project
+		Project     Project
+		StageLayout string
 	}
 	tests := []struct {
 		name    string
@@ -69,11 +70,28 @@ func TestUpdateProjectParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "directory layout",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				StageLayout: StageLayoutDirectory,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid layout",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				StageLayout: "folder",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := UpdateProjectParams{
-				Project: tt.fields.Project,
+				Project:     tt.fields.Project,
+				StageLayout: tt.fields.StageLayout,
 			}
 			if err := p.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -84,7 +102,8 @@ func TestUpdateProjectParams_Validate(t *testing.T) {
 
 func TestCreateProjectParams_Validate(t *testing.T) {
 	type fields struct {
-		Project Project
+		Project     Project
+		StageLayout string
 	}
 	tests := []struct {
 		name    string
@@ -109,11 +128,28 @@ func TestCreateProjectParams_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "directory layout",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				StageLayout: StageLayoutDirectory,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid layout",
+			fields: fields{
+				Project:     Project{ProjectName: "my-project"},
+				StageLayout: "folder",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := CreateProjectParams{
-				Project: tt.fields.Project,
+				Project:     tt.fields.Project,
+				StageLayout: tt.fields.StageLayout,
 			}
 			if err := p.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/base64"
 	"github.com/keptn/keptn/resource-service/errors"
+	"io"
 	"strings"
 )
 
@@ -43,6 +44,8 @@ type ResourceContext struct {
 	Project
 	Stage   *Stage
 	Service *Service
+	// ExemptFromPullRequests is set for requests of the Keptn services whose changes are committed directly, even if the changes of the project are proposed via pull requests
+	ExemptFromPullRequests bool
 }
 
 func (rc ResourceContext) Validate() error {
@@ -143,6 +146,23 @@ func (p UpdateResourceParams) Validate() error {
 	return nil
 }
 
+// UpdateResourceContentParams contains the raw content of a resource, which is streamed from the request body
+type UpdateResourceContentParams struct {
+	ResourceContext
+	ResourceURI string
+	Content     io.Reader
+}
+
+func (p UpdateResourceContentParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	return nil
+}
+
 type CreateResourcesPayload struct {
 	Resources []Resource `json:"resources"`
 }
@@ -212,9 +232,37 @@ type GetResourceResponse struct {
 	Metadata Version `json:"metadata"`
 }
 
+// GetResourceContentResponse contains the raw content of a resource, which is streamed to the client. The caller is responsible for closing the content
+type GetResourceContentResponse struct {
+	Content  io.ReadCloser
+	Size     int64
+	Metadata Version
+}
+
 type WriteResourceResponse struct {
 	CommitID string  `json:"commitID"`
 	Metadata Version `json:"metadata"`
+
+	// Pull request proposing the change, if the pull request based write mode is enabled for the project
+	PullRequest *PullRequest `json:"pullRequest,omitempty"`
+}
+
+// PullRequest pull request
+//
+// swagger:model PullRequest
+type PullRequest struct {
+
+	// ID of the pull request at the Git hosting service
+	ID int64 `json:"id"`
+
+	// URL of the pull request
+	URL string `json:"url"`
+
+	// Branch containing the change
+	SourceBranch string `json:"sourceBranch"`
+
+	// Branch the change is merged into
+	TargetBranch string `json:"targetBranch"`
 }
 
 func validateResourceURI(uri string) error {
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"github.com/keptn/keptn/resource-service/errors"
+)
+
+// ResourceCommit contains the metadata of a commit that changed a resource
+//
+// swagger:model ResourceCommit
+type ResourceCommit struct {
+	// ID of the commit
+	CommitID string `json:"commitID"`
+
+	// Author of the commit
+	Author string `json:"author"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Time of the commit
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type GetResourceHistoryQuery struct {
+	NextPageKey string `json:"nextPageKey,omitempty" form:"nextPageKey"`
+	PageSize    int64  `json:"pageSize,omitempty" form:"pageSize"`
+}
+
+type GetResourceHistoryParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceHistoryQuery
+}
+
+func (p GetResourceHistoryParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetResourceHistoryResponse contains the commits that changed a resource, starting with the most recent one
+//
+// swagger:model GetResourceHistoryResponse
+type GetResourceHistoryResponse struct {
+
+	// Pointer to next page
+	NextPageKey string `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize float64 `json:"pageSize,omitempty"`
+
+	// commits
+	Commits []ResourceCommit `json:"commits"`
+
+	// Total number of commits
+	TotalCount float64 `json:"totalCount,omitempty"`
+}
+
+type GetResourceDiffQuery struct {
+	From string `json:"from" form:"from"`
+	To   string `json:"to,omitempty" form:"to"`
+}
+
+type GetResourceDiffParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceDiffQuery
+}
+
+func (p GetResourceDiffParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.From == "" {
+		return errors.ErrResourceRevisionMustNotBeEmpty
+	}
+	return nil
+}
+
+// GetResourceDiffResponse contains the changes of a resource between two revisions
+//
+// swagger:model GetResourceDiffResponse
+type GetResourceDiffResponse struct {
+	// Commit ID of the old revision
+	From string `json:"from"`
+
+	// Commit ID of the new revision
+	To string `json:"to"`
+
+	// Changes in unified diff format
+	Diff string `json:"diff"`
+}
+
+type RevertResourcePayload struct {
+	GitCommitID string `json:"gitCommitID"`
+}
+
+type RevertResourceParams struct {
+	ResourceContext
+	ResourceURI string
+	RevertResourcePayload
+}
+
+func (p RevertResourceParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.GitCommitID == "" {
+		return errors.ErrResourceRevisionMustNotBeEmpty
+	}
+	return nil
+}
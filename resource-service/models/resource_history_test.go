@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResourceDiffParams_Validate(t *testing.T) {
+	params := GetResourceDiffParams{
+		ResourceContext: ResourceContext{Project: Project{ProjectName: "my-project"}},
+		ResourceURI:     "resource.yaml",
+		GetResourceDiffQuery: GetResourceDiffQuery{
+			From: "commit-1",
+		},
+	}
+	require.Nil(t, params.Validate())
+
+	params.From = ""
+	require.ErrorIs(t, params.Validate(), errors.ErrResourceRevisionMustNotBeEmpty)
+
+	params.From = "commit-1"
+	params.ResourceURI = "../resource.yaml"
+	require.ErrorIs(t, params.Validate(), errors.ErrResourceInvalidResourceURI)
+}
+
+func TestRevertResourceParams_Validate(t *testing.T) {
+	params := RevertResourceParams{
+		ResourceContext: ResourceContext{Project: Project{ProjectName: "my-project"}},
+		ResourceURI:     "resource.yaml",
+		RevertResourcePayload: RevertResourcePayload{
+			GitCommitID: "commit-1",
+		},
+	}
+	require.Nil(t, params.Validate())
+
+	params.GitCommitID = ""
+	require.ErrorIs(t, params.Validate(), errors.ErrResourceRevisionMustNotBeEmpty)
+
+	params.GitCommitID = "commit-1"
+	params.Project.ProjectName = ""
+	require.NotNil(t, params.Validate())
+}
@@ -1,9 +1,15 @@
 package common
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
@@ -18,10 +24,19 @@ var ErrServiceNotFound = errors.New("service not found")
 const configServiceSvcDoesNotExistErrorMsg = "service does not exists" // [sic] this is what we get from the configuration service
 const resourceServiceSvcDoesNotExistErrorMsg = "service not found"
 
+const configStoreProjectPath = "/v1/project"
+
+// requestOriginHeader names the sender of a request to the configuration store. The resource-service commits the changes of the shipyard-controller directly,
+// even if the changes of the project are proposed via pull requests, hence it is only set for uploading the initial shipyard of a new project
+const requestOriginHeader = "X-Keptn-Request-Origin"
+const requestOrigin = "shipyard-controller"
+
 //go:generate moq -pkg common_mock -out ./fake/configurationstore_mock.go . ConfigurationStore
 type ConfigurationStore interface {
-	CreateProject(project apimodels.Project) error
-	UpdateProject(project apimodels.Project) error
+	// CreateProject creates the project with the given stage layout. If the stage layout is empty, the default stage layout of the configuration store is used
+	CreateProject(project apimodels.Project, stageLayout string) error
+	// UpdateProject updates the project and migrates it to the given stage layout. If the stage layout is empty, the project is not migrated
+	UpdateProject(project apimodels.Project, stageLayout string) error
 	CreateProjectShipyard(projectName string, resources []*apimodels.Resource) error
 	UpdateProjectResource(projectName string, resource *apimodels.Resource) error
 	DeleteProject(projectName string) error
@@ -32,20 +47,58 @@ type ConfigurationStore interface {
 	DeleteService(projectName string, stageName string, serviceName string) error
 }
 
+// configStoreProject is the project payload of the configuration store, which extends the project of the go-utils API by the stage layout
+type configStoreProject struct {
+	apimodels.Project
+	StageLayout string `json:"stageLayout,omitempty"`
+}
+
 type GitConfigurationStore struct {
+	baseURL     string
+	httpClient  *http.Client
 	projectAPI  *keptnapi.ProjectHandler
 	stagesAPI   *keptnapi.StageHandler
 	servicesAPI *keptnapi.ServiceHandler
 	resourceAPI *keptnapi.ResourceHandler
+	// shipyardAPI uploads the initial shipyard of a new project, which cannot be used before its shipyard is committed
+	shipyardAPI *keptnapi.ResourceHandler
 }
 
 func NewGitConfigurationStore(configurationServiceEndpoint string) *GitConfigurationStore {
+	baseURL := strings.TrimSuffix(configurationServiceEndpoint, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		baseURL = "http://" + baseURL
+	}
 	return &GitConfigurationStore{
+		baseURL:     baseURL,
+		httpClient:  &http.Client{Timeout: 5 * time.Minute},
 		projectAPI:  keptnapi.NewProjectHandler(configurationServiceEndpoint),
 		stagesAPI:   keptnapi.NewStageHandler(configurationServiceEndpoint),
 		servicesAPI: keptnapi.NewServiceHandler(configurationServiceEndpoint),
 		resourceAPI: keptnapi.NewResourceHandler(configurationServiceEndpoint),
+		shipyardAPI: newOriginResourceHandler(configurationServiceEndpoint),
+	}
+}
+
+// originTransport sets the origin of all requests sent by the wrapped transport
+type originTransport struct {
+	next http.RoundTripper
+}
+
+func (t originTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(requestOriginHeader, requestOrigin)
+	return t.next.RoundTrip(req)
+}
+
+func newOriginResourceHandler(configurationServiceEndpoint string) *keptnapi.ResourceHandler {
+	resourceHandler := keptnapi.NewResourceHandler(configurationServiceEndpoint)
+	next := resourceHandler.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
 	}
+	resourceHandler.HTTPClient.Transport = originTransport{next: next}
+	return resourceHandler
 }
 
 func (g GitConfigurationStore) GetProjectResource(projectName string, resourceURI string) (*apimodels.Resource, error) {
@@ -56,21 +109,58 @@ func (g GitConfigurationStore) GetStageResource(projectName, stageName, resource
 	return g.resourceAPI.GetStageResource(projectName, stageName, resourceURI)
 }
 
-func (g GitConfigurationStore) CreateProject(project apimodels.Project) error {
-	if _, err := g.projectAPI.CreateProject(project); err != nil {
+func (g GitConfigurationStore) CreateProject(project apimodels.Project, stageLayout string) error {
+	if err := g.sendProject(http.MethodPost, configStoreProjectPath, configStoreProject{Project: project, StageLayout: stageLayout}); err != nil {
 		return g.buildErrResponse(err)
 	}
 	return nil
 }
 
-func (g GitConfigurationStore) UpdateProject(project apimodels.Project) error {
-	if _, err := g.projectAPI.UpdateConfigurationServiceProject(project); err != nil {
+func (g GitConfigurationStore) UpdateProject(project apimodels.Project, stageLayout string) error {
+	if err := g.sendProject(http.MethodPut, configStoreProjectPath+"/"+url.PathEscape(project.ProjectName), configStoreProject{Project: project, StageLayout: stageLayout}); err != nil {
 		return g.buildErrResponse(err)
 	}
 
 	return nil
 }
 
+// sendProject sends the project to the configuration store. The go-utils API cannot be used for this, since its project does not contain the stage layout
+func (g GitConfigurationStore) sendProject(method string, path string, project configStoreProject) *apimodels.Error {
+	payload, err := json.Marshal(project)
+	if err != nil {
+		return buildConfigStoreError(http.StatusInternalServerError, err.Error())
+	}
+	req, err := http.NewRequest(method, g.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return buildConfigStoreError(http.StatusInternalServerError, err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return buildConfigStoreError(http.StatusInternalServerError, err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return buildConfigStoreError(resp.StatusCode, err.Error())
+	}
+	apiErr := &apimodels.Error{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == nil {
+		return buildConfigStoreError(resp.StatusCode, fmt.Sprintf("received unexpected response: %d %s", resp.StatusCode, string(body)))
+	}
+	apiErr.Code = int64(resp.StatusCode)
+	return apiErr
+}
+
+func buildConfigStoreError(code int, message string) *apimodels.Error {
+	return &apimodels.Error{Code: int64(code), Message: &message}
+}
+
 func (g GitConfigurationStore) DeleteProject(projectName string) error {
 	p := apimodels.Project{
 		ProjectName: projectName,
@@ -82,7 +172,7 @@ func (g GitConfigurationStore) DeleteProject(projectName string) error {
 }
 
 func (g GitConfigurationStore) CreateProjectShipyard(projectName string, resources []*apimodels.Resource) error {
-	if _, err := g.resourceAPI.CreateProjectResources(projectName, resources); err != nil {
+	if _, err := g.shipyardAPI.CreateProjectResources(projectName, resources); err != nil {
 		return err
 	}
 	return nil
@@ -17,7 +17,23 @@ func TestConfigurationStore(t *testing.T) {
 		defer ts.Close()
 
 		instance := NewGitConfigurationStore(ts.URL)
-		err := instance.CreateProject(apimodels.Project{})
+		err := instance.CreateProject(apimodels.Project{}, "")
+		assert.Nil(t, err)
+	})
+
+	t.Run("TestCreateProject_WithStageLayout", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/v1/project", r.URL.Path)
+			payload := map[string]interface{}{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, "my-project", payload["projectName"])
+			assert.Equal(t, StageLayoutDirectory, payload["stageLayout"])
+		}))
+		defer ts.Close()
+
+		instance := NewGitConfigurationStore(ts.URL)
+		err := instance.CreateProject(apimodels.Project{ProjectName: "my-project"}, StageLayoutDirectory)
 		assert.Nil(t, err)
 	})
 
@@ -28,7 +44,7 @@ func TestConfigurationStore(t *testing.T) {
 		defer ts.Close()
 
 		instance := NewGitConfigurationStore(ts.URL)
-		err := instance.CreateProject(apimodels.Project{})
+		err := instance.CreateProject(apimodels.Project{}, "")
 		assert.NotNil(t, err)
 	})
 
@@ -37,10 +53,37 @@ func TestConfigurationStore(t *testing.T) {
 		defer ts.Close()
 
 		instance := NewGitConfigurationStore(ts.URL)
-		err := instance.UpdateProject(apimodels.Project{})
+		err := instance.UpdateProject(apimodels.Project{}, "")
 		assert.Nil(t, err)
 	})
 
+	t.Run("TestUpdateProject_WithStageLayout", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/v1/project/my-project", r.URL.Path)
+			payload := map[string]interface{}{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, StageLayoutBranch, payload["stageLayout"])
+		}))
+		defer ts.Close()
+
+		instance := NewGitConfigurationStore(ts.URL)
+		err := instance.UpdateProject(apimodels.Project{ProjectName: "my-project"}, StageLayoutBranch)
+		assert.Nil(t, err)
+	})
+
+	t.Run("TestUpdateProject_APIReturnsInvalidToken", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusFailedDependency)
+			_, _ = w.Write([]byte(`{"code": 424, "message": "invalid token"}`))
+		}))
+		defer ts.Close()
+
+		instance := NewGitConfigurationStore(ts.URL)
+		err := instance.UpdateProject(apimodels.Project{ProjectName: "my-project"}, "")
+		assert.ErrorIs(t, err, ErrConfigStoreInvalidToken)
+	})
+
 	t.Run("TestUpdateProject_APIReturnsInternalServerError", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -48,7 +91,7 @@ func TestConfigurationStore(t *testing.T) {
 		defer ts.Close()
 
 		instance := NewGitConfigurationStore(ts.URL)
-		err := instance.UpdateProject(apimodels.Project{})
+		err := instance.UpdateProject(apimodels.Project{}, "")
 		assert.NotNil(t, err)
 	})
 
@@ -59,7 +102,7 @@ func TestConfigurationStore(t *testing.T) {
 		defer ts.Close()
 
 		instance := NewGitConfigurationStore(ts.URL)
-		err := instance.UpdateProject(apimodels.Project{})
+		err := instance.UpdateProject(apimodels.Project{}, "")
 		assert.NotNil(t, err)
 	})
 
@@ -148,6 +191,8 @@ func TestConfigurationStore(t *testing.T) {
 
 	t.Run("TestCreateProjectShipyard_Success", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the initial shipyard is committed directly, even if the changes of the project are proposed via pull requests
+			assert.Equal(t, "shipyard-controller", r.Header.Get("X-Keptn-Request-Origin"))
 			io.WriteString(w, "{}")
 		}))
 		defer ts.Close()
@@ -169,6 +214,8 @@ func TestConfigurationStore(t *testing.T) {
 
 	t.Run("TestUpdateProjectResource_Success", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// updates of the shipyard are proposed via pull requests, if enabled for the project
+			assert.Empty(t, r.Header.Get("X-Keptn-Request-Origin"))
 			io.WriteString(w, "{}")
 		}))
 		defer ts.Close()
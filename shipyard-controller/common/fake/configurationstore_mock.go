@@ -19,7 +19,7 @@ var _ common.ConfigurationStore = &ConfigurationStoreMock{}
 //
 // 		// make and configure a mocked common.ConfigurationStore
 // 		mockedConfigurationStore := &ConfigurationStoreMock{
-// 			CreateProjectFunc: func(project apimodels.Project) error {
+// 			CreateProjectFunc: func(project apimodels.Project, stageLayout string) error {
 // 				panic("mock out the CreateProject method")
 // 			},
 // 			CreateProjectShipyardFunc: func(projectName string, resources []*apimodels.Resource) error {
@@ -43,7 +43,7 @@ var _ common.ConfigurationStore = &ConfigurationStoreMock{}
 // 			GetStageResourceFunc: func(projectName string, stageName string, resourceURI string) (*apimodels.Resource, error) {
 // 				panic("mock out the GetStageResource method")
 // 			},
-// 			UpdateProjectFunc: func(project apimodels.Project) error {
+// 			UpdateProjectFunc: func(project apimodels.Project, stageLayout string) error {
 // 				panic("mock out the UpdateProject method")
 // 			},
 // 			UpdateProjectResourceFunc: func(projectName string, resource *apimodels.Resource) error {
@@ -57,7 +57,7 @@ var _ common.ConfigurationStore = &ConfigurationStoreMock{}
 // 	}
 type ConfigurationStoreMock struct {
 	// CreateProjectFunc mocks the CreateProject method.
-	CreateProjectFunc func(project apimodels.Project) error
+	CreateProjectFunc func(project apimodels.Project, stageLayout string) error
 
 	// CreateProjectShipyardFunc mocks the CreateProjectShipyard method.
 	CreateProjectShipyardFunc func(projectName string, resources []*apimodels.Resource) error
@@ -81,7 +81,7 @@ type ConfigurationStoreMock struct {
 	GetStageResourceFunc func(projectName string, stageName string, resourceURI string) (*apimodels.Resource, error)
 
 	// UpdateProjectFunc mocks the UpdateProject method.
-	UpdateProjectFunc func(project apimodels.Project) error
+	UpdateProjectFunc func(project apimodels.Project, stageLayout string) error
 
 	// UpdateProjectResourceFunc mocks the UpdateProjectResource method.
 	UpdateProjectResourceFunc func(projectName string, resource *apimodels.Resource) error
@@ -92,6 +92,8 @@ type ConfigurationStoreMock struct {
 		CreateProject []struct {
 			// Project is the project argument value.
 			Project apimodels.Project
+			// StageLayout is the stageLayout argument value.
+			StageLayout string
 		}
 		// CreateProjectShipyard holds details about calls to the CreateProjectShipyard method.
 		CreateProjectShipyard []struct {
@@ -150,6 +152,8 @@ type ConfigurationStoreMock struct {
 		UpdateProject []struct {
 			// Project is the project argument value.
 			Project apimodels.Project
+			// StageLayout is the stageLayout argument value.
+			StageLayout string
 		}
 		// UpdateProjectResource holds details about calls to the UpdateProjectResource method.
 		UpdateProjectResource []struct {
@@ -172,29 +176,33 @@ type ConfigurationStoreMock struct {
 }
 
 // CreateProject calls CreateProjectFunc.
-func (mock *ConfigurationStoreMock) CreateProject(project apimodels.Project) error {
+func (mock *ConfigurationStoreMock) CreateProject(project apimodels.Project, stageLayout string) error {
 	if mock.CreateProjectFunc == nil {
 		panic("ConfigurationStoreMock.CreateProjectFunc: method is nil but ConfigurationStore.CreateProject was just called")
 	}
 	callInfo := struct {
-		Project apimodels.Project
+		Project     apimodels.Project
+		StageLayout string
 	}{
-		Project: project,
+		Project:     project,
+		StageLayout: stageLayout,
 	}
 	mock.lockCreateProject.Lock()
 	mock.calls.CreateProject = append(mock.calls.CreateProject, callInfo)
 	mock.lockCreateProject.Unlock()
-	return mock.CreateProjectFunc(project)
+	return mock.CreateProjectFunc(project, stageLayout)
 }
 
 // CreateProjectCalls gets all the calls that were made to CreateProject.
 // Check the length with:
 //     len(mockedConfigurationStore.CreateProjectCalls())
 func (mock *ConfigurationStoreMock) CreateProjectCalls() []struct {
-	Project apimodels.Project
+	Project     apimodels.Project
+	StageLayout string
 } {
 	var calls []struct {
-		Project apimodels.Project
+		Project     apimodels.Project
+		StageLayout string
 	}
 	mock.lockCreateProject.RLock()
 	calls = mock.calls.CreateProject
@@ -456,29 +464,33 @@ func (mock *ConfigurationStoreMock) GetStageResourceCalls() []struct {
 }
 
 // UpdateProject calls UpdateProjectFunc.
-func (mock *ConfigurationStoreMock) UpdateProject(project apimodels.Project) error {
+func (mock *ConfigurationStoreMock) UpdateProject(project apimodels.Project, stageLayout string) error {
 	if mock.UpdateProjectFunc == nil {
 		panic("ConfigurationStoreMock.UpdateProjectFunc: method is nil but ConfigurationStore.UpdateProject was just called")
 	}
 	callInfo := struct {
-		Project apimodels.Project
+		Project     apimodels.Project
+		StageLayout string
 	}{
-		Project: project,
+		Project:     project,
+		StageLayout: stageLayout,
 	}
 	mock.lockUpdateProject.Lock()
 	mock.calls.UpdateProject = append(mock.calls.UpdateProject, callInfo)
 	mock.lockUpdateProject.Unlock()
-	return mock.UpdateProjectFunc(project)
+	return mock.UpdateProjectFunc(project, stageLayout)
 }
 
 // UpdateProjectCalls gets all the calls that were made to UpdateProject.
 // Check the length with:
 //     len(mockedConfigurationStore.UpdateProjectCalls())
 func (mock *ConfigurationStoreMock) UpdateProjectCalls() []struct {
-	Project apimodels.Project
+	Project     apimodels.Project
+	StageLayout string
 } {
 	var calls []struct {
-		Project apimodels.Project
+		Project     apimodels.Project
+		StageLayout string
 	}
 	mock.lockUpdateProject.RLock()
 	calls = mock.calls.UpdateProject
@@ -23,6 +23,12 @@ const keptnSpecVersionEnvVar = "KEPTN_SPEC_VERSION"
 const shipyardVersionPrefix = "spec.keptn.sh/"
 const shipyardSpecVersionPrefix = "0.2"
 
+// StageLayoutBranch represents each stage of a project as a branch of its Git repository
+const StageLayoutBranch = "branch"
+
+// StageLayoutDirectory represents each stage of a project as a directory within the default branch of its Git repository
+const StageLayoutDirectory = "directory"
+
 // GetKeptnNamespace godoc
 func GetKeptnNamespace() string {
 	ns := os.Getenv("POD_NAMESPACE")
@@ -78,6 +84,14 @@ func ValidateGitRemoteURL(gitUrl string) error {
 	return nil
 }
 
+// ValidateStageLayout checks whether the given stage layout is supported by the resource-service. An empty stage layout is valid
+func ValidateStageLayout(stageLayout string) error {
+	if stageLayout != "" && stageLayout != StageLayoutBranch && stageLayout != StageLayoutDirectory {
+		return fmt.Errorf("stage layout must be either '%s' or '%s'", StageLayoutBranch, StageLayoutDirectory)
+	}
+	return nil
+}
+
 // ValidateShipyardStages godoc
 func ValidateShipyardStages(shipyard *keptnv2.Shipyard) error {
 	// A shipyard must have at least one stage
@@ -152,6 +152,13 @@ func TestValidateShipyardStages(t *testing.T) {
 	}
 }
 
+func TestValidateStageLayout(t *testing.T) {
+	require.Nil(t, ValidateStageLayout(""))
+	require.Nil(t, ValidateStageLayout(StageLayoutBranch))
+	require.Nil(t, ValidateStageLayout(StageLayoutDirectory))
+	require.NotNil(t, ValidateStageLayout("folder"))
+}
+
 func TestValidateGitRemoteURL(t *testing.T) {
 	type args struct {
 		shipyard *keptnv2.Shipyard
@@ -25,6 +25,10 @@ type EnvConfig struct {
 	EventDispatchIntervalSec int `envconfig:"EVENT_DISPATCH_INTERVAL_SEC" default:"10"`
 	// SequenceDispatchIntervalSec is the interval with which the sequence dispatcher tries to dispatch sequences
 	SequenceDispatchIntervalSec string `envconfig:"SEQUENCE_DISPATCH_INTERVAL_SEC" default:"10s"`
+	// SequencePriorityAgingInterval is the interval after which the priority of a queued sequence is increased by one. This prevents sequences with a low priority from waiting forever
+	SequencePriorityAgingInterval string `envconfig:"SEQUENCE_PRIORITY_AGING_INTERVAL" default:"5m"`
+	// SchedulerInterval is the interval with which the scheduler checks whether sequences need to be triggered according to their schedules
+	SchedulerInterval string `envconfig:"SCHEDULER_INTERVAL" default:"30s"`
 	// TaskStartedWaitDuration is the time the sequence watcher waits before timing out a sequence if there is no .started event for a sent task.triggered event
 	TaskStartedWaitDuration string `envconfig:"TASK_STARTED_WAIT_DURATION" default:"10m"`
 	// UniformIntegrationTTL is the time after which a uniform integration gets removed from the database if it did not receive a heartbeat signal
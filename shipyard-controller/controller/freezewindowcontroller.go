@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type FreezeWindowController struct {
+	FreezeWindowHandler handler.IFreezeWindowHandler
+}
+
+func NewFreezeWindowController(freezeWindowHandler handler.IFreezeWindowHandler) Controller {
+	return &FreezeWindowController{FreezeWindowHandler: freezeWindowHandler}
+}
+
+func (controller FreezeWindowController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/project/:project/freezewindow", controller.FreezeWindowHandler.GetFreezeWindows)
+	apiGroup.POST("/project/:project/freezewindow", controller.FreezeWindowHandler.CreateFreezeWindow)
+	apiGroup.GET("/project/:project/freezewindow/:freezeWindowID", controller.FreezeWindowHandler.GetFreezeWindow)
+	apiGroup.PUT("/project/:project/freezewindow/:freezeWindowID", controller.FreezeWindowHandler.UpdateFreezeWindow)
+	apiGroup.DELETE("/project/:project/freezewindow/:freezeWindowID", controller.FreezeWindowHandler.DeleteFreezeWindow)
+}
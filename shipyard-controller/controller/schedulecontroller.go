@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type ScheduleController struct {
+	ScheduleHandler handler.IScheduleHandler
+}
+
+func NewScheduleController(scheduleHandler handler.IScheduleHandler) Controller {
+	return &ScheduleController{ScheduleHandler: scheduleHandler}
+}
+
+func (controller ScheduleController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/project/:project/schedule", controller.ScheduleHandler.GetSchedules)
+	apiGroup.POST("/project/:project/schedule", controller.ScheduleHandler.CreateSchedule)
+	apiGroup.GET("/project/:project/schedule/:scheduleID", controller.ScheduleHandler.GetSchedule)
+	apiGroup.PUT("/project/:project/schedule/:scheduleID", controller.ScheduleHandler.UpdateSchedule)
+	apiGroup.DELETE("/project/:project/schedule/:scheduleID", controller.ScheduleHandler.DeleteSchedule)
+}
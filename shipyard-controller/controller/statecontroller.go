@@ -16,4 +16,5 @@ func NewStateController(sequenceStateHandler handler.IStateHandler) Controller {
 func (controller StateController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.GET("/sequence/:project", controller.SequenceStateHandler.GetSequenceState)
 	apiGroup.POST("/sequence/:project/:keptnContext/control", controller.SequenceStateHandler.ControlSequenceState)
+	apiGroup.GET("/sequence/:project/:keptnContext/history", controller.SequenceStateHandler.GetSequenceHistory)
 }
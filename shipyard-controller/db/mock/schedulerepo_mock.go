@@ -0,0 +1,304 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package db_mock
+
+import (
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+	"time"
+)
+
+// ScheduleRepoMock is a mock implementation of db.ScheduleRepo.
+//
+// 	func TestSomethingThatUsesScheduleRepo(t *testing.T) {
+//
+// 		// make and configure a mocked db.ScheduleRepo
+// 		mockedScheduleRepo := &ScheduleRepoMock{
+// 			CreateScheduleFunc: func(schedule models.Schedule) error {
+// 				panic("mock out the CreateSchedule method")
+// 			},
+// 			DeleteScheduleFunc: func(project string, id string) error {
+// 				panic("mock out the DeleteSchedule method")
+// 			},
+// 			DeleteSchedulesFunc: func(project string) error {
+// 				panic("mock out the DeleteSchedules method")
+// 			},
+// 			GetSchedulesFunc: func(params models.GetScheduleParams) ([]models.Schedule, error) {
+// 				panic("mock out the GetSchedules method")
+// 			},
+// 			UpdateLastTriggeredFunc: func(id string, lastTriggered time.Time, keptnContext string) error {
+// 				panic("mock out the UpdateLastTriggered method")
+// 			},
+// 			UpdateScheduleFunc: func(schedule models.Schedule) error {
+// 				panic("mock out the UpdateSchedule method")
+// 			},
+// 		}
+//
+// 		// use mockedScheduleRepo in code that requires db.ScheduleRepo
+// 		// and then make assertions.
+//
+// 	}
+type ScheduleRepoMock struct {
+	// CreateScheduleFunc mocks the CreateSchedule method.
+	CreateScheduleFunc func(schedule models.Schedule) error
+
+	// DeleteScheduleFunc mocks the DeleteSchedule method.
+	DeleteScheduleFunc func(project string, id string) error
+
+	// DeleteSchedulesFunc mocks the DeleteSchedules method.
+	DeleteSchedulesFunc func(project string) error
+
+	// GetSchedulesFunc mocks the GetSchedules method.
+	GetSchedulesFunc func(params models.GetScheduleParams) ([]models.Schedule, error)
+
+	// UpdateLastTriggeredFunc mocks the UpdateLastTriggered method.
+	UpdateLastTriggeredFunc func(id string, lastTriggered time.Time, keptnContext string) error
+
+	// UpdateScheduleFunc mocks the UpdateSchedule method.
+	UpdateScheduleFunc func(schedule models.Schedule) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateSchedule holds details about calls to the CreateSchedule method.
+		CreateSchedule []struct {
+			// Schedule is the schedule argument value.
+			Schedule models.Schedule
+		}
+		// DeleteSchedule holds details about calls to the DeleteSchedule method.
+		DeleteSchedule []struct {
+			// Project is the project argument value.
+			Project string
+			// ID is the id argument value.
+			ID string
+		}
+		// DeleteSchedules holds details about calls to the DeleteSchedules method.
+		DeleteSchedules []struct {
+			// Project is the project argument value.
+			Project string
+		}
+		// GetSchedules holds details about calls to the GetSchedules method.
+		GetSchedules []struct {
+			// Params is the params argument value.
+			Params models.GetScheduleParams
+		}
+		// UpdateLastTriggered holds details about calls to the UpdateLastTriggered method.
+		UpdateLastTriggered []struct {
+			// ID is the id argument value.
+			ID string
+			// LastTriggered is the lastTriggered argument value.
+			LastTriggered time.Time
+			// KeptnContext is the keptnContext argument value.
+			KeptnContext string
+		}
+		// UpdateSchedule holds details about calls to the UpdateSchedule method.
+		UpdateSchedule []struct {
+			// Schedule is the schedule argument value.
+			Schedule models.Schedule
+		}
+	}
+	lockCreateSchedule      sync.RWMutex
+	lockDeleteSchedule      sync.RWMutex
+	lockDeleteSchedules     sync.RWMutex
+	lockGetSchedules        sync.RWMutex
+	lockUpdateLastTriggered sync.RWMutex
+	lockUpdateSchedule      sync.RWMutex
+}
+
+// CreateSchedule calls CreateScheduleFunc.
+func (mock *ScheduleRepoMock) CreateSchedule(schedule models.Schedule) error {
+	if mock.CreateScheduleFunc == nil {
+		panic("ScheduleRepoMock.CreateScheduleFunc: method is nil but ScheduleRepo.CreateSchedule was just called")
+	}
+	callInfo := struct {
+		Schedule models.Schedule
+	}{
+		Schedule: schedule,
+	}
+	mock.lockCreateSchedule.Lock()
+	mock.calls.CreateSchedule = append(mock.calls.CreateSchedule, callInfo)
+	mock.lockCreateSchedule.Unlock()
+	return mock.CreateScheduleFunc(schedule)
+}
+
+// CreateScheduleCalls gets all the calls that were made to CreateSchedule.
+// Check the length with:
+//     len(mockedScheduleRepo.CreateScheduleCalls())
+func (mock *ScheduleRepoMock) CreateScheduleCalls() []struct {
+	Schedule models.Schedule
+} {
+	var calls []struct {
+		Schedule models.Schedule
+	}
+	mock.lockCreateSchedule.RLock()
+	calls = mock.calls.CreateSchedule
+	mock.lockCreateSchedule.RUnlock()
+	return calls
+}
+
+// DeleteSchedule calls DeleteScheduleFunc.
+func (mock *ScheduleRepoMock) DeleteSchedule(project string, id string) error {
+	if mock.DeleteScheduleFunc == nil {
+		panic("ScheduleRepoMock.DeleteScheduleFunc: method is nil but ScheduleRepo.DeleteSchedule was just called")
+	}
+	callInfo := struct {
+		Project string
+		ID      string
+	}{
+		Project: project,
+		ID:      id,
+	}
+	mock.lockDeleteSchedule.Lock()
+	mock.calls.DeleteSchedule = append(mock.calls.DeleteSchedule, callInfo)
+	mock.lockDeleteSchedule.Unlock()
+	return mock.DeleteScheduleFunc(project, id)
+}
+
+// DeleteScheduleCalls gets all the calls that were made to DeleteSchedule.
+// Check the length with:
+//     len(mockedScheduleRepo.DeleteScheduleCalls())
+func (mock *ScheduleRepoMock) DeleteScheduleCalls() []struct {
+	Project string
+	ID      string
+} {
+	var calls []struct {
+		Project string
+		ID      string
+	}
+	mock.lockDeleteSchedule.RLock()
+	calls = mock.calls.DeleteSchedule
+	mock.lockDeleteSchedule.RUnlock()
+	return calls
+}
+
+// DeleteSchedules calls DeleteSchedulesFunc.
+func (mock *ScheduleRepoMock) DeleteSchedules(project string) error {
+	if mock.DeleteSchedulesFunc == nil {
+		panic("ScheduleRepoMock.DeleteSchedulesFunc: method is nil but ScheduleRepo.DeleteSchedules was just called")
+	}
+	callInfo := struct {
+		Project string
+	}{
+		Project: project,
+	}
+	mock.lockDeleteSchedules.Lock()
+	mock.calls.DeleteSchedules = append(mock.calls.DeleteSchedules, callInfo)
+	mock.lockDeleteSchedules.Unlock()
+	return mock.DeleteSchedulesFunc(project)
+}
+
+// DeleteSchedulesCalls gets all the calls that were made to DeleteSchedules.
+// Check the length with:
+//     len(mockedScheduleRepo.DeleteSchedulesCalls())
+func (mock *ScheduleRepoMock) DeleteSchedulesCalls() []struct {
+	Project string
+} {
+	var calls []struct {
+		Project string
+	}
+	mock.lockDeleteSchedules.RLock()
+	calls = mock.calls.DeleteSchedules
+	mock.lockDeleteSchedules.RUnlock()
+	return calls
+}
+
+// GetSchedules calls GetSchedulesFunc.
+func (mock *ScheduleRepoMock) GetSchedules(params models.GetScheduleParams) ([]models.Schedule, error) {
+	if mock.GetSchedulesFunc == nil {
+		panic("ScheduleRepoMock.GetSchedulesFunc: method is nil but ScheduleRepo.GetSchedules was just called")
+	}
+	callInfo := struct {
+		Params models.GetScheduleParams
+	}{
+		Params: params,
+	}
+	mock.lockGetSchedules.Lock()
+	mock.calls.GetSchedules = append(mock.calls.GetSchedules, callInfo)
+	mock.lockGetSchedules.Unlock()
+	return mock.GetSchedulesFunc(params)
+}
+
+// GetSchedulesCalls gets all the calls that were made to GetSchedules.
+// Check the length with:
+//     len(mockedScheduleRepo.GetSchedulesCalls())
+func (mock *ScheduleRepoMock) GetSchedulesCalls() []struct {
+	Params models.GetScheduleParams
+} {
+	var calls []struct {
+		Params models.GetScheduleParams
+	}
+	mock.lockGetSchedules.RLock()
+	calls = mock.calls.GetSchedules
+	mock.lockGetSchedules.RUnlock()
+	return calls
+}
+
+// UpdateLastTriggered calls UpdateLastTriggeredFunc.
+func (mock *ScheduleRepoMock) UpdateLastTriggered(id string, lastTriggered time.Time, keptnContext string) error {
+	if mock.UpdateLastTriggeredFunc == nil {
+		panic("ScheduleRepoMock.UpdateLastTriggeredFunc: method is nil but ScheduleRepo.UpdateLastTriggered was just called")
+	}
+	callInfo := struct {
+		ID            string
+		LastTriggered time.Time
+		KeptnContext  string
+	}{
+		ID:            id,
+		LastTriggered: lastTriggered,
+		KeptnContext:  keptnContext,
+	}
+	mock.lockUpdateLastTriggered.Lock()
+	mock.calls.UpdateLastTriggered = append(mock.calls.UpdateLastTriggered, callInfo)
+	mock.lockUpdateLastTriggered.Unlock()
+	return mock.UpdateLastTriggeredFunc(id, lastTriggered, keptnContext)
+}
+
+// UpdateLastTriggeredCalls gets all the calls that were made to UpdateLastTriggered.
+// Check the length with:
+//     len(mockedScheduleRepo.UpdateLastTriggeredCalls())
+func (mock *ScheduleRepoMock) UpdateLastTriggeredCalls() []struct {
+	ID            string
+	LastTriggered time.Time
+	KeptnContext  string
+} {
+	var calls []struct {
+		ID            string
+		LastTriggered time.Time
+		KeptnContext  string
+	}
+	mock.lockUpdateLastTriggered.RLock()
+	calls = mock.calls.UpdateLastTriggered
+	mock.lockUpdateLastTriggered.RUnlock()
+	return calls
+}
+
+// UpdateSchedule calls UpdateScheduleFunc.
+func (mock *ScheduleRepoMock) UpdateSchedule(schedule models.Schedule) error {
+	if mock.UpdateScheduleFunc == nil {
+		panic("ScheduleRepoMock.UpdateScheduleFunc: method is nil but ScheduleRepo.UpdateSchedule was just called")
+	}
+	callInfo := struct {
+		Schedule models.Schedule
+	}{
+		Schedule: schedule,
+	}
+	mock.lockUpdateSchedule.Lock()
+	mock.calls.UpdateSchedule = append(mock.calls.UpdateSchedule, callInfo)
+	mock.lockUpdateSchedule.Unlock()
+	return mock.UpdateScheduleFunc(schedule)
+}
+
+// UpdateScheduleCalls gets all the calls that were made to UpdateSchedule.
+// Check the length with:
+//     len(mockedScheduleRepo.UpdateScheduleCalls())
+func (mock *ScheduleRepoMock) UpdateScheduleCalls() []struct {
+	Schedule models.Schedule
+} {
+	var calls []struct {
+		Schedule models.Schedule
+	}
+	mock.lockUpdateSchedule.RLock()
+	calls = mock.calls.UpdateSchedule
+	mock.lockUpdateSchedule.RUnlock()
+	return calls
+}
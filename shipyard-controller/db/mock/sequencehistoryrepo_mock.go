@@ -0,0 +1,162 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package db_mock
+
+import (
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+)
+
+// SequenceHistoryRepoMock is a mock implementation of db.SequenceHistoryRepo.
+//
+// 	func TestSomethingThatUsesSequenceHistoryRepo(t *testing.T) {
+//
+// 		// make and configure a mocked db.SequenceHistoryRepo
+// 		mockedSequenceHistoryRepo := &SequenceHistoryRepoMock{
+// 			AppendTransitionFunc: func(transition models.SequenceStateTransition) error {
+// 				panic("mock out the AppendTransition method")
+// 			},
+// 			DeleteTransitionsFunc: func(project string) error {
+// 				panic("mock out the DeleteTransitions method")
+// 			},
+// 			GetTransitionsFunc: func(project string, keptnContext string) ([]models.SequenceStateTransition, error) {
+// 				panic("mock out the GetTransitions method")
+// 			},
+// 		}
+//
+// 		// use mockedSequenceHistoryRepo in code that requires db.SequenceHistoryRepo
+// 		// and then make assertions.
+//
+// 	}
+type SequenceHistoryRepoMock struct {
+	// AppendTransitionFunc mocks the AppendTransition method.
+	AppendTransitionFunc func(transition models.SequenceStateTransition) error
+
+	// DeleteTransitionsFunc mocks the DeleteTransitions method.
+	DeleteTransitionsFunc func(project string) error
+
+	// GetTransitionsFunc mocks the GetTransitions method.
+	GetTransitionsFunc func(project string, keptnContext string) ([]models.SequenceStateTransition, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AppendTransition holds details about calls to the AppendTransition method.
+		AppendTransition []struct {
+			// Transition is the transition argument value.
+			Transition models.SequenceStateTransition
+		}
+		// DeleteTransitions holds details about calls to the DeleteTransitions method.
+		DeleteTransitions []struct {
+			// Project is the project argument value.
+			Project string
+		}
+		// GetTransitions holds details about calls to the GetTransitions method.
+		GetTransitions []struct {
+			// Project is the project argument value.
+			Project string
+			// KeptnContext is the keptnContext argument value.
+			KeptnContext string
+		}
+	}
+	lockAppendTransition  sync.RWMutex
+	lockDeleteTransitions sync.RWMutex
+	lockGetTransitions    sync.RWMutex
+}
+
+// AppendTransition calls AppendTransitionFunc.
+func (mock *SequenceHistoryRepoMock) AppendTransition(transition models.SequenceStateTransition) error {
+	if mock.AppendTransitionFunc == nil {
+		panic("SequenceHistoryRepoMock.AppendTransitionFunc: method is nil but SequenceHistoryRepo.AppendTransition was just called")
+	}
+	callInfo := struct {
+		Transition models.SequenceStateTransition
+	}{
+		Transition: transition,
+	}
+	mock.lockAppendTransition.Lock()
+	mock.calls.AppendTransition = append(mock.calls.AppendTransition, callInfo)
+	mock.lockAppendTransition.Unlock()
+	return mock.AppendTransitionFunc(transition)
+}
+
+// AppendTransitionCalls gets all the calls that were made to AppendTransition.
+// Check the length with:
+//     len(mockedSequenceHistoryRepo.AppendTransitionCalls())
+func (mock *SequenceHistoryRepoMock) AppendTransitionCalls() []struct {
+	Transition models.SequenceStateTransition
+} {
+	var calls []struct {
+		Transition models.SequenceStateTransition
+	}
+	mock.lockAppendTransition.RLock()
+	calls = mock.calls.AppendTransition
+	mock.lockAppendTransition.RUnlock()
+	return calls
+}
+
+// DeleteTransitions calls DeleteTransitionsFunc.
+func (mock *SequenceHistoryRepoMock) DeleteTransitions(project string) error {
+	if mock.DeleteTransitionsFunc == nil {
+		panic("SequenceHistoryRepoMock.DeleteTransitionsFunc: method is nil but SequenceHistoryRepo.DeleteTransitions was just called")
+	}
+	callInfo := struct {
+		Project string
+	}{
+		Project: project,
+	}
+	mock.lockDeleteTransitions.Lock()
+	mock.calls.DeleteTransitions = append(mock.calls.DeleteTransitions, callInfo)
+	mock.lockDeleteTransitions.Unlock()
+	return mock.DeleteTransitionsFunc(project)
+}
+
+// DeleteTransitionsCalls gets all the calls that were made to DeleteTransitions.
+// Check the length with:
+//     len(mockedSequenceHistoryRepo.DeleteTransitionsCalls())
+func (mock *SequenceHistoryRepoMock) DeleteTransitionsCalls() []struct {
+	Project string
+} {
+	var calls []struct {
+		Project string
+	}
+	mock.lockDeleteTransitions.RLock()
+	calls = mock.calls.DeleteTransitions
+	mock.lockDeleteTransitions.RUnlock()
+	return calls
+}
+
+// GetTransitions calls GetTransitionsFunc.
+func (mock *SequenceHistoryRepoMock) GetTransitions(project string, keptnContext string) ([]models.SequenceStateTransition, error) {
+	if mock.GetTransitionsFunc == nil {
+		panic("SequenceHistoryRepoMock.GetTransitionsFunc: method is nil but SequenceHistoryRepo.GetTransitions was just called")
+	}
+	callInfo := struct {
+		Project      string
+		KeptnContext string
+	}{
+		Project:      project,
+		KeptnContext: keptnContext,
+	}
+	mock.lockGetTransitions.Lock()
+	mock.calls.GetTransitions = append(mock.calls.GetTransitions, callInfo)
+	mock.lockGetTransitions.Unlock()
+	return mock.GetTransitionsFunc(project, keptnContext)
+}
+
+// GetTransitionsCalls gets all the calls that were made to GetTransitions.
+// Check the length with:
+//     len(mockedSequenceHistoryRepo.GetTransitionsCalls())
+func (mock *SequenceHistoryRepoMock) GetTransitionsCalls() []struct {
+	Project      string
+	KeptnContext string
+} {
+	var calls []struct {
+		Project      string
+		KeptnContext string
+	}
+	mock.lockGetTransitions.RLock()
+	calls = mock.calls.GetTransitions
+	mock.lockGetTransitions.RUnlock()
+	return calls
+}
@@ -4,7 +4,8 @@
 package db_mock
 
 import (
-	"github.com/keptn/go-utils/pkg/api/models"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -17,10 +18,10 @@ import (
 // 			CreateSequenceStateFunc: func(state models.SequenceState) error {
 // 				panic("mock out the CreateSequenceState method")
 // 			},
-// 			DeleteSequenceStatesFunc: func(filter models.StateFilter) error {
+// 			DeleteSequenceStatesFunc: func(filter apimodels.StateFilter) error {
 // 				panic("mock out the DeleteSequenceStates method")
 // 			},
-// 			FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+// 			FindSequenceStatesFunc: func(filter apimodels.StateFilter) (*models.SequenceStates, error) {
 // 				panic("mock out the FindSequenceStates method")
 // 			},
 // 			UpdateSequenceStateFunc: func(state models.SequenceState) error {
@@ -37,10 +38,10 @@ type SequenceStateRepoMock struct {
 	CreateSequenceStateFunc func(state models.SequenceState) error
 
 	// DeleteSequenceStatesFunc mocks the DeleteSequenceStates method.
-	DeleteSequenceStatesFunc func(filter models.StateFilter) error
+	DeleteSequenceStatesFunc func(filter apimodels.StateFilter) error
 
 	// FindSequenceStatesFunc mocks the FindSequenceStates method.
-	FindSequenceStatesFunc func(filter models.StateFilter) (*models.SequenceStates, error)
+	FindSequenceStatesFunc func(filter apimodels.StateFilter) (*models.SequenceStates, error)
 
 	// UpdateSequenceStateFunc mocks the UpdateSequenceState method.
 	UpdateSequenceStateFunc func(state models.SequenceState) error
@@ -55,12 +56,12 @@ type SequenceStateRepoMock struct {
 		// DeleteSequenceStates holds details about calls to the DeleteSequenceStates method.
 		DeleteSequenceStates []struct {
 			// Filter is the filter argument value.
-			Filter models.StateFilter
+			Filter apimodels.StateFilter
 		}
 		// FindSequenceStates holds details about calls to the FindSequenceStates method.
 		FindSequenceStates []struct {
 			// Filter is the filter argument value.
-			Filter models.StateFilter
+			Filter apimodels.StateFilter
 		}
 		// UpdateSequenceState holds details about calls to the UpdateSequenceState method.
 		UpdateSequenceState []struct {
@@ -106,12 +107,12 @@ func (mock *SequenceStateRepoMock) CreateSequenceStateCalls() []struct {
 }
 
 // DeleteSequenceStates calls DeleteSequenceStatesFunc.
-func (mock *SequenceStateRepoMock) DeleteSequenceStates(filter models.StateFilter) error {
+func (mock *SequenceStateRepoMock) DeleteSequenceStates(filter apimodels.StateFilter) error {
 	if mock.DeleteSequenceStatesFunc == nil {
 		panic("SequenceStateRepoMock.DeleteSequenceStatesFunc: method is nil but SequenceStateRepo.DeleteSequenceStates was just called")
 	}
 	callInfo := struct {
-		Filter models.StateFilter
+		Filter apimodels.StateFilter
 	}{
 		Filter: filter,
 	}
@@ -125,10 +126,10 @@ func (mock *SequenceStateRepoMock) DeleteSequenceStates(filter models.StateFilte
 // Check the length with:
 //     len(mockedSequenceStateRepo.DeleteSequenceStatesCalls())
 func (mock *SequenceStateRepoMock) DeleteSequenceStatesCalls() []struct {
-	Filter models.StateFilter
+	Filter apimodels.StateFilter
 } {
 	var calls []struct {
-		Filter models.StateFilter
+		Filter apimodels.StateFilter
 	}
 	mock.lockDeleteSequenceStates.RLock()
 	calls = mock.calls.DeleteSequenceStates
@@ -137,12 +138,12 @@ func (mock *SequenceStateRepoMock) DeleteSequenceStatesCalls() []struct {
 }
 
 // FindSequenceStates calls FindSequenceStatesFunc.
-func (mock *SequenceStateRepoMock) FindSequenceStates(filter models.StateFilter) (*models.SequenceStates, error) {
+func (mock *SequenceStateRepoMock) FindSequenceStates(filter apimodels.StateFilter) (*models.SequenceStates, error) {
 	if mock.FindSequenceStatesFunc == nil {
 		panic("SequenceStateRepoMock.FindSequenceStatesFunc: method is nil but SequenceStateRepo.FindSequenceStates was just called")
 	}
 	callInfo := struct {
-		Filter models.StateFilter
+		Filter apimodels.StateFilter
 	}{
 		Filter: filter,
 	}
@@ -156,10 +157,10 @@ func (mock *SequenceStateRepoMock) FindSequenceStates(filter models.StateFilter)
 // Check the length with:
 //     len(mockedSequenceStateRepo.FindSequenceStatesCalls())
 func (mock *SequenceStateRepoMock) FindSequenceStatesCalls() []struct {
-	Filter models.StateFilter
+	Filter apimodels.StateFilter
 } {
 	var calls []struct {
-		Filter models.StateFilter
+		Filter apimodels.StateFilter
 	}
 	mock.lockFindSequenceStates.RLock()
 	calls = mock.calls.FindSequenceStates
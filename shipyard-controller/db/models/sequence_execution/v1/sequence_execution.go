@@ -75,6 +75,8 @@ func (s SequenceExecutionStatus) DecodePreviousTasks() []models.TaskExecutionRes
 			TriggeredID: previousTask.TriggeredID,
 			Result:      previousTask.Result,
 			Status:      previousTask.Status,
+			Skipped:     previousTask.Skipped,
+			Attempts:    previousTask.Attempts,
 		}
 
 		if previousTask.EncodedProperties != "" {
@@ -95,13 +97,18 @@ type TaskExecutionResult struct {
 	Result      keptnv2.ResultType `json:"result" bson:"result"`
 	Status      keptnv2.StatusType `json:"status" bson:"status"`
 	// EncodedProperties contains the aggregated results of the task's executors
-	EncodedProperties string `json:"encodedProperties" bson:"encodedProperties"`
+	EncodedProperties string               `json:"encodedProperties" bson:"encodedProperties"`
+	Skipped           bool                 `json:"skipped,omitempty" bson:"skipped,omitempty"`
+	Attempts          []models.TaskAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
 }
 
 type TaskExecutionState struct {
-	Name        string      `json:"name" bson:"name"`
-	TriggeredID string      `json:"triggeredID" bson:"triggeredID"`
-	Events      []TaskEvent `json:"events" bson:"events"`
+	Name          string                `json:"name" bson:"name"`
+	TriggeredID   string                `json:"triggeredID" bson:"triggeredID"`
+	Events        []TaskEvent           `json:"events" bson:"events"`
+	ParallelTasks []models.ParallelTask `json:"parallelTasks,omitempty" bson:"parallelTasks,omitempty"`
+	Timeout       string                `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	Attempts      []models.TaskAttempt  `json:"attempts,omitempty" bson:"attempts,omitempty"`
 }
 
 func (s TaskExecutionState) DecodeEvents() []models.TaskEvent {
@@ -109,11 +116,12 @@ func (s TaskExecutionState) DecodeEvents() []models.TaskEvent {
 
 	for _, event := range s.Events {
 		newEvent := models.TaskEvent{
-			EventType: event.EventType,
-			Source:    event.Source,
-			Result:    event.Result,
-			Status:    event.Status,
-			Time:      event.Time,
+			EventType:   event.EventType,
+			Source:      event.Source,
+			Result:      event.Result,
+			Status:      event.Status,
+			Time:        event.Time,
+			TriggeredID: event.TriggeredID,
 		}
 		if event.EncodedProperties != "" {
 			properties := map[string]interface{}{}
@@ -133,6 +141,7 @@ type TaskEvent struct {
 	Status            keptnv2.StatusType `json:"status" bson:"status"`
 	Time              string             `json:"time" bson:"time"`
 	EncodedProperties string             `json:"encodedProperties" bson:"encodedProperties"`
+	TriggeredID       string             `json:"triggeredID,omitempty" bson:"triggeredID,omitempty"`
 }
 
 func (e JsonStringEncodedSequenceExecution) ToSequenceExecution() models.SequenceExecution {
@@ -148,9 +157,12 @@ func (e JsonStringEncodedSequenceExecution) ToSequenceExecution() models.Sequenc
 			StateBeforePause: e.Status.StateBeforePause,
 			PreviousTasks:    e.Status.DecodePreviousTasks(),
 			CurrentTask: models.TaskExecutionState{
-				Name:        e.Status.CurrentTask.Name,
-				TriggeredID: e.Status.CurrentTask.TriggeredID,
-				Events:      e.Status.CurrentTask.DecodeEvents(),
+				Name:          e.Status.CurrentTask.Name,
+				TriggeredID:   e.Status.CurrentTask.TriggeredID,
+				Events:        e.Status.CurrentTask.DecodeEvents(),
+				ParallelTasks: e.Status.CurrentTask.ParallelTasks,
+				Timeout:       e.Status.CurrentTask.Timeout,
+				Attempts:      e.Status.CurrentTask.Attempts,
 			},
 		},
 		Scope:       e.Scope,
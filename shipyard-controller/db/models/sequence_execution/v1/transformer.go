@@ -98,9 +98,12 @@ func transformStatus(status models.SequenceExecutionStatus) SequenceExecutionSta
 
 func transformCurrentTask(task models.TaskExecutionState) TaskExecutionState {
 	newTaskExecutionState := TaskExecutionState{
-		Name:        task.Name,
-		TriggeredID: task.TriggeredID,
-		Events:      transformTaskEvents(task.Events),
+		Name:          task.Name,
+		TriggeredID:   task.TriggeredID,
+		Events:        transformTaskEvents(task.Events),
+		ParallelTasks: task.ParallelTasks,
+		Timeout:       task.Timeout,
+		Attempts:      task.Attempts,
 	}
 	return newTaskExecutionState
 }
@@ -117,11 +120,12 @@ func transformTaskEvents(events []models.TaskEvent) []TaskEvent {
 
 func transformTaskEvent(e models.TaskEvent) TaskEvent {
 	newTaskEvent := TaskEvent{
-		EventType: e.EventType,
-		Source:    e.Source,
-		Result:    e.Result,
-		Status:    e.Status,
-		Time:      e.Time,
+		EventType:   e.EventType,
+		Source:      e.Source,
+		Result:      e.Result,
+		Status:      e.Status,
+		Time:        e.Time,
+		TriggeredID: e.TriggeredID,
 	}
 
 	if e.Properties != nil {
@@ -142,6 +146,8 @@ func transformPreviousTasks(tasks []models.TaskExecutionResult) []TaskExecutionR
 			TriggeredID: t.TriggeredID,
 			Result:      t.Result,
 			Status:      t.Status,
+			Skipped:     t.Skipped,
+			Attempts:    t.Attempts,
 		}
 
 		if t.Properties != nil {
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const scheduleCollectionName = "shipyard-controller-schedules"
+
+type MongoDBScheduleRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+func NewMongoDBScheduleRepo(dbConnection *MongoDBConnection) *MongoDBScheduleRepo {
+	return &MongoDBScheduleRepo{DBConnection: dbConnection}
+}
+
+func (mdbrepo *MongoDBScheduleRepo) CreateSchedule(schedule models.Schedule) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = collection.InsertOne(ctx, schedule)
+	return err
+}
+
+func (mdbrepo *MongoDBScheduleRepo) GetSchedules(params models.GetScheduleParams) ([]models.Schedule, error) {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{}
+	searchOptions = appendFilterAs(searchOptions, params.Project, "project")
+	searchOptions = appendFilterAs(searchOptions, params.Stage, "stage")
+	searchOptions = appendFilterAs(searchOptions, params.Service, "service")
+	searchOptions = appendFilterAs(searchOptions, params.ID, "_id")
+
+	cur, err := collection.Find(ctx, searchOptions, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve schedules: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	schedules := []models.Schedule{}
+	if err := cur.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("could not decode schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// UpdateSchedule updates the user defined properties of a schedule. The creation date and the information about the most recent trigger are retained
+func (mdbrepo *MongoDBScheduleRepo) UpdateSchedule(schedule models.Schedule) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"stage":       schedule.Stage,
+			"service":     schedule.Service,
+			"sequence":    schedule.Sequence,
+			"description": schedule.Description,
+			"cron":        schedule.Cron,
+			"timeZone":    schedule.TimeZone,
+			"labels":      schedule.Labels,
+		},
+	}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": schedule.ID, "project": schedule.Project}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (mdbrepo *MongoDBScheduleRepo) UpdateLastTriggered(id string, lastTriggered time.Time, keptnContext string) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"lastTriggered":    lastTriggered,
+			"lastKeptnContext": keptnContext,
+		},
+	}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (mdbrepo *MongoDBScheduleRepo) DeleteSchedule(project, id string) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": id, "project": project})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (mdbrepo *MongoDBScheduleRepo) DeleteSchedules(project string) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = collection.DeleteMany(ctx, bson.M{"project": project})
+	return err
+}
+
+func (mdbrepo *MongoDBScheduleRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := mdbrepo.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := mdbrepo.DBConnection.Client.Database(getDatabaseName()).Collection(scheduleCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,72 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBScheduleRepo_CRUD(t *testing.T) {
+	repo := db.NewMongoDBScheduleRepo(db.GetMongoDBConnectionInstance())
+
+	devSchedule := models.Schedule{
+		ID:        "dev-schedule",
+		Project:   "my-schedule-project",
+		Stage:     "dev",
+		Service:   "my-service",
+		Sequence:  "evaluation",
+		Cron:      "0 2 * * *",
+		CreatedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	prodSchedule := models.Schedule{
+		ID:        "prod-schedule",
+		Project:   "my-schedule-project",
+		Stage:     "production",
+		Service:   "my-service",
+		Sequence:  "performance",
+		Cron:      "@weekly",
+		Labels:    map[string]string{"type": "weekly"},
+		CreatedAt: time.Date(2022, 3, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	require.Nil(t, repo.CreateSchedule(devSchedule))
+	require.Nil(t, repo.CreateSchedule(prodSchedule))
+
+	schedules, err := repo.GetSchedules(models.GetScheduleParams{})
+	require.Nil(t, err)
+	require.Equal(t, []models.Schedule{devSchedule, prodSchedule}, schedules)
+
+	schedules, err = repo.GetSchedules(models.GetScheduleParams{Project: "my-schedule-project", Stage: "production"})
+	require.Nil(t, err)
+	require.Equal(t, []models.Schedule{prodSchedule}, schedules)
+
+	// updating a schedule must not change its trigger information
+	lastTriggered := time.Date(2022, 3, 3, 2, 0, 0, 0, time.UTC)
+	require.Nil(t, repo.UpdateLastTriggered("dev-schedule", lastTriggered, "my-context"))
+
+	devSchedule.Cron = "0 3 * * *"
+	require.Nil(t, repo.UpdateSchedule(models.Schedule{ID: devSchedule.ID, Project: devSchedule.Project, Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 3 * * *"}))
+
+	devSchedule.LastTriggered = &lastTriggered
+	devSchedule.LastKeptnContext = "my-context"
+	schedules, err = repo.GetSchedules(models.GetScheduleParams{Project: "my-schedule-project", ID: "dev-schedule"})
+	require.Nil(t, err)
+	require.Equal(t, []models.Schedule{devSchedule}, schedules)
+
+	err = repo.UpdateSchedule(models.Schedule{ID: "unknown", Project: "my-schedule-project"})
+	require.ErrorIs(t, err, db.ErrScheduleNotFound)
+
+	require.ErrorIs(t, repo.UpdateLastTriggered("unknown", lastTriggered, "my-context"), db.ErrScheduleNotFound)
+
+	require.Nil(t, repo.DeleteSchedule("my-schedule-project", "prod-schedule"))
+	require.ErrorIs(t, repo.DeleteSchedule("my-schedule-project", "prod-schedule"), db.ErrScheduleNotFound)
+
+	require.Nil(t, repo.DeleteSchedules("my-schedule-project"))
+
+	schedules, err = repo.GetSchedules(models.GetScheduleParams{Project: "my-schedule-project"})
+	require.Nil(t, err)
+	require.Empty(t, schedules)
+}
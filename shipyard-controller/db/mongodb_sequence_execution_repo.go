@@ -346,7 +346,27 @@ func (mdbrepo *MongoDBSequenceExecutionRepo) getSearchOptions(filter models.Sequ
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Project, "scope.project")
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Stage, "scope.stage")
 	searchOptions = appendFilterAs(searchOptions, filter.Scope.Service, "scope.service")
-	searchOptions = appendFilterAs(searchOptions, filter.CurrentTriggeredID, "status.currentTask.triggeredID")
+	conditions := []bson.M{}
+	if filter.CurrentTriggeredID != "" {
+		// the triggeredID can belong to the current task, or to any other task of its parallel group
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"status.currentTask.triggeredID": filter.CurrentTriggeredID},
+				{"status.currentTask.parallelTasks.triggeredID": filter.CurrentTriggeredID},
+			},
+		})
+	}
+	if filter.WithTaskTimeout {
+		conditions = append(conditions, bson.M{
+			"$or": []bson.M{
+				{"status.currentTask.timeout": bson.M{"$exists": true}},
+				{"status.currentTask.parallelTasks.timeout": bson.M{"$exists": true}},
+			},
+		})
+	}
+	if len(conditions) > 0 {
+		searchOptions["$and"] = conditions
+	}
 	if !filter.TriggeredAt.IsZero() {
 		searchOptions["triggeredAt"] = bson.M{
 			"$lt": filter.TriggeredAt,
@@ -107,6 +107,80 @@ func TestMongoDBTaskSequenceV2Repo_GetByTriggeredID(t *testing.T) {
 	require.NotNil(t, sequenceByTriggeredID)
 }
 
+func TestMongoDBTaskSequenceV2Repo_GetByCurrentTriggeredIDOfParallelTask(t *testing.T) {
+	scope, sequence := getTestSequenceExecution()
+	sequence.Status.CurrentTask.ParallelTasks = []models.ParallelTask{
+		{Name: "deploy", TriggeredID: "1234"},
+		{Name: "test", TriggeredID: "5678"},
+	}
+
+	mdbrepo := NewMongoDBSequenceExecutionRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.Upsert(sequence, nil)
+	require.Nil(t, err)
+
+	for _, triggeredID := range []string{"1234", "5678"} {
+		get, err := mdbrepo.Get(models.SequenceExecutionFilter{
+			Scope:              scope,
+			CurrentTriggeredID: triggeredID,
+			Status:             []string{"triggered"},
+		})
+		require.Nil(t, err)
+		require.Len(t, get, 1)
+		get[0].SchemaVersion = ""
+		require.Equal(t, sequence, get[0])
+	}
+
+	get, err := mdbrepo.Get(models.SequenceExecutionFilter{
+		Scope:              scope,
+		CurrentTriggeredID: "unknown",
+	})
+	require.Nil(t, err)
+	require.Empty(t, get)
+
+	err = mdbrepo.Clear("my-project")
+	require.Nil(t, err)
+}
+
+func TestMongoDBTaskSequenceV2Repo_GetWithTaskTimeout(t *testing.T) {
+	scope, sequence := getTestSequenceExecution()
+
+	mdbrepo := NewMongoDBSequenceExecutionRepo(GetMongoDBConnectionInstance())
+
+	err := mdbrepo.Upsert(sequence, nil)
+	require.Nil(t, err)
+
+	get, err := mdbrepo.Get(models.SequenceExecutionFilter{
+		Scope:           models.EventScope{EventData: keptnv2.EventData{Project: scope.Project}},
+		WithTaskTimeout: true,
+	})
+	require.Nil(t, err)
+	require.Empty(t, get)
+
+	for _, currentTask := range []models.TaskExecutionState{
+		{Name: "deploy", TriggeredID: "1234", Timeout: "10m"},
+		{Name: "deploy", TriggeredID: "1234", ParallelTasks: []models.ParallelTask{
+			{Name: "deploy", TriggeredID: "1234"},
+			{Name: "test", TriggeredID: "5678", Timeout: "10m"},
+		}},
+	} {
+		sequence.Status.CurrentTask = currentTask
+		err = mdbrepo.Upsert(sequence, &models.SequenceExecutionUpsertOptions{Replace: true})
+		require.Nil(t, err)
+
+		get, err = mdbrepo.Get(models.SequenceExecutionFilter{
+			Scope:              models.EventScope{EventData: keptnv2.EventData{Project: scope.Project}},
+			CurrentTriggeredID: "1234",
+			WithTaskTimeout:    true,
+		})
+		require.Nil(t, err)
+		require.Len(t, get, 1)
+	}
+
+	err = mdbrepo.Clear("my-project")
+	require.Nil(t, err)
+}
+
 func TestMongoDBTaskSequenceV2Repo_InsertAndRetrieveSameStage(t *testing.T) {
 	scope, sequence := getTestSequenceExecution()
 	scope2 := scope
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const sequenceHistoryCollectionName = "shipyard-controller-sequence-history"
+
+// MongoDBSequenceHistoryRepo stores the state transitions of sequence executions.
+// Entries are only ever appended - they are removed only when the project they belong to is deleted
+type MongoDBSequenceHistoryRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+func NewMongoDBSequenceHistoryRepo(dbConnection *MongoDBConnection) *MongoDBSequenceHistoryRepo {
+	return &MongoDBSequenceHistoryRepo{DBConnection: dbConnection}
+}
+
+func (mdbrepo *MongoDBSequenceHistoryRepo) AppendTransition(transition models.SequenceStateTransition) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = collection.InsertOne(ctx, transition)
+	return err
+}
+
+func (mdbrepo *MongoDBSequenceHistoryRepo) GetTransitions(project, keptnContext string) ([]models.SequenceStateTransition, error) {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	searchOptions := bson.M{"project": project, "keptnContext": keptnContext}
+
+	// sorting by _id as a secondary criteria retains the insertion order of transitions that happened at the same time
+	cur, err := collection.Find(ctx, searchOptions, options.Find().SetSort(bson.D{{Key: "time", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve sequence history: %w", err)
+	}
+	defer closeCursor(ctx, cur)
+
+	transitions := []models.SequenceStateTransition{}
+	if err := cur.All(ctx, &transitions); err != nil {
+		return nil, fmt.Errorf("could not decode sequence history: %w", err)
+	}
+	return transitions, nil
+}
+
+func (mdbrepo *MongoDBSequenceHistoryRepo) DeleteTransitions(project string) error {
+	collection, ctx, cancel, err := mdbrepo.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = collection.DeleteMany(ctx, bson.M{"project": project})
+	return err
+}
+
+func (mdbrepo *MongoDBSequenceHistoryRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := mdbrepo.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := mdbrepo.DBConnection.Client.Database(getDatabaseName()).Collection(sequenceHistoryCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,61 @@
+package db_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMongoDBSequenceHistoryRepo(t *testing.T) {
+	repo := db.NewMongoDBSequenceHistoryRepo(db.GetMongoDBConnectionInstance())
+
+	triggeredAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	triggered := models.SequenceStateTransition{
+		Project:      "my-history-project",
+		Stage:        "dev",
+		Service:      "carts",
+		Sequence:     "delivery",
+		KeptnContext: "my-context",
+		State:        "triggered",
+		Source:       "cli",
+		Time:         triggeredAt,
+	}
+	paused := models.SequenceStateTransition{
+		Project:      "my-history-project",
+		Stage:        "dev",
+		KeptnContext: "my-context",
+		State:        "paused",
+		User:         "jane.doe@example.com",
+		Source:       "api",
+		Reason:       "waiting for the database migration",
+		Time:         triggeredAt.Add(time.Minute),
+	}
+	otherSequence := models.SequenceStateTransition{
+		Project:      "my-history-project",
+		KeptnContext: "other-context",
+		State:        "triggered",
+		Source:       "cli",
+		Time:         triggeredAt,
+	}
+
+	require.Nil(t, repo.AppendTransition(paused))
+	require.Nil(t, repo.AppendTransition(triggered))
+	require.Nil(t, repo.AppendTransition(otherSequence))
+
+	transitions, err := repo.GetTransitions("my-history-project", "my-context")
+	require.Nil(t, err)
+	require.Equal(t, []models.SequenceStateTransition{triggered, paused}, transitions)
+
+	transitions, err = repo.GetTransitions("other-project", "my-context")
+	require.Nil(t, err)
+	require.Empty(t, transitions)
+
+	require.Nil(t, repo.DeleteTransitions("my-history-project"))
+
+	transitions, err = repo.GetTransitions("my-history-project", "my-context")
+	require.Nil(t, err)
+	require.Empty(t, transitions)
+}
@@ -6,6 +6,7 @@ import (
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 	"github.com/tryvium-travels/memongo"
@@ -61,32 +62,32 @@ func TestMongoDBStateRepo_FindSequenceStates(t *testing.T) {
 
 	mdbrepo := db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance())
 
-	state := apimodels.SequenceState{
+	state := models.SequenceState{SequenceState: apimodels.SequenceState{
 		Name:           "my-sequence",
 		Service:        "my-service",
 		Project:        "my-project",
 		Time:           "2021-05-10T10:15:00.000Z",
 		Shkeptncontext: "my-context",
 		State:          "triggered",
-	}
+	}}
 
-	state2 := apimodels.SequenceState{
+	state2 := models.SequenceState{SequenceState: apimodels.SequenceState{
 		Name:           "my-sequence2",
 		Service:        "my-service",
 		Project:        "my-project",
 		Time:           "2021-05-10T10:00:00.000Z",
 		Shkeptncontext: "my-context2",
 		State:          "finished",
-	}
+	}}
 
-	state3 := apimodels.SequenceState{
+	state3 := models.SequenceState{SequenceState: apimodels.SequenceState{
 		Name:           "my-sequence3",
 		Service:        "my-service",
 		Project:        "my-project",
 		Time:           "2021-05-10T09:50:00.000Z",
 		Shkeptncontext: "my-context3",
 		State:          "triggered",
-	}
+	}}
 
 	err := mdbrepo.CreateSequenceState(state)
 	require.Nil(t, err)
@@ -209,7 +210,7 @@ func TestMongoDBStateRepo_StateRepoInsertAndRetrieve(t *testing.T) {
 
 	mdbrepo := db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance())
 
-	state := apimodels.SequenceState{
+	state := models.SequenceState{SequenceState: apimodels.SequenceState{
 		Name:           "my-sequence",
 		Service:        "my-service",
 		Project:        "my-project",
@@ -217,7 +218,7 @@ func TestMongoDBStateRepo_StateRepoInsertAndRetrieve(t *testing.T) {
 		Shkeptncontext: "my-context",
 		State:          "triggered",
 		Stages:         nil,
-	}
+	}}
 
 	// first, delete any entries that might have been inserted previously
 	err := mdbrepo.DeleteSequenceStates(apimodels.StateFilter{
@@ -291,14 +292,14 @@ func TestMongoDBStateRepo_StateRepoInsertInvalidStates(t *testing.T) {
 	mdbrepo := db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance())
 
 	// create a state without a project
-	invalidState := apimodels.SequenceState{
+	invalidState := models.SequenceState{SequenceState: apimodels.SequenceState{
 		Name:           "my-sequence",
 		Service:        "my-service",
 		Time:           "",
 		Shkeptncontext: "my-context",
 		State:          "triggered",
 		Stages:         nil,
-	}
+	}}
 
 	err := mdbrepo.CreateSequenceState(invalidState)
 	require.NotNil(t, err)
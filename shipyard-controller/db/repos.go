@@ -20,14 +20,17 @@ var ErrStageNotFound = errors.New("stage not found")
 // ErrServiceNotFound indicates that a service has not been found
 var ErrServiceNotFound = errors.New("service not found")
 
+// ErrScheduleNotFound indicates that a schedule has not been found
+var ErrScheduleNotFound = errors.New("schedule not found")
+
 // ErrOpenRemediationNotFound indicates that no open remediation has been found
 var ErrOpenRemediationNotFound = errors.New("open remediation not found")
 
 //go:generate moq --skip-ensure -pkg db_mock -out ./mock/sequencestaterepo_mock.go . SequenceStateRepo
 type SequenceStateRepo interface {
-	CreateSequenceState(state apimodels.SequenceState) error
-	FindSequenceStates(filter apimodels.StateFilter) (*apimodels.SequenceStates, error)
-	UpdateSequenceState(state apimodels.SequenceState) error
+	CreateSequenceState(state models.SequenceState) error
+	FindSequenceStates(filter apimodels.StateFilter) (*models.SequenceStates, error)
+	UpdateSequenceState(state models.SequenceState) error
 	DeleteSequenceStates(filter apimodels.StateFilter) error
 }
 
@@ -46,6 +49,17 @@ type UniformRepo interface {
 	UpdateVersionInfo(integrationID, integrationVersion, distributorVersion string) (*apimodels.Integration, error)
 }
 
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/schedulerepo_mock.go . ScheduleRepo
+// ScheduleRepo defines the interface for storing, retrieving and deleting sequence schedules
+type ScheduleRepo interface {
+	CreateSchedule(schedule models.Schedule) error
+	GetSchedules(params models.GetScheduleParams) ([]models.Schedule, error)
+	UpdateSchedule(schedule models.Schedule) error
+	UpdateLastTriggered(id string, lastTriggered time.Time, keptnContext string) error
+	DeleteSchedule(project, id string) error
+	DeleteSchedules(project string) error
+}
+
 type LogRepo interface {
 	CreateLogEntries(entries []apimodels.LogEntry) error
 	GetLogEntries(filter models.GetLogParams) (*models.GetLogsResponse, error)
@@ -111,3 +125,11 @@ type SequenceExecutionRepo interface {
 	IsContextPaused(eventScope models.EventScope) bool
 	Clear(projectName string) error
 }
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/sequencehistoryrepo_mock.go . SequenceHistoryRepo
+// SequenceHistoryRepo defines the interface for the append-only audit trail of sequence executions
+type SequenceHistoryRepo interface {
+	AppendTransition(transition models.SequenceStateTransition) error
+	GetTransitions(project, keptnContext string) ([]models.SequenceStateTransition, error)
+	DeleteTransitions(project string) error
+}
@@ -563,6 +563,610 @@ const docTemplate = `{
                 }
             }
         },
+        "/project/{project}/freezewindow": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the freeze windows of a project. If a stage is provided, only the freeze windows affecting this stage are returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FreezeWindow"
+                ],
+                "summary": "Get the freeze windows of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the stage",
+                        "name": "stage",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindows"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new freeze window for a project or a stage. The freeze window is added to the shipyard of the project. While a freeze window is active, no sequences are started in the affected stages",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FreezeWindow"
+                ],
+                "summary": "Create a new freeze window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Freeze window",
+                        "name": "freezeWindow",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindow"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindow"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{project}/freezewindow/{freezeWindowID}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a freeze window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FreezeWindow"
+                ],
+                "summary": "Get a freeze window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the freeze window",
+                        "name": "freezeWindowID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindow"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a freeze window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FreezeWindow"
+                ],
+                "summary": "Update a freeze window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the freeze window",
+                        "name": "freezeWindowID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Freeze window",
+                        "name": "freezeWindow",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindow"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.FreezeWindow"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a freeze window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FreezeWindow"
+                ],
+                "summary": "Delete a freeze window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the freeze window",
+                        "name": "freezeWindowID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{project}/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the schedules of a project, optionally filtered by stage and service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get the schedules of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the stage",
+                        "name": "stage",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "The name of the service",
+                        "name": "service",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedules"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new schedule that periodically triggers a sequence for a service according to a cron expression",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create a new schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/project/{project}/schedule/{scheduleID}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the schedule",
+                        "name": "scheduleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a schedule. The information about the most recently triggered sequence is retained",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Update a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the schedule",
+                        "name": "scheduleID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Delete a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The name of the project",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the schedule",
+                        "name": "scheduleID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/project/{project}/service": {
             "post": {
                 "security": [
@@ -1118,7 +1722,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence",
+                "description": "Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence.\nA finished sequence can be restarted in a specific stage, starting with the task given in fromTask, or with the task that has failed or timed out",
                 "consumes": [
                     "application/json"
                 ],
@@ -1128,7 +1732,7 @@ const docTemplate = `{
                 "tags": [
                     "Sequence"
                 ],
-                "summary": "Pause/Resume/Abort a task sequence",
+                "summary": "Pause/Resume/Abort/Restart a task sequence",
                 "parameters": [
                     {
                         "type": "string",
@@ -1182,6 +1786,56 @@ const docTemplate = `{
                 }
             }
         },
+        "/sequence/{project}/{keptnContext}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the state transitions of a task sequence execution in chronological order, including who or what caused them and why",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sequence"
+                ],
+                "summary": "Get the history of a task sequence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The keptnContext ID of the sequence",
+                        "name": "keptnContext",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.SequenceHistory"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/uniform/registration": {
             "get": {
                 "security": [
@@ -1782,6 +2436,10 @@ const docTemplate = `{
                 "shipyard": {
                     "description": "shipyard",
                     "type": "string"
+                },
+                "stageLayout": {
+                    "description": "stage layout of the project, i.e. either \"branch\" or \"directory\". If not set, the default stage layout of the resource-service is used",
+                    "type": "string"
                 }
             }
         },
@@ -2072,6 +2730,56 @@ const docTemplate = `{
                 }
             }
         },
+        "models.FreezeWindow": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "description": "Cron is a standard cron expression (minute, hour, day of month, month, day of week) that determines when a recurring freeze window starts",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration is the duration of a recurring freeze window, e.g. 12h",
+                    "type": "string"
+                },
+                "end": {
+                    "description": "End is the end of a fixed freeze window, either in RFC3339 format, or in the format 2006-01-02T15:04:05 in the given time zone",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "project": {
+                    "description": "Project is the project the freeze window belongs to. It is not stored in the shipyard, since it is given by the project of the shipyard",
+                    "type": "string"
+                },
+                "stage": {
+                    "description": "Stage is the stage the freeze window applies to. If empty, the freeze window applies to all stages of the project",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "Start is the beginning of a fixed freeze window, either in RFC3339 format, or in the format 2006-01-02T15:04:05 in the given time zone",
+                    "type": "string"
+                },
+                "timeZone": {
+                    "description": "TimeZone is the IANA time zone name (e.g. Europe/Vienna) used to evaluate Start, End and Cron. Defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FreezeWindows": {
+            "type": "object",
+            "properties": {
+                "freezeWindows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FreezeWindow"
+                    }
+                }
+            }
+        },
         "models.GetLogsResponse": {
             "type": "object",
             "properties": {
@@ -2267,12 +2975,85 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Schedule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "CreatedAt is the point in time at which the schedule has been created",
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "Cron is a standard cron expression (minute, hour, day of month, month, day of week) that determines when the sequence is triggered",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "Labels are added to the sequence.triggered events created by the schedule",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "lastKeptnContext": {
+                    "description": "LastKeptnContext is the keptn context of the sequence that has been triggered most recently by the schedule",
+                    "type": "string"
+                },
+                "lastTriggered": {
+                    "description": "LastTriggered is the point in time at which the schedule has triggered a sequence for the last time",
+                    "type": "string"
+                },
+                "nextTrigger": {
+                    "description": "NextTrigger is the point in time at which the schedule will trigger the next sequence. It is calculated when the schedule is retrieved",
+                    "type": "string"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "timeZone": {
+                    "description": "TimeZone is the IANA time zone name (e.g. Europe/Vienna) used to evaluate Cron. Defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Schedules": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Schedule"
+                    }
+                }
+            }
+        },
         "models.SequenceControlCommand": {
             "type": "object",
             "required": [
                 "state"
             ],
             "properties": {
+                "fromTask": {
+                    "description": "FromTask is the name of the task from which the sequence should be restarted. It is only considered for the 'restart' state.\nIf it is not set, the sequence is restarted from the task that has failed or timed out",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason describes why the state of the sequence is changed. It will be stored in the history of the sequence",
+                    "type": "string"
+                },
                 "stage": {
                     "type": "string"
                 },
@@ -2284,6 +3065,17 @@ const docTemplate = `{
         "models.SequenceControlResponse": {
             "type": "object"
         },
+        "models.SequenceHistory": {
+            "type": "object",
+            "properties": {
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SequenceStateTransition"
+                    }
+                }
+            }
+        },
         "models.SequenceState": {
             "type": "object",
             "properties": {
@@ -2313,6 +3105,10 @@ const docTemplate = `{
                 },
                 "time": {
                     "type": "string"
+                },
+                "waitingReason": {
+                    "description": "WaitingReason describes why the sequence is currently waiting to be started",
+                    "type": "string"
                 }
             }
         },
@@ -2364,6 +3160,45 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SequenceStateTransition": {
+            "type": "object",
+            "properties": {
+                "keptnContext": {
+                    "type": "string"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason optionally describes why the transition has happened",
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is the component that caused the transition, e.g. the source of the event that triggered the sequence",
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State is the state the sequence has entered, e.g. triggered, paused, resumed, aborted, timedOut or finished",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "User is the API user that requested the transition. It is empty for transitions caused by Keptn itself",
+                    "type": "string"
+                }
+            }
+        },
         "models.SequenceStates": {
             "type": "object",
             "properties": {
@@ -2472,6 +3307,10 @@ const docTemplate = `{
                 "shipyard": {
                     "description": "shipyard",
                     "type": "string"
+                },
+                "stageLayout": {
+                    "description": "stage layout the project is migrated to, i.e. either \"branch\" or \"directory\". If not set, the stage layout is not changed",
+                    "type": "string"
                 }
             }
         },
@@ -54,3 +54,16 @@ func SetConflictErrorResponse(c *gin.Context, msg string) {
 		Message: &msg,
 	})
 }
+
+// APITokenUser is used as the user of requests that have been authenticated with the Keptn API token, since the token is not bound to a specific user
+const APITokenUser = "keptn-api-token"
+
+// getRequestUser returns the user who sent a request. Only verified identities are considered. Since the auth backend of the API gateway
+// only verifies the Keptn API token, which is shared by all users, requests using the token are attributed to the APITokenUser.
+// Bearer tokens are not verified by the shipyard controller, hence their claims are not used. If no verified identity is available, an empty string is returned
+func getRequestUser(c *gin.Context) string {
+	if c.GetHeader("x-token") != "" {
+		return APITokenUser
+	}
+	return ""
+}
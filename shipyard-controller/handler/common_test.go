@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -209,3 +210,48 @@ func TestSetInternalServerErrorResponse(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, r.Code)
 	assert.Equal(t, "message", *createdErr.Message)
 }
+
+func TestGetRequestUser(t *testing.T) {
+	// payload: {"sub":"1234","preferred_username":"jane.doe"}
+	unverifiedToken := "Bearer eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1234","preferred_username":"jane.doe"}`)) + ".signature"
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:    "user headers set by the client are ignored",
+			headers: map[string]string{"X-Auth-Request-User": "jane.doe", "x-token": "my-token"},
+			want:    APITokenUser,
+		},
+		{
+			name:    "api token",
+			headers: map[string]string{"x-token": "my-token"},
+			want:    APITokenUser,
+		},
+		{
+			name:    "claims of bearer tokens are ignored",
+			headers: map[string]string{"Authorization": unverifiedToken, "x-token": "my-token"},
+			want:    APITokenUser,
+		},
+		{
+			name:    "bearer token only",
+			headers: map[string]string{"Authorization": unverifiedToken},
+			want:    "",
+		},
+		{
+			name: "no token",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			for key, value := range tt.headers {
+				c.Request.Header.Set(key, value)
+			}
+			assert.Equal(t, tt.want, getRequestUser(c))
+		})
+	}
+}
@@ -17,6 +17,8 @@ var ErrInvalidStageChange = errors.New("stage name cannot be changed or removed"
 
 var ErrStageNotFound = errors.New("stage not found")
 
+var ErrFreezeWindowNotFound = errors.New("freeze window not found")
+
 var ErrChangesRollback = errors.New("failed to rollback changes")
 
 var ErrSequencePaused = errors.New("sequence is paused")
@@ -25,10 +27,26 @@ var ErrSequenceBlocked = errors.New("sequence is currently blocked")
 
 var ErrSequenceBlockedWaiting = errors.New("sequence is currently blocked by waiting for another sequence to end")
 
+// SequenceBlockedError indicates that a sequence has to wait before it can be started. It wraps ErrSequenceBlockedWaiting
+type SequenceBlockedError struct {
+	// Reason is a human-readable description of why the sequence is waiting
+	Reason string
+}
+
+func (e *SequenceBlockedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrSequenceBlockedWaiting.Error(), e.Reason)
+}
+
+func (e *SequenceBlockedError) Unwrap() error {
+	return ErrSequenceBlockedWaiting
+}
+
 var ErrNoMatchingEvent = errors.New("no matching event found")
 
 var ErrSequenceNotFound = errors.New("sequence not found")
 
+var ErrSequenceNotRestartable = errors.New("sequence cannot be restarted")
+
 var ErrInternalError = errors.New("internal server error")
 
 var InvalidRequestFormatMsg = "Invalid request format: %s"
@@ -49,6 +67,8 @@ var UnableControleSequenceMsg = "Unable to control sequence: %s"
 
 var UnableFindSequenceMsg = "Unable to control sequence: %s"
 
+var UnableQuerySequenceHistoryMsg = "Unable to query sequence history: %s"
+
 var UnableQueryIntegrationsMsg = "Unable to query uniform integrations repository: %s"
 
 var UnableMarshallProvisioningData = "Error marshalling provisioning data: %s"
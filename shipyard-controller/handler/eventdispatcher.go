@@ -14,6 +14,7 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
@@ -137,6 +138,12 @@ func (e *EventDispatcher) dispatchEvents() {
 		log.Debugf("could not fetch event queue: %s", err.Error())
 	}
 
+	queueSize := map[string]int{}
+	for _, queueItem := range events {
+		queueSize[queueItem.Scope.Project]++
+	}
+	metrics.SetPerProject(metrics.EventQueueSize, queueSize)
+
 	for _, queueItem := range events {
 		log.Infof("Dispatching event with ID %s", queueItem.EventID)
 		events, err := e.eventRepo.GetEvents(queueItem.Scope.Project, common.EventFilter{ID: &queueItem.EventID}, common.TriggeredEvent)
@@ -171,6 +178,8 @@ func (e *EventDispatcher) dispatchEvents() {
 			continue
 		}
 
+		metrics.ObserveDuration(metrics.EventDispatchLatency.WithLabelValues(queueItem.Scope.Project), queueItem.Timestamp, e.theClock.Now())
+
 		if err := e.eventQueueRepo.DeleteQueuedEvent(queueItem.EventID); err != nil {
 			log.Errorf("could not delete event from event queue: %s", err.Error())
 			continue
@@ -223,7 +232,7 @@ func checkStarted(startedSequenceExecutions []models.SequenceExecution, event mo
 	if startedSequenceExecutions != nil && len(startedSequenceExecutions) > 0 {
 		// if there is another sequence with the state 'started'
 		for _, otherSequence := range startedSequenceExecutions {
-			if otherSequence.Status.CurrentTask.TriggeredID != event.Event.ID() {
+			if !otherSequence.Status.CurrentTask.HasTriggeredID(event.Event.ID()) {
 				if !e.isCurrentEventOverrulingOtherEvent(otherSequence, event) {
 					return errors.New(fmt.Sprint(OtherActiveSequencesRunning, otherSequence.Scope.KeptnContext))
 				}
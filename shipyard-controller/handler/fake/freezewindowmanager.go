@@ -0,0 +1,254 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+)
+
+// IFreezeWindowManagerMock is a mock implementation of handler.IFreezeWindowManager.
+//
+// 	func TestSomethingThatUsesIFreezeWindowManager(t *testing.T) {
+//
+// 		// make and configure a mocked handler.IFreezeWindowManager
+// 		mockedIFreezeWindowManager := &IFreezeWindowManagerMock{
+// 			CreateFreezeWindowFunc: func(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error) {
+// 				panic("mock out the CreateFreezeWindow method")
+// 			},
+// 			DeleteFreezeWindowFunc: func(project string, id string) error {
+// 				panic("mock out the DeleteFreezeWindow method")
+// 			},
+// 			GetFreezeWindowFunc: func(project string, id string) (*scmodels.FreezeWindow, error) {
+// 				panic("mock out the GetFreezeWindow method")
+// 			},
+// 			GetFreezeWindowsFunc: func(params scmodels.GetFreezeWindowParams) ([]scmodels.FreezeWindow, error) {
+// 				panic("mock out the GetFreezeWindows method")
+// 			},
+// 			UpdateFreezeWindowFunc: func(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error) {
+// 				panic("mock out the UpdateFreezeWindow method")
+// 			},
+// 		}
+//
+// 		// use mockedIFreezeWindowManager in code that requires handler.IFreezeWindowManager
+// 		// and then make assertions.
+//
+// 	}
+type IFreezeWindowManagerMock struct {
+	// CreateFreezeWindowFunc mocks the CreateFreezeWindow method.
+	CreateFreezeWindowFunc func(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error)
+
+	// DeleteFreezeWindowFunc mocks the DeleteFreezeWindow method.
+	DeleteFreezeWindowFunc func(project string, id string) error
+
+	// GetFreezeWindowFunc mocks the GetFreezeWindow method.
+	GetFreezeWindowFunc func(project string, id string) (*scmodels.FreezeWindow, error)
+
+	// GetFreezeWindowsFunc mocks the GetFreezeWindows method.
+	GetFreezeWindowsFunc func(params scmodels.GetFreezeWindowParams) ([]scmodels.FreezeWindow, error)
+
+	// UpdateFreezeWindowFunc mocks the UpdateFreezeWindow method.
+	UpdateFreezeWindowFunc func(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateFreezeWindow holds details about calls to the CreateFreezeWindow method.
+		CreateFreezeWindow []struct {
+			// FreezeWindow is the freezeWindow argument value.
+			FreezeWindow scmodels.FreezeWindow
+		}
+		// DeleteFreezeWindow holds details about calls to the DeleteFreezeWindow method.
+		DeleteFreezeWindow []struct {
+			// Project is the project argument value.
+			Project string
+			// ID is the id argument value.
+			ID string
+		}
+		// GetFreezeWindow holds details about calls to the GetFreezeWindow method.
+		GetFreezeWindow []struct {
+			// Project is the project argument value.
+			Project string
+			// ID is the id argument value.
+			ID string
+		}
+		// GetFreezeWindows holds details about calls to the GetFreezeWindows method.
+		GetFreezeWindows []struct {
+			// Params is the params argument value.
+			Params scmodels.GetFreezeWindowParams
+		}
+		// UpdateFreezeWindow holds details about calls to the UpdateFreezeWindow method.
+		UpdateFreezeWindow []struct {
+			// FreezeWindow is the freezeWindow argument value.
+			FreezeWindow scmodels.FreezeWindow
+		}
+	}
+	lockCreateFreezeWindow sync.RWMutex
+	lockDeleteFreezeWindow sync.RWMutex
+	lockGetFreezeWindow    sync.RWMutex
+	lockGetFreezeWindows   sync.RWMutex
+	lockUpdateFreezeWindow sync.RWMutex
+}
+
+// CreateFreezeWindow calls CreateFreezeWindowFunc.
+func (mock *IFreezeWindowManagerMock) CreateFreezeWindow(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error) {
+	if mock.CreateFreezeWindowFunc == nil {
+		panic("IFreezeWindowManagerMock.CreateFreezeWindowFunc: method is nil but IFreezeWindowManager.CreateFreezeWindow was just called")
+	}
+	callInfo := struct {
+		FreezeWindow scmodels.FreezeWindow
+	}{
+		FreezeWindow: freezeWindow,
+	}
+	mock.lockCreateFreezeWindow.Lock()
+	mock.calls.CreateFreezeWindow = append(mock.calls.CreateFreezeWindow, callInfo)
+	mock.lockCreateFreezeWindow.Unlock()
+	return mock.CreateFreezeWindowFunc(freezeWindow)
+}
+
+// CreateFreezeWindowCalls gets all the calls that were made to CreateFreezeWindow.
+// Check the length with:
+//     len(mockedIFreezeWindowManager.CreateFreezeWindowCalls())
+func (mock *IFreezeWindowManagerMock) CreateFreezeWindowCalls() []struct {
+	FreezeWindow scmodels.FreezeWindow
+} {
+	var calls []struct {
+		FreezeWindow scmodels.FreezeWindow
+	}
+	mock.lockCreateFreezeWindow.RLock()
+	calls = mock.calls.CreateFreezeWindow
+	mock.lockCreateFreezeWindow.RUnlock()
+	return calls
+}
+
+// DeleteFreezeWindow calls DeleteFreezeWindowFunc.
+func (mock *IFreezeWindowManagerMock) DeleteFreezeWindow(project string, id string) error {
+	if mock.DeleteFreezeWindowFunc == nil {
+		panic("IFreezeWindowManagerMock.DeleteFreezeWindowFunc: method is nil but IFreezeWindowManager.DeleteFreezeWindow was just called")
+	}
+	callInfo := struct {
+		Project string
+		ID      string
+	}{
+		Project: project,
+		ID:      id,
+	}
+	mock.lockDeleteFreezeWindow.Lock()
+	mock.calls.DeleteFreezeWindow = append(mock.calls.DeleteFreezeWindow, callInfo)
+	mock.lockDeleteFreezeWindow.Unlock()
+	return mock.DeleteFreezeWindowFunc(project, id)
+}
+
+// DeleteFreezeWindowCalls gets all the calls that were made to DeleteFreezeWindow.
+// Check the length with:
+//     len(mockedIFreezeWindowManager.DeleteFreezeWindowCalls())
+func (mock *IFreezeWindowManagerMock) DeleteFreezeWindowCalls() []struct {
+	Project string
+	ID      string
+} {
+	var calls []struct {
+		Project string
+		ID      string
+	}
+	mock.lockDeleteFreezeWindow.RLock()
+	calls = mock.calls.DeleteFreezeWindow
+	mock.lockDeleteFreezeWindow.RUnlock()
+	return calls
+}
+
+// GetFreezeWindow calls GetFreezeWindowFunc.
+func (mock *IFreezeWindowManagerMock) GetFreezeWindow(project string, id string) (*scmodels.FreezeWindow, error) {
+	if mock.GetFreezeWindowFunc == nil {
+		panic("IFreezeWindowManagerMock.GetFreezeWindowFunc: method is nil but IFreezeWindowManager.GetFreezeWindow was just called")
+	}
+	callInfo := struct {
+		Project string
+		ID      string
+	}{
+		Project: project,
+		ID:      id,
+	}
+	mock.lockGetFreezeWindow.Lock()
+	mock.calls.GetFreezeWindow = append(mock.calls.GetFreezeWindow, callInfo)
+	mock.lockGetFreezeWindow.Unlock()
+	return mock.GetFreezeWindowFunc(project, id)
+}
+
+// GetFreezeWindowCalls gets all the calls that were made to GetFreezeWindow.
+// Check the length with:
+//     len(mockedIFreezeWindowManager.GetFreezeWindowCalls())
+func (mock *IFreezeWindowManagerMock) GetFreezeWindowCalls() []struct {
+	Project string
+	ID      string
+} {
+	var calls []struct {
+		Project string
+		ID      string
+	}
+	mock.lockGetFreezeWindow.RLock()
+	calls = mock.calls.GetFreezeWindow
+	mock.lockGetFreezeWindow.RUnlock()
+	return calls
+}
+
+// GetFreezeWindows calls GetFreezeWindowsFunc.
+func (mock *IFreezeWindowManagerMock) GetFreezeWindows(params scmodels.GetFreezeWindowParams) ([]scmodels.FreezeWindow, error) {
+	if mock.GetFreezeWindowsFunc == nil {
+		panic("IFreezeWindowManagerMock.GetFreezeWindowsFunc: method is nil but IFreezeWindowManager.GetFreezeWindows was just called")
+	}
+	callInfo := struct {
+		Params scmodels.GetFreezeWindowParams
+	}{
+		Params: params,
+	}
+	mock.lockGetFreezeWindows.Lock()
+	mock.calls.GetFreezeWindows = append(mock.calls.GetFreezeWindows, callInfo)
+	mock.lockGetFreezeWindows.Unlock()
+	return mock.GetFreezeWindowsFunc(params)
+}
+
+// GetFreezeWindowsCalls gets all the calls that were made to GetFreezeWindows.
+// Check the length with:
+//     len(mockedIFreezeWindowManager.GetFreezeWindowsCalls())
+func (mock *IFreezeWindowManagerMock) GetFreezeWindowsCalls() []struct {
+	Params scmodels.GetFreezeWindowParams
+} {
+	var calls []struct {
+		Params scmodels.GetFreezeWindowParams
+	}
+	mock.lockGetFreezeWindows.RLock()
+	calls = mock.calls.GetFreezeWindows
+	mock.lockGetFreezeWindows.RUnlock()
+	return calls
+}
+
+// UpdateFreezeWindow calls UpdateFreezeWindowFunc.
+func (mock *IFreezeWindowManagerMock) UpdateFreezeWindow(freezeWindow scmodels.FreezeWindow) (*scmodels.FreezeWindow, error) {
+	if mock.UpdateFreezeWindowFunc == nil {
+		panic("IFreezeWindowManagerMock.UpdateFreezeWindowFunc: method is nil but IFreezeWindowManager.UpdateFreezeWindow was just called")
+	}
+	callInfo := struct {
+		FreezeWindow scmodels.FreezeWindow
+	}{
+		FreezeWindow: freezeWindow,
+	}
+	mock.lockUpdateFreezeWindow.Lock()
+	mock.calls.UpdateFreezeWindow = append(mock.calls.UpdateFreezeWindow, callInfo)
+	mock.lockUpdateFreezeWindow.Unlock()
+	return mock.UpdateFreezeWindowFunc(freezeWindow)
+}
+
+// UpdateFreezeWindowCalls gets all the calls that were made to UpdateFreezeWindow.
+// Check the length with:
+//     len(mockedIFreezeWindowManager.UpdateFreezeWindowCalls())
+func (mock *IFreezeWindowManagerMock) UpdateFreezeWindowCalls() []struct {
+	FreezeWindow scmodels.FreezeWindow
+} {
+	var calls []struct {
+		FreezeWindow scmodels.FreezeWindow
+	}
+	mock.lockUpdateFreezeWindow.RLock()
+	calls = mock.calls.UpdateFreezeWindow
+	mock.lockUpdateFreezeWindow.RUnlock()
+	return calls
+}
@@ -0,0 +1,254 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+)
+
+// IScheduleManagerMock is a mock implementation of handler.IScheduleManager.
+//
+// 	func TestSomethingThatUsesIScheduleManager(t *testing.T) {
+//
+// 		// make and configure a mocked handler.IScheduleManager
+// 		mockedIScheduleManager := &IScheduleManagerMock{
+// 			CreateScheduleFunc: func(schedule scmodels.Schedule) (*scmodels.Schedule, error) {
+// 				panic("mock out the CreateSchedule method")
+// 			},
+// 			DeleteScheduleFunc: func(project string, id string) error {
+// 				panic("mock out the DeleteSchedule method")
+// 			},
+// 			GetScheduleFunc: func(project string, id string) (*scmodels.Schedule, error) {
+// 				panic("mock out the GetSchedule method")
+// 			},
+// 			GetSchedulesFunc: func(params scmodels.GetScheduleParams) ([]scmodels.Schedule, error) {
+// 				panic("mock out the GetSchedules method")
+// 			},
+// 			UpdateScheduleFunc: func(schedule scmodels.Schedule) (*scmodels.Schedule, error) {
+// 				panic("mock out the UpdateSchedule method")
+// 			},
+// 		}
+//
+// 		// use mockedIScheduleManager in code that requires handler.IScheduleManager
+// 		// and then make assertions.
+//
+// 	}
+type IScheduleManagerMock struct {
+	// CreateScheduleFunc mocks the CreateSchedule method.
+	CreateScheduleFunc func(schedule scmodels.Schedule) (*scmodels.Schedule, error)
+
+	// DeleteScheduleFunc mocks the DeleteSchedule method.
+	DeleteScheduleFunc func(project string, id string) error
+
+	// GetScheduleFunc mocks the GetSchedule method.
+	GetScheduleFunc func(project string, id string) (*scmodels.Schedule, error)
+
+	// GetSchedulesFunc mocks the GetSchedules method.
+	GetSchedulesFunc func(params scmodels.GetScheduleParams) ([]scmodels.Schedule, error)
+
+	// UpdateScheduleFunc mocks the UpdateSchedule method.
+	UpdateScheduleFunc func(schedule scmodels.Schedule) (*scmodels.Schedule, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateSchedule holds details about calls to the CreateSchedule method.
+		CreateSchedule []struct {
+			// Schedule is the schedule argument value.
+			Schedule scmodels.Schedule
+		}
+		// DeleteSchedule holds details about calls to the DeleteSchedule method.
+		DeleteSchedule []struct {
+			// Project is the project argument value.
+			Project string
+			// ID is the id argument value.
+			ID string
+		}
+		// GetSchedule holds details about calls to the GetSchedule method.
+		GetSchedule []struct {
+			// Project is the project argument value.
+			Project string
+			// ID is the id argument value.
+			ID string
+		}
+		// GetSchedules holds details about calls to the GetSchedules method.
+		GetSchedules []struct {
+			// Params is the params argument value.
+			Params scmodels.GetScheduleParams
+		}
+		// UpdateSchedule holds details about calls to the UpdateSchedule method.
+		UpdateSchedule []struct {
+			// Schedule is the schedule argument value.
+			Schedule scmodels.Schedule
+		}
+	}
+	lockCreateSchedule sync.RWMutex
+	lockDeleteSchedule sync.RWMutex
+	lockGetSchedule    sync.RWMutex
+	lockGetSchedules   sync.RWMutex
+	lockUpdateSchedule sync.RWMutex
+}
+
+// CreateSchedule calls CreateScheduleFunc.
+func (mock *IScheduleManagerMock) CreateSchedule(schedule scmodels.Schedule) (*scmodels.Schedule, error) {
+	if mock.CreateScheduleFunc == nil {
+		panic("IScheduleManagerMock.CreateScheduleFunc: method is nil but IScheduleManager.CreateSchedule was just called")
+	}
+	callInfo := struct {
+		Schedule scmodels.Schedule
+	}{
+		Schedule: schedule,
+	}
+	mock.lockCreateSchedule.Lock()
+	mock.calls.CreateSchedule = append(mock.calls.CreateSchedule, callInfo)
+	mock.lockCreateSchedule.Unlock()
+	return mock.CreateScheduleFunc(schedule)
+}
+
+// CreateScheduleCalls gets all the calls that were made to CreateSchedule.
+// Check the length with:
+//     len(mockedIScheduleManager.CreateScheduleCalls())
+func (mock *IScheduleManagerMock) CreateScheduleCalls() []struct {
+	Schedule scmodels.Schedule
+} {
+	var calls []struct {
+		Schedule scmodels.Schedule
+	}
+	mock.lockCreateSchedule.RLock()
+	calls = mock.calls.CreateSchedule
+	mock.lockCreateSchedule.RUnlock()
+	return calls
+}
+
+// DeleteSchedule calls DeleteScheduleFunc.
+func (mock *IScheduleManagerMock) DeleteSchedule(project string, id string) error {
+	if mock.DeleteScheduleFunc == nil {
+		panic("IScheduleManagerMock.DeleteScheduleFunc: method is nil but IScheduleManager.DeleteSchedule was just called")
+	}
+	callInfo := struct {
+		Project string
+		ID      string
+	}{
+		Project: project,
+		ID:      id,
+	}
+	mock.lockDeleteSchedule.Lock()
+	mock.calls.DeleteSchedule = append(mock.calls.DeleteSchedule, callInfo)
+	mock.lockDeleteSchedule.Unlock()
+	return mock.DeleteScheduleFunc(project, id)
+}
+
+// DeleteScheduleCalls gets all the calls that were made to DeleteSchedule.
+// Check the length with:
+//     len(mockedIScheduleManager.DeleteScheduleCalls())
+func (mock *IScheduleManagerMock) DeleteScheduleCalls() []struct {
+	Project string
+	ID      string
+} {
+	var calls []struct {
+		Project string
+		ID      string
+	}
+	mock.lockDeleteSchedule.RLock()
+	calls = mock.calls.DeleteSchedule
+	mock.lockDeleteSchedule.RUnlock()
+	return calls
+}
+
+// GetSchedule calls GetScheduleFunc.
+func (mock *IScheduleManagerMock) GetSchedule(project string, id string) (*scmodels.Schedule, error) {
+	if mock.GetScheduleFunc == nil {
+		panic("IScheduleManagerMock.GetScheduleFunc: method is nil but IScheduleManager.GetSchedule was just called")
+	}
+	callInfo := struct {
+		Project string
+		ID      string
+	}{
+		Project: project,
+		ID:      id,
+	}
+	mock.lockGetSchedule.Lock()
+	mock.calls.GetSchedule = append(mock.calls.GetSchedule, callInfo)
+	mock.lockGetSchedule.Unlock()
+	return mock.GetScheduleFunc(project, id)
+}
+
+// GetScheduleCalls gets all the calls that were made to GetSchedule.
+// Check the length with:
+//     len(mockedIScheduleManager.GetScheduleCalls())
+func (mock *IScheduleManagerMock) GetScheduleCalls() []struct {
+	Project string
+	ID      string
+} {
+	var calls []struct {
+		Project string
+		ID      string
+	}
+	mock.lockGetSchedule.RLock()
+	calls = mock.calls.GetSchedule
+	mock.lockGetSchedule.RUnlock()
+	return calls
+}
+
+// GetSchedules calls GetSchedulesFunc.
+func (mock *IScheduleManagerMock) GetSchedules(params scmodels.GetScheduleParams) ([]scmodels.Schedule, error) {
+	if mock.GetSchedulesFunc == nil {
+		panic("IScheduleManagerMock.GetSchedulesFunc: method is nil but IScheduleManager.GetSchedules was just called")
+	}
+	callInfo := struct {
+		Params scmodels.GetScheduleParams
+	}{
+		Params: params,
+	}
+	mock.lockGetSchedules.Lock()
+	mock.calls.GetSchedules = append(mock.calls.GetSchedules, callInfo)
+	mock.lockGetSchedules.Unlock()
+	return mock.GetSchedulesFunc(params)
+}
+
+// GetSchedulesCalls gets all the calls that were made to GetSchedules.
+// Check the length with:
+//     len(mockedIScheduleManager.GetSchedulesCalls())
+func (mock *IScheduleManagerMock) GetSchedulesCalls() []struct {
+	Params scmodels.GetScheduleParams
+} {
+	var calls []struct {
+		Params scmodels.GetScheduleParams
+	}
+	mock.lockGetSchedules.RLock()
+	calls = mock.calls.GetSchedules
+	mock.lockGetSchedules.RUnlock()
+	return calls
+}
+
+// UpdateSchedule calls UpdateScheduleFunc.
+func (mock *IScheduleManagerMock) UpdateSchedule(schedule scmodels.Schedule) (*scmodels.Schedule, error) {
+	if mock.UpdateScheduleFunc == nil {
+		panic("IScheduleManagerMock.UpdateScheduleFunc: method is nil but IScheduleManager.UpdateSchedule was just called")
+	}
+	callInfo := struct {
+		Schedule scmodels.Schedule
+	}{
+		Schedule: schedule,
+	}
+	mock.lockUpdateSchedule.Lock()
+	mock.calls.UpdateSchedule = append(mock.calls.UpdateSchedule, callInfo)
+	mock.lockUpdateSchedule.Unlock()
+	return mock.UpdateScheduleFunc(schedule)
+}
+
+// UpdateScheduleCalls gets all the calls that were made to UpdateSchedule.
+// Check the length with:
+//     len(mockedIScheduleManager.UpdateScheduleCalls())
+func (mock *IScheduleManagerMock) UpdateScheduleCalls() []struct {
+	Schedule scmodels.Schedule
+} {
+	var calls []struct {
+		Schedule scmodels.Schedule
+	}
+	mock.lockUpdateSchedule.RLock()
+	calls = mock.calls.UpdateSchedule
+	mock.lockUpdateSchedule.RUnlock()
+	return calls
+}
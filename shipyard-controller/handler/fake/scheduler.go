@@ -0,0 +1,106 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"context"
+	"sync"
+)
+
+// ISchedulerMock is a mock implementation of handler.IScheduler.
+//
+// 	func TestSomethingThatUsesIScheduler(t *testing.T) {
+//
+// 		// make and configure a mocked handler.IScheduler
+// 		mockedIScheduler := &ISchedulerMock{
+// 			RunFunc: func(ctx context.Context)  {
+// 				panic("mock out the Run method")
+// 			},
+// 			StopFunc: func()  {
+// 				panic("mock out the Stop method")
+// 			},
+// 		}
+//
+// 		// use mockedIScheduler in code that requires handler.IScheduler
+// 		// and then make assertions.
+//
+// 	}
+type ISchedulerMock struct {
+	// RunFunc mocks the Run method.
+	RunFunc func(ctx context.Context)
+
+	// StopFunc mocks the Stop method.
+	StopFunc func()
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Run holds details about calls to the Run method.
+		Run []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Stop holds details about calls to the Stop method.
+		Stop []struct {
+		}
+	}
+	lockRun  sync.RWMutex
+	lockStop sync.RWMutex
+}
+
+// Run calls RunFunc.
+func (mock *ISchedulerMock) Run(ctx context.Context) {
+	if mock.RunFunc == nil {
+		panic("ISchedulerMock.RunFunc: method is nil but IScheduler.Run was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRun.Lock()
+	mock.calls.Run = append(mock.calls.Run, callInfo)
+	mock.lockRun.Unlock()
+	mock.RunFunc(ctx)
+}
+
+// RunCalls gets all the calls that were made to Run.
+// Check the length with:
+//     len(mockedIScheduler.RunCalls())
+func (mock *ISchedulerMock) RunCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRun.RLock()
+	calls = mock.calls.Run
+	mock.lockRun.RUnlock()
+	return calls
+}
+
+// Stop calls StopFunc.
+func (mock *ISchedulerMock) Stop() {
+	if mock.StopFunc == nil {
+		panic("ISchedulerMock.StopFunc: method is nil but IScheduler.Stop was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockStop.Lock()
+	mock.calls.Stop = append(mock.calls.Stop, callInfo)
+	mock.lockStop.Unlock()
+	mock.StopFunc()
+}
+
+// StopCalls gets all the calls that were made to Stop.
+// Check the length with:
+//     len(mockedIScheduler.StopCalls())
+func (mock *ISchedulerMock) StopCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockStop.RLock()
+	calls = mock.calls.Stop
+	mock.lockStop.RUnlock()
+	return calls
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
@@ -20,10 +21,13 @@ import (
 // 			AddFunc: func(queueItem models.QueueItem) error {
 // 				panic("mock out the Add method")
 // 			},
-// 			RemoveFunc: func(eventScope apimodels.KeptnContextExtendedCEScope) error {
+// 			AddSequenceWaitingHookFunc: func(hook sequencehooks.ISequenceWaitingHook)  {
+// 				panic("mock out the AddSequenceWaitingHook method")
+// 			},
+// 			RemoveFunc: func(eventScope models.EventScope) error {
 // 				panic("mock out the Remove method")
 // 			},
-// 			RunFunc: func(ctx context.Context, startSequenceFunc func(event apimodels.KeptnContextExtendedCE) error)  {
+// 			RunFunc: func(ctx context.Context, mode common.SDMode, startSequenceFunc func(event apimodels.KeptnContextExtendedCE) error)  {
 // 				panic("mock out the Run method")
 // 			},
 // 			StopFunc: func()  {
@@ -39,6 +43,9 @@ type ISequenceDispatcherMock struct {
 	// AddFunc mocks the Add method.
 	AddFunc func(queueItem models.QueueItem) error
 
+	// AddSequenceWaitingHookFunc mocks the AddSequenceWaitingHook method.
+	AddSequenceWaitingHookFunc func(hook sequencehooks.ISequenceWaitingHook)
+
 	// RemoveFunc mocks the Remove method.
 	RemoveFunc func(eventScope models.EventScope) error
 
@@ -55,6 +62,11 @@ type ISequenceDispatcherMock struct {
 			// QueueItem is the queueItem argument value.
 			QueueItem models.QueueItem
 		}
+		// AddSequenceWaitingHook holds details about calls to the AddSequenceWaitingHook method.
+		AddSequenceWaitingHook []struct {
+			// Hook is the hook argument value.
+			Hook sequencehooks.ISequenceWaitingHook
+		}
 		// Remove holds details about calls to the Remove method.
 		Remove []struct {
 			// EventScope is the eventScope argument value.
@@ -64,6 +76,8 @@ type ISequenceDispatcherMock struct {
 		Run []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Mode is the mode argument value.
+			Mode common.SDMode
 			// StartSequenceFunc is the startSequenceFunc argument value.
 			StartSequenceFunc func(event apimodels.KeptnContextExtendedCE) error
 		}
@@ -71,10 +85,11 @@ type ISequenceDispatcherMock struct {
 		Stop []struct {
 		}
 	}
-	lockAdd    sync.RWMutex
-	lockRemove sync.RWMutex
-	lockRun    sync.RWMutex
-	lockStop   sync.RWMutex
+	lockAdd                    sync.RWMutex
+	lockAddSequenceWaitingHook sync.RWMutex
+	lockRemove                 sync.RWMutex
+	lockRun                    sync.RWMutex
+	lockStop                   sync.RWMutex
 }
 
 // Add calls AddFunc.
@@ -95,6 +110,7 @@ func (mock *ISequenceDispatcherMock) Add(queueItem models.QueueItem) error {
 
 // AddCalls gets all the calls that were made to Add.
 // Check the length with:
+//
 //     len(mockedISequenceDispatcher.AddCalls())
 func (mock *ISequenceDispatcherMock) AddCalls() []struct {
 	QueueItem models.QueueItem
@@ -108,6 +124,38 @@ func (mock *ISequenceDispatcherMock) AddCalls() []struct {
 	return calls
 }
 
+// AddSequenceWaitingHook calls AddSequenceWaitingHookFunc.
+func (mock *ISequenceDispatcherMock) AddSequenceWaitingHook(hook sequencehooks.ISequenceWaitingHook) {
+	if mock.AddSequenceWaitingHookFunc == nil {
+		panic("ISequenceDispatcherMock.AddSequenceWaitingHookFunc: method is nil but ISequenceDispatcher.AddSequenceWaitingHook was just called")
+	}
+	callInfo := struct {
+		Hook sequencehooks.ISequenceWaitingHook
+	}{
+		Hook: hook,
+	}
+	mock.lockAddSequenceWaitingHook.Lock()
+	mock.calls.AddSequenceWaitingHook = append(mock.calls.AddSequenceWaitingHook, callInfo)
+	mock.lockAddSequenceWaitingHook.Unlock()
+	mock.AddSequenceWaitingHookFunc(hook)
+}
+
+// AddSequenceWaitingHookCalls gets all the calls that were made to AddSequenceWaitingHook.
+// Check the length with:
+//
+//     len(mockedISequenceDispatcher.AddSequenceWaitingHookCalls())
+func (mock *ISequenceDispatcherMock) AddSequenceWaitingHookCalls() []struct {
+	Hook sequencehooks.ISequenceWaitingHook
+} {
+	var calls []struct {
+		Hook sequencehooks.ISequenceWaitingHook
+	}
+	mock.lockAddSequenceWaitingHook.RLock()
+	calls = mock.calls.AddSequenceWaitingHook
+	mock.lockAddSequenceWaitingHook.RUnlock()
+	return calls
+}
+
 // Remove calls RemoveFunc.
 func (mock *ISequenceDispatcherMock) Remove(eventScope models.EventScope) error {
 	if mock.RemoveFunc == nil {
@@ -126,6 +174,7 @@ func (mock *ISequenceDispatcherMock) Remove(eventScope models.EventScope) error
 
 // RemoveCalls gets all the calls that were made to Remove.
 // Check the length with:
+//
 //     len(mockedISequenceDispatcher.RemoveCalls())
 func (mock *ISequenceDispatcherMock) RemoveCalls() []struct {
 	EventScope models.EventScope
@@ -146,9 +195,11 @@ func (mock *ISequenceDispatcherMock) Run(ctx context.Context, mode common.SDMode
 	}
 	callInfo := struct {
 		Ctx               context.Context
+		Mode              common.SDMode
 		StartSequenceFunc func(event apimodels.KeptnContextExtendedCE) error
 	}{
 		Ctx:               ctx,
+		Mode:              mode,
 		StartSequenceFunc: startSequenceFunc,
 	}
 	mock.lockRun.Lock()
@@ -159,13 +210,16 @@ func (mock *ISequenceDispatcherMock) Run(ctx context.Context, mode common.SDMode
 
 // RunCalls gets all the calls that were made to Run.
 // Check the length with:
+//
 //     len(mockedISequenceDispatcher.RunCalls())
 func (mock *ISequenceDispatcherMock) RunCalls() []struct {
 	Ctx               context.Context
+	Mode              common.SDMode
 	StartSequenceFunc func(event apimodels.KeptnContextExtendedCE) error
 } {
 	var calls []struct {
 		Ctx               context.Context
+		Mode              common.SDMode
 		StartSequenceFunc func(event apimodels.KeptnContextExtendedCE) error
 	}
 	mock.lockRun.RLock()
@@ -189,6 +243,7 @@ func (mock *ISequenceDispatcherMock) Stop() {
 
 // StopCalls gets all the calls that were made to Stop.
 // Check the length with:
+//
 //     len(mockedISequenceDispatcher.StopCalls())
 func (mock *ISequenceDispatcherMock) StopCalls() []struct {
 } {
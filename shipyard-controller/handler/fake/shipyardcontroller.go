@@ -7,6 +7,7 @@ import (
 	"context"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/common"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -16,7 +17,7 @@ import (
 //
 // 		// make and configure a mocked handler.IShipyardController
 // 		mockedIShipyardController := &IShipyardControllerMock{
-// 			ControlSequenceFunc: func(controlSequence models.SequenceControl) error {
+// 			ControlSequenceFunc: func(controlSequence scmodels.SequenceControl) error {
 // 				panic("mock out the ControlSequence method")
 // 			},
 // 			GetAllTriggeredEventsFunc: func(filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error) {
@@ -28,7 +29,7 @@ import (
 // 			HandleIncomingEventFunc: func(event apimodels.KeptnContextExtendedCE, waitForCompletion bool) error {
 // 				panic("mock out the HandleIncomingEvent method")
 // 			},
-// 			StartDispatchersFunc: func(ctx context.Context)  {
+// 			StartDispatchersFunc: func(ctx context.Context, mode common.SDMode)  {
 // 				panic("mock out the StartDispatchers method")
 // 			},
 // 			StartTaskSequenceFunc: func(event apimodels.KeptnContextExtendedCE) error {
@@ -45,7 +46,7 @@ import (
 // 	}
 type IShipyardControllerMock struct {
 	// ControlSequenceFunc mocks the ControlSequence method.
-	ControlSequenceFunc func(controlSequence apimodels.SequenceControl) error
+	ControlSequenceFunc func(controlSequence scmodels.SequenceControl) error
 
 	// GetAllTriggeredEventsFunc mocks the GetAllTriggeredEvents method.
 	GetAllTriggeredEventsFunc func(filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error)
@@ -70,7 +71,7 @@ type IShipyardControllerMock struct {
 		// ControlSequence holds details about calls to the ControlSequence method.
 		ControlSequence []struct {
 			// ControlSequence is the controlSequence argument value.
-			ControlSequence apimodels.SequenceControl
+			ControlSequence scmodels.SequenceControl
 		}
 		// GetAllTriggeredEvents holds details about calls to the GetAllTriggeredEvents method.
 		GetAllTriggeredEvents []struct {
@@ -86,7 +87,7 @@ type IShipyardControllerMock struct {
 		}
 		// HandleIncomingEvent holds details about calls to the HandleIncomingEvent method.
 		HandleIncomingEvent []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
 			// WaitForCompletion is the waitForCompletion argument value.
 			WaitForCompletion bool
@@ -95,10 +96,12 @@ type IShipyardControllerMock struct {
 		StartDispatchers []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Mode is the mode argument value.
+			Mode common.SDMode
 		}
 		// StartTaskSequence holds details about calls to the StartTaskSequence method.
 		StartTaskSequence []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
 		}
 		// StopDispatchers holds details about calls to the StopDispatchers method.
@@ -115,12 +118,12 @@ type IShipyardControllerMock struct {
 }
 
 // ControlSequence calls ControlSequenceFunc.
-func (mock *IShipyardControllerMock) ControlSequence(controlSequence apimodels.SequenceControl) error {
+func (mock *IShipyardControllerMock) ControlSequence(controlSequence scmodels.SequenceControl) error {
 	if mock.ControlSequenceFunc == nil {
 		panic("IShipyardControllerMock.ControlSequenceFunc: method is nil but IShipyardController.ControlSequence was just called")
 	}
 	callInfo := struct {
-		ControlSequence apimodels.SequenceControl
+		ControlSequence scmodels.SequenceControl
 	}{
 		ControlSequence: controlSequence,
 	}
@@ -134,10 +137,10 @@ func (mock *IShipyardControllerMock) ControlSequence(controlSequence apimodels.S
 // Check the length with:
 //     len(mockedIShipyardController.ControlSequenceCalls())
 func (mock *IShipyardControllerMock) ControlSequenceCalls() []struct {
-	ControlSequence apimodels.SequenceControl
+	ControlSequence scmodels.SequenceControl
 } {
 	var calls []struct {
-		ControlSequence apimodels.SequenceControl
+		ControlSequence scmodels.SequenceControl
 	}
 	mock.lockControlSequence.RLock()
 	calls = mock.calls.ControlSequence
@@ -252,9 +255,11 @@ func (mock *IShipyardControllerMock) StartDispatchers(ctx context.Context, mode
 		panic("IShipyardControllerMock.StartDispatchersFunc: method is nil but IShipyardController.StartDispatchers was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Mode: mode,
 	}
 	mock.lockStartDispatchers.Lock()
 	mock.calls.StartDispatchers = append(mock.calls.StartDispatchers, callInfo)
@@ -266,10 +271,12 @@ func (mock *IShipyardControllerMock) StartDispatchers(ctx context.Context, mode
 // Check the length with:
 //     len(mockedIShipyardController.StartDispatchersCalls())
 func (mock *IShipyardControllerMock) StartDispatchersCalls() []struct {
-	Ctx context.Context
+	Ctx  context.Context
+	Mode common.SDMode
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Mode common.SDMode
 	}
 	mock.lockStartDispatchers.RLock()
 	calls = mock.calls.StartDispatchers
@@ -5,6 +5,7 @@ package fake
 
 import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -17,6 +18,9 @@ import (
 // 			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
 // 				panic("mock out the GetCachedShipyard method")
 // 			},
+// 			GetCachedShipyardExtensionFunc: func(projectName string) (*scmodels.ShipyardExtension, error) {
+// 				panic("mock out the GetCachedShipyardExtension method")
+// 			},
 // 			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
 // 				panic("mock out the GetLatestCommitID method")
 // 			},
@@ -33,6 +37,9 @@ type IShipyardRetrieverMock struct {
 	// GetCachedShipyardFunc mocks the GetCachedShipyard method.
 	GetCachedShipyardFunc func(projectName string) (*keptnv2.Shipyard, error)
 
+	// GetCachedShipyardExtensionFunc mocks the GetCachedShipyardExtension method.
+	GetCachedShipyardExtensionFunc func(projectName string) (*scmodels.ShipyardExtension, error)
+
 	// GetLatestCommitIDFunc mocks the GetLatestCommitID method.
 	GetLatestCommitIDFunc func(projectName string, stageName string) (string, error)
 
@@ -46,6 +53,11 @@ type IShipyardRetrieverMock struct {
 			// ProjectName is the projectName argument value.
 			ProjectName string
 		}
+		// GetCachedShipyardExtension holds details about calls to the GetCachedShipyardExtension method.
+		GetCachedShipyardExtension []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
 		// GetLatestCommitID holds details about calls to the GetLatestCommitID method.
 		GetLatestCommitID []struct {
 			// ProjectName is the projectName argument value.
@@ -59,9 +71,10 @@ type IShipyardRetrieverMock struct {
 			ProjectName string
 		}
 	}
-	lockGetCachedShipyard sync.RWMutex
-	lockGetLatestCommitID sync.RWMutex
-	lockGetShipyard       sync.RWMutex
+	lockGetCachedShipyard          sync.RWMutex
+	lockGetCachedShipyardExtension sync.RWMutex
+	lockGetLatestCommitID          sync.RWMutex
+	lockGetShipyard                sync.RWMutex
 }
 
 // GetCachedShipyard calls GetCachedShipyardFunc.
@@ -95,6 +108,37 @@ func (mock *IShipyardRetrieverMock) GetCachedShipyardCalls() []struct {
 	return calls
 }
 
+// GetCachedShipyardExtension calls GetCachedShipyardExtensionFunc.
+func (mock *IShipyardRetrieverMock) GetCachedShipyardExtension(projectName string) (*scmodels.ShipyardExtension, error) {
+	if mock.GetCachedShipyardExtensionFunc == nil {
+		panic("IShipyardRetrieverMock.GetCachedShipyardExtensionFunc: method is nil but IShipyardRetriever.GetCachedShipyardExtension was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockGetCachedShipyardExtension.Lock()
+	mock.calls.GetCachedShipyardExtension = append(mock.calls.GetCachedShipyardExtension, callInfo)
+	mock.lockGetCachedShipyardExtension.Unlock()
+	return mock.GetCachedShipyardExtensionFunc(projectName)
+}
+
+// GetCachedShipyardExtensionCalls gets all the calls that were made to GetCachedShipyardExtension.
+// Check the length with:
+//     len(mockedIShipyardRetriever.GetCachedShipyardExtensionCalls())
+func (mock *IShipyardRetrieverMock) GetCachedShipyardExtensionCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockGetCachedShipyardExtension.RLock()
+	calls = mock.calls.GetCachedShipyardExtension
+	mock.lockGetCachedShipyardExtension.RUnlock()
+	return calls
+}
+
 // GetLatestCommitID calls GetLatestCommitIDFunc.
 func (mock *IShipyardRetrieverMock) GetLatestCommitID(projectName string, stageName string) (string, error) {
 	if mock.GetLatestCommitIDFunc == nil {
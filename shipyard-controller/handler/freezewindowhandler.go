@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type IFreezeWindowHandler interface {
+	CreateFreezeWindow(context *gin.Context)
+	GetFreezeWindows(context *gin.Context)
+	GetFreezeWindow(context *gin.Context)
+	UpdateFreezeWindow(context *gin.Context)
+	DeleteFreezeWindow(context *gin.Context)
+}
+
+type FreezeWindowHandler struct {
+	freezeWindowManager IFreezeWindowManager
+}
+
+func NewFreezeWindowHandler(freezeWindowManager IFreezeWindowManager) *FreezeWindowHandler {
+	return &FreezeWindowHandler{freezeWindowManager: freezeWindowManager}
+}
+
+// CreateFreezeWindow creates a new freeze window
+// @Summary      Create a new freeze window
+// @Description  Create a new freeze window for a project or a stage. The freeze window is added to the shipyard of the project. While a freeze window is active, no sequences are started in the affected stages
+// @Tags         FreezeWindow
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project       path      string               true  "The name of the project"
+// @Param        freezeWindow  body      models.FreezeWindow  true  "Freeze window"
+// @Success      201           {object}  models.FreezeWindow  "ok"
+// @Failure      400           {object}  models.Error         "Invalid payload"
+// @Failure      404           {object}  models.Error         "Not found"
+// @Failure      500           {object}  models.Error         "Internal error"
+// @Router       /project/{project}/freezewindow [post]
+func (fh *FreezeWindowHandler) CreateFreezeWindow(c *gin.Context) {
+	freezeWindow := &models.FreezeWindow{}
+	if err := c.ShouldBindJSON(freezeWindow); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	freezeWindow.Project = c.Param("project")
+
+	createdFreezeWindow, err := fh.freezeWindowManager.CreateFreezeWindow(*freezeWindow)
+	if err != nil {
+		setFreezeWindowErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, createdFreezeWindow)
+}
+
+// GetFreezeWindows returns the freeze windows of a project
+// @Summary      Get the freeze windows of a project
+// @Description  Get the freeze windows of a project. If a stage is provided, only the freeze windows affecting this stage are returned
+// @Tags         FreezeWindow
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string                true   "The name of the project"
+// @Param        stage    query     string                false  "The name of the stage"
+// @Success      200      {object}  models.FreezeWindows  "ok"
+// @Failure      400      {object}  models.Error          "Invalid payload"
+// @Failure      404      {object}  models.Error          "Not found"
+// @Failure      500      {object}  models.Error          "Internal error"
+// @Router       /project/{project}/freezewindow [get]
+func (fh *FreezeWindowHandler) GetFreezeWindows(c *gin.Context) {
+	params := &models.GetFreezeWindowParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	params.Project = c.Param("project")
+
+	freezeWindows, err := fh.freezeWindowManager.GetFreezeWindows(*params)
+	if err != nil {
+		setFreezeWindowErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.FreezeWindows{FreezeWindows: freezeWindows})
+}
+
+// GetFreezeWindow returns a freeze window
+// @Summary      Get a freeze window
+// @Description  Get a freeze window
+// @Tags         FreezeWindow
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project         path      string               true  "The name of the project"
+// @Param        freezeWindowID  path      string               true  "The ID of the freeze window"
+// @Success      200             {object}  models.FreezeWindow  "ok"
+// @Failure      404             {object}  models.Error         "Not found"
+// @Failure      500             {object}  models.Error         "Internal error"
+// @Router       /project/{project}/freezewindow/{freezeWindowID} [get]
+func (fh *FreezeWindowHandler) GetFreezeWindow(c *gin.Context) {
+	freezeWindow, err := fh.freezeWindowManager.GetFreezeWindow(c.Param("project"), c.Param("freezeWindowID"))
+	if err != nil {
+		setFreezeWindowErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, freezeWindow)
+}
+
+// UpdateFreezeWindow updates a freeze window
+// @Summary      Update a freeze window
+// @Description  Update a freeze window
+// @Tags         FreezeWindow
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project         path      string               true  "The name of the project"
+// @Param        freezeWindowID  path      string               true  "The ID of the freeze window"
+// @Param        freezeWindow    body      models.FreezeWindow  true  "Freeze window"
+// @Success      200             {object}  models.FreezeWindow  "ok"
+// @Failure      400             {object}  models.Error         "Invalid payload"
+// @Failure      404             {object}  models.Error         "Not found"
+// @Failure      500             {object}  models.Error         "Internal error"
+// @Router       /project/{project}/freezewindow/{freezeWindowID} [put]
+func (fh *FreezeWindowHandler) UpdateFreezeWindow(c *gin.Context) {
+	freezeWindow := &models.FreezeWindow{}
+	if err := c.ShouldBindJSON(freezeWindow); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	freezeWindow.Project = c.Param("project")
+	freezeWindow.ID = c.Param("freezeWindowID")
+
+	updatedFreezeWindow, err := fh.freezeWindowManager.UpdateFreezeWindow(*freezeWindow)
+	if err != nil {
+		setFreezeWindowErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updatedFreezeWindow)
+}
+
+// DeleteFreezeWindow deletes a freeze window
+// @Summary      Delete a freeze window
+// @Description  Delete a freeze window
+// @Tags         FreezeWindow
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project         path  string  true  "The name of the project"
+// @Param        freezeWindowID  path  string  true  "The ID of the freeze window"
+// @Success      200
+// @Failure      404  {object}  models.Error  "Not found"
+// @Failure      500  {object}  models.Error  "Internal error"
+// @Router       /project/{project}/freezewindow/{freezeWindowID} [delete]
+func (fh *FreezeWindowHandler) DeleteFreezeWindow(c *gin.Context) {
+	if err := fh.freezeWindowManager.DeleteFreezeWindow(c.Param("project"), c.Param("freezeWindowID")); err != nil {
+		setFreezeWindowErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func setFreezeWindowErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidFreezeWindow):
+		SetBadRequestErrorResponse(c, err.Error())
+	case errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrStageNotFound), errors.Is(err, ErrFreezeWindowNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
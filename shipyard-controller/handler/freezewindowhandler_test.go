@@ -0,0 +1,232 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeWindowHandler_CreateFreezeWindow(t *testing.T) {
+	freezeWindow := models.FreezeWindow{
+		Stage:       "production",
+		Description: "christmas",
+		Start:       "2022-12-24",
+		End:         "2022-12-27",
+	}
+	payload, _ := json.Marshal(freezeWindow)
+
+	tests := []struct {
+		name                string
+		freezeWindowManager *fake.IFreezeWindowManagerMock
+		request             *http.Request
+		wantStatus          int
+	}{
+		{
+			name: "create freeze window",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				CreateFreezeWindowFunc: func(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+					freezeWindow.ID = "my-id"
+					return &freezeWindow, nil
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/freezewindow", bytes.NewReader(payload)),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid freeze window",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				CreateFreezeWindowFunc: func(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+					return nil, fmt.Errorf("%w: end must be after start", models.ErrInvalidFreezeWindow)
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/freezewindow", bytes.NewReader(payload)),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				CreateFreezeWindowFunc: func(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+					return nil, handler.ErrProjectNotFound
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/freezewindow", bytes.NewReader(payload)),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				CreateFreezeWindowFunc: func(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/freezewindow", bytes.NewReader(payload)),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:                "invalid payload",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{},
+			request:             httptest.NewRequest(http.MethodPost, "/project/my-project/freezewindow", bytes.NewReader([]byte("foo"))),
+			wantStatus:          http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fh := handler.NewFreezeWindowHandler(tt.freezeWindowManager)
+
+			router := gin.Default()
+			router.POST("/project/:project/freezewindow", func(c *gin.Context) {
+				fh.CreateFreezeWindow(c)
+			})
+			w := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				require.Len(t, tt.freezeWindowManager.CreateFreezeWindowCalls(), 1)
+				require.Equal(t, "my-project", tt.freezeWindowManager.CreateFreezeWindowCalls()[0].FreezeWindow.Project)
+
+				createdFreezeWindow := &models.FreezeWindow{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), createdFreezeWindow))
+				require.Equal(t, "my-id", createdFreezeWindow.ID)
+			}
+		})
+	}
+}
+
+func TestFreezeWindowHandler_GetFreezeWindows(t *testing.T) {
+	freezeWindowManager := &fake.IFreezeWindowManagerMock{
+		GetFreezeWindowsFunc: func(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+			return []models.FreezeWindow{{ID: "my-id", Project: "my-project", Cron: "0 18 * * 5", Duration: "62h"}}, nil
+		},
+	}
+	fh := handler.NewFreezeWindowHandler(freezeWindowManager)
+
+	router := gin.Default()
+	router.GET("/project/:project/freezewindow", func(c *gin.Context) {
+		fh.GetFreezeWindows(c)
+	})
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/freezewindow?stage=production", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, models.GetFreezeWindowParams{Project: "my-project", Stage: "production"}, freezeWindowManager.GetFreezeWindowsCalls()[0].Params)
+
+	freezeWindows := &models.FreezeWindows{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), freezeWindows))
+	require.Len(t, freezeWindows.FreezeWindows, 1)
+	require.Equal(t, "my-id", freezeWindows.FreezeWindows[0].ID)
+}
+
+func TestFreezeWindowHandler_GetFreezeWindow(t *testing.T) {
+	tests := []struct {
+		name                string
+		freezeWindowManager *fake.IFreezeWindowManagerMock
+		wantStatus          int
+	}{
+		{
+			name: "get freeze window",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				GetFreezeWindowFunc: func(project string, id string) (*models.FreezeWindow, error) {
+					return &models.FreezeWindow{ID: id, Project: project}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "freeze window not found",
+			freezeWindowManager: &fake.IFreezeWindowManagerMock{
+				GetFreezeWindowFunc: func(project string, id string) (*models.FreezeWindow, error) {
+					return nil, handler.ErrFreezeWindowNotFound
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fh := handler.NewFreezeWindowHandler(tt.freezeWindowManager)
+
+			router := gin.Default()
+			router.GET("/project/:project/freezewindow/:freezeWindowID", func(c *gin.Context) {
+				fh.GetFreezeWindow(c)
+			})
+			w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/freezewindow/my-id", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-project", tt.freezeWindowManager.GetFreezeWindowCalls()[0].Project)
+			require.Equal(t, "my-id", tt.freezeWindowManager.GetFreezeWindowCalls()[0].ID)
+		})
+	}
+}
+
+func TestFreezeWindowHandler_UpdateFreezeWindow(t *testing.T) {
+	payload, _ := json.Marshal(models.FreezeWindow{ID: "other-id", Start: "2022-12-24", End: "2022-12-27"})
+
+	freezeWindowManager := &fake.IFreezeWindowManagerMock{
+		UpdateFreezeWindowFunc: func(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+			return &freezeWindow, nil
+		},
+	}
+	fh := handler.NewFreezeWindowHandler(freezeWindowManager)
+
+	router := gin.Default()
+	router.PUT("/project/:project/freezewindow/:freezeWindowID", func(c *gin.Context) {
+		fh.UpdateFreezeWindow(c)
+	})
+	w := performRequest(router, httptest.NewRequest(http.MethodPut, "/project/my-project/freezewindow/my-id", bytes.NewReader(payload)))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	// the ID and project from the path must take precedence over the payload
+	require.Equal(t, "my-id", freezeWindowManager.UpdateFreezeWindowCalls()[0].FreezeWindow.ID)
+	require.Equal(t, "my-project", freezeWindowManager.UpdateFreezeWindowCalls()[0].FreezeWindow.Project)
+}
+
+func TestFreezeWindowHandler_DeleteFreezeWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteErr  error
+		wantStatus int
+	}{
+		{
+			name:       "delete freeze window",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "freeze window not found",
+			deleteErr:  handler.ErrFreezeWindowNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "internal error",
+			deleteErr:  errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freezeWindowManager := &fake.IFreezeWindowManagerMock{
+				DeleteFreezeWindowFunc: func(project string, id string) error {
+					return tt.deleteErr
+				},
+			}
+			fh := handler.NewFreezeWindowHandler(freezeWindowManager)
+
+			router := gin.Default()
+			router.DELETE("/project/:project/freezewindow/:freezeWindowID", func(c *gin.Context) {
+				fh.DeleteFreezeWindow(c)
+			})
+			w := performRequest(router, httptest.NewRequest(http.MethodDelete, "/project/my-project/freezewindow/my-id", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-id", freezeWindowManager.DeleteFreezeWindowCalls()[0].ID)
+		})
+	}
+}
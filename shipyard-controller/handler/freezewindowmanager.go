@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/freezewindowmanager.go . IFreezeWindowManager
+type IFreezeWindowManager interface {
+	CreateFreezeWindow(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error)
+	GetFreezeWindows(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error)
+	GetFreezeWindow(project, id string) (*models.FreezeWindow, error)
+	UpdateFreezeWindow(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error)
+	DeleteFreezeWindow(project, id string) error
+}
+
+// FreezeWindowManager manages the freeze windows of a project, which are declared in the shipyard of the project.
+// Changes are committed to the shipyard in the configuration store, and the freeze windows are read from the shipyard stored in the materialized view,
+// which is also updated whenever the shipyard controller retrieves the shipyard from the configuration store
+type FreezeWindowManager struct {
+	configurationStore common.ConfigurationStore
+	projectMVRepo      db.ProjectMVRepo
+	// shipyardMutex prevents concurrent changes of freeze windows from overwriting each other
+	shipyardMutex sync.Mutex
+}
+
+func NewFreezeWindowManager(configurationStore common.ConfigurationStore, projectMVRepo db.ProjectMVRepo) *FreezeWindowManager {
+	return &FreezeWindowManager{
+		configurationStore: configurationStore,
+		projectMVRepo:      projectMVRepo,
+	}
+}
+
+func (fm *FreezeWindowManager) CreateFreezeWindow(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+	if err := fm.validateFreezeWindow(freezeWindow); err != nil {
+		return nil, err
+	}
+	freezeWindow.ID = uuid.New().String()
+	err := fm.updateFreezeWindows(freezeWindow.Project, func(freezeWindows []models.FreezeWindow) ([]models.FreezeWindow, error) {
+		return append(freezeWindows, freezeWindow), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &freezeWindow, nil
+}
+
+func (fm *FreezeWindowManager) GetFreezeWindows(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+	project, err := fm.getProject(params.Project)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStage(project, params.Stage); err != nil {
+		return nil, err
+	}
+	extension, err := models.DecodeShipyardExtension(project.Shipyard)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode shipyard of project %s: %w", params.Project, err)
+	}
+
+	freezeWindows := []models.FreezeWindow{}
+	for _, freezeWindow := range extension.GetFreezeWindows(params.Project) {
+		if params.ID != "" && freezeWindow.ID != params.ID {
+			continue
+		}
+		if params.Stage != "" && !freezeWindow.AppliesToStage(params.Stage) {
+			continue
+		}
+		freezeWindows = append(freezeWindows, freezeWindow)
+	}
+	return freezeWindows, nil
+}
+
+func (fm *FreezeWindowManager) GetFreezeWindow(project, id string) (*models.FreezeWindow, error) {
+	freezeWindows, err := fm.GetFreezeWindows(models.GetFreezeWindowParams{Project: project, ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(freezeWindows) == 0 {
+		return nil, ErrFreezeWindowNotFound
+	}
+	return &freezeWindows[0], nil
+}
+
+func (fm *FreezeWindowManager) UpdateFreezeWindow(freezeWindow models.FreezeWindow) (*models.FreezeWindow, error) {
+	if err := fm.validateFreezeWindow(freezeWindow); err != nil {
+		return nil, err
+	}
+	err := fm.updateFreezeWindows(freezeWindow.Project, func(freezeWindows []models.FreezeWindow) ([]models.FreezeWindow, error) {
+		for index := range freezeWindows {
+			if freezeWindows[index].ID == freezeWindow.ID {
+				freezeWindows[index] = freezeWindow
+				return freezeWindows, nil
+			}
+		}
+		return nil, ErrFreezeWindowNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &freezeWindow, nil
+}
+
+func (fm *FreezeWindowManager) DeleteFreezeWindow(project, id string) error {
+	if _, err := fm.getProject(project); err != nil {
+		return err
+	}
+	return fm.updateFreezeWindows(project, func(freezeWindows []models.FreezeWindow) ([]models.FreezeWindow, error) {
+		for index := range freezeWindows {
+			if freezeWindows[index].ID == id {
+				return append(freezeWindows[:index], freezeWindows[index+1:]...), nil
+			}
+		}
+		return nil, ErrFreezeWindowNotFound
+	})
+}
+
+// updateFreezeWindows applies the given change to the freeze windows declared in the current shipyard of the project,
+// and commits the updated shipyard to the configuration store. If the changes of the project are proposed via pull requests, the freeze windows are not changed before the pull request has been merged
+func (fm *FreezeWindowManager) updateFreezeWindows(projectName string, change func(freezeWindows []models.FreezeWindow) ([]models.FreezeWindow, error)) error {
+	fm.shipyardMutex.Lock()
+	defer fm.shipyardMutex.Unlock()
+
+	// the shipyard is retrieved from the configuration store, to retain changes that have been made in the upstream
+	shipyardResource, err := fm.configurationStore.GetProjectResource(projectName, "shipyard.yaml")
+	if err != nil {
+		return fmt.Errorf("could not retrieve shipyard.yaml for project %s: %w", projectName, err)
+	}
+	extension, err := models.DecodeShipyardExtension(shipyardResource.ResourceContent)
+	if err != nil {
+		return fmt.Errorf("could not decode shipyard of project %s: %w", projectName, err)
+	}
+
+	freezeWindows, err := change(extension.Spec.FreezeWindows)
+	if err != nil {
+		return err
+	}
+	shipyardContent, err := models.UpdateShipyardFreezeWindows(shipyardResource.ResourceContent, freezeWindows)
+	if err != nil {
+		return fmt.Errorf("could not update freeze windows in shipyard of project %s: %w", projectName, err)
+	}
+
+	if err := fm.configurationStore.UpdateProjectResource(projectName, &apimodels.Resource{
+		ResourceContent: shipyardContent,
+		ResourceURI:     common.Stringp("shipyard.yaml"),
+	}); err != nil {
+		return fmt.Errorf("could not update shipyard.yaml of project %s: %w", projectName, err)
+	}
+	return fm.projectMVRepo.UpdateShipyard(projectName, getCommittedShipyard(fm.configurationStore, projectName, shipyardResource.ResourceContent))
+}
+
+func (fm *FreezeWindowManager) validateFreezeWindow(freezeWindow models.FreezeWindow) error {
+	if err := freezeWindow.Validate(); err != nil {
+		return err
+	}
+	project, err := fm.getProject(freezeWindow.Project)
+	if err != nil {
+		return err
+	}
+	return checkStage(project, freezeWindow.Stage)
+}
+
+func (fm *FreezeWindowManager) getProject(projectName string) (*apimodels.ExpandedProject, error) {
+	project, err := fm.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		if errors.Is(err, db.ErrProjectNotFound) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func checkStage(project *apimodels.ExpandedProject, stageName string) error {
+	if stageName == "" {
+		return nil
+	}
+	for _, stage := range project.Stages {
+		if stage.StageName == stageName {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrStageNotFound, stageName)
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/shipyard-controller/common/fake"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+const freezeWindowTestShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+    - name: "production"
+  freezeWindows:
+    - id: "christmas"
+      stage: "production"
+      start: "2022-12-24"
+      end: "2022-12-27"
+    - id: "weekend"
+      cron: "0 18 * * 5"
+      duration: "62h"
+`
+
+func getFreezeWindowTestProject(projectName string) (*apimodels.ExpandedProject, error) {
+	if projectName != "my-project" {
+		return nil, nil
+	}
+	return &apimodels.ExpandedProject{
+		ProjectName: "my-project",
+		Shipyard:    freezeWindowTestShipyard,
+		Stages: []*apimodels.ExpandedStage{
+			{StageName: "dev"},
+			{StageName: "production"},
+		},
+	}, nil
+}
+
+// newFreezeWindowTestConfigurationStore returns a configuration store that commits the updated shipyard, unless the changes are proposed via pull requests
+func newFreezeWindowTestConfigurationStore(pullRequestsEnabled bool) *common_mock.ConfigurationStoreMock {
+	shipyardContent := freezeWindowTestShipyard
+	return &common_mock.ConfigurationStoreMock{
+		GetProjectResourceFunc: func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+			return &apimodels.Resource{ResourceContent: shipyardContent}, nil
+		},
+		UpdateProjectResourceFunc: func(projectName string, resource *apimodels.Resource) error {
+			if !pullRequestsEnabled {
+				shipyardContent = resource.ResourceContent
+			}
+			return nil
+		},
+	}
+}
+
+func newFreezeWindowTestProjectMVRepo() *db_mock.ProjectMVRepoMock {
+	return &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: getFreezeWindowTestProject,
+		UpdateShipyardFunc: func(projectName string, shipyardContent string) error {
+			return nil
+		},
+	}
+}
+
+// getCommittedFreezeWindows returns the freeze windows of the shipyard that has been committed to the configuration store
+func getCommittedFreezeWindows(t *testing.T, configurationStore *common_mock.ConfigurationStoreMock, projectMVRepo *db_mock.ProjectMVRepoMock) []models.FreezeWindow {
+	require.Len(t, configurationStore.UpdateProjectResourceCalls(), 1)
+	require.Equal(t, "my-project", configurationStore.UpdateProjectResourceCalls()[0].ProjectName)
+	shipyardContent := configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent
+	require.Equal(t, "shipyard.yaml", *configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceURI)
+
+	// the cached shipyard of the project is updated as well
+	require.Len(t, projectMVRepo.UpdateShipyardCalls(), 1)
+	require.Equal(t, shipyardContent, projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent)
+
+	extension, err := models.DecodeShipyardExtension(shipyardContent)
+	require.Nil(t, err)
+	require.Nil(t, extension.Validate())
+	return extension.Spec.FreezeWindows
+}
+
+func TestFreezeWindowManager_CreateFreezeWindow(t *testing.T) {
+	tests := []struct {
+		name         string
+		freezeWindow models.FreezeWindow
+		wantErr      error
+	}{
+		{
+			name:         "create freeze window for stage",
+			freezeWindow: models.FreezeWindow{Project: "my-project", Stage: "production", Start: "2022-12-31", End: "2023-01-02"},
+		},
+		{
+			name:         "create freeze window for project",
+			freezeWindow: models.FreezeWindow{Project: "my-project", Cron: "0 18 * * 5", Duration: "62h"},
+		},
+		{
+			name:         "invalid freeze window",
+			freezeWindow: models.FreezeWindow{Project: "my-project", Stage: "production"},
+			wantErr:      models.ErrInvalidFreezeWindow,
+		},
+		{
+			name:         "project not found",
+			freezeWindow: models.FreezeWindow{Project: "unknown", Start: "2022-12-24", End: "2022-12-27"},
+			wantErr:      ErrProjectNotFound,
+		},
+		{
+			name:         "stage not found",
+			freezeWindow: models.FreezeWindow{Project: "my-project", Stage: "unknown", Start: "2022-12-24", End: "2022-12-27"},
+			wantErr:      ErrStageNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configurationStore := newFreezeWindowTestConfigurationStore(false)
+			projectMVRepo := newFreezeWindowTestProjectMVRepo()
+			fm := NewFreezeWindowManager(configurationStore, projectMVRepo)
+
+			createdFreezeWindow, err := fm.CreateFreezeWindow(tt.freezeWindow)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Empty(t, configurationStore.UpdateProjectResourceCalls())
+				return
+			}
+			require.Nil(t, err)
+			require.NotEmpty(t, createdFreezeWindow.ID)
+
+			freezeWindows := getCommittedFreezeWindows(t, configurationStore, projectMVRepo)
+			require.Len(t, freezeWindows, 3)
+			// the project is given by the shipyard, and therefore not stored
+			createdFreezeWindow.Project = ""
+			require.Equal(t, *createdFreezeWindow, freezeWindows[2])
+		})
+	}
+}
+
+func TestFreezeWindowManager_UpdateFreezeWindow(t *testing.T) {
+	configurationStore := newFreezeWindowTestConfigurationStore(false)
+	projectMVRepo := newFreezeWindowTestProjectMVRepo()
+	fm := NewFreezeWindowManager(configurationStore, projectMVRepo)
+
+	_, err := fm.UpdateFreezeWindow(models.FreezeWindow{ID: "unknown", Project: "my-project", Start: "2022-12-24", End: "2022-12-28"})
+	require.ErrorIs(t, err, ErrFreezeWindowNotFound)
+	require.Empty(t, configurationStore.UpdateProjectResourceCalls())
+
+	_, err = fm.UpdateFreezeWindow(models.FreezeWindow{ID: "christmas", Project: "my-project", Stage: "production", Start: "2022-12-24", End: "2022-12-28"})
+	require.Nil(t, err)
+
+	freezeWindows := getCommittedFreezeWindows(t, configurationStore, projectMVRepo)
+	require.Len(t, freezeWindows, 2)
+	require.Equal(t, models.FreezeWindow{ID: "christmas", Stage: "production", Start: "2022-12-24", End: "2022-12-28"}, freezeWindows[0])
+}
+
+func TestFreezeWindowManager_DeleteFreezeWindow(t *testing.T) {
+	configurationStore := newFreezeWindowTestConfigurationStore(false)
+	projectMVRepo := newFreezeWindowTestProjectMVRepo()
+	fm := NewFreezeWindowManager(configurationStore, projectMVRepo)
+
+	require.ErrorIs(t, fm.DeleteFreezeWindow("my-project", "unknown"), ErrFreezeWindowNotFound)
+	require.ErrorIs(t, fm.DeleteFreezeWindow("unknown", "christmas"), ErrProjectNotFound)
+	require.Empty(t, configurationStore.UpdateProjectResourceCalls())
+
+	require.Nil(t, fm.DeleteFreezeWindow("my-project", "christmas"))
+
+	freezeWindows := getCommittedFreezeWindows(t, configurationStore, projectMVRepo)
+	require.Equal(t, []models.FreezeWindow{{ID: "weekend", Cron: "0 18 * * 5", Duration: "62h"}}, freezeWindows)
+}
+
+func TestFreezeWindowManager_CreateFreezeWindow_PullRequestsEnabled(t *testing.T) {
+	configurationStore := newFreezeWindowTestConfigurationStore(true)
+	projectMVRepo := newFreezeWindowTestProjectMVRepo()
+	fm := NewFreezeWindowManager(configurationStore, projectMVRepo)
+
+	_, err := fm.CreateFreezeWindow(models.FreezeWindow{Project: "my-project", Stage: "production", Start: "2022-12-31", End: "2023-01-02"})
+	require.Nil(t, err)
+
+	// the freeze window is proposed, but the cached shipyard of the project is not changed before the pull request has been merged
+	require.Len(t, configurationStore.UpdateProjectResourceCalls(), 1)
+	require.NotEqual(t, freezeWindowTestShipyard, configurationStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent)
+	require.Len(t, projectMVRepo.UpdateShipyardCalls(), 1)
+	require.Equal(t, freezeWindowTestShipyard, projectMVRepo.UpdateShipyardCalls()[0].ShipyardContent)
+}
+
+func TestFreezeWindowManager_GetFreezeWindow(t *testing.T) {
+	fm := NewFreezeWindowManager(&common_mock.ConfigurationStoreMock{}, newFreezeWindowTestProjectMVRepo())
+
+	freezeWindow, err := fm.GetFreezeWindow("my-project", "christmas")
+	require.Nil(t, err)
+	require.Equal(t, "christmas", freezeWindow.ID)
+	require.Equal(t, "my-project", freezeWindow.Project)
+
+	freezeWindow, err = fm.GetFreezeWindow("my-project", "unknown")
+	require.ErrorIs(t, err, ErrFreezeWindowNotFound)
+	require.Nil(t, freezeWindow)
+}
+
+func TestFreezeWindowManager_GetFreezeWindows(t *testing.T) {
+	fm := NewFreezeWindowManager(&common_mock.ConfigurationStoreMock{}, newFreezeWindowTestProjectMVRepo())
+
+	freezeWindows, err := fm.GetFreezeWindows(models.GetFreezeWindowParams{Project: "my-project"})
+	require.Nil(t, err)
+	require.Len(t, freezeWindows, 2)
+
+	// freeze windows without a stage apply to all stages
+	freezeWindows, err = fm.GetFreezeWindows(models.GetFreezeWindowParams{Project: "my-project", Stage: "dev"})
+	require.Nil(t, err)
+	require.Len(t, freezeWindows, 1)
+	require.Equal(t, "weekend", freezeWindows[0].ID)
+
+	freezeWindows, err = fm.GetFreezeWindows(models.GetFreezeWindowParams{Project: "my-project", Stage: "unknown"})
+	require.ErrorIs(t, err, ErrStageNotFound)
+	require.Nil(t, freezeWindows)
+}
@@ -68,10 +68,18 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 	}
 
+	if err := validateShipyardExtension(decodeString); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
 	if err := common.ValidateGitRemoteURL(createProjectParams.GitRemoteURL); err != nil {
 		return fmt.Errorf("provided gitRemoteURL is not valid: %s", err.Error())
 	}
 
+	if err := common.ValidateStageLayout(createProjectParams.StageLayout); err != nil {
+		return fmt.Errorf("provided stageLayout is not valid: %s", err.Error())
+	}
+
 	if createProjectParams.GitPrivateKey != "" && createProjectParams.GitToken != "" {
 		return fmt.Errorf("privateKey and token cannot be used together")
 	}
@@ -101,6 +109,15 @@ func (p ProjectValidator) validateCreateProjectParams(createProjectParams *model
 	return nil
 }
 
+// validateShipyardExtension checks the properties of the shipyard that are evaluated by the shipyard controller, but are not part of the Keptn shipyard spec
+func validateShipyardExtension(shipyardContent []byte) error {
+	extension, err := models.DecodeShipyardExtension(string(shipyardContent))
+	if err != nil {
+		return err
+	}
+	return extension.Validate()
+}
+
 func (p ProjectValidator) validateUpdateProjectParams(updateProjectParams *models.UpdateProjectParams) error {
 	if updateProjectParams.Name == nil || *updateProjectParams.Name == "" {
 		return errors.New("project name missing")
@@ -128,12 +145,20 @@ func (p ProjectValidator) validateUpdateProjectParams(updateProjectParams *model
 		if err := common.ValidateShipyardStages(shipyard); err != nil {
 			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 		}
+
+		if err := validateShipyardExtension(decodeString); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
 	}
 
 	if err := common.ValidateGitRemoteURL(updateProjectParams.GitRemoteURL); err != nil {
 		return fmt.Errorf("provided gitRemoteURL is not valid: %s", err.Error())
 	}
 
+	if err := common.ValidateStageLayout(updateProjectParams.StageLayout); err != nil {
+		return fmt.Errorf("provided stageLayout is not valid: %s", err.Error())
+	}
+
 	if updateProjectParams.GitPrivateKey != "" && updateProjectParams.GitToken != "" {
 		return fmt.Errorf("privateKey and token cannot be used together")
 	}
@@ -800,6 +800,7 @@ func TestDeleteProject(t *testing.T) {
 
 func Test_ProjectValidator(t *testing.T) {
 	encodedShipyard := "YXBpVmVyc2lvbjogInNwZWMua2VwdG4uc2gvMC4yLjMiCmtpbmQ6ICJTaGlweWFyZCIKbWV0YWRhdGE6CiAgbmFtZTogInNoaXB5YXJkLXBvZHRhdG8tb2hlYWQiCnNwZWM6CiAgc3RhZ2VzOgogICAgLSBuYW1lOiAiZGV2IgogICAgICBzZXF1ZW5jZXM6CiAgICAgICAgLSBuYW1lOiAiZGVsaXZlcnkiCiAgICAgICAgICB0YXNrczoKICAgICAgICAgICAgLSBuYW1lOiAiZGVwbG95bWVudCIKICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgZGVwbG95bWVudHN0cmF0ZWd5OiAiZGlyZWN0IgogICAgICAgICAgICAtIG5hbWU6ICJ0ZXN0IgogICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICB0ZXN0c3RyYXRlZ3k6ICJmdW5jdGlvbmFsIgogICAgICAgICAgICAtIG5hbWU6ICJldmFsdWF0aW9uIgogICAgICAgICAgICAtIG5hbWU6ICJyZWxlYXNlIgogICAgICAgIC0gbmFtZTogImRlbGl2ZXJ5LWRpcmVjdCIKICAgICAgICAgIHRhc2tzOgogICAgICAgICAgICAtIG5hbWU6ICJkZXBsb3ltZW50IgogICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICBkZXBsb3ltZW50c3RyYXRlZ3k6ICJkaXJlY3QiCiAgICAgICAgICAgIC0gbmFtZTogInJlbGVhc2UiCgogICAgLSBuYW1lOiAicHJvZCIKICAgICAgc2VxdWVuY2VzOgogICAgICAgIC0gbmFtZTogImRlbGl2ZXJ5IgogICAgICAgICAgdHJpZ2dlcmVkT246CiAgICAgICAgICAgIC0gZXZlbnQ6ICJkZXYuZGVsaXZlcnkuZmluaXNoZWQiCiAgICAgICAgICB0YXNrczoKICAgICAgICAgICAgLSBuYW1lOiAiZGVwbG95bWVudCIKICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgZGVwbG95bWVudHN0cmF0ZWd5OiAiYmx1ZV9ncmVlbl9zZXJ2aWNlIgogICAgICAgICAgICAtIG5hbWU6ICJ0ZXN0IgogICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICB0ZXN0c3RyYXRlZ3k6ICJwZXJmb3JtYW5jZSIKICAgICAgICAgICAgLSBuYW1lOiAiZXZhbHVhdGlvbiIKICAgICAgICAgICAgLSBuYW1lOiAicmVsZWFzZSIKICAgICAgICAtIG5hbWU6ICJyb2xsYmFjayIKICAgICAgICAgIHRyaWdnZXJlZE9uOgogICAgICAgICAgICAtIGV2ZW50OiAicHJvZC5kZWxpdmVyeS5maW5pc2hlZCIKICAgICAgICAgICAgICBzZWxlY3RvcjoKICAgICAgICAgICAgICAgIG1hdGNoOgogICAgICAgICAgICAgICAgICByZXN1bHQ6ICJmYWlsIgogICAgICAgICAgdGFza3M6CiAgICAgICAgICAgIC0gbmFtZTogInJvbGxiYWNrIgoKICAgICAgICAtIG5hbWU6ICJkZWxpdmVyeS1kaXJlY3QiCiAgICAgICAgICB0cmlnZ2VyZWRPbjoKICAgICAgICAgICAgLSBldmVudDogImRldi5kZWxpdmVyeS1kaXJlY3QuZmluaXNoZWQiCiAgICAgICAgICB0YXNrczoKICAgICAgICAgICAgLSBuYW1lOiAiZGVwbG95bWVudCIKICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgZGVwbG95bWVudHN0cmF0ZWd5OiAiZGlyZWN0IgogICAgICAgICAgICAtIG5hbWU6ICJyZWxlYXNlIg=="
+	encodedShipyardWithRetriesInParallelGroup := "YXBpVmVyc2lvbjogInNwZWMua2VwdG4uc2gvMC4yLjMiCmtpbmQ6ICJTaGlweWFyZCIKbWV0YWRhdGE6CiAgbmFtZTogInNoaXB5YXJkLXNvY2tzaG9wIgpzcGVjOgogIHN0YWdlczoKICAgIC0gbmFtZTogImRldiIKICAgICAgc2VxdWVuY2VzOgogICAgICAgIC0gbmFtZTogImRlbGl2ZXJ5IgogICAgICAgICAgdGFza3M6CiAgICAgICAgICAgIC0gbmFtZTogInRlc3QiCiAgICAgICAgICAgICAgZ3JvdXA6ICJ0ZXN0cyIKICAgICAgICAgICAgICByZXRyaWVzOiAyCiAgICAgICAgICAgIC0gbmFtZTogInNlY3VyaXR5c2NhbiIKICAgICAgICAgICAgICBncm91cDogInRlc3RzIgo="
 	invalidShipyard := "invalid"
 	projectName := "project-name"
 	longProjectName := "project-nameeeeeeeeee"
@@ -846,6 +847,14 @@ func Test_ProjectValidator(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "retries in parallel group",
+			params: models.CreateProjectParams{
+				Shipyard: &encodedShipyardWithRetriesInParallelGroup,
+				Name:     &projectName,
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid GitRemoteURL",
 			params: models.CreateProjectParams{
@@ -916,6 +925,24 @@ func Test_ProjectValidator(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid StageLayout",
+			params: models.CreateProjectParams{
+				Shipyard:    &encodedShipyard,
+				Name:        &projectName,
+				StageLayout: common.StageLayoutDirectory,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid StageLayout",
+			params: models.CreateProjectParams{
+				Shipyard:    &encodedShipyard,
+				Name:        &projectName,
+				StageLayout: "folder",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1013,6 +1040,22 @@ func Test_ProjectValidator_UpdateParams(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Valid StageLayout",
+			params: models.UpdateProjectParams{
+				Name:        &projectName,
+				StageLayout: common.StageLayoutBranch,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid StageLayout",
+			params: models.UpdateProjectParams{
+				Name:        &projectName,
+				StageLayout: "folder",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
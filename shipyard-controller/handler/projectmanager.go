@@ -38,6 +38,8 @@ type ProjectManager struct {
 	EventRepository         db.EventRepo
 	SequenceQueueRepo       db.SequenceQueueRepo
 	EventQueueRepo          db.EventQueueRepo
+	ScheduleRepo            db.ScheduleRepo
+	SequenceHistoryRepo     db.SequenceHistoryRepo
 }
 
 var nilRollback = func() error {
@@ -51,7 +53,9 @@ func NewProjectManager(
 	sequenceExecutionRepo db.SequenceExecutionRepo,
 	eventRepo db.EventRepo,
 	sequenceQueueRepo db.SequenceQueueRepo,
-	eventQueueRepo db.EventQueueRepo) *ProjectManager {
+	eventQueueRepo db.EventQueueRepo,
+	scheduleRepo db.ScheduleRepo,
+	sequenceHistoryRepo db.SequenceHistoryRepo) *ProjectManager {
 	projectUpdater := &ProjectManager{
 		ConfigurationStore:      configurationStore,
 		SecretStore:             secretStore,
@@ -60,6 +64,8 @@ func NewProjectManager(
 		EventRepository:         eventRepo,
 		SequenceQueueRepo:       sequenceQueueRepo,
 		EventQueueRepo:          eventQueueRepo,
+		ScheduleRepo:            scheduleRepo,
+		SequenceHistoryRepo:     sequenceHistoryRepo,
 	}
 	return projectUpdater
 }
@@ -117,7 +123,7 @@ func (pm *ProjectManager) Create(params *models.CreateProjectParams) (error, com
 
 	err = pm.ConfigurationStore.CreateProject(apimodels.Project{
 		ProjectName: *params.Name,
-	})
+	}, params.StageLayout)
 
 	rollbackFunc := func() error {
 		log.Infof("Rollback: Try to delete GIT repository credentials secret for project %s", *params.Name)
@@ -201,6 +207,7 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 		GitProxyPassword:  oldSecret.GitProxyPassword,
 		GitPemCertificate: oldSecret.GitPemCertificate,
 		InsecureSkipTLS:   oldSecret.InsecureSkipTLS,
+		PullRequest:       oldSecret.PullRequest,
 	}
 
 	// old project for rollback
@@ -230,6 +237,8 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 			GitProxyPassword:  params.GitProxyPassword,
 			GitPemCertificate: string(decodedPemCertificate),
 			InsecureSkipTLS:   params.InsecureSkipTLS,
+			// the pull request settings are not part of the project API, hence they are kept as they are
+			PullRequest: oldSecret.PullRequest,
 		})
 
 		// no roll back needed since updating the git repository secret was the first operation
@@ -243,7 +252,8 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 		ProjectName: *params.Name,
 	}
 
-	// project content in configuration service to rollback
+	// project content in configuration service to rollback. A migration to another stage layout is not rolled back,
+	// since the configuration service migrates the project either completely or not at all
 	projectToRollback := apimodels.Project{
 		CreationDate:    oldProject.CreationDate,
 		GitRemoteURI:    oldProject.GitRemoteURI,
@@ -257,7 +267,7 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 	}
 
 	// try to update the project information in configuration service
-	err = pm.ConfigurationStore.UpdateProject(projectToUpdate)
+	err = pm.ConfigurationStore.UpdateProject(projectToUpdate, params.StageLayout)
 
 	if err != nil {
 		log.Errorf("Error occurred while updating the project in configuration store: %s", err.Error())
@@ -267,11 +277,12 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 				return ErrChangesRollback
 			}
 			// try to rollback already updated project in configuration store
-			return pm.ConfigurationStore.UpdateProject(projectToRollback)
+			return pm.ConfigurationStore.UpdateProject(projectToRollback, "")
 		}
 	}
 
 	var isShipyardPresent = params.Shipyard != nil && *params.Shipyard != ""
+	shipyardContent := oldProject.Shipyard
 
 	// try to update shipyard project resource
 	if isShipyardPresent {
@@ -292,9 +303,11 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 					return ErrChangesRollback
 				}
 				// try to rollback already updated project in configuration store
-				return pm.ConfigurationStore.UpdateProject(projectToRollback)
+				return pm.ConfigurationStore.UpdateProject(projectToRollback, "")
 			}
 		}
+		// the shipyard is read again, since the update is not effective before its pull request has been merged if the changes of the project are proposed via pull requests
+		shipyardContent = getCommittedShipyard(pm.ConfigurationStore, *params.Name, oldProject.Shipyard)
 	}
 
 	// copy by value
@@ -305,9 +318,7 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 	updateProject.GitProxyScheme = params.GitProxyScheme
 	updateProject.GitProxyUser = params.GitProxyUser
 	updateProject.InsecureSkipTLS = params.InsecureSkipTLS
-	if isShipyardPresent {
-		updateProject.Shipyard = *params.Shipyard
-	}
+	updateProject.Shipyard = shipyardContent
 
 	// try to update project information in database
 	err = pm.ProjectMaterializedView.UpdateProject(&updateProject)
@@ -322,7 +333,7 @@ func (pm *ProjectManager) Update(params *models.UpdateProjectParams) (error, com
 			}
 
 			// try to rollback already updated project information in configuration service
-			if err = pm.ConfigurationStore.UpdateProject(projectToRollback); err != nil {
+			if err = pm.ConfigurationStore.UpdateProject(projectToRollback, ""); err != nil {
 				return ErrChangesRollback
 			}
 
@@ -396,6 +407,14 @@ func (pm *ProjectManager) deleteProjectSequenceCollections(projectName string) {
 	if err := pm.SequenceExecutionRepo.Clear(projectName); err != nil {
 		log.Errorf("could not delete sequence executions: %s", err.Error())
 	}
+
+	if err := pm.ScheduleRepo.DeleteSchedules(projectName); err != nil {
+		log.Errorf("could not delete schedules: %s", err.Error())
+	}
+
+	if err := pm.SequenceHistoryRepo.DeleteTransitions(projectName); err != nil {
+		log.Errorf("could not delete sequence history: %s", err.Error())
+	}
 }
 
 func (pm *ProjectManager) createProjectInRepository(params *models.CreateProjectParams, decodedShipyard []byte, shipyard *keptnv2.Shipyard) error {
@@ -431,6 +450,18 @@ func (pm *ProjectManager) createProjectInRepository(params *models.CreateProject
 	return nil
 }
 
+// getCommittedShipyard reads the shipyard of the project from the configuration store after it has been updated.
+// If the changes of the project are proposed via pull requests, it is still the shipyard of the default branch, which must not be replaced by the proposed one in the materialized view.
+// If the shipyard cannot be read, the given shipyard is returned, since the materialized view is refreshed anyway whenever the shipyard is retrieved from the configuration store
+func getCommittedShipyard(configurationStore common.ConfigurationStore, projectName string, fallback string) string {
+	shipyardResource, err := configurationStore.GetProjectResource(projectName, "shipyard.yaml")
+	if err != nil || shipyardResource == nil {
+		log.Errorf("Could not read updated shipyard.yaml of project %s: %v", projectName, err)
+		return fallback
+	}
+	return shipyardResource.ResourceContent
+}
+
 func (pm *ProjectManager) getGITRepositorySecret(projectName string) (*gitCredentials, error) {
 	secret, err := pm.SecretStore.GetSecret("git-credentials-" + projectName)
 	if err != nil {
@@ -602,4 +633,6 @@ type gitCredentials struct {
 	GitProxyPassword  string `json:"gitProxyPassword,omitempty"`
 	GitPemCertificate string `json:"gitPemCertificate,omitempty"`
 	InsecureSkipTLS   bool   `json:"insecureSkipTLS,omitempty"`
+	// PullRequest holds the settings of the pull request based write mode of the resource-service
+	PullRequest json.RawMessage `json:"pullRequest,omitempty"`
 }
@@ -31,7 +31,7 @@ func TestGetProjects(t *testing.T) {
 		return expectedProjects, nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	actualProjects, err := instance.Get()
 	assert.Nil(t, err)
 	assert.Equal(t, expectedProjects, actualProjects)
@@ -50,7 +50,7 @@ func TestGetProjectsErr(t *testing.T) {
 		return nil, fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	actualProjects, err := instance.Get()
 	assert.NotNil(t, err)
 	assert.Nil(t, actualProjects)
@@ -69,7 +69,7 @@ func TestGetByName(t *testing.T) {
 		return &apimodels.ExpandedProject{}, nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	project, err := instance.GetByName("my-project")
 	assert.Nil(t, err)
 	assert.NotNil(t, project)
@@ -89,7 +89,7 @@ func TestGetByNameErr(t *testing.T) {
 		return nil, fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	project, err := instance.GetByName("my-project")
 	assert.NotNil(t, err)
 	assert.Nil(t, project)
@@ -107,7 +107,7 @@ func TestGetByNameNotFound(t *testing.T) {
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) { return nil, nil }
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	project, err := instance.GetByName("my-project")
 	assert.NotNil(t, err)
 	assert.Equal(t, ErrProjectNotFound, err)
@@ -128,7 +128,7 @@ func TestCreate_GettingProjectFails(t *testing.T) {
 		return nil, fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -162,7 +162,7 @@ func TestCreateWithAlreadyExistingProject(t *testing.T) {
 		return project, nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -193,7 +193,7 @@ func TestCreate_WhenCreatingProjectInConfigStoreFails_ThenSecretGetsDeletedAgain
 		return nil, nil
 	}
 
-	configStore.CreateProjectFunc = func(apimodels.Project) error {
+	configStore.CreateProjectFunc = func(apimodels.Project, string) error {
 		return fmt.Errorf("whoops")
 	}
 
@@ -205,7 +205,7 @@ func TestCreate_WhenCreatingProjectInConfigStoreFails_ThenSecretGetsDeletedAgain
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		Name: common.Stringp("my-project"),
 	}
@@ -230,7 +230,7 @@ func TestCreate_WhenCreatingStageInConfigStoreFails_ThenProjectAndSecretGetDelet
 		return nil, nil
 	}
 
-	configStore.CreateProjectFunc = func(apimodels.Project) error {
+	configStore.CreateProjectFunc = func(apimodels.Project, string) error {
 		return nil
 	}
 
@@ -250,7 +250,7 @@ func TestCreate_WhenCreatingStageInConfigStoreFails_ThenProjectAndSecretGetDelet
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL: "git-url",
 		GitToken:     "git-token",
@@ -281,7 +281,7 @@ func TestCreate_WhenUploadingShipyardFails_thenProjectAndSecretGetDeletedAgain(t
 		return nil, nil
 	}
 
-	configStore.CreateProjectFunc = func(apimodels.Project) error {
+	configStore.CreateProjectFunc = func(apimodels.Project, string) error {
 		return nil
 	}
 
@@ -308,7 +308,7 @@ func TestCreate_WhenUploadingShipyardFails_thenProjectAndSecretGetDeletedAgain(t
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMvRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMvRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -341,7 +341,7 @@ func TestCreate_WhenSavingProjectInRepositoryFails_thenProjectAndSecretGetDelete
 	sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{}
 
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) { return nil, nil }
-	configStore.CreateProjectFunc = func(apimodels.Project) error { return nil }
+	configStore.CreateProjectFunc = func(apimodels.Project, string) error { return nil }
 	configStore.CreateStageFunc = func(projectName string, stageName string) error { return nil }
 	configStore.CreateProjectShipyardFunc = func(projectName string, resources []*apimodels.Resource) error { return nil }
 	configStore.DeleteProjectFunc = func(projectName string) error { return nil }
@@ -351,7 +351,7 @@ func TestCreate_WhenSavingProjectInRepositoryFails_thenProjectAndSecretGetDelete
 		return fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -387,7 +387,7 @@ func TestCreate(t *testing.T) {
 		return nil, nil
 	}
 
-	configStore.CreateProjectFunc = func(apimodels.Project) error {
+	configStore.CreateProjectFunc = func(apimodels.Project, string) error {
 		return nil
 	}
 
@@ -423,7 +423,7 @@ func TestCreate(t *testing.T) {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.CreateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -434,8 +434,10 @@ func TestCreate(t *testing.T) {
 		InsecureSkipTLS: false,
 		Name:            common.Stringp("my-project"),
 		Shipyard:        common.Stringp(encodedShipyard),
+		StageLayout:     common.StageLayoutDirectory,
 	}
 	instance.Create(params)
+	assert.Equal(t, common.StageLayoutDirectory, configStore.CreateProjectCalls()[0].StageLayout)
 	assert.Equal(t, 3, len(configStore.CreateStageCalls()))
 	assert.Equal(t, "my-project", configStore.CreateStageCalls()[0].ProjectName)
 	assert.Equal(t, "dev", configStore.CreateStageCalls()[0].Stage)
@@ -466,7 +468,7 @@ func TestUpdate_GettingOldSecretFails(t *testing.T) {
 		return nil, fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -506,7 +508,7 @@ func TestUpdate_GettingOldProjectFails(t *testing.T) {
 		return nil, fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -546,7 +548,7 @@ func TestUpdate_OldProjectNotAvailable(t *testing.T) {
 		return nil, nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -590,7 +592,7 @@ func TestUpdate_UpdateGitRepositorySecretFails(t *testing.T) {
 	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
 		return &apimodels.ExpandedProject{}, nil
 	}
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -656,11 +658,11 @@ func TestUpdate_UpdateProjectInConfigurationStoreFails(t *testing.T) {
 		return rollbackProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
 		GitToken:        "git-token",
@@ -670,6 +672,7 @@ func TestUpdate_UpdateProjectInConfigurationStoreFails(t *testing.T) {
 		GitProxyUser:    "proxy-user",
 		InsecureSkipTLS: false,
 		Name:            common.Stringp("my-project"),
+		StageLayout:     common.StageLayoutBranch,
 	}
 	err, rollback := instance.Update(params)
 	assert.NotNil(t, err)
@@ -679,6 +682,7 @@ func TestUpdate_UpdateProjectInConfigurationStoreFails(t *testing.T) {
 		ProjectName: *params.Name,
 	}
 	assert.Equal(t, expectedProjectUpdate, configStore.UpdateProjectCalls()[0].Project)
+	assert.Equal(t, common.StageLayoutBranch, configStore.UpdateProjectCalls()[0].StageLayout)
 	assert.Equal(t, "git-credentials-my-project", secretStore.UpdateSecretCalls()[0].Name)
 	assert.Equal(t, newSecretsEncoded, secretStore.UpdateSecretCalls()[0].Content["git-credentials"])
 
@@ -686,6 +690,7 @@ func TestUpdate_UpdateProjectInConfigurationStoreFails(t *testing.T) {
 	assert.Equal(t, "git-credentials-my-project", secretStore.UpdateSecretCalls()[1].Name)
 	assert.Equal(t, rollbackSecretsData, secretStore.UpdateSecretCalls()[1].Content["git-credentials"])
 	assert.Equal(t, rollbackProjectData.GitRemoteURI, configStore.UpdateProjectCalls()[1].Project.GitRemoteURI)
+	assert.Empty(t, configStore.UpdateProjectCalls()[1].StageLayout)
 }
 
 func TestUpdate_UpdateProjectShipyardResourceFails(t *testing.T) {
@@ -735,7 +740,7 @@ func TestUpdate_UpdateProjectShipyardResourceFails(t *testing.T) {
 		return oldProject, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -743,7 +748,7 @@ func TestUpdate_UpdateProjectShipyardResourceFails(t *testing.T) {
 		return fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	myShipyard := "my-shipyard"
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
@@ -830,7 +835,7 @@ func TestUpdate_UpdateProjectInRepositoryFails(t *testing.T) {
 		return oldProject, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -838,11 +843,15 @@ func TestUpdate_UpdateProjectInRepositoryFails(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return fmt.Errorf("whoops")
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	myShipyard := "my-shipyard"
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
@@ -945,7 +954,7 @@ func TestUpdate(t *testing.T) {
 		return oldProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -953,11 +962,15 @@ func TestUpdate(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	myShipyard := "my-shipyard"
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
@@ -1002,6 +1015,62 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, expectedUpdateShipyardResourceData, configStore.UpdateProjectResourceCalls()[0].Resource)
 }
 
+func TestUpdate_ShipyardProposedViaPullRequest(t *testing.T) {
+	secretStore := &common_mock.SecretStoreMock{}
+	projectMVRepo := &db_mock.ProjectMVRepoMock{}
+	configStore := &common_mock.ConfigurationStoreMock{}
+
+	oldSecretsData, _ := json.Marshal(gitCredentials{
+		User:      "my-user",
+		Token:     "my-token",
+		RemoteURI: "http://my-remote.uri",
+	})
+	oldProjectData := &apimodels.ExpandedProject{
+		GitRemoteURI: "http://my-remote.uri",
+		GitUser:      "my-user",
+		ProjectName:  "my-project",
+		Shipyard:     "my-old-shipyard",
+	}
+
+	secretStore.GetSecretFunc = func(name string) (map[string][]byte, error) {
+		return map[string][]byte{"git-credentials": oldSecretsData}, nil
+	}
+	secretStore.UpdateSecretFunc = func(name string, content map[string][]byte) error {
+		return nil
+	}
+	projectMVRepo.GetProjectFunc = func(projectName string) (*apimodels.ExpandedProject, error) {
+		return oldProjectData, nil
+	}
+	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
+		return nil
+	}
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
+		return nil
+	}
+	configStore.UpdateProjectResourceFunc = func(projectName string, resource *apimodels.Resource) error {
+		return nil
+	}
+	// the default branch still contains the old shipyard until the pull request of the update has been merged
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return &apimodels.Resource{ResourceContent: "my-old-shipyard", ResourceURI: common.Stringp("shipyard.yaml")}, nil
+	}
+
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, &db_mock.SequenceExecutionRepoMock{}, &db_mock.EventRepoMock{}, &db_mock.SequenceQueueRepoMock{}, &db_mock.EventQueueRepoMock{}, newScheduleRepoMock(), newSequenceHistoryRepoMock())
+	myShipyard := "my-shipyard"
+	err, _ := instance.Update(&models.UpdateProjectParams{
+		GitRemoteURL: "http://my-remote.uri",
+		GitToken:     "my-token",
+		GitUser:      "my-user",
+		Name:         common.Stringp("my-project"),
+		Shipyard:     &myShipyard,
+	})
+	require.Nil(t, err)
+
+	require.Equal(t, "my-shipyard", configStore.UpdateProjectResourceCalls()[0].Resource.ResourceContent)
+	require.Len(t, configStore.GetProjectResourceCalls(), 1)
+	require.Equal(t, "my-old-shipyard", projectMVRepo.UpdateProjectCalls()[0].Prj.Shipyard)
+}
+
 func TestUpdate_ShouldWorkWithEmptyGitUser(t *testing.T) {
 
 	secretStore := &common_mock.SecretStoreMock{}
@@ -1049,7 +1118,7 @@ func TestUpdate_ShouldWorkWithEmptyGitUser(t *testing.T) {
 		return oldProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -1057,11 +1126,15 @@ func TestUpdate_ShouldWorkWithEmptyGitUser(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	myShipyard := "my-shipyard"
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:    "git-url",
@@ -1152,7 +1225,7 @@ func TestUpdateNoInsecureParameter(t *testing.T) {
 		return oldProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -1160,11 +1233,15 @@ func TestUpdateNoInsecureParameter(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	myShipyard := "my-shipyard"
 	params := &models.UpdateProjectParams{
 		GitRemoteURL:   "git-url",
@@ -1245,7 +1322,7 @@ func TestUpdate_WithEmptyShipyard_ShallNotUpdateResource(t *testing.T) {
 		return oldProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -1253,11 +1330,15 @@ func TestUpdate_WithEmptyShipyard_ShallNotUpdateResource(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	shipyardTest := ""
 	params := &models.UpdateProjectParams{
 		GitRemoteURL: "git-url",
@@ -1311,7 +1392,7 @@ func TestUpdate_WithEmptyGitCredentials_ShallNotUpdateResource(t *testing.T) {
 		return oldProjectData, nil
 	}
 
-	configStore.UpdateProjectFunc = func(project apimodels.Project) error {
+	configStore.UpdateProjectFunc = func(project apimodels.Project, stageLayout string) error {
 		return nil
 	}
 
@@ -1319,11 +1400,15 @@ func TestUpdate_WithEmptyGitCredentials_ShallNotUpdateResource(t *testing.T) {
 		return nil
 	}
 
+	configStore.GetProjectResourceFunc = func(projectName string, resourceURI string) (*apimodels.Resource, error) {
+		return configStore.UpdateProjectResourceCalls()[0].Resource, nil
+	}
+
 	projectMVRepo.UpdateProjectFunc = func(prj *apimodels.ExpandedProject) error {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, newScheduleRepoMock(), newSequenceHistoryRepoMock())
 	shipyardTest := ""
 	params := &models.UpdateProjectParams{
 		GitRemoteURL: "",
@@ -1406,8 +1491,15 @@ func TestDelete(t *testing.T) {
 		return nil
 	}
 
-	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo)
+	scheduleRepo := newScheduleRepoMock()
+	sequenceHistoryRepo := newSequenceHistoryRepoMock()
+
+	instance := NewProjectManager(configStore, secretStore, projectMVRepo, sequenceExecutionRepo, eventRepo, sequenceQueueRepo, eventQueueRepo, scheduleRepo, sequenceHistoryRepo)
 	instance.Delete("my-project")
+	require.Len(t, scheduleRepo.DeleteSchedulesCalls(), 1)
+	require.Equal(t, "my-project", scheduleRepo.DeleteSchedulesCalls()[0].Project)
+	require.Len(t, sequenceHistoryRepo.DeleteTransitionsCalls(), 1)
+	require.Equal(t, "my-project", sequenceHistoryRepo.DeleteTransitionsCalls()[0].Project)
 }
 
 func TestValidateShipyardStagesUnchaged(t *testing.T) {
@@ -1510,3 +1602,19 @@ func TestValidateShipyardStagesUnchaged(t *testing.T) {
 		})
 	}
 }
+
+func newScheduleRepoMock() *db_mock.ScheduleRepoMock {
+	return &db_mock.ScheduleRepoMock{
+		DeleteSchedulesFunc: func(project string) error {
+			return nil
+		},
+	}
+}
+
+func newSequenceHistoryRepoMock() *db_mock.SequenceHistoryRepoMock {
+	return &db_mock.SequenceHistoryRepoMock{
+		DeleteTransitionsFunc: func(project string) error {
+			return nil
+		},
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+type IScheduleHandler interface {
+	CreateSchedule(context *gin.Context)
+	GetSchedules(context *gin.Context)
+	GetSchedule(context *gin.Context)
+	UpdateSchedule(context *gin.Context)
+	DeleteSchedule(context *gin.Context)
+}
+
+type ScheduleHandler struct {
+	scheduleManager IScheduleManager
+}
+
+func NewScheduleHandler(scheduleManager IScheduleManager) *ScheduleHandler {
+	return &ScheduleHandler{scheduleManager: scheduleManager}
+}
+
+// CreateSchedule creates a new schedule
+// @Summary      Create a new schedule
+// @Description  Create a new schedule that periodically triggers a sequence for a service according to a cron expression
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project   path      string           true  "The name of the project"
+// @Param        schedule  body      models.Schedule  true  "Schedule"
+// @Success      201       {object}  models.Schedule  "ok"
+// @Failure      400       {object}  models.Error     "Invalid payload"
+// @Failure      404       {object}  models.Error     "Not found"
+// @Failure      500       {object}  models.Error     "Internal error"
+// @Router       /project/{project}/schedule [post]
+func (sh *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	schedule := &models.Schedule{}
+	if err := c.ShouldBindJSON(schedule); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	schedule.Project = c.Param("project")
+
+	createdSchedule, err := sh.scheduleManager.CreateSchedule(*schedule)
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, createdSchedule)
+}
+
+// GetSchedules returns the schedules of a project
+// @Summary      Get the schedules of a project
+// @Description  Get the schedules of a project, optionally filtered by stage and service
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project  path      string            true   "The name of the project"
+// @Param        stage    query     string            false  "The name of the stage"
+// @Param        service  query     string            false  "The name of the service"
+// @Success      200      {object}  models.Schedules  "ok"
+// @Failure      400      {object}  models.Error      "Invalid payload"
+// @Failure      404      {object}  models.Error      "Not found"
+// @Failure      500      {object}  models.Error      "Internal error"
+// @Router       /project/{project}/schedule [get]
+func (sh *ScheduleHandler) GetSchedules(c *gin.Context) {
+	params := &models.GetScheduleParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	params.Project = c.Param("project")
+
+	schedules, err := sh.scheduleManager.GetSchedules(*params)
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, models.Schedules{Schedules: schedules})
+}
+
+// GetSchedule returns a schedule
+// @Summary      Get a schedule
+// @Description  Get a schedule
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project     path      string           true  "The name of the project"
+// @Param        scheduleID  path      string           true  "The ID of the schedule"
+// @Success      200         {object}  models.Schedule  "ok"
+// @Failure      404         {object}  models.Error     "Not found"
+// @Failure      500         {object}  models.Error     "Internal error"
+// @Router       /project/{project}/schedule/{scheduleID} [get]
+func (sh *ScheduleHandler) GetSchedule(c *gin.Context) {
+	schedule, err := sh.scheduleManager.GetSchedule(c.Param("project"), c.Param("scheduleID"))
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// UpdateSchedule updates a schedule
+// @Summary      Update a schedule
+// @Description  Update a schedule. The information about the most recently triggered sequence is retained
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project     path      string           true  "The name of the project"
+// @Param        scheduleID  path      string           true  "The ID of the schedule"
+// @Param        schedule    body      models.Schedule  true  "Schedule"
+// @Success      200         {object}  models.Schedule  "ok"
+// @Failure      400         {object}  models.Error     "Invalid payload"
+// @Failure      404         {object}  models.Error     "Not found"
+// @Failure      500         {object}  models.Error     "Internal error"
+// @Router       /project/{project}/schedule/{scheduleID} [put]
+func (sh *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	schedule := &models.Schedule{}
+	if err := c.ShouldBindJSON(schedule); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+	schedule.Project = c.Param("project")
+	schedule.ID = c.Param("scheduleID")
+
+	updatedSchedule, err := sh.scheduleManager.UpdateSchedule(*schedule)
+	if err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, updatedSchedule)
+}
+
+// DeleteSchedule deletes a schedule
+// @Summary      Delete a schedule
+// @Description  Delete a schedule
+// @Tags         Schedule
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project     path  string  true  "The name of the project"
+// @Param        scheduleID  path  string  true  "The ID of the schedule"
+// @Success      200
+// @Failure      404  {object}  models.Error  "Not found"
+// @Failure      500  {object}  models.Error  "Internal error"
+// @Router       /project/{project}/schedule/{scheduleID} [delete]
+func (sh *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	if err := sh.scheduleManager.DeleteSchedule(c.Param("project"), c.Param("scheduleID")); err != nil {
+		setScheduleErrorResponse(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func setScheduleErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidSchedule):
+		SetBadRequestErrorResponse(c, err.Error())
+	case errors.Is(err, ErrProjectNotFound), errors.Is(err, ErrStageNotFound), errors.Is(err, ErrServiceNotFound), errors.Is(err, db.ErrScheduleNotFound):
+		SetNotFoundErrorResponse(c, err.Error())
+	default:
+		SetInternalServerErrorResponse(c, err.Error())
+	}
+}
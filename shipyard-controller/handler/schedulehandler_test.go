@@ -0,0 +1,243 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleHandler_CreateSchedule(t *testing.T) {
+	schedule := models.Schedule{
+		Stage:    "production",
+		Service:  "my-service",
+		Sequence: "performance",
+		Cron:     "0 2 * * 6",
+	}
+	payload, _ := json.Marshal(schedule)
+
+	tests := []struct {
+		name            string
+		scheduleManager *fake.IScheduleManagerMock
+		request         *http.Request
+		wantStatus      int
+	}{
+		{
+			name: "create schedule",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+					schedule.ID = "my-id"
+					return &schedule, nil
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader(payload)),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid schedule",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+					return nil, fmt.Errorf("%w: could not parse cron expression", models.ErrInvalidSchedule)
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader(payload)),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "project not found",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+					return nil, handler.ErrProjectNotFound
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader(payload)),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service not found",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+					return nil, handler.ErrServiceNotFound
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader(payload)),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			scheduleManager: &fake.IScheduleManagerMock{
+				CreateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader(payload)),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:            "invalid payload",
+			scheduleManager: &fake.IScheduleManagerMock{},
+			request:         httptest.NewRequest(http.MethodPost, "/project/my-project/schedule", bytes.NewReader([]byte("foo"))),
+			wantStatus:      http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduleManager)
+
+			router := gin.Default()
+			router.POST("/project/:project/schedule", func(c *gin.Context) {
+				sh.CreateSchedule(c)
+			})
+			w := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusCreated {
+				require.Len(t, tt.scheduleManager.CreateScheduleCalls(), 1)
+				require.Equal(t, "my-project", tt.scheduleManager.CreateScheduleCalls()[0].Schedule.Project)
+
+				createdSchedule := &models.Schedule{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), createdSchedule))
+				require.Equal(t, "my-id", createdSchedule.ID)
+			}
+		})
+	}
+}
+
+func TestScheduleHandler_GetSchedules(t *testing.T) {
+	scheduleManager := &fake.IScheduleManagerMock{
+		GetSchedulesFunc: func(params models.GetScheduleParams) ([]models.Schedule, error) {
+			return []models.Schedule{{ID: "my-id", Project: "my-project", Stage: "production", Service: "my-service", Sequence: "performance", Cron: "0 2 * * 6"}}, nil
+		},
+	}
+	sh := handler.NewScheduleHandler(scheduleManager)
+
+	router := gin.Default()
+	router.GET("/project/:project/schedule", func(c *gin.Context) {
+		sh.GetSchedules(c)
+	})
+	w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/schedule?stage=production&service=my-service", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, models.GetScheduleParams{Project: "my-project", Stage: "production", Service: "my-service"}, scheduleManager.GetSchedulesCalls()[0].Params)
+
+	schedules := &models.Schedules{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), schedules))
+	require.Len(t, schedules.Schedules, 1)
+	require.Equal(t, "my-id", schedules.Schedules[0].ID)
+}
+
+func TestScheduleHandler_GetSchedule(t *testing.T) {
+	tests := []struct {
+		name            string
+		scheduleManager *fake.IScheduleManagerMock
+		wantStatus      int
+	}{
+		{
+			name: "get schedule",
+			scheduleManager: &fake.IScheduleManagerMock{
+				GetScheduleFunc: func(project string, id string) (*models.Schedule, error) {
+					return &models.Schedule{ID: id, Project: project}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "schedule not found",
+			scheduleManager: &fake.IScheduleManagerMock{
+				GetScheduleFunc: func(project string, id string) (*models.Schedule, error) {
+					return nil, db.ErrScheduleNotFound
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduleManager)
+
+			router := gin.Default()
+			router.GET("/project/:project/schedule/:scheduleID", func(c *gin.Context) {
+				sh.GetSchedule(c)
+			})
+			w := performRequest(router, httptest.NewRequest(http.MethodGet, "/project/my-project/schedule/my-id", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-project", tt.scheduleManager.GetScheduleCalls()[0].Project)
+			require.Equal(t, "my-id", tt.scheduleManager.GetScheduleCalls()[0].ID)
+		})
+	}
+}
+
+func TestScheduleHandler_UpdateSchedule(t *testing.T) {
+	payload, _ := json.Marshal(models.Schedule{ID: "other-id", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 2 * * *"})
+
+	scheduleManager := &fake.IScheduleManagerMock{
+		UpdateScheduleFunc: func(schedule models.Schedule) (*models.Schedule, error) {
+			return &schedule, nil
+		},
+	}
+	sh := handler.NewScheduleHandler(scheduleManager)
+
+	router := gin.Default()
+	router.PUT("/project/:project/schedule/:scheduleID", func(c *gin.Context) {
+		sh.UpdateSchedule(c)
+	})
+	w := performRequest(router, httptest.NewRequest(http.MethodPut, "/project/my-project/schedule/my-id", bytes.NewReader(payload)))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	// the ID and project from the path must take precedence over the payload
+	require.Equal(t, "my-id", scheduleManager.UpdateScheduleCalls()[0].Schedule.ID)
+	require.Equal(t, "my-project", scheduleManager.UpdateScheduleCalls()[0].Schedule.Project)
+}
+
+func TestScheduleHandler_DeleteSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleteErr  error
+		wantStatus int
+	}{
+		{
+			name:       "delete schedule",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "schedule not found",
+			deleteErr:  db.ErrScheduleNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "internal error",
+			deleteErr:  errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleManager := &fake.IScheduleManagerMock{
+				DeleteScheduleFunc: func(project string, id string) error {
+					return tt.deleteErr
+				},
+			}
+			sh := handler.NewScheduleHandler(scheduleManager)
+
+			router := gin.Default()
+			router.DELETE("/project/:project/schedule/:scheduleID", func(c *gin.Context) {
+				sh.DeleteSchedule(c)
+			})
+			w := performRequest(router, httptest.NewRequest(http.MethodDelete, "/project/my-project/schedule/my-id", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, "my-id", scheduleManager.DeleteScheduleCalls()[0].ID)
+		})
+	}
+}
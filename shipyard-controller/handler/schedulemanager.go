@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/schedulemanager.go . IScheduleManager
+type IScheduleManager interface {
+	CreateSchedule(schedule models.Schedule) (*models.Schedule, error)
+	GetSchedules(params models.GetScheduleParams) ([]models.Schedule, error)
+	GetSchedule(project, id string) (*models.Schedule, error)
+	UpdateSchedule(schedule models.Schedule) (*models.Schedule, error)
+	DeleteSchedule(project, id string) error
+}
+
+type ScheduleManager struct {
+	scheduleRepo  db.ScheduleRepo
+	projectMVRepo db.ProjectMVRepo
+	theClock      clock.Clock
+}
+
+func NewScheduleManager(scheduleRepo db.ScheduleRepo, projectMVRepo db.ProjectMVRepo, theClock clock.Clock) *ScheduleManager {
+	return &ScheduleManager{
+		scheduleRepo:  scheduleRepo,
+		projectMVRepo: projectMVRepo,
+		theClock:      theClock,
+	}
+}
+
+func (sm *ScheduleManager) CreateSchedule(schedule models.Schedule) (*models.Schedule, error) {
+	if err := sm.validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	schedule.ID = uuid.New().String()
+	schedule.CreatedAt = sm.theClock.Now().UTC()
+	schedule.LastTriggered = nil
+	schedule.LastKeptnContext = ""
+	if err := sm.scheduleRepo.CreateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return withNextTrigger(schedule), nil
+}
+
+func (sm *ScheduleManager) GetSchedules(params models.GetScheduleParams) ([]models.Schedule, error) {
+	if err := sm.checkProject(params.Project); err != nil {
+		return nil, err
+	}
+	schedules, err := sm.scheduleRepo.GetSchedules(params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schedules {
+		schedules[i] = *withNextTrigger(schedules[i])
+	}
+	return schedules, nil
+}
+
+func (sm *ScheduleManager) GetSchedule(project, id string) (*models.Schedule, error) {
+	schedules, err := sm.scheduleRepo.GetSchedules(models.GetScheduleParams{Project: project, ID: id})
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return nil, db.ErrScheduleNotFound
+	}
+	return withNextTrigger(schedules[0]), nil
+}
+
+func (sm *ScheduleManager) UpdateSchedule(schedule models.Schedule) (*models.Schedule, error) {
+	if err := sm.validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	if err := sm.scheduleRepo.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return sm.GetSchedule(schedule.Project, schedule.ID)
+}
+
+func (sm *ScheduleManager) DeleteSchedule(project, id string) error {
+	return sm.scheduleRepo.DeleteSchedule(project, id)
+}
+
+func (sm *ScheduleManager) validateSchedule(schedule models.Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if _, err := sm.projectMVRepo.GetService(schedule.Project, schedule.Stage, schedule.Service); err != nil {
+		switch {
+		case errors.Is(err, db.ErrProjectNotFound):
+			return ErrProjectNotFound
+		case errors.Is(err, db.ErrStageNotFound):
+			return fmt.Errorf("%w: %s", ErrStageNotFound, schedule.Stage)
+		case errors.Is(err, db.ErrServiceNotFound):
+			return fmt.Errorf("%w: %s", ErrServiceNotFound, schedule.Service)
+		}
+		return err
+	}
+	return nil
+}
+
+func (sm *ScheduleManager) checkProject(projectName string) error {
+	project, err := sm.projectMVRepo.GetProject(projectName)
+	if err != nil {
+		if errors.Is(err, db.ErrProjectNotFound) {
+			return ErrProjectNotFound
+		}
+		return err
+	}
+	if project == nil {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+func withNextTrigger(schedule models.Schedule) *models.Schedule {
+	// the schedule has been validated before it has been stored, so an error can only occur for corrupted entries
+	if nextTrigger, err := schedule.GetNextTrigger(); err == nil {
+		schedule.NextTrigger = nextTrigger
+	}
+	return &schedule
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func getScheduleTestService(project, stage, service string) (*apimodels.ExpandedService, error) {
+	if project != "my-project" {
+		return nil, db.ErrProjectNotFound
+	}
+	if stage != "dev" {
+		return nil, db.ErrStageNotFound
+	}
+	if service != "my-service" {
+		return nil, db.ErrServiceNotFound
+	}
+	return &apimodels.ExpandedService{ServiceName: service}, nil
+}
+
+func TestScheduleManager_CreateSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule models.Schedule
+		wantErr  error
+	}{
+		{
+			name:     "create schedule",
+			schedule: models.Schedule{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 2 * * *"},
+		},
+		{
+			name:     "invalid schedule",
+			schedule: models.Schedule{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "nightly"},
+			wantErr:  models.ErrInvalidSchedule,
+		},
+		{
+			name:     "project not found",
+			schedule: models.Schedule{Project: "unknown", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 2 * * *"},
+			wantErr:  ErrProjectNotFound,
+		},
+		{
+			name:     "stage not found",
+			schedule: models.Schedule{Project: "my-project", Stage: "unknown", Service: "my-service", Sequence: "evaluation", Cron: "0 2 * * *"},
+			wantErr:  ErrStageNotFound,
+		},
+		{
+			name:     "service not found",
+			schedule: models.Schedule{Project: "my-project", Stage: "dev", Service: "unknown", Sequence: "evaluation", Cron: "0 2 * * *"},
+			wantErr:  ErrServiceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleRepo := &db_mock.ScheduleRepoMock{
+				CreateScheduleFunc: func(schedule models.Schedule) error {
+					return nil
+				},
+			}
+			projectMVRepo := &db_mock.ProjectMVRepoMock{
+				GetServiceFunc: getScheduleTestService,
+			}
+			theClock := clock.NewMock()
+			theClock.Set(time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC))
+			sm := NewScheduleManager(scheduleRepo, projectMVRepo, theClock)
+
+			createdSchedule, err := sm.CreateSchedule(tt.schedule)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Empty(t, scheduleRepo.CreateScheduleCalls())
+				return
+			}
+			require.Nil(t, err)
+			require.NotEmpty(t, createdSchedule.ID)
+			require.Equal(t, theClock.Now(), createdSchedule.CreatedAt)
+			require.Equal(t, time.Date(2022, 3, 2, 2, 0, 0, 0, time.UTC), *createdSchedule.NextTrigger)
+			require.Len(t, scheduleRepo.CreateScheduleCalls(), 1)
+			require.Equal(t, createdSchedule.ID, scheduleRepo.CreateScheduleCalls()[0].Schedule.ID)
+			require.Nil(t, scheduleRepo.CreateScheduleCalls()[0].Schedule.NextTrigger)
+		})
+	}
+}
+
+func TestScheduleManager_GetSchedule(t *testing.T) {
+	lastTriggered := time.Date(2022, 3, 3, 2, 0, 0, 0, time.UTC)
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetSchedulesFunc: func(params models.GetScheduleParams) ([]models.Schedule, error) {
+			if params.ID == "my-id" {
+				return []models.Schedule{{ID: "my-id", Project: params.Project, Cron: "0 2 * * *", LastTriggered: &lastTriggered}}, nil
+			}
+			return []models.Schedule{}, nil
+		},
+	}
+	sm := NewScheduleManager(scheduleRepo, &db_mock.ProjectMVRepoMock{}, clock.NewMock())
+
+	schedule, err := sm.GetSchedule("my-project", "my-id")
+	require.Nil(t, err)
+	require.Equal(t, "my-id", schedule.ID)
+	require.Equal(t, time.Date(2022, 3, 4, 2, 0, 0, 0, time.UTC), *schedule.NextTrigger)
+
+	schedule, err = sm.GetSchedule("my-project", "unknown")
+	require.ErrorIs(t, err, db.ErrScheduleNotFound)
+	require.Nil(t, schedule)
+}
+
+func TestScheduleManager_GetSchedules_ProjectNotFound(t *testing.T) {
+	scheduleRepo := &db_mock.ScheduleRepoMock{}
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectFunc: func(projectName string) (*apimodels.ExpandedProject, error) {
+			return nil, nil
+		},
+	}
+	sm := NewScheduleManager(scheduleRepo, projectMVRepo, clock.NewMock())
+
+	schedules, err := sm.GetSchedules(models.GetScheduleParams{Project: "unknown"})
+	require.ErrorIs(t, err, ErrProjectNotFound)
+	require.Nil(t, schedules)
+	require.Empty(t, scheduleRepo.GetSchedulesCalls())
+}
+
+func TestScheduleManager_UpdateSchedule(t *testing.T) {
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		UpdateScheduleFunc: func(schedule models.Schedule) error {
+			return nil
+		},
+		GetSchedulesFunc: func(params models.GetScheduleParams) ([]models.Schedule, error) {
+			return []models.Schedule{{ID: params.ID, Project: params.Project, Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 3 * * *"}}, nil
+		},
+	}
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetServiceFunc: getScheduleTestService,
+	}
+	sm := NewScheduleManager(scheduleRepo, projectMVRepo, clock.NewMock())
+
+	updatedSchedule, err := sm.UpdateSchedule(models.Schedule{ID: "my-id", Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 3 * * *"})
+	require.Nil(t, err)
+	require.Equal(t, "0 3 * * *", updatedSchedule.Cron)
+	require.Len(t, scheduleRepo.UpdateScheduleCalls(), 1)
+
+	_, err = sm.UpdateSchedule(models.Schedule{ID: "my-id", Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation"})
+	require.ErrorIs(t, err, models.ErrInvalidSchedule)
+	require.Len(t, scheduleRepo.UpdateScheduleCalls(), 1)
+}
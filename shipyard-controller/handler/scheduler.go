@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/scheduler.go . IScheduler
+// IScheduler is responsible for triggering sequences according to the schedules defined for the services of a project
+type IScheduler interface {
+	Run(ctx context.Context)
+	Stop()
+}
+
+// Scheduler is an implementation of IScheduler
+// It regularly checks which schedules are due and sends a sequence.triggered event for each of them.
+// Since all replicas of the shipyard controller share the same schedules, the scheduler must only run on the elected leader
+type Scheduler struct {
+	scheduleRepo db.ScheduleRepo
+	eventSender  keptncommon.EventSender
+	syncInterval time.Duration
+	theClock     clock.Clock
+	mutex        sync.Mutex
+	cancel       context.CancelFunc
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(scheduleRepo db.ScheduleRepo, eventSender keptncommon.EventSender, syncInterval time.Duration, theClock clock.Clock) *Scheduler {
+	return &Scheduler{
+		scheduleRepo: scheduleRepo,
+		eventSender:  eventSender,
+		syncInterval: syncInterval,
+		theClock:     theClock,
+	}
+}
+
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// make sure there is only one loop, even if the leadership has been acquired multiple times
+	if s.cancel != nil {
+		s.cancel()
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	ticker := s.theClock.Ticker(s.syncInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("Cancelling scheduler loop")
+				return
+			case <-ticker.C:
+				if ctx.Err() != nil {
+					// the scheduler has been stopped in the meantime
+					return
+				}
+				log.Debugf("%.2f seconds have passed. Triggering due schedules", s.syncInterval.Seconds())
+				s.triggerDueSchedules()
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+}
+
+func (s *Scheduler) triggerDueSchedules() {
+	schedules, err := s.scheduleRepo.GetSchedules(models.GetScheduleParams{})
+	if err != nil {
+		log.WithError(err).Error("Could not load schedules")
+		return
+	}
+
+	now := s.theClock.Now().UTC()
+	for _, schedule := range schedules {
+		nextTrigger, err := schedule.GetNextTrigger()
+		if err != nil {
+			log.WithError(err).Errorf("Could not determine next trigger of schedule %s", schedule.ID)
+			continue
+		}
+		// if the scheduler has not been running for a while, missed triggers are not caught up. Only a single sequence is triggered
+		if nextTrigger.After(now) {
+			continue
+		}
+		if err := s.triggerSequence(schedule, now); err != nil {
+			log.WithError(err).Errorf("Could not trigger sequence of schedule %s", schedule.ID)
+		}
+	}
+}
+
+func (s *Scheduler) triggerSequence(schedule models.Schedule, now time.Time) error {
+	keptnContext := uuid.New().String()
+	eventData := keptnv2.EventData{
+		Project: schedule.Project,
+		Stage:   schedule.Stage,
+		Service: schedule.Service,
+		Labels:  schedule.Labels,
+	}
+	event := common.CreateEventWithPayload(keptnContext, "", keptnv2.GetTriggeredEventType(schedule.Stage+"."+schedule.Sequence), eventData)
+
+	// the trigger is stored before the event is sent to make sure the sequence is not triggered again in case the update fails
+	if err := s.scheduleRepo.UpdateLastTriggered(schedule.ID, now, keptnContext); err != nil {
+		return fmt.Errorf("could not update last trigger: %w", err)
+	}
+	if err := s.eventSender.Send(context.TODO(), event); err != nil {
+		return fmt.Errorf("could not send %s event: %w", event.Type(), err)
+	}
+	log.Infof("Triggered sequence %s in stage %s for service %s of project %s. Keptn context: %s", schedule.Sequence, schedule.Stage, schedule.Service, schedule.Project, keptnContext)
+	return nil
+}
@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	dbmock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	mutex := sync.Mutex{}
+	schedules := []models.Schedule{
+		{
+			ID:        "nightly",
+			Project:   "my-project",
+			Stage:     "dev",
+			Service:   "my-service",
+			Sequence:  "evaluation",
+			Cron:      "0 2 * * *",
+			Labels:    map[string]string{"trigger": "nightly"},
+			CreatedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        "weekly",
+			Project:   "my-project",
+			Stage:     "production",
+			Service:   "my-service",
+			Sequence:  "performance",
+			Cron:      "0 2 * * 6",
+			CreatedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	scheduleRepo := &dbmock.ScheduleRepoMock{
+		GetSchedulesFunc: func(params models.GetScheduleParams) ([]models.Schedule, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			result := make([]models.Schedule, len(schedules))
+			copy(result, schedules)
+			return result, nil
+		},
+		UpdateLastTriggeredFunc: func(id string, lastTriggered time.Time, keptnContext string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			for i := range schedules {
+				if schedules[i].ID == id {
+					schedules[i].LastTriggered = &lastTriggered
+					schedules[i].LastKeptnContext = keptnContext
+				}
+			}
+			return nil
+		},
+	}
+
+	sentEvents := []cloudevents.Event{}
+	eventSender := &fake.IEventSenderMock{
+		SendFunc: func(ctx context.Context, event cloudevents.Event) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			sentEvents = append(sentEvents, event)
+			return nil
+		},
+	}
+	getSentEvents := func() []cloudevents.Event {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return sentEvents
+	}
+
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 3, 2, 1, 59, 30, 0, time.UTC))
+
+	scheduler := handler.NewScheduler(scheduleRepo, eventSender, 30*time.Second, theClock)
+	scheduler.Run(context.Background())
+
+	// the nightly schedule is due at 02:00
+	theClock.Add(30 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(getSentEvents()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	event := getSentEvents()[0]
+	require.Equal(t, keptnv2.GetTriggeredEventType("dev.evaluation"), event.Type())
+	eventData := &keptnv2.EventData{}
+	require.Nil(t, event.DataAs(eventData))
+	require.Equal(t, keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "my-service", Labels: map[string]string{"trigger": "nightly"}}, *eventData)
+
+	require.Len(t, scheduleRepo.UpdateLastTriggeredCalls(), 1)
+	require.Equal(t, "nightly", scheduleRepo.UpdateLastTriggeredCalls()[0].ID)
+	require.Equal(t, event.Extensions()["shkeptncontext"], scheduleRepo.UpdateLastTriggeredCalls()[0].KeptnContext)
+
+	// the nightly schedule must not be triggered again on the same day
+	theClock.Add(30 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(scheduleRepo.GetSchedulesCalls()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, getSentEvents(), 1)
+
+	// after the scheduler has been stopped, no more sequences are triggered
+	scheduler.Stop()
+	theClock.Add(5 * 24 * time.Hour)
+	require.Len(t, getSentEvents(), 1)
+
+	// when the scheduler is started again, missed triggers lead to a single sequence per schedule
+	scheduler.Run(context.Background())
+	defer scheduler.Stop()
+	theClock.Add(30 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(getSentEvents()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	theClock.Add(30 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(scheduleRepo.GetSchedulesCalls()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, getSentEvents(), 3)
+}
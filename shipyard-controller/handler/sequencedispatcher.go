@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -13,6 +14,8 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
@@ -24,18 +27,25 @@ type ISequenceDispatcher interface {
 	Run(ctx context.Context, mode common.SDMode, startSequenceFunc func(event apimodels.KeptnContextExtendedCE) error)
 	Remove(eventScope models.EventScope) error
 	Stop()
+	AddSequenceWaitingHook(hook sequencehooks.ISequenceWaitingHook)
 }
 
 type SequenceDispatcher struct {
 	eventRepo             db.EventRepo
 	sequenceQueue         db.SequenceQueueRepo
 	sequenceExecutionRepo db.SequenceExecutionRepo
+	freezeWindowManager   IFreezeWindowManager
 	theClock              clock.Clock
 	syncInterval          time.Duration
+	priorityAgingInterval time.Duration
 	startSequenceFunc     func(event apimodels.KeptnContextExtendedCE) error
 	shipyardController    shipyardController
 	ticker                *clock.Ticker
 	mode                  common.SDMode
+	sequenceWaitingHooks  []sequencehooks.ISequenceWaitingHook
+	// waitingReasons contains the reason each queued sequence is currently waiting for, identified by the ID of its triggered event
+	waitingReasons      map[string]string
+	waitingReasonsMutex sync.Mutex
 }
 
 // NewSequenceDispatcher creates a new SequenceDispatcher
@@ -43,7 +53,9 @@ func NewSequenceDispatcher(
 	eventRepo db.EventRepo,
 	sequenceQueueRepo db.SequenceQueueRepo,
 	sequenceExecutionRepo db.SequenceExecutionRepo,
+	freezeWindowManager IFreezeWindowManager,
 	syncInterval time.Duration,
+	priorityAgingInterval time.Duration,
 	theClock clock.Clock,
 	mode common.SDMode,
 ) ISequenceDispatcher {
@@ -51,9 +63,12 @@ func NewSequenceDispatcher(
 		eventRepo:             eventRepo,
 		sequenceQueue:         sequenceQueueRepo,
 		sequenceExecutionRepo: sequenceExecutionRepo,
+		freezeWindowManager:   freezeWindowManager,
 		theClock:              theClock,
 		syncInterval:          syncInterval,
+		priorityAgingInterval: priorityAgingInterval,
 		mode:                  mode,
+		waitingReasons:        map[string]string{},
 	}
 }
 
@@ -70,7 +85,9 @@ func (sd *SequenceDispatcher) Add(queueItem models.QueueItem) error {
 				if err2 := sd.add(queueItem); err2 != nil {
 					return err2
 				}
-				return ErrSequenceBlockedWaiting
+				// the waiting state of the sequence is set by the caller, hence the hooks only need to be notified once the reason changes
+				sd.setWaitingReason(queueItem.EventID, err)
+				return err
 			} else {
 				return err
 			}
@@ -92,6 +109,12 @@ func (sd *SequenceDispatcher) Remove(eventScope models.EventScope) error {
 	})
 }
 
+// AddSequenceWaitingHook adds a hook that is notified whenever the reason a queued sequence is waiting for changes while it is dispatched,
+// e.g. because a freeze window has been changed or has ended, but the sequence is still blocked by another sequence
+func (sd *SequenceDispatcher) AddSequenceWaitingHook(hook sequencehooks.ISequenceWaitingHook) {
+	sd.sequenceWaitingHooks = append(sd.sequenceWaitingHooks, hook)
+}
+
 func (sd *SequenceDispatcher) SetStartSequenceCallback(startSequenceFunc func(event apimodels.KeptnContextExtendedCE) error) {
 	sd.startSequenceFunc = startSequenceFunc
 }
@@ -129,75 +152,236 @@ func (sd *SequenceDispatcher) dispatchSequences() {
 	if err != nil {
 		if errors.Is(err, db.ErrNoEventFound) {
 			// if no sequences are in the queue, we can return here
+			metrics.SetPerProject(metrics.SequenceQueueSize, nil)
+			metrics.SetPerProject(metrics.BlockedSequences, nil)
+			sd.pruneWaitingReasons(nil)
 			return
 		}
 		log.WithError(err).Error("Could not load queued sequences")
 		return
 	}
 
+	// sequences with a higher priority are dispatched first
+	models.SortQueueItemsByPriority(queuedSequences, sd.theClock.Now(), sd.priorityAgingInterval)
+
+	queueSize := map[string]int{}
+	blockedSequences := map[string]int{}
 	for _, queuedSequence := range queuedSequences {
+		project := queuedSequence.Scope.Project
 		if err := sd.dispatchSequence(queuedSequence); err != nil {
+			queueSize[project]++
 			if errors.Is(err, ErrSequenceBlocked) || errors.Is(err, ErrSequenceBlockedWaiting) {
+				blockedSequences[project]++
 				log.Infof("Could not dispatch sequence with keptnContext %s. Sequence is currently blocked by other sequence", queuedSequence.Scope.KeptnContext)
+				sd.refreshWaitingReason(queuedSequence, err)
 			} else {
 				log.WithError(err).Errorf("Could not dispatch sequence with keptnContext %s", queuedSequence.Scope.KeptnContext)
 			}
+			continue
+		}
+		metrics.ObserveDuration(metrics.SequenceDispatchLatency.WithLabelValues(project), queuedSequence.Timestamp, sd.theClock.Now())
+	}
+	metrics.SetPerProject(metrics.SequenceQueueSize, queueSize)
+	metrics.SetPerProject(metrics.BlockedSequences, blockedSequences)
+	sd.pruneWaitingReasons(queuedSequences)
+}
+
+// refreshWaitingReason notifies the sequence waiting hooks if the sequence of the queue item is waiting for another reason than before.
+// This keeps the waiting reason of the sequence state up to date, e.g. when a freeze window is changed or ends
+func (sd *SequenceDispatcher) refreshWaitingReason(queueItem models.QueueItem, err error) {
+	reason, changed := sd.setWaitingReason(queueItem.EventID, err)
+	if !changed {
+		return
+	}
+	event, err := sd.getTriggeredEvent(queueItem)
+	if err != nil {
+		log.WithError(err).Errorf("Could not update waiting reason of sequence with keptnContext %s", queueItem.Scope.KeptnContext)
+		return
+	}
+	for _, hook := range sd.sequenceWaitingHooks {
+		hook.OnSequenceWaiting(*event, reason)
+	}
+}
+
+// setWaitingReason stores the reason of the SequenceBlockedError the sequence of the given event is waiting for, and returns whether it has changed
+func (sd *SequenceDispatcher) setWaitingReason(eventID string, err error) (string, bool) {
+	blockedErr := &SequenceBlockedError{}
+	if !errors.As(err, &blockedErr) {
+		return "", false
+	}
+	sd.waitingReasonsMutex.Lock()
+	defer sd.waitingReasonsMutex.Unlock()
+	previousReason, ok := sd.waitingReasons[eventID]
+	sd.waitingReasons[eventID] = blockedErr.Reason
+	return blockedErr.Reason, !ok || previousReason != blockedErr.Reason
+}
+
+// pruneWaitingReasons removes the waiting reasons of sequences that are not queued anymore
+func (sd *SequenceDispatcher) pruneWaitingReasons(queuedSequences []models.QueueItem) {
+	queued := map[string]bool{}
+	for _, queuedSequence := range queuedSequences {
+		queued[queuedSequence.EventID] = true
+	}
+	sd.waitingReasonsMutex.Lock()
+	defer sd.waitingReasonsMutex.Unlock()
+	for eventID := range sd.waitingReasons {
+		if !queued[eventID] {
+			delete(sd.waitingReasons, eventID)
+		}
+	}
+}
+
+// isStageFrozen checks whether a freeze window is currently active for the stage of the given queue item.
+// If this is the case, a SequenceBlockedError containing the freeze window and its end is returned
+func (sd *SequenceDispatcher) isStageFrozen(queueItem models.QueueItem) (*SequenceBlockedError, error) {
+	freezeWindows, err := sd.freezeWindowManager.GetFreezeWindows(models.GetFreezeWindowParams{
+		Project: queueItem.Scope.Project,
+		Stage:   queueItem.Scope.Stage,
+	})
+	if err != nil {
+		log.Errorf("Could not load freeze windows for project %s, stage %s: %v", queueItem.Scope.Project, queueItem.Scope.Stage, err)
+		return nil, err
+	}
+
+	now := sd.theClock.Now()
+	for _, freezeWindow := range freezeWindows {
+		if !freezeWindow.AppliesToStage(queueItem.Scope.Stage) {
+			continue
+		}
+		activeUntil, err := freezeWindow.GetActiveUntil(now)
+		if err != nil {
+			log.Errorf("Could not evaluate freeze window %s of project %s: %v", freezeWindow.ID, freezeWindow.Project, err)
+			continue
 		}
+		if activeUntil == nil {
+			continue
+		}
+		freezeWindowName := freezeWindow.ID
+		if freezeWindow.Description != "" {
+			freezeWindowName = fmt.Sprintf("'%s'", freezeWindow.Description)
+		}
+		log.Infof("Sequence with KeptnContext %s is blocked by freeze window %s in stage %s", queueItem.Scope.KeptnContext, freezeWindow.ID, queueItem.Scope.Stage)
+		return &SequenceBlockedError{
+			Reason: fmt.Sprintf("stage %s is frozen by freeze window %s until %s", queueItem.Scope.Stage, freezeWindowName, activeUntil.Format(time.RFC3339)),
+		}, nil
 	}
+	return nil, nil
 }
 
-func (sd *SequenceDispatcher) isSequenceBlocked(queueItem models.QueueItem) (bool, error) {
+// isSequenceBlocked checks whether the sequence of the given queue item can be started, based on the concurrency policy of the stage.
+// If the sequence needs to wait, a SequenceBlockedError containing the reason is returned
+func (sd *SequenceDispatcher) isSequenceBlocked(queueItem models.QueueItem) (*SequenceBlockedError, error) {
+	scope := models.EventScope{
+		EventData: keptnv2.EventData{
+			Project: queueItem.Scope.Project,
+			Stage:   queueItem.Scope.Stage,
+			Service: queueItem.Scope.Service,
+		},
+	}
 	// searching for running sequences
 	startedSequenceExecutions, err := sd.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
-		Scope: models.EventScope{
-			EventData: keptnv2.EventData{
-				Project: queueItem.Scope.Project,
-				Stage:   queueItem.Scope.Stage,
-				Service: queueItem.Scope.Service,
-			},
-		},
+		Scope:  scope,
 		Status: []string{apimodels.SequenceStartedState},
 	})
 	if err != nil {
 		log.Errorf("Could not load started sequences for project %s, service %s, stage %s: %v", queueItem.Scope.Project, queueItem.Scope.Service, queueItem.Scope.Stage, err)
-		return true, err
+		return nil, err
 	}
 
-	if len(startedSequenceExecutions) > 0 {
+	isParallel := queueItem.Concurrency.GetPolicy() == models.ConcurrencyParallel
+
+	if !isParallel && len(startedSequenceExecutions) > 0 {
 		log.Infof("Sequence with KeptnContext %s blocked due to started sequence with KeptnContext %s in stage %s", queueItem.Scope.KeptnContext, startedSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Stage)
-		return true, nil
+		return &SequenceBlockedError{
+			Reason: fmt.Sprintf("sequence with keptnContext %s is currently running for service %s in stage %s", startedSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Service, queueItem.Scope.Stage),
+		}, nil
 	}
 
 	//searching for triggered sequences which were triggered before the actual sequence
 	triggeredSequenceExecutions, err := sd.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
-		Scope: models.EventScope{
-			EventData: keptnv2.EventData{
-				Project: queueItem.Scope.Project,
-				Stage:   queueItem.Scope.Stage,
-				Service: queueItem.Scope.Service,
-			},
-		},
+		Scope:       scope,
 		Status:      []string{apimodels.SequenceTriggeredState},
 		TriggeredAt: queueItem.Timestamp,
 	})
 	if err != nil {
 		log.Errorf("Could not load triggered sequences for project %s, service %s, stage %s: %v", queueItem.Scope.Project, queueItem.Scope.Service, queueItem.Scope.Stage, err)
-		return true, err
+		return nil, err
+	}
+
+	otherTriggeredSequenceExecutions := []models.SequenceExecution{}
+	for _, sequenceExecution := range triggeredSequenceExecutions {
+		if sequenceExecution.Scope.KeptnContext != queueItem.Scope.KeptnContext {
+			otherTriggeredSequenceExecutions = append(otherTriggeredSequenceExecutions, sequenceExecution)
+		}
 	}
 
-	if len(triggeredSequenceExecutions) == 1 {
-		if triggeredSequenceExecutions[0].Scope.KeptnContext != queueItem.Scope.KeptnContext {
-			log.Infof("Sequence with KeptnContext %s is blocked due to triggered sequence with KeptnContext %s in stage %s", queueItem.Scope.KeptnContext, triggeredSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Stage)
-			return true, nil
+	if queueItem.GetEffectivePriority(sd.theClock.Now(), sd.priorityAgingInterval) > 0 && len(otherTriggeredSequenceExecutions) > 0 {
+		// sequences that have been triggered earlier, but have a lower effective priority, must not block the given sequence.
+		// A sequence without an initial priority can gain priority by waiting in the queue
+		otherTriggeredSequenceExecutions, err = sd.filterLowerPrioritySequences(queueItem, otherTriggeredSequenceExecutions)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	if len(triggeredSequenceExecutions) > 1 {
-		log.Infof("Sequence with KeptnContext %s is blocked due to triggered sequences in stage %s with KeptnContext %s", queueItem.Scope.KeptnContext, queueItem.Scope.Stage, triggeredSequenceExecutions[0].Scope.KeptnContext)
-		return true, nil
+	if isParallel {
+		return isParallelLimitReached(queueItem, len(startedSequenceExecutions)+len(otherTriggeredSequenceExecutions)), nil
 	}
 
-	return false, nil
+	if len(otherTriggeredSequenceExecutions) > 0 {
+		log.Infof("Sequence with KeptnContext %s is blocked due to triggered sequence with KeptnContext %s in stage %s", queueItem.Scope.KeptnContext, otherTriggeredSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Stage)
+		return &SequenceBlockedError{
+			Reason: fmt.Sprintf("sequence with keptnContext %s has been triggered earlier for service %s in stage %s", otherTriggeredSequenceExecutions[0].Scope.KeptnContext, queueItem.Scope.Service, queueItem.Scope.Stage),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// filterLowerPrioritySequences removes all sequence executions whose queued sequences have a lower effective priority than the given queue item.
+// Sequence executions without a corresponding queue item are retained
+func (sd *SequenceDispatcher) filterLowerPrioritySequences(queueItem models.QueueItem, sequenceExecutions []models.SequenceExecution) ([]models.SequenceExecution, error) {
+	queuedSequences, err := sd.sequenceQueue.GetQueuedSequences()
+	if err != nil && !errors.Is(err, db.ErrNoEventFound) {
+		log.Errorf("Could not load queued sequences for project %s, service %s, stage %s: %v", queueItem.Scope.Project, queueItem.Scope.Service, queueItem.Scope.Stage, err)
+		return nil, err
+	}
+
+	now := sd.theClock.Now()
+	queueItemPriority := queueItem.GetEffectivePriority(now, sd.priorityAgingInterval)
+
+	lowerPriorityContexts := map[string]bool{}
+	for _, queuedSequence := range queuedSequences {
+		if queuedSequence.Scope.Project != queueItem.Scope.Project || queuedSequence.Scope.Stage != queueItem.Scope.Stage || queuedSequence.Scope.Service != queueItem.Scope.Service {
+			continue
+		}
+		if queuedSequence.GetEffectivePriority(now, sd.priorityAgingInterval) < queueItemPriority {
+			lowerPriorityContexts[queuedSequence.Scope.KeptnContext] = true
+		}
+	}
+
+	result := []models.SequenceExecution{}
+	for _, sequenceExecution := range sequenceExecutions {
+		if lowerPriorityContexts[sequenceExecution.Scope.KeptnContext] {
+			log.Debugf("Sequence with KeptnContext %s is not blocked by sequence with KeptnContext %s due to its higher priority", queueItem.Scope.KeptnContext, sequenceExecution.Scope.KeptnContext)
+			continue
+		}
+		result = append(result, sequenceExecution)
+	}
+	return result, nil
+}
+
+// isParallelLimitReached checks if the number of running sequences, as well as sequences that have been queued before the given sequence
+// has reached the maximum number of parallel sequences allowed by the concurrency policy
+func isParallelLimitReached(queueItem models.QueueItem, nrActiveSequences int) *SequenceBlockedError {
+	maxParallel := queueItem.Concurrency.MaxParallel
+	if maxParallel <= 0 || nrActiveSequences < maxParallel {
+		return nil
+	}
+	log.Infof("Sequence with KeptnContext %s is blocked because the maximum number of %d parallel sequences in stage %s has been reached", queueItem.Scope.KeptnContext, maxParallel, queueItem.Scope.Stage)
+	return &SequenceBlockedError{
+		Reason: fmt.Sprintf("the maximum number of %d parallel sequences for service %s in stage %s has been reached", maxParallel, queueItem.Scope.Service, queueItem.Scope.Stage),
+	}
 }
 
 func (sd *SequenceDispatcher) dispatchSequence(queueItem models.QueueItem) error {
@@ -216,32 +400,48 @@ func (sd *SequenceDispatcher) dispatchSequence(queueItem models.QueueItem) error
 		return ErrSequenceBlocked
 	}
 
-	sequenceBlocked, err := sd.isSequenceBlocked(queueItem)
+	frozenErr, err := sd.isStageFrozen(queueItem)
 	if err != nil {
 		return err
 	}
 
-	if sequenceBlocked {
-		return ErrSequenceBlockedWaiting
+	if frozenErr != nil {
+		return frozenErr
 	}
 
-	events, err := sd.eventRepo.GetEvents(queueItem.Scope.Project, common.EventFilter{
-		ID: &queueItem.EventID,
-	}, common.TriggeredEvent)
-
+	blockedErr, err := sd.isSequenceBlocked(queueItem)
 	if err != nil {
 		return err
 	}
 
-	if len(events) == 0 {
-		return fmt.Errorf("sequence.triggered event with ID %s cannot be found anymore", queueItem.EventID)
+	if blockedErr != nil {
+		return blockedErr
 	}
 
-	sequenceTriggeredEvent := events[0]
+	sequenceTriggeredEvent, err := sd.getTriggeredEvent(queueItem)
+	if err != nil {
+		return err
+	}
 
-	if err := sd.startSequenceFunc(sequenceTriggeredEvent); err != nil {
+	if err := sd.startSequenceFunc(*sequenceTriggeredEvent); err != nil {
 		return fmt.Errorf("could not start task sequence %s: %s", queueItem.EventID, err.Error())
 	}
 
 	return sd.sequenceQueue.DeleteQueuedSequences(queueItem)
 }
+
+// getTriggeredEvent returns the sequence.triggered event of the given queue item
+func (sd *SequenceDispatcher) getTriggeredEvent(queueItem models.QueueItem) (*apimodels.KeptnContextExtendedCE, error) {
+	events, err := sd.eventRepo.GetEvents(queueItem.Scope.Project, common.EventFilter{
+		ID: &queueItem.EventID,
+	}, common.TriggeredEvent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("sequence.triggered event with ID %s cannot be found anymore", queueItem.EventID)
+	}
+	return &events[0], nil
+}
@@ -3,6 +3,7 @@ package handler_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/common"
 	dbmock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	fakehooks "github.com/keptn/keptn/shipyard-controller/handler/sequencehooks/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 )
@@ -78,7 +81,7 @@ func TestSequenceDispatcher(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, &fake.IFreezeWindowManagerMock{GetFreezeWindowsFunc: noFreezeWindows}, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -236,7 +239,7 @@ func TestSequenceDispatcher_Remove(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, 10*time.Second, nil, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, mockSequenceQueueRepo, nil, nil, 10*time.Second, 5*time.Minute, nil, common.SDModeRW)
 
 	myScope := models.EventScope{
 		EventData:    keptnv2.EventData{Project: "my-project"},
@@ -326,7 +329,7 @@ func TestSequenceDispatcher_AddError(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, &fake.IFreezeWindowManagerMock{GetFreezeWindowsFunc: noFreezeWindows}, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -421,7 +424,7 @@ func TestSequenceDispatcher_QueueIsNotEmpty(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, 10*time.Second, theClock, common.SDModeRW)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, &fake.IFreezeWindowManagerMock{GetFreezeWindowsFunc: noFreezeWindows}, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
 
 	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
 		startSequenceCalls = append(startSequenceCalls, event)
@@ -490,7 +493,8 @@ func TestSequenceDispatcher_QueueIsNotEmpty(t *testing.T) {
 		EventID: "my-event-id2",
 	}
 	err = sequenceDispatcher.Add(queueItem)
-	require.Equal(t, err.Error(), "sequence is currently blocked by waiting for another sequence to end")
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Equal(t, "sequence is currently blocked by waiting for another sequence to end: sequence with keptnContext my-context-id is currently running for service my-service in stage my-stage", err.Error())
 	require.Len(t, mockSequenceExecutionRepo.GetCalls(), 3)
 
 	require.Len(t, mockEventRepo.GetEventsCalls(), 1)
@@ -551,7 +555,7 @@ func TestSequenceDispatcher_QueueIsNotEmpty(t *testing.T) {
 		EventID: "my-event-id2",
 	}
 	err = sequenceDispatcher.Add(queueItem)
-	require.Equal(t, err.Error(), "sequence is currently blocked by waiting for another sequence to end")
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
 	require.Len(t, mockSequenceExecutionRepo.GetCalls(), 5)
 
 	require.Len(t, mockEventRepo.GetEventsCalls(), 1)
@@ -617,3 +621,485 @@ func getQueueItem(id string) models.QueueItem {
 		EventID: id,
 	}
 }
+
+func TestSequenceDispatcher_ParallelConcurrencyPolicy(t *testing.T) {
+	theClock := clock.NewMock()
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	triggeredEvents := []apimodels.KeptnContextExtendedCE{
+		{
+			Data: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			ID:             "my-event-id",
+			Shkeptncontext: "my-context-id",
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType("dev.delivery")),
+		},
+	}
+
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return triggeredEvents, nil
+		},
+	}
+
+	newSequenceExecution := func(keptnContext, state string) models.SequenceExecution {
+		return models.SequenceExecution{
+			ID: keptnContext,
+			Sequence: keptnv2.Sequence{
+				Name: "delivery",
+			},
+			Status: models.SequenceExecutionStatus{
+				State: state,
+			},
+			Scope: models.EventScope{
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				KeptnContext: keptnContext,
+			},
+		}
+	}
+
+	startedSequenceExecutions := []models.SequenceExecution{
+		newSequenceExecution("my-started-context-1", apimodels.SequenceStartedState),
+	}
+	triggeredSequenceExecutions := []models.SequenceExecution{}
+
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.Status[0] == apimodels.SequenceStartedState {
+				return startedSequenceExecutions, nil
+			}
+			return triggeredSequenceExecutions, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, &fake.IFreezeWindowManagerMock{GetFreezeWindowsFunc: noFreezeWindows}, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	queueItem := models.QueueItem{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+			EventType:    keptnv2.GetTriggeredEventType("dev.delivery"),
+		},
+		EventID:   "my-event-id",
+		Timestamp: theClock.Now(),
+		Concurrency: models.ConcurrencyPolicy{
+			Policy:      models.ConcurrencyParallel,
+			MaxParallel: 2,
+		},
+	}
+
+	// one sequence is running - the new one should be started since up to two sequences are allowed
+	err := sequenceDispatcher.Add(queueItem)
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 1)
+	require.Empty(t, mockSequenceQueueRepo.QueueSequenceCalls())
+
+	// one sequence is running, and another one has been queued before - the new one has to wait
+	triggeredSequenceExecutions = []models.SequenceExecution{
+		newSequenceExecution("my-triggered-context", apimodels.SequenceTriggeredState),
+		newSequenceExecution("my-context-id", apimodels.SequenceTriggeredState),
+	}
+	err = sequenceDispatcher.Add(queueItem)
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Equal(t, "sequence is currently blocked by waiting for another sequence to end: the maximum number of 2 parallel sequences for service my-service in stage my-stage has been reached", err.Error())
+	require.Len(t, startSequenceCalls, 1)
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+
+	// without a limit, the sequence can be started regardless of the other sequences
+	queueItem.Concurrency.MaxParallel = 0
+	err = sequenceDispatcher.Add(queueItem)
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 2)
+}
+
+func TestSequenceDispatcher_Priority(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{
+				{
+					Data: keptnv2.EventData{
+						Project: "my-project",
+						Stage:   "my-stage",
+						Service: "my-service",
+					},
+					ID:             *filter.ID,
+					Shkeptncontext: strings.TrimSuffix(*filter.ID, "-event-id"),
+					Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-stage.delivery")),
+				},
+			}, nil
+		},
+	}
+
+	newQueueItem := func(keptnContext string, priority int, timestamp time.Time) models.QueueItem {
+		return models.QueueItem{
+			Scope: models.EventScope{
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "my-stage",
+					Service: "my-service",
+				},
+				KeptnContext: keptnContext,
+				EventType:    keptnv2.GetTriggeredEventType("my-stage.delivery"),
+			},
+			EventID:   keptnContext + "-event-id",
+			Timestamp: timestamp,
+			Priority:  priority,
+		}
+	}
+
+	// a sequence with a low priority has been queued before
+	queuedSequences := []models.QueueItem{
+		newQueueItem("low-priority-context", 0, theClock.Now().Add(-1*time.Minute)),
+	}
+
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			queuedSequences = append(queuedSequences, item)
+			return nil
+		},
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return queuedSequences, nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if filter.Status[0] == apimodels.SequenceStartedState {
+				return []models.SequenceExecution{}, nil
+			}
+			return []models.SequenceExecution{
+				{
+					ID: "low-priority-context",
+					Scope: models.EventScope{
+						EventData: keptnv2.EventData{
+							Project: "my-project",
+							Stage:   "my-stage",
+							Service: "my-service",
+						},
+						KeptnContext: "low-priority-context",
+					},
+				},
+			}, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, &fake.IFreezeWindowManagerMock{GetFreezeWindowsFunc: noFreezeWindows}, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	// a sequence with the same priority has to wait for the sequence that has been queued before
+	err := sequenceDispatcher.Add(newQueueItem("same-priority-context", 0, theClock.Now()))
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Empty(t, startSequenceCalls)
+
+	// a sequence with a higher priority can overtake the sequence that has been queued before
+	err = sequenceDispatcher.Add(newQueueItem("high-priority-context", 1, theClock.Now()))
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 1)
+	require.Equal(t, "high-priority-context", startSequenceCalls[0].Shkeptncontext)
+
+	// after waiting long enough, the sequence with the low priority can no longer be overtaken
+	queuedSequences[0].Timestamp = theClock.Now().Add(-10 * time.Minute)
+	err = sequenceDispatcher.Add(newQueueItem("another-high-priority-context", 1, theClock.Now()))
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Len(t, startSequenceCalls, 1)
+
+	// a sequence without an initial priority overtakes the sequence with the low priority once it has gained priority by waiting in the queue
+	queuedSequences[0].Timestamp = theClock.Now()
+	err = sequenceDispatcher.Add(newQueueItem("aged-context", 0, theClock.Now().Add(-10*time.Minute)))
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 2)
+	require.Equal(t, "aged-context", startSequenceCalls[1].Shkeptncontext)
+}
+
+func noFreezeWindows(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+	return []models.FreezeWindow{}, nil
+}
+
+func TestSequenceDispatcher_FreezeWindow(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 12, 24, 12, 0, 0, 0, time.UTC))
+
+	startSequenceCalls := []apimodels.KeptnContextExtendedCE{}
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{
+				{
+					Data: keptnv2.EventData{
+						Project: "my-project",
+						Stage:   "my-stage",
+						Service: "my-service",
+					},
+					ID:             "my-event-id",
+					Shkeptncontext: "my-context-id",
+					Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-stage.delivery")),
+				},
+			}, nil
+		},
+	}
+
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{}, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+
+	freezeWindows := []models.FreezeWindow{
+		{
+			ID:          "my-freeze-window",
+			Project:     "my-project",
+			Stage:       "my-stage",
+			Description: "christmas",
+			TimeZone:    "Europe/Vienna",
+			Start:       "2022-12-24T00:00",
+			End:         "2022-12-27T00:00",
+		},
+	}
+	mockFreezeWindowManager := &fake.IFreezeWindowManagerMock{
+		GetFreezeWindowsFunc: func(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+			return freezeWindows, nil
+		},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, mockFreezeWindowManager, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceCalls = append(startSequenceCalls, event)
+		return nil
+	})
+
+	queueItem := models.QueueItem{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+			EventType:    keptnv2.GetTriggeredEventType("my-stage.delivery"),
+		},
+		EventID:   "my-event-id",
+		Timestamp: theClock.Now(),
+	}
+
+	err := sequenceDispatcher.Add(queueItem)
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+	require.Equal(t, "sequence is currently blocked by waiting for another sequence to end: stage my-stage is frozen by freeze window 'christmas' until 2022-12-27T00:00:00+01:00", err.Error())
+	require.Empty(t, startSequenceCalls)
+	require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+	require.Equal(t, "my-project", mockFreezeWindowManager.GetFreezeWindowsCalls()[0].Params.Project)
+	require.Equal(t, "my-stage", mockFreezeWindowManager.GetFreezeWindowsCalls()[0].Params.Stage)
+
+	// once the freeze window is over, the sequence can be started
+	freezeWindows[0].End = "2022-12-24T10:00"
+	err = sequenceDispatcher.Add(queueItem)
+	require.Nil(t, err)
+	require.Len(t, startSequenceCalls, 1)
+}
+
+func TestSequenceDispatcher_FreezeWindowChanged(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2022, 12, 24, 12, 0, 0, 0, time.UTC))
+
+	mockEventRepo := &dbmock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			return []apimodels.KeptnContextExtendedCE{
+				{
+					Data: keptnv2.EventData{
+						Project: "my-project",
+						Stage:   "my-stage",
+						Service: "my-service",
+					},
+					ID:             "my-event-id",
+					Shkeptncontext: "my-context-id",
+					Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-stage.delivery")),
+				},
+			}, nil
+		},
+	}
+
+	queueItem := models.QueueItem{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			KeptnContext: "my-context-id",
+			EventType:    keptnv2.GetTriggeredEventType("my-stage.delivery"),
+		},
+		EventID:   "my-event-id",
+		Timestamp: theClock.Now(),
+	}
+
+	mockSequenceQueueRepo := &dbmock.SequenceQueueRepoMock{
+		QueueSequenceFunc: func(item models.QueueItem) error {
+			return nil
+		},
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return []models.QueueItem{queueItem}, nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+
+	mockSequenceExecutionRepo := &dbmock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return []models.SequenceExecution{}, nil
+		},
+		GetByTriggeredIDFunc: func(project string, triggeredID string) (*models.SequenceExecution, error) {
+			return &models.SequenceExecution{
+				ID: "my-id",
+				Status: models.SequenceExecutionStatus{
+					State: apimodels.SequenceTriggeredState,
+				},
+			}, nil
+		},
+		IsContextPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+
+	freezeWindow := models.FreezeWindow{
+		ID:          "my-freeze-window",
+		Project:     "my-project",
+		Stage:       "my-stage",
+		Description: "christmas",
+		Start:       "2022-12-24T00:00:00Z",
+		End:         "2022-12-27T00:00:00Z",
+	}
+	currentFreezeWindow := freezeWindow
+	freezeWindowChannel := make(chan models.FreezeWindow, 1)
+	mockFreezeWindowManager := &fake.IFreezeWindowManagerMock{
+		GetFreezeWindowsFunc: func(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+			// the freeze window can be changed by the test while the dispatcher is running
+			select {
+			case currentFreezeWindow = <-freezeWindowChannel:
+			default:
+			}
+			return []models.FreezeWindow{currentFreezeWindow}, nil
+		},
+	}
+
+	waitingHook := &fakehooks.ISequenceWaitingHookMock{
+		OnSequenceWaitingFunc: func(event apimodels.KeptnContextExtendedCE, reason string) {},
+	}
+
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockSequenceQueueRepo, mockSequenceExecutionRepo, mockFreezeWindowManager, 10*time.Second, 5*time.Minute, theClock, common.SDModeRW)
+	sequenceDispatcher.AddSequenceWaitingHook(waitingHook)
+	startSequenceChannel := make(chan apimodels.KeptnContextExtendedCE, 1)
+	sequenceDispatcher.Run(context.Background(), common.SDModeRW, func(event apimodels.KeptnContextExtendedCE) error {
+		startSequenceChannel <- event
+		return nil
+	})
+
+	// the waiting state of the sequence is set by the caller of Add, so the hook is not called
+	err := sequenceDispatcher.Add(queueItem)
+	require.ErrorIs(t, err, handler.ErrSequenceBlockedWaiting)
+
+	// the sequence is still waiting for the same reason, so the hook is not called either
+	theClock.Add(11 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(mockSequenceQueueRepo.GetQueuedSequencesCalls()) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Empty(t, waitingHook.OnSequenceWaitingCalls())
+
+	// the freeze window has been extended, so the waiting reason is updated
+	extendedFreezeWindow := freezeWindow
+	extendedFreezeWindow.End = "2022-12-28T00:00:00Z"
+	freezeWindowChannel <- extendedFreezeWindow
+	theClock.Add(10 * time.Second)
+	require.Eventually(t, func() bool {
+		return len(waitingHook.OnSequenceWaitingCalls()) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	require.Equal(t, "my-context-id", waitingHook.OnSequenceWaitingCalls()[0].Event.Shkeptncontext)
+	require.Equal(t, "stage my-stage is frozen by freeze window 'christmas' until 2022-12-28T00:00:00Z", waitingHook.OnSequenceWaitingCalls()[0].Reason)
+
+	// once the freeze window has ended, the sequence is started
+	endedFreezeWindow := freezeWindow
+	endedFreezeWindow.End = "2022-12-24T10:00:00Z"
+	freezeWindowChannel <- endedFreezeWindow
+	theClock.Add(10 * time.Second)
+	select {
+	case event := <-startSequenceChannel:
+		require.Equal(t, "my-event-id", event.ID)
+	case <-time.After(5 * time.Second):
+		t.Error("sequence has not been started")
+	}
+	require.Len(t, waitingHook.OnSequenceWaitingCalls(), 1)
+}
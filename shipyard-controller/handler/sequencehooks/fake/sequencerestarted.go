@@ -0,0 +1,78 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+)
+
+// ISequenceRestartedHookMock is a mock implementation of sequencehooks.ISequenceRestartedHook.
+//
+//	func TestSomethingThatUsesISequenceRestartedHook(t *testing.T) {
+//
+//		// make and configure a mocked sequencehooks.ISequenceRestartedHook
+//		mockedISequenceRestartedHook := &ISequenceRestartedHookMock{
+//			OnSequenceRestartedFunc: func(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl)  {
+//				panic("mock out the OnSequenceRestarted method")
+//			},
+//		}
+//
+//		// use mockedISequenceRestartedHook in code that requires sequencehooks.ISequenceRestartedHook
+//		// and then make assertions.
+//
+//	}
+type ISequenceRestartedHookMock struct {
+	// OnSequenceRestartedFunc mocks the OnSequenceRestarted method.
+	OnSequenceRestartedFunc func(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// OnSequenceRestarted holds details about calls to the OnSequenceRestarted method.
+		OnSequenceRestarted []struct {
+			// Event is the event argument value.
+			Event apimodels.KeptnContextExtendedCE
+			// Restart is the restart argument value.
+			Restart models.SequenceControl
+		}
+	}
+	lockOnSequenceRestarted sync.RWMutex
+}
+
+// OnSequenceRestarted calls OnSequenceRestartedFunc.
+func (mock *ISequenceRestartedHookMock) OnSequenceRestarted(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl) {
+	if mock.OnSequenceRestartedFunc == nil {
+		panic("ISequenceRestartedHookMock.OnSequenceRestartedFunc: method is nil but ISequenceRestartedHook.OnSequenceRestarted was just called")
+	}
+	callInfo := struct {
+		Event   apimodels.KeptnContextExtendedCE
+		Restart models.SequenceControl
+	}{
+		Event:   event,
+		Restart: restart,
+	}
+	mock.lockOnSequenceRestarted.Lock()
+	mock.calls.OnSequenceRestarted = append(mock.calls.OnSequenceRestarted, callInfo)
+	mock.lockOnSequenceRestarted.Unlock()
+	mock.OnSequenceRestartedFunc(event, restart)
+}
+
+// OnSequenceRestartedCalls gets all the calls that were made to OnSequenceRestarted.
+// Check the length with:
+//
+//	len(mockedISequenceRestartedHook.OnSequenceRestartedCalls())
+func (mock *ISequenceRestartedHookMock) OnSequenceRestartedCalls() []struct {
+	Event   apimodels.KeptnContextExtendedCE
+	Restart models.SequenceControl
+} {
+	var calls []struct {
+		Event   apimodels.KeptnContextExtendedCE
+		Restart models.SequenceControl
+	}
+	mock.lockOnSequenceRestarted.RLock()
+	calls = mock.calls.OnSequenceRestarted
+	mock.lockOnSequenceRestarted.RUnlock()
+	return calls
+}
@@ -0,0 +1,77 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"sync"
+)
+
+// ISequenceSupersededHookMock is a mock implementation of sequencehooks.ISequenceSupersededHook.
+//
+//	func TestSomethingThatUsesISequenceSupersededHook(t *testing.T) {
+//
+//		// make and configure a mocked sequencehooks.ISequenceSupersededHook
+//		mockedISequenceSupersededHook := &ISequenceSupersededHookMock{
+//			OnSequenceSupersededFunc: func(superseded models.SequenceExecution, supersededBy models.EventScope)  {
+//				panic("mock out the OnSequenceSuperseded method")
+//			},
+//		}
+//
+//		// use mockedISequenceSupersededHook in code that requires sequencehooks.ISequenceSupersededHook
+//		// and then make assertions.
+//
+//	}
+type ISequenceSupersededHookMock struct {
+	// OnSequenceSupersededFunc mocks the OnSequenceSuperseded method.
+	OnSequenceSupersededFunc func(superseded models.SequenceExecution, supersededBy models.EventScope)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// OnSequenceSuperseded holds details about calls to the OnSequenceSuperseded method.
+		OnSequenceSuperseded []struct {
+			// Superseded is the superseded argument value.
+			Superseded models.SequenceExecution
+			// SupersededBy is the supersededBy argument value.
+			SupersededBy models.EventScope
+		}
+	}
+	lockOnSequenceSuperseded sync.RWMutex
+}
+
+// OnSequenceSuperseded calls OnSequenceSupersededFunc.
+func (mock *ISequenceSupersededHookMock) OnSequenceSuperseded(superseded models.SequenceExecution, supersededBy models.EventScope) {
+	if mock.OnSequenceSupersededFunc == nil {
+		panic("ISequenceSupersededHookMock.OnSequenceSupersededFunc: method is nil but ISequenceSupersededHook.OnSequenceSuperseded was just called")
+	}
+	callInfo := struct {
+		Superseded   models.SequenceExecution
+		SupersededBy models.EventScope
+	}{
+		Superseded:   superseded,
+		SupersededBy: supersededBy,
+	}
+	mock.lockOnSequenceSuperseded.Lock()
+	mock.calls.OnSequenceSuperseded = append(mock.calls.OnSequenceSuperseded, callInfo)
+	mock.lockOnSequenceSuperseded.Unlock()
+	mock.OnSequenceSupersededFunc(superseded, supersededBy)
+}
+
+// OnSequenceSupersededCalls gets all the calls that were made to OnSequenceSuperseded.
+// Check the length with:
+//
+//	len(mockedISequenceSupersededHook.OnSequenceSupersededCalls())
+func (mock *ISequenceSupersededHookMock) OnSequenceSupersededCalls() []struct {
+	Superseded   models.SequenceExecution
+	SupersededBy models.EventScope
+} {
+	var calls []struct {
+		Superseded   models.SequenceExecution
+		SupersededBy models.EventScope
+	}
+	mock.lockOnSequenceSuperseded.RLock()
+	calls = mock.calls.OnSequenceSuperseded
+	mock.lockOnSequenceSuperseded.RUnlock()
+	return calls
+}
@@ -14,7 +14,7 @@ import (
 //
 // 		// make and configure a mocked sequencehooks.ISequenceWaitingHook
 // 		mockedISequenceWaitingHook := &ISequenceWaitingHookMock{
-// 			OnSequenceWaitingFunc: func(event apimodels.KeptnContextExtendedCE)  {
+// 			OnSequenceWaitingFunc: func(event apimodels.KeptnContextExtendedCE, reason string)  {
 // 				panic("mock out the OnSequenceWaiting method")
 // 			},
 // 		}
@@ -25,43 +25,49 @@ import (
 // 	}
 type ISequenceWaitingHookMock struct {
 	// OnSequenceWaitingFunc mocks the OnSequenceWaiting method.
-	OnSequenceWaitingFunc func(event apimodels.KeptnContextExtendedCE)
+	OnSequenceWaitingFunc func(event apimodels.KeptnContextExtendedCE, reason string)
 
 	// calls tracks calls to the methods.
 	calls struct {
 		// OnSequenceWaiting holds details about calls to the OnSequenceWaiting method.
 		OnSequenceWaiting []struct {
-			//models.KeptnContextExtendedCEis the event argument value.
+			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
+			// Reason is the reason argument value.
+			Reason string
 		}
 	}
 	lockOnSequenceWaiting sync.RWMutex
 }
 
 // OnSequenceWaiting calls OnSequenceWaitingFunc.
-func (mock *ISequenceWaitingHookMock) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE) {
+func (mock *ISequenceWaitingHookMock) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE, reason string) {
 	if mock.OnSequenceWaitingFunc == nil {
 		panic("ISequenceWaitingHookMock.OnSequenceWaitingFunc: method is nil but ISequenceWaitingHook.OnSequenceWaiting was just called")
 	}
 	callInfo := struct {
-		Event apimodels.KeptnContextExtendedCE
+		Event  apimodels.KeptnContextExtendedCE
+		Reason string
 	}{
-		Event: event,
+		Event:  event,
+		Reason: reason,
 	}
 	mock.lockOnSequenceWaiting.Lock()
 	mock.calls.OnSequenceWaiting = append(mock.calls.OnSequenceWaiting, callInfo)
 	mock.lockOnSequenceWaiting.Unlock()
-	mock.OnSequenceWaitingFunc(event)
+	mock.OnSequenceWaitingFunc(event, reason)
 }
 
 // OnSequenceWaitingCalls gets all the calls that were made to OnSequenceWaiting.
 // Check the length with:
 //     len(mockedISequenceWaitingHook.OnSequenceWaitingCalls())
 func (mock *ISequenceWaitingHookMock) OnSequenceWaitingCalls() []struct {
-	Event apimodels.KeptnContextExtendedCE
+	Event  apimodels.KeptnContextExtendedCE
+	Reason string
 } {
 	var calls []struct {
-		Event apimodels.KeptnContextExtendedCE
+		Event  apimodels.KeptnContextExtendedCE
+		Reason string
 	}
 	mock.lockOnSequenceWaiting.RLock()
 	calls = mock.calls.OnSequenceWaiting
@@ -15,9 +15,9 @@ type ISequenceStartedHook interface {
 	OnSequenceStarted(apimodels.KeptnContextExtendedCE)
 }
 
-//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencestarted.go . ISequenceWaitingHook
+//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencewaiting.go . ISequenceWaitingHook
 type ISequenceWaitingHook interface {
-	OnSequenceWaiting(apimodels.KeptnContextExtendedCE)
+	OnSequenceWaiting(event apimodels.KeptnContextExtendedCE, reason string)
 }
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetasktriggered.go . ISequenceTaskTriggeredHook
@@ -50,6 +50,16 @@ type ISequenceAbortedHook interface {
 	OnSequenceAborted(event models.EventScope)
 }
 
+//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencesuperseded.go . ISequenceSupersededHook
+type ISequenceSupersededHook interface {
+	OnSequenceSuperseded(superseded models.SequenceExecution, supersededBy models.EventScope)
+}
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencerestarted.go . ISequenceRestartedHook
+type ISequenceRestartedHook interface {
+	OnSequenceRestarted(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl)
+}
+
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetimeout.go . ISequenceTimeoutHook
 type ISequenceTimeoutHook interface {
 	OnSequenceTimeout(event apimodels.KeptnContextExtendedCE)
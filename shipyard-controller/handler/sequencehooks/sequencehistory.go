@@ -0,0 +1,150 @@
+package sequencehooks
+
+import (
+	"fmt"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// SequenceHistorySource is the source of the state transitions that are caused by the shipyard controller itself
+const SequenceHistorySource = "shipyard-controller"
+
+// SequenceControlSource is the source of the state transitions that have been requested via the API
+const SequenceControlSource = "api"
+
+// SequenceHistoryRecorder appends the state transitions of sequence executions to their history.
+// Pause, resume and abort requests received via the API are not recorded by the hooks, since the hooks do not know who requested them.
+// These are recorded by the API handler instead. Restarts are recorded by the hooks, since only the shipyard controller knows from which task a sequence is restarted
+type SequenceHistoryRecorder struct {
+	SequenceHistoryRepo db.SequenceHistoryRepo
+}
+
+func NewSequenceHistoryRecorder(historyRepo db.SequenceHistoryRepo) *SequenceHistoryRecorder {
+	return &SequenceHistoryRecorder{SequenceHistoryRepo: historyRepo}
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceTriggered(event apimodels.KeptnContextExtendedCE) {
+	source := SequenceHistorySource
+	if event.Source != nil && *event.Source != "" {
+		source = *event.Source
+	}
+	shr.record(event, apimodels.SequenceTriggeredState, source, "")
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceStarted(event apimodels.KeptnContextExtendedCE) {
+	shr.record(event, apimodels.SequenceStartedState, SequenceHistorySource, "")
+}
+
+// OnSequenceWaiting records that a sequence is waiting, unless the last recorded transition of the sequence is already waiting for the same reason.
+// The sequence dispatcher only keeps the reasons of the queued sequences in memory, hence it reports them again after a restart of the shipyard controller
+func (shr *SequenceHistoryRecorder) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE, reason string) {
+	if shr.isWaitingFor(event, reason) {
+		return
+	}
+	shr.record(event, apimodels.SequenceWaitingState, SequenceHistorySource, reason)
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE) {
+	reason := ""
+	if event.Type != nil {
+		reason = fmt.Sprintf("no response received for event of type %s", *event.Type)
+	}
+	shr.record(event, apimodels.TimedOut, SequenceHistorySource, reason)
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceFinished(event apimodels.KeptnContextExtendedCE) {
+	eventData := keptnv2.EventData{}
+	reason := ""
+	if err := keptnv2.Decode(event.Data, &eventData); err == nil && eventData.Result != "" {
+		reason = fmt.Sprintf("finished with result '%s' and status '%s'", eventData.Result, eventData.Status)
+		if eventData.Message != "" {
+			reason += ": " + eventData.Message
+		}
+	}
+	shr.record(event, apimodels.SequenceFinished, SequenceHistorySource, reason)
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceSuperseded(superseded models.SequenceExecution, supersededBy models.EventScope) {
+	shr.append(models.SequenceStateTransition{
+		Project:      superseded.Scope.Project,
+		Stage:        superseded.Scope.Stage,
+		Service:      superseded.Scope.Service,
+		Sequence:     superseded.Sequence.Name,
+		KeptnContext: superseded.Scope.KeptnContext,
+		State:        apimodels.SequenceAborted,
+		Source:       SequenceHistorySource,
+		Reason:       fmt.Sprintf("superseded by sequence with keptnContext %s", supersededBy.KeptnContext),
+	})
+}
+
+func (shr *SequenceHistoryRecorder) OnSequenceRestarted(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl) {
+	reason := fmt.Sprintf("restarted from task %s", restart.FromTask)
+	if restart.Reason != "" {
+		reason += ": " + restart.Reason
+	}
+	shr.recordEvent(event, models.SequenceStateTransition{
+		State:  models.SequenceRestartedState,
+		User:   restart.User,
+		Source: SequenceControlSource,
+		Reason: reason,
+	})
+}
+
+// isWaitingFor checks whether the last recorded transition of the sequence of the event is the waiting state with the given reason
+func (shr *SequenceHistoryRecorder) isWaitingFor(event apimodels.KeptnContextExtendedCE, reason string) bool {
+	eventScope, err := models.NewEventScope(event)
+	if err != nil {
+		return false
+	}
+	transitions, err := shr.SequenceHistoryRepo.GetTransitions(eventScope.Project, eventScope.KeptnContext)
+	if err != nil {
+		log.WithError(err).Errorf("could not retrieve the history of sequence with keptnContext %s", eventScope.KeptnContext)
+		return false
+	}
+	if len(transitions) == 0 {
+		return false
+	}
+	lastTransition := transitions[len(transitions)-1]
+	return lastTransition.State == apimodels.SequenceWaitingState && lastTransition.Reason == reason
+}
+
+func (shr *SequenceHistoryRecorder) record(event apimodels.KeptnContextExtendedCE, state, source, reason string) {
+	shr.recordEvent(event, models.SequenceStateTransition{
+		State:  state,
+		Source: source,
+		Reason: reason,
+	})
+}
+
+// recordEvent completes the given transition with the scope of the event and appends it to the history of the sequence
+func (shr *SequenceHistoryRecorder) recordEvent(event apimodels.KeptnContextExtendedCE, transition models.SequenceStateTransition) {
+	eventScope, err := models.NewEventScope(event)
+	if err != nil {
+		log.WithError(err).Errorf(eventScopeErrorMessage)
+		return
+	}
+
+	transition.Project = eventScope.Project
+	transition.Stage = eventScope.Stage
+	transition.Service = eventScope.Service
+	transition.KeptnContext = eventScope.KeptnContext
+	// the timeout hook receives the last task event, which does not contain the name of the sequence
+	if event.Type != nil {
+		if _, sequenceName, _, err := keptnv2.ParseSequenceEventType(*event.Type); err == nil {
+			transition.Sequence = sequenceName
+		}
+	}
+	shr.append(transition)
+}
+
+func (shr *SequenceHistoryRecorder) append(transition models.SequenceStateTransition) {
+	transition.Time = time.Now().UTC()
+	if err := shr.SequenceHistoryRepo.AppendTransition(transition); err != nil {
+		log.WithError(err).Errorf("could not append state transition to the history of sequence with keptnContext %s", transition.KeptnContext)
+	}
+}
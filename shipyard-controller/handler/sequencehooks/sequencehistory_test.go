@@ -0,0 +1,114 @@
+package sequencehooks_test
+
+import (
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceHistoryRecorder(t *testing.T) {
+	eventData := keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "dev",
+		Service: "carts",
+	}
+	finishedEventData := eventData
+	finishedEventData.Result = keptnv2.ResultFailed
+	finishedEventData.Status = keptnv2.StatusSucceeded
+	finishedEventData.Message = "evaluation failed"
+
+	sequenceEvent := func(eventType string, data keptnv2.EventData) models.KeptnContextExtendedCE {
+		return models.KeptnContextExtendedCE{
+			Data:           data,
+			Shkeptncontext: "my-context",
+			Source:         common.Stringp("cli"),
+			Type:           common.Stringp(eventType),
+		}
+	}
+
+	historyRepo := &db_mock.SequenceHistoryRepoMock{
+		AppendTransitionFunc: func(transition scmodels.SequenceStateTransition) error {
+			return nil
+		},
+		GetTransitionsFunc: func(project string, keptnContext string) ([]scmodels.SequenceStateTransition, error) {
+			return nil, nil
+		},
+	}
+	recorder := sequencehooks.NewSequenceHistoryRecorder(historyRepo)
+
+	recorder.OnSequenceTriggered(sequenceEvent(keptnv2.GetTriggeredEventType("dev.delivery"), eventData))
+	recorder.OnSequenceWaiting(sequenceEvent(keptnv2.GetTriggeredEventType("dev.delivery"), eventData), "stage dev is frozen")
+	recorder.OnSequenceStarted(sequenceEvent(keptnv2.GetTriggeredEventType("dev.delivery"), eventData))
+	recorder.OnSequenceTimeout(sequenceEvent(keptnv2.GetTriggeredEventType("test"), eventData))
+	recorder.OnSequenceFinished(sequenceEvent(keptnv2.GetFinishedEventType("dev.delivery"), finishedEventData))
+	recorder.OnSequenceRestarted(sequenceEvent(keptnv2.GetTriggeredEventType("dev.delivery"), eventData), scmodels.SequenceControl{FromTask: "deployment", User: "jane.doe", Reason: "fixed the helm chart"})
+	recorder.OnSequenceSuperseded(scmodels.SequenceExecution{
+		Sequence: keptnv2.Sequence{Name: "delivery"},
+		Scope:    scmodels.EventScope{EventData: eventData, KeptnContext: "my-context"},
+	}, scmodels.EventScope{EventData: eventData, KeptnContext: "other-context"})
+
+	expected := []scmodels.SequenceStateTransition{
+		{State: models.SequenceTriggeredState, Sequence: "delivery", Source: "cli"},
+		{State: models.SequenceWaitingState, Sequence: "delivery", Source: sequencehooks.SequenceHistorySource, Reason: "stage dev is frozen"},
+		{State: models.SequenceStartedState, Sequence: "delivery", Source: sequencehooks.SequenceHistorySource},
+		{State: models.TimedOut, Source: sequencehooks.SequenceHistorySource, Reason: "no response received for event of type sh.keptn.event.test.triggered"},
+		{State: models.SequenceFinished, Sequence: "delivery", Source: sequencehooks.SequenceHistorySource, Reason: "finished with result 'fail' and status 'succeeded': evaluation failed"},
+		{State: scmodels.SequenceRestartedState, Sequence: "delivery", User: "jane.doe", Source: sequencehooks.SequenceControlSource, Reason: "restarted from task deployment: fixed the helm chart"},
+		{State: models.SequenceAborted, Sequence: "delivery", Source: sequencehooks.SequenceHistorySource, Reason: "superseded by sequence with keptnContext other-context"},
+	}
+
+	calls := historyRepo.AppendTransitionCalls()
+	require.Len(t, calls, len(expected))
+	for i, call := range calls {
+		require.Equal(t, "my-project", call.Transition.Project)
+		require.Equal(t, "dev", call.Transition.Stage)
+		require.Equal(t, "carts", call.Transition.Service)
+		require.Equal(t, "my-context", call.Transition.KeptnContext)
+		require.False(t, call.Transition.Time.IsZero())
+
+		require.Equal(t, expected[i].State, call.Transition.State)
+		require.Equal(t, expected[i].Sequence, call.Transition.Sequence)
+		require.Equal(t, expected[i].User, call.Transition.User)
+		require.Equal(t, expected[i].Source, call.Transition.Source)
+		require.Equal(t, expected[i].Reason, call.Transition.Reason)
+	}
+}
+
+func TestSequenceHistoryRecorder_OnSequenceWaitingSkipsUnchangedReason(t *testing.T) {
+	event := models.KeptnContextExtendedCE{
+		Data:           keptnv2.EventData{Project: "my-project", Stage: "dev", Service: "carts"},
+		Shkeptncontext: "my-context",
+		Type:           common.Stringp(keptnv2.GetTriggeredEventType("dev.delivery")),
+	}
+
+	transitions := []scmodels.SequenceStateTransition{
+		{State: models.SequenceTriggeredState},
+		{State: models.SequenceWaitingState, Reason: "stage dev is frozen"},
+	}
+	historyRepo := &db_mock.SequenceHistoryRepoMock{
+		AppendTransitionFunc: func(transition scmodels.SequenceStateTransition) error {
+			transitions = append(transitions, transition)
+			return nil
+		},
+		GetTransitionsFunc: func(project string, keptnContext string) ([]scmodels.SequenceStateTransition, error) {
+			return transitions, nil
+		},
+	}
+	recorder := sequencehooks.NewSequenceHistoryRecorder(historyRepo)
+
+	// the reason is reported again, e.g. after a restart of the shipyard controller
+	recorder.OnSequenceWaiting(event, "stage dev is frozen")
+	require.Empty(t, historyRepo.AppendTransitionCalls())
+	require.Equal(t, "my-project", historyRepo.GetTransitionsCalls()[0].Project)
+	require.Equal(t, "my-context", historyRepo.GetTransitionsCalls()[0].KeptnContext)
+
+	recorder.OnSequenceWaiting(event, "sequence is blocked by another sequence")
+	require.Len(t, historyRepo.AppendTransitionCalls(), 1)
+	require.Equal(t, "sequence is blocked by another sequence", historyRepo.AppendTransitionCalls()[0].Transition.Reason)
+}
@@ -0,0 +1,66 @@
+package sequencehooks
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// SequenceMetricsCollector counts the state transitions of sequences, which are exposed via the /metrics endpoint
+type SequenceMetricsCollector struct{}
+
+func NewSequenceMetricsCollector() *SequenceMetricsCollector {
+	return &SequenceMetricsCollector{}
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceTriggered(event apimodels.KeptnContextExtendedCE) {
+	smc.countEvent(event, apimodels.SequenceTriggeredState)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceStarted(event apimodels.KeptnContextExtendedCE) {
+	smc.countEvent(event, apimodels.SequenceStartedState)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE, reason string) {
+	smc.countEvent(event, apimodels.SequenceWaitingState)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceTimeout(event apimodels.KeptnContextExtendedCE) {
+	smc.countEvent(event, apimodels.TimedOut)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceFinished(event apimodels.KeptnContextExtendedCE) {
+	smc.countEvent(event, apimodels.SequenceFinished)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceAborted(eventScope models.EventScope) {
+	smc.count(eventScope, "", apimodels.SequenceAborted)
+}
+
+func (smc *SequenceMetricsCollector) OnSequencePaused(pause models.EventScope) {
+	smc.count(pause, "", apimodels.SequencePaused)
+}
+
+func (smc *SequenceMetricsCollector) OnSequenceResumed(resume models.EventScope) {
+	smc.count(resume, "", models.SequenceResumedState)
+}
+
+func (smc *SequenceMetricsCollector) countEvent(event apimodels.KeptnContextExtendedCE, state string) {
+	eventScope, err := models.NewEventScope(event)
+	if err != nil {
+		log.WithError(err).Errorf(eventScopeErrorMessage)
+		return
+	}
+	// the timeout hook receives the last task event, which does not contain the name of the sequence
+	sequenceName := ""
+	if _, name, _, err := keptnv2.ParseSequenceEventType(*event.Type); err == nil {
+		sequenceName = name
+	}
+	smc.count(*eventScope, sequenceName, state)
+}
+
+func (smc *SequenceMetricsCollector) count(eventScope models.EventScope, sequenceName, state string) {
+	metrics.SequenceStateTransitions.WithLabelValues(eventScope.Project, eventScope.Stage, sequenceName, state).Inc()
+}
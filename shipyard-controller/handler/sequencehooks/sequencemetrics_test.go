@@ -0,0 +1,51 @@
+package sequencehooks_test
+
+import (
+	"testing"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceMetricsCollector(t *testing.T) {
+	const project = "metrics-project"
+	event := models.KeptnContextExtendedCE{
+		Data: keptnv2.EventData{
+			Project: project,
+			Stage:   "dev",
+			Service: "carts",
+		},
+		Shkeptncontext: "my-context",
+		Source:         common.Stringp("cli"),
+		Type:           common.Stringp(keptnv2.GetTriggeredEventType("dev.delivery")),
+	}
+	eventScope := scmodels.EventScope{
+		EventData:    keptnv2.EventData{Project: project, Stage: "dev"},
+		KeptnContext: "my-context",
+	}
+
+	collector := sequencehooks.NewSequenceMetricsCollector()
+
+	collector.OnSequenceTriggered(event)
+	collector.OnSequenceStarted(event)
+	collector.OnSequenceWaiting(event, "")
+	collector.OnSequenceWaiting(event, "")
+	collector.OnSequencePaused(eventScope)
+	collector.OnSequenceResumed(eventScope)
+
+	count := func(sequence, state string) float64 {
+		return testutil.ToFloat64(metrics.SequenceStateTransitions.WithLabelValues(project, "dev", sequence, state))
+	}
+	require.Equal(t, float64(1), count("delivery", models.SequenceTriggeredState))
+	require.Equal(t, float64(1), count("delivery", models.SequenceStartedState))
+	require.Equal(t, float64(2), count("delivery", models.SequenceWaitingState))
+	require.Equal(t, float64(1), count("", models.SequencePaused))
+	require.Equal(t, float64(1), count("", scmodels.SequenceResumedState))
+	require.Equal(t, float64(0), count("delivery", models.SequenceFinished))
+}
@@ -43,14 +43,16 @@ func (smv *SequenceStateMaterializedView) OnSequenceTriggered(event apimodels.Ke
 		return
 	}
 
-	state := apimodels.SequenceState{
-		Name:           sequenceName,
-		Service:        eventScope.Service,
-		Project:        eventScope.Project,
-		Time:           timeutils.GetKeptnTimeStamp(event.Time),
-		Shkeptncontext: eventScope.KeptnContext,
-		State:          apimodels.SequenceTriggeredState,
-		Stages:         []apimodels.SequenceStateStage{},
+	state := models.SequenceState{
+		SequenceState: apimodels.SequenceState{
+			Name:           sequenceName,
+			Service:        eventScope.Service,
+			Project:        eventScope.Project,
+			Time:           timeutils.GetKeptnTimeStamp(event.Time),
+			Shkeptncontext: eventScope.KeptnContext,
+			State:          apimodels.SequenceTriggeredState,
+			Stages:         []apimodels.SequenceStateStage{},
+		},
 	}
 
 	//if the next event in sequence is an action we get the problem title form it
@@ -79,7 +81,7 @@ func (smv *SequenceStateMaterializedView) OnSequenceStarted(event apimodels.Kept
 	smv.updateOverallSequenceState(*eventScope, apimodels.SequenceStartedState)
 }
 
-func (smv *SequenceStateMaterializedView) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE) {
+func (smv *SequenceStateMaterializedView) OnSequenceWaiting(event apimodels.KeptnContextExtendedCE, reason string) {
 	smv.mutex.Lock()
 	defer smv.mutex.Unlock()
 	eventScope, err := models.NewEventScope(event)
@@ -87,7 +89,16 @@ func (smv *SequenceStateMaterializedView) OnSequenceWaiting(event apimodels.Kept
 		log.WithError(err).Errorf(eventScopeErrorMessage)
 		return
 	}
-	smv.updateOverallSequenceState(*eventScope, apimodels.SequenceWaitingState)
+	state, err := smv.findSequenceStateForEvent(*eventScope)
+	if err != nil {
+		log.Errorf(sequenceStateRetrievalErrorMsg, eventScope.KeptnContext, err.Error())
+		return
+	}
+	state.State = apimodels.SequenceWaitingState
+	state.WaitingReason = reason
+	if err := smv.SequenceStateRepo.UpdateSequenceState(*state); err != nil {
+		log.Errorf("could not update sequence state: %s", err.Error())
+	}
 }
 
 func (smv *SequenceStateMaterializedView) OnSequenceTaskTriggered(event apimodels.KeptnContextExtendedCE) {
@@ -208,11 +219,11 @@ func (smv *SequenceStateMaterializedView) OnSequenceResumed(resume models.EventS
 	}
 }
 
-func (smv *SequenceStateMaterializedView) findSequenceStateForEvent(eventScope models.EventScope) (*apimodels.SequenceState, error) {
+func (smv *SequenceStateMaterializedView) findSequenceStateForEvent(eventScope models.EventScope) (*models.SequenceState, error) {
 	return smv.findSequenceState(eventScope.Project, eventScope.KeptnContext)
 }
 
-func (smv *SequenceStateMaterializedView) findSequenceState(project, keptnContext string) (*apimodels.SequenceState, error) {
+func (smv *SequenceStateMaterializedView) findSequenceState(project, keptnContext string) (*models.SequenceState, error) {
 	states, err := smv.SequenceStateRepo.FindSequenceStates(apimodels.StateFilter{
 		GetSequenceStateParams: apimodels.GetSequenceStateParams{
 			Project:      project,
@@ -247,6 +258,8 @@ func (smv *SequenceStateMaterializedView) updateOverallSequenceState(eventScope
 		}
 	}
 	state.State = status
+	// the reason for waiting is only relevant as long as the sequence is waiting
+	state.WaitingReason = ""
 	if err := smv.SequenceStateRepo.UpdateSequenceState(*state); err != nil {
 		log.Errorf("could not update sequence state: %s", err.Error())
 	}
@@ -270,7 +283,7 @@ func (smv *SequenceStateMaterializedView) updateSequenceStateInStage(eventScope
 	}
 }
 
-func (smv *SequenceStateMaterializedView) updateEvaluationOfSequence(event apimodels.KeptnContextExtendedCE, state apimodels.SequenceState) error {
+func (smv *SequenceStateMaterializedView) updateEvaluationOfSequence(event apimodels.KeptnContextExtendedCE, state models.SequenceState) error {
 	evaluationFinishedEventData := &keptnv2.EvaluationFinishedEventData{}
 	if err := keptnv2.Decode(event.Data, evaluationFinishedEventData); err != nil {
 		return fmt.Errorf("could not decode evaluation.finished event data: %s", err.Error())
@@ -290,7 +303,7 @@ func (smv *SequenceStateMaterializedView) updateEvaluationOfSequence(event apimo
 	return nil
 }
 
-func (smv *SequenceStateMaterializedView) updateImageOfSequence(event apimodels.KeptnContextExtendedCE, state apimodels.SequenceState) error {
+func (smv *SequenceStateMaterializedView) updateImageOfSequence(event apimodels.KeptnContextExtendedCE, state models.SequenceState) error {
 	deploymentTriggeredEventData := &keptnv2.DeploymentTriggeredEventData{}
 	if err := keptnv2.Decode(event.Data, deploymentTriggeredEventData); err != nil {
 		return fmt.Errorf("could not decode deployment.triggered event data: %s", err.Error())
@@ -311,10 +324,10 @@ func (smv *SequenceStateMaterializedView) updateImageOfSequence(event apimodels.
 	return nil
 }
 
-func (smv *SequenceStateMaterializedView) updateLastEventOfSequence(event apimodels.KeptnContextExtendedCE) (apimodels.SequenceState, error) {
+func (smv *SequenceStateMaterializedView) updateLastEventOfSequence(event apimodels.KeptnContextExtendedCE) (models.SequenceState, error) {
 	eventScope, err := models.NewEventScope(event)
 	if err != nil {
-		return apimodels.SequenceState{}, fmt.Errorf("could not determine event scope: %s", err.Error())
+		return models.SequenceState{}, fmt.Errorf("could not determine event scope: %s", err.Error())
 	}
 
 	states, err := smv.SequenceStateRepo.FindSequenceStates(apimodels.StateFilter{
@@ -325,17 +338,17 @@ func (smv *SequenceStateMaterializedView) updateLastEventOfSequence(event apimod
 	})
 
 	if err != nil {
-		return apimodels.SequenceState{}, fmt.Errorf(sequenceStateRetrievalErrorMsg, eventScope.KeptnContext, err.Error())
+		return models.SequenceState{}, fmt.Errorf(sequenceStateRetrievalErrorMsg, eventScope.KeptnContext, err.Error())
 	}
 
 	if len(states.States) == 0 {
-		return apimodels.SequenceState{}, fmt.Errorf("could not find sequence state for keptnContext %s", eventScope.KeptnContext)
+		return models.SequenceState{}, fmt.Errorf("could not find sequence state for keptnContext %s", eventScope.KeptnContext)
 	}
 	state := states.States[0]
 
 	eventData := &keptnv2.EventData{}
 	if err := keptnv2.Decode(event.Data, eventData); err != nil {
-		return apimodels.SequenceState{}, fmt.Errorf("could not parse event data: %s", err.Error())
+		return models.SequenceState{}, fmt.Errorf("could not parse event data: %s", err.Error())
 	}
 
 	newLastEvent := &apimodels.SequenceStateEvent{
@@ -33,21 +33,21 @@ func TestSequenceStateMaterializedView_OnSequenceStarted(t *testing.T) {
 			name: "start sequence",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -83,7 +83,8 @@ func TestSequenceStateMaterializedView_OnSequenceStarted(t *testing.T) {
 
 func TestSequenceStateMaterializedView_OnSequenceWaiting(t *testing.T) {
 	type args struct {
-		event models.KeptnContextExtendedCE
+		event  models.KeptnContextExtendedCE
+		reason string
 	}
 	tests := []struct {
 		name                   string
@@ -95,21 +96,21 @@ func TestSequenceStateMaterializedView_OnSequenceWaiting(t *testing.T) {
 			name: "start sequence",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -124,6 +125,7 @@ func TestSequenceStateMaterializedView_OnSequenceWaiting(t *testing.T) {
 					Shkeptncontext: "my-context",
 					Type:           common.Stringp("my-type"),
 				},
+				reason: "sequence with keptnContext my-other-context is currently running",
 			},
 			expectUpdateToBeCalled: true,
 		},
@@ -131,11 +133,12 @@ func TestSequenceStateMaterializedView_OnSequenceWaiting(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			smv := sequencehooks.NewSequenceStateMaterializedView(tt.fields.SequenceStateRepo)
-			smv.OnSequenceWaiting(tt.args.event)
+			smv.OnSequenceWaiting(tt.args.event, tt.args.reason)
 
 			if tt.expectUpdateToBeCalled {
 				require.NotEmpty(t, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls())
 				require.Equal(t, models.SequenceWaitingState, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls()[0].State.State)
+				require.Equal(t, tt.args.reason, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls()[0].State.WaitingReason)
 			} else {
 				require.Empty(t, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls())
 			}
@@ -157,21 +160,21 @@ func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 			name: "sequence timed out",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -220,10 +223,10 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 			name: "finish sequence",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
@@ -239,11 +242,11 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 											State: "succeeded",
 										},
 									},
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -265,10 +268,10 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 			name: "try to finish sequence - not all stages finished yet",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
@@ -284,11 +287,11 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 											State: "triggered",
 										},
 									},
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -310,21 +313,21 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 			name: "invalid event scope - do not update",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -341,10 +344,10 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 			name: "cannot find sequence - do not update",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
 						return nil, errors.New("oops")
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -365,10 +368,10 @@ func TestSequenceStateMaterializedView_OnSequenceFinished(t *testing.T) {
 			name: "cannot find sequence - do not update (2)",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{}, nil
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -419,21 +422,21 @@ func TestSequenceStateMaterializedView_OnSequenceTaskFinished(t *testing.T) {
 			name: "update evaluation",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -460,21 +463,21 @@ func TestSequenceStateMaterializedView_OnSequenceTaskFinished(t *testing.T) {
 			name: "update evaluation fails: not a lighthouse finished event",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -501,21 +504,21 @@ func TestSequenceStateMaterializedView_OnSequenceTaskFinished(t *testing.T) {
 			name: "failed task",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -637,21 +640,21 @@ func TestSequenceStateMaterializedView_MultipleScoresOnSequenceFinished(t *testi
 	t.Run("multiple score test", func(t *testing.T) {
 
 		SequenceStateRepo := &db_mock.SequenceStateRepoMock{
-			FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-				return &models.SequenceStates{
-					States: []models.SequenceState{
-						{
+			FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+				return &scmodels.SequenceStates{
+					States: []scmodels.SequenceState{
+						{SequenceState: models.SequenceState{
 							Name:           "my-sequence",
 							Service:        "my-service",
 							Project:        "my-project",
 							Shkeptncontext: "my-context",
 							State:          "triggered",
 							Stages:         nil,
-						},
+						}},
 					},
 				}, nil
 			},
-			UpdateSequenceStateFunc: func(state models.SequenceState) error {
+			UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 				return nil
 			},
 		}
@@ -708,21 +711,21 @@ func TestSequenceStateMaterializedView_OnSequenceTaskTriggered(t *testing.T) {
 			name: "update sequence state - insert new stage",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -740,10 +743,10 @@ func TestSequenceStateMaterializedView_OnSequenceTaskTriggered(t *testing.T) {
 			name: "update sequence state with existing stage",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
@@ -758,11 +761,11 @@ func TestSequenceStateMaterializedView_OnSequenceTaskTriggered(t *testing.T) {
 											},
 										},
 									},
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -792,7 +795,7 @@ func TestSequenceStateMaterializedView_OnSequenceTaskTriggered(t *testing.T) {
 			name: "find state returns error - do not call update",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
 						return nil, errors.New("oops")
 					},
 				},
@@ -874,7 +877,7 @@ func TestSequenceStateMaterializedView_OnSequenceTriggered(t *testing.T) {
 			name: "create a new sequence state",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					CreateSequenceStateFunc: func(state models.SequenceState) error {
+					CreateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -890,7 +893,7 @@ func TestSequenceStateMaterializedView_OnSequenceTriggered(t *testing.T) {
 			name: "create a new remediation sequence",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					CreateSequenceStateFunc: func(state models.SequenceState) error {
+					CreateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -931,7 +934,7 @@ func TestSequenceStateMaterializedView_OnSequenceTriggered(t *testing.T) {
 			name: "state already exists",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					CreateSequenceStateFunc: func(state models.SequenceState) error {
+					CreateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return db.ErrStateAlreadyExists
 					},
 				},
@@ -947,7 +950,7 @@ func TestSequenceStateMaterializedView_OnSequenceTriggered(t *testing.T) {
 			name: "create state returns an error",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					CreateSequenceStateFunc: func(state models.SequenceState) error {
+					CreateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return errors.New("oops")
 					},
 				},
@@ -1020,21 +1023,21 @@ func TestSequenceStateMaterializedView_OnSequencePaused(t *testing.T) {
 			name: "overall sequence paused",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
 									Stages:         nil,
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -1048,10 +1051,10 @@ func TestSequenceStateMaterializedView_OnSequencePaused(t *testing.T) {
 			name: "stage of sequence paused",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
@@ -1062,11 +1065,11 @@ func TestSequenceStateMaterializedView_OnSequencePaused(t *testing.T) {
 											Name: "my-stage",
 										},
 									},
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -1106,10 +1109,10 @@ func TestSequenceStateMaterializedView_OnSubSequenceFinished(t *testing.T) {
 			name: "abort subsequence",
 			fields: SequenceStateMVTestFields{
 				SequenceStateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
-						return &models.SequenceStates{
-							States: []models.SequenceState{
-								{
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
+								{SequenceState: models.SequenceState{
 									Name:           "my-sequence",
 									Service:        "my-service",
 									Project:        "my-project",
@@ -1122,11 +1125,11 @@ func TestSequenceStateMaterializedView_OnSubSequenceFinished(t *testing.T) {
 											LatestFailedEvent: &models.SequenceStateEvent{},
 										},
 									},
-								},
+								}},
 							},
 						}, nil
 					},
-					UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					UpdateSequenceStateFunc: func(state scmodels.SequenceState) error {
 						return nil
 					},
 				},
@@ -11,6 +11,8 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,17 +21,19 @@ type SequenceWatcher struct {
 	eventRepo             db.EventRepo
 	eventQueueRepo        db.EventQueueRepo
 	projectRepo           db.ProjectRepo
+	sequenceExecutionRepo db.SequenceExecutionRepo
 	eventTimeout          time.Duration
 	syncInterval          time.Duration
 	theClock              clock.Clock
 }
 
-func NewSequenceWatcher(cancelSequenceChannel chan apimodels.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
+func NewSequenceWatcher(cancelSequenceChannel chan apimodels.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, sequenceExecutionRepo db.SequenceExecutionRepo, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
 	return &SequenceWatcher{
 		cancelSequenceChannel: cancelSequenceChannel,
 		eventRepo:             eventRepo,
 		eventQueueRepo:        eventQueueRepo,
 		projectRepo:           projectRepo,
+		sequenceExecutionRepo: sequenceExecutionRepo,
 		eventTimeout:          eventTimeout,
 		syncInterval:          syncInterval,
 		theClock:              theClock,
@@ -78,6 +82,8 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
 		return fmt.Errorf("could not retrieve open triggered events: %s", err.Error())
 	}
 
+	// the timeouts of the tasks are determined once per project, and only for the tasks that declare one
+	var taskTimeouts map[string]string
 	for _, event := range events {
 		// only consider timed out tasks
 		if keptnv2.IsSequenceEventType(*event.Type) {
@@ -95,9 +101,18 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
 		//	}
 		//}
 
+		if taskTimeouts == nil {
+			taskTimeouts = sw.getTaskTimeouts(project)
+		}
+		taskTimeout := taskTimeouts[event.ID]
+
 		timeOut := eventSentTime.Add(sw.eventTimeout)
 		now := sw.theClock.Now().UTC()
-		if now.After(timeOut) {
+		isEventTimedOut := now.After(timeOut)
+		if !isEventTimedOut && taskTimeout == "" {
+			continue
+		}
+		if isEventTimedOut {
 			isItemInQueue, err := sw.eventQueueRepo.IsEventInQueue(event.ID)
 			if err != nil {
 				log.WithError(err).Error("could not check if item is still in queue")
@@ -105,29 +120,95 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
 				log.Info("triggered event is still in queue")
 				continue
 			}
-			// check if an event that reacted to the .triggered event has been received in the meantime
-			responseEvents, err := sw.eventRepo.GetEvents(project, common.EventFilter{
-				TriggeredID:  &event.ID,
-				KeptnContext: &event.Shkeptncontext,
-			})
-			if err != nil && err != db.ErrNoEventFound {
-				log.WithError(err).Errorf("could not fetch events with triggeredId %s", event.ID)
-				continue
-			}
-			if len(responseEvents) == 0 {
-				// time out -> tell shipyard controller to complete the task sequence
-				sequenceCancellation := apimodels.SequenceTimeout{
-					KeptnContext: event.Shkeptncontext,
-					LastEvent:    event,
-				}
-
-				sw.cancelSequenceChannel <- sequenceCancellation
-				// clean up open .triggered event
-				if err := sw.eventRepo.DeleteEvent(project, event.ID, common.TriggeredEvent); err != nil {
-					log.WithError(err).Errorf("could not delete event %s", event.ID)
-				}
+		}
+		// check if an event that reacted to the .triggered event has been received in the meantime
+		responseEvents, err := sw.eventRepo.GetEvents(project, common.EventFilter{
+			TriggeredID:  &event.ID,
+			KeptnContext: &event.Shkeptncontext,
+		})
+		if err != nil && err != db.ErrNoEventFound {
+			log.WithError(err).Errorf("could not fetch events with triggeredId %s", event.ID)
+			continue
+		}
+		if len(responseEvents) == 0 {
+			if isEventTimedOut {
+				sw.timeoutTask(project, event)
 			}
+			continue
+		}
+		// the timeout of a started task is evaluated independently of the event timeout, which may be longer
+		if taskTimeout != "" && sw.isTaskTimedOut(event, taskTimeout, responseEvents) {
+			sw.timeoutTask(project, event)
 		}
 	}
 	return nil
 }
+
+// getTaskTimeouts returns the timeouts declared in the shipyard for the current tasks of the active sequences of a project, mapped by the IDs of their .triggered events
+func (sw *SequenceWatcher) getTaskTimeouts(project string) map[string]string {
+	taskTimeouts := map[string]string{}
+	sequenceExecutions, err := sw.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{Project: project},
+		},
+		Status:          []string{apimodels.SequenceStartedState, apimodels.SequenceWaitingForApprovalState, apimodels.SequencePaused},
+		WithTaskTimeout: true,
+	})
+	if err != nil {
+		log.WithError(err).Errorf("could not fetch sequence executions with task timeouts of project %s", project)
+		return taskTimeouts
+	}
+	for index := range sequenceExecutions {
+		currentTask := sequenceExecutions[index].Status.CurrentTask
+		for _, triggeredID := range currentTask.GetTriggeredIDs() {
+			if timeout := currentTask.GetTimeout(triggeredID); timeout != "" {
+				taskTimeouts[triggeredID] = timeout
+			}
+		}
+	}
+	return taskTimeouts
+}
+
+// isTaskTimedOut checks whether a task that has already been started has exceeded the timeout declared for it in the shipyard.
+// The timeout is measured from the first .started event of the task
+func (sw *SequenceWatcher) isTaskTimedOut(triggeredEvent apimodels.KeptnContextExtendedCE, timeout string, responseEvents []apimodels.KeptnContextExtendedCE) bool {
+	var startedTime *time.Time
+	for index := range responseEvents {
+		if responseEvents[index].Type == nil || !keptnv2.IsStartedEventType(*responseEvents[index].Type) {
+			continue
+		}
+		if startedTime == nil || responseEvents[index].Time.Before(*startedTime) {
+			startedTime = &responseEvents[index].Time
+		}
+	}
+	if startedTime == nil {
+		return false
+	}
+
+	timeoutDuration, err := time.ParseDuration(timeout)
+	if err != nil {
+		log.WithError(err).Errorf("could not parse timeout of event with id %s", triggeredEvent.ID)
+		return false
+	}
+	return sw.theClock.Now().UTC().After(startedTime.Add(timeoutDuration))
+}
+
+func (sw *SequenceWatcher) timeoutTask(project string, event apimodels.KeptnContextExtendedCE) {
+	eventData := keptnv2.EventData{}
+	if err := keptnv2.Decode(event.Data, &eventData); err == nil {
+		taskName, _, _ := keptnv2.ParseTaskEventType(*event.Type)
+		metrics.TaskTimeouts.WithLabelValues(project, eventData.Stage, taskName).Inc()
+	}
+
+	// time out -> tell shipyard controller to complete the task sequence
+	sequenceCancellation := apimodels.SequenceTimeout{
+		KeptnContext: event.Shkeptncontext,
+		LastEvent:    event,
+	}
+
+	sw.cancelSequenceChannel <- sequenceCancellation
+	// clean up open .triggered event
+	if err := sw.eventRepo.DeleteEvent(project, event.ID, common.TriggeredEvent); err != nil {
+		log.WithError(err).Errorf("could not delete event %s", event.ID)
+	}
+}
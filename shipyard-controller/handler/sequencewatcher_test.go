@@ -116,6 +116,11 @@ func TestSequenceWatcher(t *testing.T) {
 		eventRepoMock,
 		eventQueueMock,
 		projectRepoMock,
+		&db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return nil, nil
+			},
+		},
 		10*time.Minute,
 		1*time.Minute,
 		theClock,
@@ -146,3 +151,215 @@ func TestSequenceWatcher(t *testing.T) {
 	}
 	cancel()
 }
+
+// runTaskTimeoutSequenceWatcher runs a sequence watcher for a task with a timeout of five minutes, which has been started after one minute
+func runTaskTimeoutSequenceWatcher(ctx context.Context, eventTimeout time.Duration) (*clock.Mock, chan apimodels.SequenceTimeout, *db_mock.EventRepoMock) {
+	theClock := clock.NewMock()
+
+	nowTimeStamp := theClock.Now().UTC()
+
+	openTriggeredEvents := []apimodels.KeptnContextExtendedCE{
+		{
+			Data: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			ID:             "my-triggered-id",
+			Shkeptncontext: "my-keptn-context",
+			Time:           nowTimeStamp,
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.TestTaskName)),
+		},
+	}
+
+	startedEvents := []apimodels.KeptnContextExtendedCE{
+		{
+			Data: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+			},
+			ID:             "my-started-id",
+			Triggeredid:    "my-triggered-id",
+			Shkeptncontext: "my-keptn-context",
+			Time:           nowTimeStamp.Add(1 * time.Minute),
+			Type:           common.Stringp(keptnv2.GetStartedEventType(keptnv2.TestTaskName)),
+		},
+	}
+
+	eventRepoMock := &db_mock.EventRepoMock{
+		DeleteEventFunc: func(project string, eventID string, status common.EventStatus) error {
+			openTriggeredEvents = []apimodels.KeptnContextExtendedCE{}
+			return nil
+		},
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			if len(status) > 0 && status[0] == common.TriggeredEvent {
+				return openTriggeredEvents, nil
+			}
+			return startedEvents, nil
+		},
+	}
+
+	sequenceExecutionRepoMock := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			if !filter.WithTaskTimeout {
+				return nil, nil
+			}
+			return []models.SequenceExecution{
+				{
+					Status: models.SequenceExecutionStatus{
+						CurrentTask: models.TaskExecutionState{
+							Name:        keptnv2.TestTaskName,
+							TriggeredID: "my-triggered-id",
+							Timeout:     "5m",
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	cancelSequenceChannel := make(chan apimodels.SequenceTimeout)
+
+	watcher := handler.NewSequenceWatcher(
+		cancelSequenceChannel,
+		eventRepoMock,
+		&db_mock.EventQueueRepoMock{
+			IsEventInQueueFunc: func(eventID string) (bool, error) {
+				return false, nil
+			},
+		},
+		&db_mock.ProjectRepoMock{
+			GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+				return []*apimodels.ExpandedProject{{ProjectName: "my-project"}}, nil
+			},
+		},
+		sequenceExecutionRepoMock,
+		eventTimeout,
+		1*time.Minute,
+		theClock,
+	)
+	watcher.Run(ctx)
+	return theClock, cancelSequenceChannel, eventRepoMock
+}
+
+func TestSequenceWatcher_TaskTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	theClock, cancelSequenceChannel, eventRepoMock := runTaskTimeoutSequenceWatcher(ctx, 1*time.Minute)
+
+	// the task has been started after one minute, so it should not be timed out after five minutes
+	theClock.Add(5 * time.Minute)
+
+	select {
+	case cancelCall := <-cancelSequenceChannel:
+		t.Errorf("received unexpected sequence cancellation for %s", cancelCall.KeptnContext)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	// after seven minutes, the timeout of the task has been exceeded
+	theClock.Add(2 * time.Minute)
+
+	select {
+	case cancelCall := <-cancelSequenceChannel:
+		require.Equal(t, "my-keptn-context", cancelCall.KeptnContext)
+		require.Equal(t, "my-triggered-id", cancelCall.LastEvent.ID)
+
+		require.Eventually(t, func() bool {
+			return len(eventRepoMock.DeleteEventCalls()) == 1
+		}, 5*time.Second, 1*time.Second)
+	case <-time.After(5 * time.Second):
+		t.Error("did not receive expected sequence cancellation")
+	}
+}
+
+func TestSequenceWatcher_TaskTimeoutShorterThanEventTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	theClock, cancelSequenceChannel, eventRepoMock := runTaskTimeoutSequenceWatcher(ctx, 30*time.Minute)
+
+	// the timeout of the task is exceeded after seven minutes, even though the event timeout has not been reached yet
+	theClock.Add(7 * time.Minute)
+
+	select {
+	case cancelCall := <-cancelSequenceChannel:
+		require.Equal(t, "my-keptn-context", cancelCall.KeptnContext)
+		require.Equal(t, "my-triggered-id", cancelCall.LastEvent.ID)
+
+		require.Eventually(t, func() bool {
+			return len(eventRepoMock.DeleteEventCalls()) == 1
+		}, 5*time.Second, 1*time.Second)
+	case <-time.After(5 * time.Second):
+		t.Error("did not receive expected sequence cancellation")
+	}
+}
+
+func TestSequenceWatcher_TasksWithoutTimeoutAreNotLookedUp(t *testing.T) {
+	theClock := clock.NewMock()
+
+	openTriggeredEvents := []apimodels.KeptnContextExtendedCE{
+		{
+			Data:           keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+			ID:             "my-triggered-id",
+			Shkeptncontext: "my-keptn-context",
+			Time:           theClock.Now().UTC(),
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.TestTaskName)),
+		},
+		{
+			Data:           keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+			ID:             "my-triggered-id-2",
+			Shkeptncontext: "my-keptn-context-2",
+			Time:           theClock.Now().UTC(),
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.TestTaskName)),
+		},
+	}
+
+	eventRepoMock := &db_mock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]apimodels.KeptnContextExtendedCE, error) {
+			if len(status) > 0 && status[0] == common.TriggeredEvent {
+				return openTriggeredEvents, nil
+			}
+			return nil, db.ErrNoEventFound
+		},
+	}
+
+	sequenceExecutionRepoMock := &db_mock.SequenceExecutionRepoMock{
+		GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+			return nil, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := handler.NewSequenceWatcher(
+		make(chan apimodels.SequenceTimeout),
+		eventRepoMock,
+		&db_mock.EventQueueRepoMock{},
+		&db_mock.ProjectRepoMock{
+			GetProjectsFunc: func() ([]*apimodels.ExpandedProject, error) {
+				return []*apimodels.ExpandedProject{{ProjectName: "my-project"}}, nil
+			},
+		},
+		sequenceExecutionRepoMock,
+		30*time.Minute,
+		1*time.Minute,
+		theClock,
+	)
+	watcher.Run(ctx)
+
+	theClock.Add(3 * time.Minute)
+
+	// the task timeouts are fetched once per project and tick
+	require.Eventually(t, func() bool {
+		return len(sequenceExecutionRepoMock.GetCalls()) >= 2
+	}, 5*time.Second, 100*time.Millisecond)
+	for _, call := range sequenceExecutionRepoMock.GetCalls() {
+		require.True(t, call.Filter.WithTaskTimeout)
+	}
+
+	// before the event timeout has been reached, no response events of tasks without a timeout are fetched
+	for _, call := range eventRepoMock.GetEventsCalls() {
+		require.Equal(t, []common.EventStatus{common.TriggeredEvent}, call.Status)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/uuid"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
@@ -14,6 +15,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
+	"github.com/keptn/keptn/shipyard-controller/metrics"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
 )
@@ -30,7 +32,7 @@ type IShipyardController interface {
 	GetAllTriggeredEvents(filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error)
 	GetTriggeredEventsOfProject(project string, filter common.EventFilter) ([]apimodels.KeptnContextExtendedCE, error)
 	HandleIncomingEvent(event apimodels.KeptnContextExtendedCE, waitForCompletion bool) error
-	ControlSequence(controlSequence apimodels.SequenceControl) error
+	ControlSequence(controlSequence models.SequenceControl) error
 	StartTaskSequence(event apimodels.KeptnContextExtendedCE) error
 	StartDispatchers(ctx context.Context, mode common.SDMode)
 	StopDispatchers()
@@ -52,6 +54,8 @@ type shipyardController struct {
 	subSequenceFinishedHooks   []sequencehooks.ISubSequenceFinishedHook
 	sequenceFinishedHooks      []sequencehooks.ISequenceFinishedHook
 	sequenceAbortedHooks       []sequencehooks.ISequenceAbortedHook
+	sequenceSupersededHooks    []sequencehooks.ISequenceSupersededHook
+	sequenceRestartedHooks     []sequencehooks.ISequenceRestartedHook
 	sequenceTimoutHooks        []sequencehooks.ISequenceTimeoutHook
 	sequencePausedHooks        []sequencehooks.ISequencePausedHook
 	sequenceResumedHooks       []sequencehooks.ISequenceResumedHook
@@ -101,11 +105,11 @@ func (sc *shipyardController) run(ctx context.Context) {
 	}()
 }
 
-func (sc *shipyardController) ControlSequence(controlSequence apimodels.SequenceControl) error {
+func (sc *shipyardController) ControlSequence(controlSequence models.SequenceControl) error {
 	switch controlSequence.State {
 	case apimodels.AbortSequence:
 		log.Info("Processing ABORT sequence control")
-		return sc.cancelSequence(controlSequence)
+		return sc.cancelSequence(controlSequence.SequenceControl)
 	case apimodels.PauseSequence:
 		log.Info("Processing PAUSE sequence control")
 		sc.onSequencePaused(models.EventScope{
@@ -115,7 +119,7 @@ func (sc *shipyardController) ControlSequence(controlSequence apimodels.Sequence
 			},
 			KeptnContext: controlSequence.KeptnContext,
 		})
-		return sc.pauseSequence(controlSequence)
+		return sc.pauseSequence(controlSequence.SequenceControl)
 	case apimodels.ResumeSequence:
 		log.Info("Processing RESUME sequence control")
 		sc.onSequenceResumed(models.EventScope{
@@ -125,7 +129,10 @@ func (sc *shipyardController) ControlSequence(controlSequence apimodels.Sequence
 			},
 			KeptnContext: controlSequence.KeptnContext,
 		})
-		return sc.resumeSequence(controlSequence)
+		return sc.resumeSequence(controlSequence.SequenceControl)
+	case models.RestartSequence:
+		log.Info("Processing RESTART sequence control")
+		return sc.restartSequence(controlSequence)
 	}
 	return nil
 }
@@ -233,6 +240,39 @@ func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnConte
 		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
 	}
 
+	concurrencyPolicy := sc.getConcurrencyPolicy(*eventScope)
+	if err := concurrencyPolicy.Validate(); err != nil {
+		msg := fmt.Sprintf("Unable to start sequence %s: %v '%s' in stage %s", taskSequenceName, err, concurrencyPolicy.Policy, eventScope.Stage)
+		log.Error(msg)
+		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
+	}
+
+	// the shipyard may have been changed in the upstream, hence the tasks of the sequence are validated again before it is started
+	if err := sc.validateSequenceExtension(*eventScope, taskSequenceName); err != nil {
+		msg := fmt.Sprintf("Unable to start sequence %s: %v", taskSequenceName, err)
+		log.Error(msg)
+		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
+	}
+
+	if concurrencyPolicy.GetPolicy() == models.ConcurrencySkipDuplicate {
+		duplicateSequence, err := sc.getActiveSequenceExecution(*eventScope, taskSequenceName)
+		if err != nil {
+			return err
+		}
+		if duplicateSequence != nil {
+			msg := fmt.Sprintf("Sequence %s has been skipped because sequence with keptnContext %s is already active for service %s in stage %s", taskSequenceName, duplicateSequence.Scope.KeptnContext, eventScope.Service, eventScope.Stage)
+			log.Info(msg)
+			return sc.skipSequence(*eventScope, msg, taskSequenceName)
+		}
+	}
+
+	priority, err := models.GetSequencePriority(eventScope.WrappedEvent.Data)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to start sequence %s: invalid priority: %v", taskSequenceName, err)
+		log.Error(msg)
+		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
+	}
+
 	sc.appendLatestCommitIDToEvent(*eventScope, &eventScope.WrappedEvent)
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, eventScope.WrappedEvent, common.TriggeredEvent); err != nil {
 		log.Infof("could not store event that triggered task sequence: %s", err.Error())
@@ -268,19 +308,125 @@ func (sc *shipyardController) handleSequenceTriggered(event apimodels.KeptnConte
 	}
 
 	sc.onSequenceTriggered(eventScope.WrappedEvent)
+
+	if concurrencyPolicy.GetPolicy() == models.ConcurrencySupersede {
+		sc.supersedeQueuedSequences(*eventScope, taskSequenceName)
+	}
+
 	err = sc.sequenceDispatcher.Add(models.QueueItem{
-		Scope:     *eventScope,
-		EventID:   eventScope.WrappedEvent.ID,
-		Timestamp: eventScope.WrappedEvent.Time,
+		Scope:       *eventScope,
+		EventID:     eventScope.WrappedEvent.ID,
+		Timestamp:   eventScope.WrappedEvent.Time,
+		Concurrency: concurrencyPolicy,
+		Priority:    priority,
 	})
 	if errors.Is(err, ErrSequenceBlockedWaiting) {
-		sc.onSequenceWaiting(eventScope.WrappedEvent)
+		waitingReason := ""
+		blockedErr := &SequenceBlockedError{}
+		if errors.As(err, &blockedErr) {
+			waitingReason = blockedErr.Reason
+		}
+		sc.onSequenceWaiting(eventScope.WrappedEvent, waitingReason)
 		return nil
 	}
 
 	return err
 }
 
+// getConcurrencyPolicy returns the concurrency policy declared in the shipyard for the stage of the given event scope.
+// If the policy cannot be determined, the default policy is returned
+func (sc *shipyardController) getConcurrencyPolicy(eventScope models.EventScope) models.ConcurrencyPolicy {
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		// log the error, but continue with the default policy
+		log.Errorf("Could not determine concurrency policy for stage %s in project %s: %v", eventScope.Stage, eventScope.Project, err)
+		return models.ConcurrencyPolicy{Policy: models.ConcurrencySerial}
+	}
+	return shipyardExtension.GetConcurrencyPolicy(eventScope.Stage)
+}
+
+// validateSequenceExtension checks the shipyard controller specific properties of the tasks of a sequence. If they cannot be determined, the sequence is not rejected
+func (sc *shipyardController) validateSequenceExtension(eventScope models.EventScope, sequenceName string) error {
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		// log the error, but continue
+		log.Errorf("Could not validate sequence %s in stage %s in project %s: %v", sequenceName, eventScope.Stage, eventScope.Project, err)
+		return nil
+	}
+	sequence := shipyardExtension.GetSequence(eventScope.Stage, sequenceName)
+	if sequence == nil {
+		return nil
+	}
+	return sequence.Validate()
+}
+
+// getActiveSequenceExecution returns a sequence execution with the given name that is currently queued or running for the same service in the stage of the event scope,
+// but belongs to a different keptnContext. If no such sequence is found, nil is returned
+func (sc *shipyardController) getActiveSequenceExecution(eventScope models.EventScope, sequenceName string) (*models.SequenceExecution, error) {
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: eventScope.Project,
+				Stage:   eventScope.Stage,
+				Service: eventScope.Service,
+			},
+		},
+		Name: sequenceName,
+		Status: []string{
+			apimodels.SequenceTriggeredState,
+			apimodels.SequenceStartedState,
+			apimodels.SequenceWaitingForApprovalState,
+			apimodels.SequencePaused,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load active sequences for service %s in stage %s: %w", eventScope.Service, eventScope.Stage, err)
+	}
+	for index := range sequenceExecutions {
+		if sequenceExecutions[index].Scope.KeptnContext != eventScope.KeptnContext {
+			return &sequenceExecutions[index], nil
+		}
+	}
+	return nil, nil
+}
+
+// supersedeQueuedSequences aborts all sequences with the given name that have been queued, but not started yet, for the same service in the stage of the event scope
+func (sc *shipyardController) supersedeQueuedSequences(eventScope models.EventScope, sequenceName string) {
+	queuedSequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{
+		Scope: models.EventScope{
+			EventData: keptnv2.EventData{
+				Project: eventScope.Project,
+				Stage:   eventScope.Stage,
+				Service: eventScope.Service,
+			},
+		},
+		Name:   sequenceName,
+		Status: []string{apimodels.SequenceTriggeredState},
+	})
+	if err != nil {
+		log.Errorf("Could not load queued sequences for service %s in stage %s: %v", eventScope.Service, eventScope.Stage, err)
+		return
+	}
+
+	for _, sequenceExecution := range queuedSequenceExecutions {
+		if sequenceExecution.Scope.KeptnContext == eventScope.KeptnContext {
+			continue
+		}
+		log.Infof("Sequence with keptnContext %s is superseded by sequence with keptnContext %s in stage %s", sequenceExecution.Scope.KeptnContext, eventScope.KeptnContext, eventScope.Stage)
+		err := sc.cancelSequence(apimodels.SequenceControl{
+			State:        apimodels.AbortSequence,
+			KeptnContext: sequenceExecution.Scope.KeptnContext,
+			Stage:        sequenceExecution.Scope.Stage,
+			Project:      sequenceExecution.Scope.Project,
+		})
+		if err != nil {
+			log.Errorf("Could not abort superseded sequence with keptnContext %s: %v", sequenceExecution.Scope.KeptnContext, err)
+			continue
+		}
+		sc.onSequenceSuperseded(sequenceExecution, eventScope)
+	}
+}
+
 func (sc *shipyardController) appendLatestCommitIDToEvent(eventScope models.EventScope, event *apimodels.KeptnContextExtendedCE) {
 	// get the latest git commit ID for the stage if it is not specified in the event
 	if eventScope.WrappedEvent.GitCommitID == "" {
@@ -323,11 +469,12 @@ func (sc *shipyardController) handleTaskEvent(event apimodels.KeptnContextExtend
 
 func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtendedCE, sequenceExecution models.SequenceExecution, eventScope *models.EventScope) error {
 	taskEvent := models.TaskEvent{
-		EventType: *event.Type,
-		Source:    *event.Source,
-		Result:    eventScope.Result,
-		Status:    eventScope.Status,
-		Time:      timeutils.GetKeptnTimeStamp(event.Time),
+		EventType:   *event.Type,
+		Source:      *event.Source,
+		Result:      eventScope.Result,
+		Status:      eventScope.Status,
+		Time:        timeutils.GetKeptnTimeStamp(event.Time),
+		TriggeredID: eventScope.TriggeredID,
 	}
 	if keptnv2.IsFinishedEventType(taskEvent.EventType) {
 		eventData := map[string]interface{}{}
@@ -349,6 +496,13 @@ func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtende
 		return nil
 	}
 
+	if retried, err := sc.retryTask(*eventScope, *updatedSequenceExecution, false); err != nil {
+		return err
+	} else if retried {
+		return nil
+	}
+
+	triggeredIDs := updatedSequenceExecution.Status.CurrentTask.GetTriggeredIDs()
 	result, status := updatedSequenceExecution.CompleteCurrentTask()
 
 	eventScope.Result = result
@@ -368,12 +522,28 @@ func (sc *shipyardController) onTaskProgress(event apimodels.KeptnContextExtende
 		return fmt.Errorf("no matching task '.triggered' event found for event %s with triggered ID %s", eventScope.WrappedEvent.ID, eventScope.TriggeredID)
 	}
 
+	if taskName, _, err := keptnv2.ParseTaskEventType(eventScope.EventType); err == nil {
+		taskDuration := metrics.TaskDuration.WithLabelValues(eventScope.Project, eventScope.Stage, taskName, string(result))
+		metrics.ObserveDuration(taskDuration, triggeredEvents[0].Time, event.Time)
+	}
+
 	// the '.triggered' event can now be removed
 	err = sc.eventRepo.DeleteEvent(eventScope.Project, triggeredEvents[0].ID, common.TriggeredEvent)
 	if err != nil {
 		return fmt.Errorf("unable to delete associated task '.triggered' event with ID %s: %w", eventScope.TriggeredID, err)
 	}
 
+	// if the task was part of a parallel group, the '.triggered' events of the other tasks of the group can be removed as well
+	for _, triggeredID := range triggeredIDs {
+		if triggeredID == eventScope.TriggeredID {
+			continue
+		}
+		if err := sc.eventRepo.DeleteEvent(eventScope.Project, triggeredID, common.TriggeredEvent); err != nil {
+			// log the error, but continue
+			log.WithError(err).Errorf("could not delete '.triggered' event with ID %s", triggeredID)
+		}
+	}
+
 	sc.onSequenceTaskFinished(eventScope.WrappedEvent)
 	return sc.proceedTaskSequence(*eventScope, *updatedSequenceExecution)
 }
@@ -427,10 +597,12 @@ func (sc *shipyardController) cancelSequence(cancel apimodels.SequenceControl) e
 
 	// delete all open .triggered events for the task sequence
 	for _, sequenceExecution := range sequenceExecutions {
-		err := sc.eventRepo.DeleteEvent(cancel.Project, sequenceExecution.Status.CurrentTask.TriggeredID, common.TriggeredEvent)
-		if err != nil {
-			// log the error, but continue
-			log.WithError(err).Error("could not delete event")
+		for _, triggeredID := range sequenceExecution.Status.CurrentTask.GetTriggeredIDs() {
+			err := sc.eventRepo.DeleteEvent(cancel.Project, triggeredID, common.TriggeredEvent)
+			if err != nil {
+				// log the error, but continue
+				log.WithError(err).Error("could not delete event")
+			}
 		}
 
 		if err := sc.forceTaskSequenceCompletion(sequenceExecution); err != nil {
@@ -508,6 +680,141 @@ func (sc *shipyardController) resumeSequence(resume apimodels.SequenceControl) e
 	return nil
 }
 
+// restartSequence executes a finished sequence in the given stage again, starting with the task specified in the sequence control.
+// The restarted sequence keeps its keptnContext and is queued like a newly triggered sequence, so that the concurrency policy of the stage is respected
+func (sc *shipyardController) restartSequence(restart models.SequenceControl) error {
+	if restart.Stage == "" {
+		return fmt.Errorf("%w: a stage must be specified", ErrSequenceNotRestartable)
+	}
+	scope := models.EventScope{
+		KeptnContext: restart.KeptnContext,
+		EventData: keptnv2.EventData{
+			Project: restart.Project,
+			Stage:   restart.Stage,
+		},
+	}
+	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(models.SequenceExecutionFilter{Scope: scope})
+	if err != nil {
+		return fmt.Errorf(couldNotGetActiveSequencesErrMsg, restart.Project, restart.Stage, restart.KeptnContext, err)
+	}
+	if len(sequenceExecutions) == 0 {
+		return fmt.Errorf("%w: no sequence execution for project %s in stage %s for Keptn context %s found", ErrSequenceNotFound, restart.Project, restart.Stage, restart.KeptnContext)
+	}
+
+	// if a stage has been visited multiple times within the same context, e.g. by a rollback sequence, the latest sequence is restarted
+	sequenceExecution := sequenceExecutions[0]
+	for _, otherSequenceExecution := range sequenceExecutions {
+		if !otherSequenceExecution.CanBeRestarted() {
+			return fmt.Errorf("%w: sequence %s is still active in stage %s", ErrSequenceNotRestartable, otherSequenceExecution.Sequence.Name, restart.Stage)
+		}
+		if otherSequenceExecution.TriggeredAt.After(sequenceExecution.TriggeredAt) {
+			sequenceExecution = otherSequenceExecution
+		}
+	}
+
+	taskIndex, err := sequenceExecution.GetRestartTaskIndex(restart.FromTask)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSequenceNotRestartable, err)
+	}
+	taskIndex = sc.getTaskGroupStartIndex(sequenceExecution, taskIndex)
+
+	// the event that triggered the sequence is needed by the sequence dispatcher to start the sequence again
+	inputEvent, err := sc.restoreSequenceTriggeredEvent(sequenceExecution)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSequenceNotRestartable, err)
+	}
+
+	log.Infof("Restarting sequence %s.%s with KeptnContext %s from task %s", restart.Stage, sequenceExecution.Sequence.Name, restart.KeptnContext, sequenceExecution.Sequence.Tasks[taskIndex].Name)
+
+	restartedAt := time.Now().UTC()
+	sequenceExecution.Restart(taskIndex, restartedAt)
+	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
+		return fmt.Errorf("could not store restarted sequence execution: %w", err)
+	}
+
+	restart.FromTask = sequenceExecution.Sequence.Tasks[taskIndex].Name
+	sc.onSequenceRestarted(*inputEvent, restart)
+
+	priority, err := models.GetSequencePriority(sequenceExecution.InputProperties)
+	if err != nil {
+		// log the error, but continue with the default priority
+		log.Errorf("Could not determine priority of sequence %s.%s with KeptnContext %s: %v", restart.Stage, sequenceExecution.Sequence.Name, restart.KeptnContext, err)
+	}
+
+	err = sc.sequenceDispatcher.Add(models.QueueItem{
+		Scope:       sequenceExecution.Scope,
+		EventID:     sequenceExecution.Scope.TriggeredID,
+		Timestamp:   restartedAt,
+		Concurrency: sc.getConcurrencyPolicy(sequenceExecution.Scope),
+		Priority:    priority,
+	})
+	if errors.Is(err, ErrSequenceBlockedWaiting) {
+		waitingReason := ""
+		blockedErr := &SequenceBlockedError{}
+		if errors.As(err, &blockedErr) {
+			waitingReason = blockedErr.Reason
+		}
+		sc.onSequenceWaiting(*inputEvent, waitingReason)
+		return nil
+	}
+	return err
+}
+
+// restoreSequenceTriggeredEvent returns the event that triggered the given sequence. Since the events of a sequence are deleted once
+// it has been completed, the event is re-created from the input properties and the scope of the sequence execution if it is not available anymore
+func (sc *shipyardController) restoreSequenceTriggeredEvent(sequenceExecution models.SequenceExecution) (*apimodels.KeptnContextExtendedCE, error) {
+	triggeredEvent, err := sc.getSequenceTriggeredEvent(sequenceExecution)
+	if err != nil && !errors.Is(err, db.ErrNoEventFound) {
+		return nil, err
+	}
+	if triggeredEvent != nil {
+		return triggeredEvent, nil
+	}
+
+	scope := sequenceExecution.Scope
+	eventData := common.CopyMap(sequenceExecution.InputProperties)
+	eventData["project"] = scope.Project
+	eventData["stage"] = scope.Stage
+	eventData["service"] = scope.Service
+
+	event := common.CreateEventWithPayload(scope.KeptnContext, "", keptnv2.GetTriggeredEventType(scope.Stage+"."+sequenceExecution.Sequence.Name), eventData)
+	// the sequence dispatcher looks up the event by the id stored in the scope of the sequence execution
+	event.SetID(scope.TriggeredID)
+	event.SetTime(sequenceExecution.TriggeredAt)
+
+	triggeredEvent, err = models.ConvertToEvent(event)
+	if err != nil {
+		return nil, fmt.Errorf("could not restore event that triggered task sequence %s.%s with KeptnContext %s: %w", scope.Stage, sequenceExecution.Sequence.Name, scope.KeptnContext, err)
+	}
+	triggeredEvent.GitCommitID = scope.GitCommitID
+
+	if err := sc.eventRepo.InsertEvent(scope.Project, *triggeredEvent, common.TriggeredEvent); err != nil {
+		return nil, fmt.Errorf("could not store restored event that triggered task sequence %s.%s with KeptnContext %s: %w", scope.Stage, sequenceExecution.Sequence.Name, scope.KeptnContext, err)
+	}
+	return triggeredEvent, nil
+}
+
+// getTaskGroupStartIndex returns the position of the first task of the parallel group the task at the given position belongs to,
+// since the tasks of a group can only be executed together. If the task is not part of a group, the given position is returned
+func (sc *shipyardController) getTaskGroupStartIndex(sequenceExecution models.SequenceExecution, taskIndex int) int {
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(sequenceExecution.Scope.Project)
+	if err != nil {
+		// log the error, but continue without parallel groups
+		log.Errorf("Could not determine parallel groups of sequence %s.%s in project %s: %v", sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sequenceExecution.Scope.Project, err)
+		return taskIndex
+	}
+	stageName := sequenceExecution.Scope.Stage
+	sequenceName := sequenceExecution.Sequence.Name
+	group := shipyardExtension.GetTaskGroup(stageName, sequenceName, taskIndex)
+	if group == "" {
+		return taskIndex
+	}
+	for taskIndex > 0 && shipyardExtension.GetTaskGroup(stageName, sequenceName, taskIndex-1) == group {
+		taskIndex--
+	}
+	return taskIndex
+}
+
 func (sc *shipyardController) forceTaskSequenceCompletion(sequenceExecution models.SequenceExecution) error {
 	scope := sequenceExecution.Scope
 
@@ -543,6 +850,17 @@ func (sc *shipyardController) timeoutSequence(timeout apimodels.SequenceTimeout)
 	}
 
 	sequenceExecution := sequenceExecutions[0]
+	currentTask := sequenceExecution.Status.CurrentTask
+	if currentTask.IsStarted(timeout.LastEvent.ID) {
+		eventScope.Message = fmt.Sprintf("sequence timed out because task %s has not been finished within its timeout of %s after it has been started", *timeout.LastEvent.Type, currentTask.GetTimeout(timeout.LastEvent.ID))
+	}
+
+	if retried, err := sc.retryTask(*eventScope, sequenceExecution, true); err != nil {
+		return err
+	} else if retried {
+		return nil
+	}
+
 	sc.onSequenceTimeout(timeout.LastEvent)
 
 	if err := sc.completeTaskSequence(*eventScope, sequenceExecution, apimodels.TimedOut); err != nil {
@@ -552,6 +870,15 @@ func (sc *shipyardController) timeoutSequence(timeout apimodels.SequenceTimeout)
 }
 
 func (sc *shipyardController) triggerSequenceFailed(eventScope models.EventScope, msg string, taskSequenceName string) error {
+	return sc.finishSequenceWithoutExecution(eventScope, keptnv2.ResultFailed, keptnv2.StatusErrored, msg, taskSequenceName)
+}
+
+// skipSequence completes a sequence that has not been started due to the concurrency policy of its stage
+func (sc *shipyardController) skipSequence(eventScope models.EventScope, msg string, taskSequenceName string) error {
+	return sc.finishSequenceWithoutExecution(eventScope, keptnv2.ResultPass, keptnv2.StatusAborted, msg, taskSequenceName)
+}
+
+func (sc *shipyardController) finishSequenceWithoutExecution(eventScope models.EventScope, result keptnv2.ResultType, status keptnv2.StatusType, msg string, taskSequenceName string) error {
 	event := eventScope.WrappedEvent
 	sc.onSequenceTriggered(event) //TODO: remove?
 	finishedEvent := event
@@ -560,8 +887,8 @@ func (sc *shipyardController) triggerSequenceFailed(eventScope models.EventScope
 		Stage:   eventScope.Stage,
 		Service: eventScope.Service,
 		Labels:  eventScope.Labels,
-		Status:  keptnv2.StatusErrored,
-		Result:  keptnv2.ResultFailed,
+		Status:  status,
+		Result:  result,
 		Message: msg,
 	}
 	finishedEvent.Data = finishedEventData
@@ -584,8 +911,6 @@ func (sc *shipyardController) StartTaskSequence(event apimodels.KeptnContextExte
 		return err
 	}
 
-	sc.onSequenceStarted(event)
-
 	sequenceExecutions, err := sc.sequenceExecutionRepo.Get(
 		models.SequenceExecutionFilter{
 			Scope:  *eventScope,
@@ -693,8 +1018,17 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 		return err
 	}
 
-	task := sequenceExecution.GetNextTaskOfSequence()
-	if task == nil {
+	tasks, err := sc.getNextTasksToExecute(&sequenceExecution)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		// conditional tasks may have been executed after a failed task - in this case, the sequence keeps the result of the failed task
+		sequenceResult := sequenceExecution.GetSequenceResult()
+		if sequenceResult.IsFailed() || sequenceResult.IsErrored() {
+			eventScope.Result = sequenceResult.Result
+			eventScope.Status = sequenceResult.Status
+		}
 		// task sequence completed -> send .finished event and check if a new task sequence should be triggered by the completion
 		err = sc.completeTaskSequence(eventScope, sequenceExecution, apimodels.SequenceFinished)
 		if err != nil {
@@ -704,7 +1038,91 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 		return sc.triggerNextTaskSequences(eventScope, inputEvent, sequenceExecution)
 	}
 
-	return sc.triggerTask(eventScope, sequenceExecution, *task)
+	if len(tasks) == 1 {
+		return sc.triggerTask(eventScope, sequenceExecution, tasks[0])
+	}
+	return sc.triggerParallelTasks(eventScope, sequenceExecution, tasks)
+}
+
+// nextTask is a task of a sequence that is about to be triggered. If skip is set, the task is part of a parallel group, but will not be triggered because its condition is not met
+type nextTask struct {
+	task    keptnv2.Task
+	skip    bool
+	timeout string
+}
+
+// getNextTasksToExecute returns the next task of the sequence whose 'when' condition is met. If the task is part of a parallel group, all tasks of the group are returned.
+// Tasks whose condition is not met are marked as skipped. Once a task of the sequence has failed, only tasks with a condition are considered.
+// If no task is remaining, an empty slice is returned
+func (sc *shipyardController) getNextTasksToExecute(sequenceExecution *models.SequenceExecution) ([]nextTask, error) {
+	var shipyardExtension *models.ShipyardExtension
+	skippedTasks := false
+	for sequenceExecution.GetRemainingTask() != nil {
+		if shipyardExtension == nil {
+			var err error
+			shipyardExtension, err = sc.shipyardRetriever.GetCachedShipyardExtension(sequenceExecution.Scope.Project)
+			if err != nil {
+				// log the error, but continue without conditions and parallel groups
+				log.Errorf("Could not determine task conditions for sequence %s.%s in project %s: %v", sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sequenceExecution.Scope.Project, err)
+				shipyardExtension = &models.ShipyardExtension{}
+			}
+		}
+
+		stageName := sequenceExecution.Scope.Stage
+		sequenceName := sequenceExecution.Sequence.Name
+		firstIndex := sequenceExecution.GetNextTaskIndex()
+		lastIndex := firstIndex
+		if group := shipyardExtension.GetTaskGroup(stageName, sequenceName, firstIndex); group != "" {
+			for lastIndex+1 < len(sequenceExecution.Sequence.Tasks) && shipyardExtension.GetTaskGroup(stageName, sequenceName, lastIndex+1) == group {
+				lastIndex++
+			}
+		}
+
+		conditionData := sequenceExecution.GetTaskConditionData()
+		tasks := []nextTask{}
+		executeAny := false
+		stopSequence := false
+		for index := firstIndex; index <= lastIndex; index++ {
+			task := sequenceExecution.Sequence.Tasks[index]
+			condition := shipyardExtension.GetTaskCondition(stageName, sequenceName, index)
+
+			execute := false
+			if condition == "" {
+				execute = !sequenceExecution.HasFailedTask()
+				stopSequence = stopSequence || !execute
+			} else {
+				conditionMet, err := models.EvaluateTaskCondition(condition, conditionData)
+				if err != nil {
+					log.Errorf("Could not evaluate condition of task %s in sequence %s.%s with KeptnContext %s: %v", task.Name, stageName, sequenceName, sequenceExecution.Scope.KeptnContext, err)
+				}
+				execute = conditionMet
+				if !execute {
+					log.Infof("Skipping task %s in sequence %s.%s with KeptnContext %s because its condition '%s' is not met", task.Name, stageName, sequenceName, sequenceExecution.Scope.KeptnContext, condition)
+				}
+			}
+			executeAny = executeAny || execute
+			tasks = append(tasks, nextTask{task: task, skip: !execute, timeout: getTaskTimeout(shipyardExtension.GetTask(stageName, sequenceName, index))})
+		}
+
+		if executeAny {
+			return tasks, nil
+		}
+		if stopSequence {
+			break
+		}
+		for range tasks {
+			sequenceExecution.SkipNextTask()
+		}
+		skippedTasks = true
+	}
+
+	if skippedTasks {
+		// the next task would store the skipped tasks when being triggered - since there is none, the sequence execution needs to be updated here
+		if err := sc.sequenceExecutionRepo.Upsert(*sequenceExecution, nil); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
 }
 
 // this function retrieves the .triggered event for the task sequence and appends its properties to the existing .finished events
@@ -767,45 +1185,160 @@ func (sc *shipyardController) completeTaskSequence(eventScope models.EventScope,
 	return sc.sendTaskSequenceFinishedEvent(eventScope, sequenceExecution.Sequence.Name, sequenceExecution.Scope.TriggeredID)
 }
 
-func (sc *shipyardController) triggerTask(eventScope models.EventScope, sequenceExecution models.SequenceExecution, task keptnv2.Task) error {
-	eventPayload := sequenceExecution.GetNextTriggeredEventData()
+func (sc *shipyardController) triggerTask(eventScope models.EventScope, sequenceExecution models.SequenceExecution, task nextTask) error {
+	event, sendTaskTimestamp, err := sc.storeTaskTriggeredEvent(eventScope, sequenceExecution, task.task.Name, sequenceExecution.GetNextTriggeredEventData(), getTriggeredAfter(task.task))
+	if err != nil {
+		return err
+	}
+
+	sequenceExecution.SetNextCurrentTask(task.task.Name, event.ID())
+	sequenceExecution.Status.CurrentTask.Timeout = task.timeout
+
+	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
+		return err
+	}
+	if err := sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: *event}, false); err != nil {
+		return err
+	}
+	return nil
+}
+
+// triggerParallelTasks triggers all tasks of a parallel group at the same time. The sequence proceeds once all of them are finished
+func (sc *shipyardController) triggerParallelTasks(eventScope models.EventScope, sequenceExecution models.SequenceExecution, tasks []nextTask) error {
+	parallelTasks := []models.ParallelTask{}
+	dispatcherEvents := []models.DispatcherEvent{}
+	for index := range tasks {
+		task := tasks[index].task
+		if tasks[index].skip {
+			parallelTasks = append(parallelTasks, models.ParallelTask{Name: task.Name, Skipped: true})
+			continue
+		}
+		event, sendTaskTimestamp, err := sc.storeTaskTriggeredEvent(eventScope, sequenceExecution, task.Name, sequenceExecution.GetTriggeredEventData(&task), getTriggeredAfter(task))
+		if err != nil {
+			return err
+		}
+		parallelTasks = append(parallelTasks, models.ParallelTask{Name: task.Name, TriggeredID: event.ID(), Timeout: tasks[index].timeout})
+		dispatcherEvents = append(dispatcherEvents, models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: *event})
+	}
+
+	sequenceExecution.SetNextParallelTasks(parallelTasks)
+
+	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
+		return err
+	}
+	for _, dispatcherEvent := range dispatcherEvents {
+		if err := sc.eventDispatcher.Add(dispatcherEvent, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retryTask triggers the current task of the sequence again if it has failed or timed out, and the number of retries declared for the task in the shipyard has not been exceeded.
+// Tasks of a parallel group are not retried. If the task has been retried, true is returned
+func (sc *shipyardController) retryTask(eventScope models.EventScope, sequenceExecution models.SequenceExecution, timedOut bool) (bool, error) {
+	currentTask := sequenceExecution.Status.CurrentTask
+	// retries are rejected for tasks of parallel groups when the shipyard is validated
+	if len(currentTask.ParallelTasks) > 0 {
+		return false, nil
+	}
+	if !timedOut && !currentTask.IsFailed() && !currentTask.IsErrored() {
+		return false, nil
+	}
+	task := sequenceExecution.GetRemainingTask()
+	if task == nil || task.Name != currentTask.Name {
+		return false, nil
+	}
+
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(sequenceExecution.Scope.Project)
+	if err != nil {
+		// log the error, but continue without retrying the task
+		log.Errorf("Could not determine retry policy of task %s in sequence %s.%s in project %s: %v", task.Name, sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sequenceExecution.Scope.Project, err)
+		return false, nil
+	}
+	taskExtension := shipyardExtension.GetTask(sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sequenceExecution.GetNextTaskIndex())
+	retry := len(currentTask.Attempts)
+	if taskExtension == nil || retry >= taskExtension.Retries {
+		return false, nil
+	}
+
+	log.Infof("Retrying task %s in sequence %s.%s with KeptnContext %s (retry %d of %d)", task.Name, sequenceExecution.Scope.Stage, sequenceExecution.Sequence.Name, sequenceExecution.Scope.KeptnContext, retry+1, taskExtension.Retries)
+
+	previousTriggeredID := currentTask.TriggeredID
+	sequenceExecution.FailCurrentAttempt(timedOut)
+
+	event, sendTaskTimestamp, err := sc.storeTaskTriggeredEvent(eventScope, sequenceExecution, task.Name, sequenceExecution.GetNextTriggeredEventData(), taskExtension.GetBackoff(retry))
+	if err != nil {
+		return false, err
+	}
+	sequenceExecution.RetryCurrentTask(event.ID())
+
+	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
+		return false, err
+	}
+
+	// the '.triggered' event of the failed attempt is not needed anymore
+	if err := sc.eventRepo.DeleteEvent(eventScope.Project, previousTriggeredID, common.TriggeredEvent); err != nil {
+		// log the error, but continue
+		log.WithError(err).Errorf("could not delete '.triggered' event with ID %s", previousTriggeredID)
+	}
+
+	if err := sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: *event}, false); err != nil {
+		return false, err
+	}
+	return true, nil
+}
 
-	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(task.Name), eventPayload)
+// storeTaskTriggeredEvent creates the .triggered event for the given task and stores it. It returns the event, as well as the point in time at which it should be sent
+func (sc *shipyardController) storeTaskTriggeredEvent(eventScope models.EventScope, sequenceExecution models.SequenceExecution, taskName string, eventPayload map[string]interface{}, delay time.Duration) (*cloudevents.Event, time.Time, error) {
+	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(taskName), eventPayload)
 	event.SetExtension("gitcommitid", sequenceExecution.Scope.GitCommitID)
 
 	storeEvent := &apimodels.KeptnContextExtendedCE{}
 	if err := keptnv2.Decode(event, storeEvent); err != nil {
 		log.Errorf("could not transform CloudEvent for storage in mongodb: %s", err.Error())
-		return err
+		return nil, time.Time{}, err
 	}
 
 	sendTaskTimestamp := time.Now().UTC()
-	if task.TriggeredAfter != "" {
-		if duration, err := time.ParseDuration(task.TriggeredAfter); err == nil {
-			sendTaskTimestamp = sendTaskTimestamp.Add(duration)
-		} else {
-			log.Errorf("could not parse triggeredAfter property: %s", err.Error())
-		}
+	if delay > 0 {
+		sendTaskTimestamp = sendTaskTimestamp.Add(delay)
 		log.Infof("queueing %s event with ID %s to be sent at %s", event.Type(), event.ID(), sendTaskTimestamp.String())
 	}
 	storeEvent.Time = sendTaskTimestamp
 
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, *storeEvent, common.TriggeredEvent); err != nil {
 		log.Errorf("Could not store event: %s", err.Error())
-		return err
+		return nil, time.Time{}, err
 	}
 
 	sc.onSequenceTaskTriggered(*storeEvent)
+	return &event, sendTaskTimestamp, nil
+}
 
-	sequenceExecution.SetNextCurrentTask(task.Name, storeEvent.ID)
+// getTriggeredAfter returns the duration after which the .triggered event of the task should be sent
+func getTriggeredAfter(task keptnv2.Task) time.Duration {
+	if task.TriggeredAfter == "" {
+		return 0
+	}
+	duration, err := time.ParseDuration(task.TriggeredAfter)
+	if err != nil {
+		log.Errorf("could not parse triggeredAfter property: %s", err.Error())
+		return 0
+	}
+	return duration
+}
 
-	if err := sc.sequenceExecutionRepo.Upsert(sequenceExecution, nil); err != nil {
-		return err
+// getTaskTimeout returns the timeout declared for a task in the shipyard. If no valid timeout has been declared, an empty string is returned
+func getTaskTimeout(taskExtension *models.TaskExtension) string {
+	if taskExtension == nil || taskExtension.Timeout == "" {
+		return ""
 	}
-	if err := sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: sendTaskTimestamp, Event: event}, false); err != nil {
-		return err
+	if _, err := time.ParseDuration(taskExtension.Timeout); err != nil {
+		log.Errorf("could not parse timeout property of task %s: %s", taskExtension.Name, err.Error())
+		return ""
 	}
-	return nil
+	return taskExtension.Timeout
 }
 
 func (sc *shipyardController) sendTaskSequenceTriggeredEvent(eventScope *models.EventScope, taskSequenceName string, completedSequence models.SequenceExecution) error {
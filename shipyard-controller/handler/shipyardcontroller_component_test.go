@@ -100,6 +100,18 @@ spec:
       - name: deployment
       - name: evaluation`
 
+const testShipyardFileWithSingleTask = `apiVersion: spec.keptn.sh/0.2.2
+kind: Shipyard
+metadata:
+  name: test-shipyard
+spec:
+  stages:
+  - name: dev
+    sequences:
+    - name: artifact-delivery
+      tasks:
+      - name: deployment`
+
 const mongoDBVersion = "4.4.9"
 
 func TestMain(m *testing.M) {
@@ -895,6 +907,7 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
 	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Contains(t, eventData.Message, "to receive a correlating .started or .finished event")
 }
 
 func Test_shipyardController_TimeoutSequence_ErrorWhenSendingEvent(t *testing.T) {
@@ -1053,6 +1066,70 @@ func Test_shipyardController_CancelSequence(t *testing.T) {
 	require.Len(t, fakeSequenceAbortedHook.OnSequenceAbortedCalls(), 1)
 }
 
+func Test_shipyardController_RestartCompletedSequence(t *testing.T) {
+	sc, cancel := getTestShipyardController(testShipyardFileWithSingleTask)
+	defer sc.StopDispatchers()
+	defer cancel()
+	projectName := "test-project"
+	defer cleanupCollections(projectName, sc)
+
+	mockDispatcher := sc.eventDispatcher.(*fake.IEventDispatcherMock)
+
+	// run the sequence to completion
+	err := sc.HandleIncomingEvent(getArtifactDeliveryTriggeredEvent("dev", "my-commit-id"), true)
+	require.Nil(t, err)
+	require.Len(t, mockDispatcher.AddCalls(), 1)
+	deploymentTriggeredEvent := mockDispatcher.AddCalls()[0].Event
+	require.Equal(t, keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName), deploymentTriggeredEvent.Event.Type())
+
+	sendFinishedEventAndVerifyTaskSequenceCompletion(
+		t,
+		sc,
+		getDeploymentFinishedEvent("dev", deploymentTriggeredEvent.Event.ID(), "test-source", keptnv2.ResultPass),
+		keptnv2.DeploymentTaskName,
+		"",
+	)
+
+	// the event that triggered the sequence has been removed once the sequence has been completed
+	sequenceTriggeredEvents, _ := sc.eventRepo.GetEvents(projectName, common.EventFilter{
+		Type:         keptnv2.GetTriggeredEventType("dev.artifact-delivery"),
+		KeptnContext: common.Stringp("test-context"),
+	}, common.TriggeredEvent)
+	require.Empty(t, sequenceTriggeredEvents)
+	addCallsBeforeRestart := len(mockDispatcher.AddCalls())
+
+	err = sc.ControlSequence(models.SequenceControl{
+		SequenceControl: apimodels.SequenceControl{
+			State:        models.RestartSequence,
+			Project:      projectName,
+			Stage:        "dev",
+			KeptnContext: "test-context",
+		},
+		FromTask: keptnv2.DeploymentTaskName,
+	})
+	require.Nil(t, err)
+
+	// the event that triggered the sequence has been restored
+	sequenceTriggeredEvents, err = sc.eventRepo.GetEvents(projectName, common.EventFilter{
+		Type:         keptnv2.GetTriggeredEventType("dev.artifact-delivery"),
+		KeptnContext: common.Stringp("test-context"),
+	}, common.TriggeredEvent)
+	require.Nil(t, err)
+	require.Len(t, sequenceTriggeredEvents, 1)
+	require.Equal(t, "artifact-delivery-triggered-id", sequenceTriggeredEvents[0].ID)
+	require.Equal(t, "my-commit-id", sequenceTriggeredEvents[0].GitCommitID)
+
+	// the deployment task has been triggered again, with the properties of the original event
+	require.Len(t, mockDispatcher.AddCalls(), addCallsBeforeRestart+1)
+	restartedDeploymentEvent := mockDispatcher.AddCalls()[addCallsBeforeRestart].Event
+	require.Equal(t, keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName), restartedDeploymentEvent.Event.Type())
+	deploymentData := &keptnv2.DeploymentTriggeredEventData{}
+	err = restartedDeploymentEvent.Event.DataAs(deploymentData)
+	require.Nil(t, err)
+	require.Equal(t, "carts", deploymentData.Service)
+	require.Equal(t, "carts", deploymentData.ConfigurationChange.Values["image"])
+}
+
 func Test_shipyardController_CancelQueuedSequence(t *testing.T) {
 	sc, cancel := getTestShipyardController("")
 	defer cancel()
@@ -1278,7 +1355,13 @@ func getTestShipyardController(shipyardContent string) (*shipyardController, con
 		eventRepo,
 		sequenceQueueRepo,
 		sequenceExecutionRepo,
+		&fake.IFreezeWindowManagerMock{
+			GetFreezeWindowsFunc: func(params models.GetFreezeWindowParams) ([]models.FreezeWindow, error) {
+				return nil, nil
+			},
+		},
 		time.Second,
+		5*time.Minute,
 		clock.New(),
 		common.SDModeRW,
 	)
@@ -1305,6 +1388,9 @@ func getTestShipyardController(shipyardContent string) (*shipyardController, con
 			GetLatestCommitIDFunc: func(projectName string, stageName string) (string, error) {
 				return "latest-commit-id", nil
 			},
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return models.DecodeShipyardExtension(shipyardContent)
+			},
 		},
 		sequenceExecutionRepo: sequenceExecutionRepo,
 	}
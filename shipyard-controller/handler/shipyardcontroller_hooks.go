@@ -54,6 +54,14 @@ func (sc *shipyardController) AddSequenceAbortedHook(hook sequencehooks.ISequenc
 	sc.sequenceAbortedHooks = append(sc.sequenceAbortedHooks, hook)
 }
 
+func (sc *shipyardController) AddSequenceSupersededHook(hook sequencehooks.ISequenceSupersededHook) {
+	sc.sequenceSupersededHooks = append(sc.sequenceSupersededHooks, hook)
+}
+
+func (sc *shipyardController) AddSequenceRestartedHook(hook sequencehooks.ISequenceRestartedHook) {
+	sc.sequenceRestartedHooks = append(sc.sequenceRestartedHooks, hook)
+}
+
 func (sc *shipyardController) onSequenceTriggered(event models.KeptnContextExtendedCE) {
 	for _, hook := range sc.sequenceTriggeredHooks {
 		hook.OnSequenceTriggered(event)
@@ -66,9 +74,9 @@ func (sc *shipyardController) onSequenceStarted(event models.KeptnContextExtende
 	}
 }
 
-func (sc *shipyardController) onSequenceWaiting(event models.KeptnContextExtendedCE) {
+func (sc *shipyardController) onSequenceWaiting(event models.KeptnContextExtendedCE, reason string) {
 	for _, hook := range sc.sequenceWaitingHooks {
-		hook.OnSequenceWaiting(event)
+		hook.OnSequenceWaiting(event, reason)
 	}
 }
 
@@ -108,6 +116,18 @@ func (sc *shipyardController) onSequenceAborted(eventScope scmodels.EventScope)
 	}
 }
 
+func (sc *shipyardController) onSequenceSuperseded(superseded scmodels.SequenceExecution, supersededBy scmodels.EventScope) {
+	for _, hook := range sc.sequenceSupersededHooks {
+		hook.OnSequenceSuperseded(superseded, supersededBy)
+	}
+}
+
+func (sc *shipyardController) onSequenceRestarted(event models.KeptnContextExtendedCE, restart scmodels.SequenceControl) {
+	for _, hook := range sc.sequenceRestartedHooks {
+		hook.OnSequenceRestarted(event, restart)
+	}
+}
+
 func (sc *shipyardController) onSequenceTimeout(event models.KeptnContextExtendedCE) {
 	for _, hook := range sc.sequenceTimoutHooks {
 		hook.OnSequenceTimeout(event)
@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func Test_GetAllTriggeredEvents(t *testing.T) {
@@ -205,3 +207,608 @@ func TestHandleTaskEvent(t *testing.T) {
 		})
 	}
 }
+
+const testShipyardWithTaskConditions = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "test"
+              when: "deployment.deploymentstrategy != blue_green_service"
+            - name: "evaluation"
+            - name: "rollback"
+              when: "evaluation.result == fail"
+            - name: "release"
+              when: "evaluation.result != fail"`
+
+func TestGetNextTasksToExecute(t *testing.T) {
+	blueGreenDeployment := models.TaskExecutionResult{
+		Name:       "deployment",
+		Result:     keptnv2.ResultPass,
+		Status:     keptnv2.StatusSucceeded,
+		Properties: map[string]interface{}{"deployment": map[string]interface{}{"deploymentstrategy": "blue_green_service"}},
+	}
+	skippedTest := models.TaskExecutionResult{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true}
+	passedEvaluation := models.TaskExecutionResult{Name: "evaluation", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}
+	failedEvaluation := models.TaskExecutionResult{Name: "evaluation", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded}
+	passedRollback := models.TaskExecutionResult{Name: "rollback", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}
+
+	tests := []struct {
+		name              string
+		previousTasks     []models.TaskExecutionResult
+		wantTask          string
+		wantPreviousTasks []models.TaskExecutionResult
+		wantUpsert        bool
+	}{
+		{
+			name:              "first task without condition",
+			previousTasks:     []models.TaskExecutionResult{},
+			wantTask:          "deployment",
+			wantPreviousTasks: []models.TaskExecutionResult{},
+		},
+		{
+			name:              "skip test for blue green deployment",
+			previousTasks:     []models.TaskExecutionResult{blueGreenDeployment},
+			wantTask:          "evaluation",
+			wantPreviousTasks: []models.TaskExecutionResult{blueGreenDeployment, skippedTest},
+		},
+		{
+			name:              "rollback after failed evaluation",
+			previousTasks:     []models.TaskExecutionResult{blueGreenDeployment, skippedTest, failedEvaluation},
+			wantTask:          "rollback",
+			wantPreviousTasks: []models.TaskExecutionResult{blueGreenDeployment, skippedTest, failedEvaluation},
+		},
+		{
+			name:              "skip rollback after passed evaluation",
+			previousTasks:     []models.TaskExecutionResult{blueGreenDeployment, skippedTest, passedEvaluation},
+			wantTask:          "release",
+			wantPreviousTasks: []models.TaskExecutionResult{blueGreenDeployment, skippedTest, passedEvaluation, {Name: "rollback", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true}},
+		},
+		{
+			name:              "skip remaining tasks after rollback",
+			previousTasks:     []models.TaskExecutionResult{blueGreenDeployment, skippedTest, failedEvaluation, passedRollback},
+			wantTask:          "",
+			wantPreviousTasks: []models.TaskExecutionResult{blueGreenDeployment, skippedTest, failedEvaluation, passedRollback, {Name: "release", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true}},
+			wantUpsert:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequenceExecutionRepo := &db_mock.SequenceExecutionRepoMock{
+				UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+					return nil
+				},
+			}
+			sc := &shipyardController{
+				sequenceExecutionRepo: sequenceExecutionRepo,
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return models.DecodeShipyardExtension(testShipyardWithTaskConditions)
+					},
+				},
+			}
+			sequenceExecution := &models.SequenceExecution{
+				Sequence: keptnv2.Sequence{
+					Name:  "delivery",
+					Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "evaluation"}, {Name: "rollback"}, {Name: "release"}},
+				},
+				Status: models.SequenceExecutionStatus{PreviousTasks: tt.previousTasks},
+				Scope:  models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "production", Service: "carts"}},
+			}
+
+			tasks, err := sc.getNextTasksToExecute(sequenceExecution)
+			require.Nil(t, err)
+			if tt.wantTask == "" {
+				require.Empty(t, tasks)
+			} else {
+				require.Len(t, tasks, 1)
+				require.Equal(t, tt.wantTask, tasks[0].task.Name)
+			}
+			require.Equal(t, tt.wantPreviousTasks, sequenceExecution.Status.PreviousTasks)
+			if tt.wantUpsert {
+				require.Len(t, sequenceExecutionRepo.UpsertCalls(), 1)
+			} else {
+				require.Empty(t, sequenceExecutionRepo.UpsertCalls())
+			}
+		})
+	}
+}
+
+const testShipyardWithParallelGroup = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "hardening"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "test"
+              group: "tests"
+              properties:
+                teststrategy: "performance"
+            - name: "securityscan"
+              group: "tests"
+            - name: "test"
+              group: "tests"
+              when: "deployment.deploymentstrategy == direct"
+              properties:
+                teststrategy: "functional"
+            - name: "evaluation"`
+
+func TestProceedTaskSequence_ParallelGroup(t *testing.T) {
+	insertedEvents := []apimodels.KeptnContextExtendedCE{}
+	var upsertedSequenceExecution models.SequenceExecution
+	dispatchedEvents := []models.DispatcherEvent{}
+
+	sc := &shipyardController{
+		eventRepo: &db_mock.EventRepoMock{
+			GetTaskSequenceTriggeredEventFunc: func(eventScope models.EventScope, taskSequenceName string) (*apimodels.KeptnContextExtendedCE, error) {
+				return &apimodels.KeptnContextExtendedCE{}, nil
+			},
+			InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+				insertedEvents = append(insertedEvents, event)
+				return nil
+			},
+		},
+		sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+			UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+				upsertedSequenceExecution = item
+				return nil
+			},
+		},
+		eventDispatcher: &fake.IEventDispatcherMock{
+			AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+				dispatchedEvents = append(dispatchedEvents, event)
+				return nil
+			},
+		},
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return models.DecodeShipyardExtension(testShipyardWithParallelGroup)
+			},
+		},
+	}
+
+	sequenceExecution := models.SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "deployment"},
+				{Name: "test", Properties: map[string]interface{}{"teststrategy": "performance"}},
+				{Name: "securityscan"},
+				{Name: "test", Properties: map[string]interface{}{"teststrategy": "functional"}},
+				{Name: "evaluation"},
+			},
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceStartedState,
+			PreviousTasks: []models.TaskExecutionResult{
+				{
+					Name:       "deployment",
+					Result:     keptnv2.ResultPass,
+					Status:     keptnv2.StatusSucceeded,
+					Properties: map[string]interface{}{"deployment": map[string]interface{}{"deploymentstrategy": "blue_green_service"}},
+				},
+			},
+		},
+		Scope: models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "hardening", Service: "carts"}, KeptnContext: "my-context"},
+	}
+
+	err := sc.proceedTaskSequence(sequenceExecution.Scope, sequenceExecution)
+	require.Nil(t, err)
+
+	// the tests and the security scan are triggered at the same time, the functional test is skipped because of its condition
+	require.Len(t, insertedEvents, 2)
+	require.Len(t, dispatchedEvents, 2)
+	require.Equal(t, keptnv2.GetTriggeredEventType("test"), *insertedEvents[0].Type)
+	require.Equal(t, keptnv2.GetTriggeredEventType("securityscan"), *insertedEvents[1].Type)
+
+	testData := map[string]interface{}{}
+	require.Nil(t, keptnv2.Decode(insertedEvents[0].Data, &testData))
+	require.Equal(t, map[string]interface{}{"teststrategy": "performance"}, testData["test"])
+
+	currentTask := upsertedSequenceExecution.Status.CurrentTask
+	require.Equal(t, "test", currentTask.Name)
+	require.Equal(t, insertedEvents[0].ID, currentTask.TriggeredID)
+	require.Equal(t, []models.ParallelTask{
+		{Name: "test", TriggeredID: insertedEvents[0].ID},
+		{Name: "securityscan", TriggeredID: insertedEvents[1].ID},
+		{Name: "test", Skipped: true},
+	}, currentTask.ParallelTasks)
+	require.True(t, currentTask.HasTriggeredID(insertedEvents[1].ID))
+}
+
+const testShipyardWithRetryPolicy = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "test"
+              timeout: "30m"
+              retries: 2
+              backoff: "1m"`
+
+func TestRetryTask(t *testing.T) {
+	failedTestEvents := []models.TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("test"), TriggeredID: "test-id"},
+		{EventType: keptnv2.GetFinishedEventType("test"), TriggeredID: "test-id", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+	}
+	previousAttempt := models.TaskAttempt{TriggeredID: "first-test-id", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded}
+
+	tests := []struct {
+		name             string
+		currentTask      models.TaskExecutionState
+		timedOut         bool
+		wantRetried      bool
+		wantAttempts     []models.TaskAttempt
+		wantBackoff      time.Duration
+		wantDeletedEvent string
+	}{
+		{
+			name:             "failed task is retried",
+			currentTask:      models.TaskExecutionState{Name: "test", TriggeredID: "test-id", Events: failedTestEvents},
+			wantRetried:      true,
+			wantAttempts:     []models.TaskAttempt{{TriggeredID: "test-id", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded}},
+			wantBackoff:      time.Minute,
+			wantDeletedEvent: "test-id",
+		},
+		{
+			name:             "timed out task is retried with increased backoff",
+			currentTask:      models.TaskExecutionState{Name: "test", TriggeredID: "test-id", Attempts: []models.TaskAttempt{previousAttempt}},
+			timedOut:         true,
+			wantRetried:      true,
+			wantAttempts:     []models.TaskAttempt{previousAttempt, {TriggeredID: "test-id", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored, TimedOut: true}},
+			wantBackoff:      2 * time.Minute,
+			wantDeletedEvent: "test-id",
+		},
+		{
+			name:        "retries exceeded",
+			currentTask: models.TaskExecutionState{Name: "test", TriggeredID: "test-id", Events: failedTestEvents, Attempts: []models.TaskAttempt{previousAttempt, previousAttempt}},
+			wantRetried: false,
+		},
+		{
+			name: "passed task is not retried",
+			currentTask: models.TaskExecutionState{Name: "test", TriggeredID: "test-id", Events: []models.TaskEvent{
+				{EventType: keptnv2.GetStartedEventType("test"), TriggeredID: "test-id"},
+				{EventType: keptnv2.GetFinishedEventType("test"), TriggeredID: "test-id", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			}},
+			wantRetried: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upsertedSequenceExecution *models.SequenceExecution
+			dispatchedEvents := []models.DispatcherEvent{}
+			eventRepo := &db_mock.EventRepoMock{
+				InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+					return nil
+				},
+				DeleteEventFunc: func(project string, eventID string, status common.EventStatus) error {
+					return nil
+				},
+			}
+			sc := &shipyardController{
+				eventRepo: eventRepo,
+				sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+					UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+						upsertedSequenceExecution = &item
+						return nil
+					},
+				},
+				eventDispatcher: &fake.IEventDispatcherMock{
+					AddFunc: func(event models.DispatcherEvent, skipQueue bool) error {
+						dispatchedEvents = append(dispatchedEvents, event)
+						return nil
+					},
+				},
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return models.DecodeShipyardExtension(testShipyardWithRetryPolicy)
+					},
+				},
+			}
+			sequenceExecution := models.SequenceExecution{
+				Sequence: keptnv2.Sequence{
+					Name:  "delivery",
+					Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}},
+				},
+				Status: models.SequenceExecutionStatus{
+					State:         apimodels.SequenceStartedState,
+					PreviousTasks: []models.TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}},
+					CurrentTask:   tt.currentTask,
+				},
+				Scope: models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "dev", Service: "carts"}, KeptnContext: "my-context"},
+			}
+
+			before := time.Now().UTC()
+			retried, err := sc.retryTask(sequenceExecution.Scope, sequenceExecution, tt.timedOut)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantRetried, retried)
+
+			if !tt.wantRetried {
+				require.Nil(t, upsertedSequenceExecution)
+				require.Empty(t, dispatchedEvents)
+				return
+			}
+
+			require.NotNil(t, upsertedSequenceExecution)
+			currentTask := upsertedSequenceExecution.Status.CurrentTask
+			require.Equal(t, tt.wantAttempts, currentTask.Attempts)
+			require.Empty(t, currentTask.Events)
+
+			require.Len(t, dispatchedEvents, 1)
+			require.Equal(t, dispatchedEvents[0].Event.ID(), currentTask.TriggeredID)
+			require.Equal(t, keptnv2.GetTriggeredEventType("test"), dispatchedEvents[0].Event.Type())
+			require.False(t, dispatchedEvents[0].TimeStamp.Before(before.Add(tt.wantBackoff)))
+
+			require.Len(t, eventRepo.DeleteEventCalls(), 1)
+			require.Equal(t, tt.wantDeletedEvent, eventRepo.DeleteEventCalls()[0].EventID)
+		})
+	}
+}
+
+func TestRestartSequence(t *testing.T) {
+	finishedSequenceExecution := models.SequenceExecution{
+		ID: "my-sequence-execution",
+		Sequence: keptnv2.Sequence{
+			Name: "delivery",
+			Tasks: []keptnv2.Task{
+				{Name: "deployment"},
+				{Name: "test"},
+				{Name: "securityscan"},
+				{Name: "test"},
+				{Name: "evaluation"},
+			},
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceFinished,
+			PreviousTasks: []models.TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Properties: map[string]interface{}{"deployment": map[string]interface{}{"deploymentstrategy": "blue_green_service"}}},
+				{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+				{Name: "securityscan", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+				{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true},
+			},
+		},
+		Scope: models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "hardening", Service: "carts"}, KeptnContext: "my-context", TriggeredID: "sequence-triggered-id"},
+	}
+	activeSequenceExecution := finishedSequenceExecution
+	activeSequenceExecution.Status.State = apimodels.SequenceStartedState
+
+	tests := []struct {
+		name               string
+		restart            models.SequenceControl
+		sequenceExecutions []models.SequenceExecution
+		wantErr            error
+		wantPreviousTasks  []string
+	}{
+		{
+			name:               "restart from given task",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}, FromTask: "deployment"},
+			sequenceExecutions: []models.SequenceExecution{finishedSequenceExecution},
+			wantPreviousTasks:  []string{},
+		},
+		{
+			name:               "restart from the beginning of the parallel group containing the failed task",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}},
+			sequenceExecutions: []models.SequenceExecution{finishedSequenceExecution},
+			wantPreviousTasks:  []string{"deployment"},
+		},
+		{
+			name:               "stage is missing",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", KeptnContext: "my-context"}},
+			sequenceExecutions: []models.SequenceExecution{finishedSequenceExecution},
+			wantErr:            ErrSequenceNotRestartable,
+		},
+		{
+			name:               "sequence is still active",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}},
+			sequenceExecutions: []models.SequenceExecution{activeSequenceExecution},
+			wantErr:            ErrSequenceNotRestartable,
+		},
+		{
+			name:               "task has not been executed",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}, FromTask: "release"},
+			sequenceExecutions: []models.SequenceExecution{finishedSequenceExecution},
+			wantErr:            ErrSequenceNotRestartable,
+		},
+		{
+			name:               "sequence not found",
+			restart:            models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}},
+			sequenceExecutions: []models.SequenceExecution{},
+			wantErr:            ErrSequenceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upsertedSequenceExecution *models.SequenceExecution
+			queueItems := []models.QueueItem{}
+			restartedHook := &fakehooks.ISequenceRestartedHookMock{
+				OnSequenceRestartedFunc: func(event apimodels.KeptnContextExtendedCE, restart models.SequenceControl) {},
+			}
+			sc := &shipyardController{
+				eventRepo: &db_mock.EventRepoMock{
+					GetTaskSequenceTriggeredEventFunc: func(eventScope models.EventScope, taskSequenceName string) (*apimodels.KeptnContextExtendedCE, error) {
+						return &apimodels.KeptnContextExtendedCE{ID: "sequence-triggered-id"}, nil
+					},
+				},
+				sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+					GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+						return tt.sequenceExecutions, nil
+					},
+					UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+						upsertedSequenceExecution = &item
+						return nil
+					},
+				},
+				sequenceDispatcher: &fake.ISequenceDispatcherMock{
+					AddFunc: func(queueItem models.QueueItem) error {
+						queueItems = append(queueItems, queueItem)
+						return nil
+					},
+				},
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return models.DecodeShipyardExtension(testShipyardWithParallelGroup)
+					},
+				},
+			}
+
+			sc.AddSequenceRestartedHook(restartedHook)
+
+			err := sc.ControlSequence(tt.restart)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, upsertedSequenceExecution)
+				require.Empty(t, queueItems)
+				require.Empty(t, restartedHook.OnSequenceRestartedCalls())
+				return
+			}
+			require.Nil(t, err)
+
+			// the hook receives the task from which the sequence has actually been restarted
+			require.Len(t, restartedHook.OnSequenceRestartedCalls(), 1)
+			require.Equal(t, upsertedSequenceExecution.Sequence.Tasks[len(tt.wantPreviousTasks)].Name, restartedHook.OnSequenceRestartedCalls()[0].Restart.FromTask)
+
+			require.NotNil(t, upsertedSequenceExecution)
+			require.Equal(t, apimodels.SequenceTriggeredState, upsertedSequenceExecution.Status.State)
+			require.Empty(t, upsertedSequenceExecution.Status.CurrentTask.TriggeredID)
+			previousTasks := []string{}
+			for _, previousTask := range upsertedSequenceExecution.Status.PreviousTasks {
+				previousTasks = append(previousTasks, previousTask.Name)
+			}
+			require.Equal(t, tt.wantPreviousTasks, previousTasks)
+
+			// the results of the tasks before the restarted task are passed on to the restarted tasks
+			if len(tt.wantPreviousTasks) > 0 {
+				eventData := upsertedSequenceExecution.GetNextTriggeredEventData()
+				require.Equal(t, map[string]interface{}{"deploymentstrategy": "blue_green_service"}, eventData["deployment"])
+			}
+
+			require.Len(t, queueItems, 1)
+			require.Equal(t, "sequence-triggered-id", queueItems[0].EventID)
+			require.Equal(t, "my-context", queueItems[0].Scope.KeptnContext)
+			require.Equal(t, upsertedSequenceExecution.TriggeredAt, queueItems[0].Timestamp)
+		})
+	}
+}
+
+func TestRestartSequence_RestoresTriggeredEvent(t *testing.T) {
+	triggeredAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	finishedSequenceExecution := models.SequenceExecution{
+		ID: "my-sequence-execution",
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}},
+		},
+		Status: models.SequenceExecutionStatus{
+			State: apimodels.SequenceFinished,
+			PreviousTasks: []models.TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+			},
+		},
+		Scope:           models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "hardening", Service: "carts"}, KeptnContext: "my-context", TriggeredID: "sequence-triggered-id", GitCommitID: "my-commit-id"},
+		InputProperties: map[string]interface{}{"configurationChange": map[string]interface{}{"values": map[string]interface{}{"image": "carts:0.1"}}},
+		TriggeredAt:     triggeredAt,
+	}
+
+	var insertedEvents []apimodels.KeptnContextExtendedCE
+	sc := &shipyardController{
+		eventRepo: &db_mock.EventRepoMock{
+			GetTaskSequenceTriggeredEventFunc: func(eventScope models.EventScope, taskSequenceName string) (*apimodels.KeptnContextExtendedCE, error) {
+				// the events of a completed sequence have been deleted
+				return nil, nil
+			},
+			InsertEventFunc: func(project string, event apimodels.KeptnContextExtendedCE, status common.EventStatus) error {
+				require.Equal(t, common.TriggeredEvent, status)
+				insertedEvents = append(insertedEvents, event)
+				return nil
+			},
+		},
+		sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				return []models.SequenceExecution{finishedSequenceExecution}, nil
+			},
+			UpsertFunc: func(item models.SequenceExecution, options *models.SequenceExecutionUpsertOptions) error {
+				return nil
+			},
+		},
+		sequenceDispatcher: &fake.ISequenceDispatcherMock{
+			AddFunc: func(queueItem models.QueueItem) error {
+				require.Len(t, insertedEvents, 1)
+				require.Equal(t, insertedEvents[0].ID, queueItem.EventID)
+				return nil
+			},
+		},
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return models.DecodeShipyardExtension(testShipyardWithParallelGroup)
+			},
+		},
+	}
+
+	err := sc.ControlSequence(models.SequenceControl{SequenceControl: apimodels.SequenceControl{State: models.RestartSequence, Project: "sockshop", Stage: "hardening", KeptnContext: "my-context"}})
+	require.Nil(t, err)
+
+	require.Len(t, insertedEvents, 1)
+	restoredEvent := insertedEvents[0]
+	require.Equal(t, "sequence-triggered-id", restoredEvent.ID)
+	require.Equal(t, "my-context", restoredEvent.Shkeptncontext)
+	require.Equal(t, keptnv2.GetTriggeredEventType("hardening.delivery"), *restoredEvent.Type)
+	require.Equal(t, "my-commit-id", restoredEvent.GitCommitID)
+	require.True(t, triggeredAt.Equal(restoredEvent.Time))
+
+	eventData := &keptnv2.DeploymentTriggeredEventData{}
+	require.Nil(t, keptnv2.Decode(restoredEvent.Data, eventData))
+	require.Equal(t, "sockshop", eventData.Project)
+	require.Equal(t, "hardening", eventData.Stage)
+	require.Equal(t, "carts", eventData.Service)
+	require.Equal(t, "carts:0.1", eventData.ConfigurationChange.Values["image"])
+}
+
+func TestSupersedeQueuedSequences(t *testing.T) {
+	scope := models.EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "hardening", Service: "carts"}, KeptnContext: "new-context"}
+	queuedSequenceExecution := models.SequenceExecution{
+		Sequence: keptnv2.Sequence{Name: "delivery"},
+		Status:   models.SequenceExecutionStatus{State: apimodels.SequenceTriggeredState},
+		Scope:    models.EventScope{EventData: scope.EventData, KeptnContext: "queued-context"},
+	}
+	newSequenceExecution := queuedSequenceExecution
+	newSequenceExecution.Scope = scope
+
+	supersededHook := &fakehooks.ISequenceSupersededHookMock{
+		OnSequenceSupersededFunc: func(superseded models.SequenceExecution, supersededBy models.EventScope) {},
+	}
+	sc := &shipyardController{
+		sequenceExecutionRepo: &db_mock.SequenceExecutionRepoMock{
+			GetFunc: func(filter models.SequenceExecutionFilter) ([]models.SequenceExecution, error) {
+				if filter.Name == "delivery" {
+					return []models.SequenceExecution{queuedSequenceExecution, newSequenceExecution}, nil
+				}
+				// the superseded sequence has already been removed when it is aborted
+				return nil, nil
+			},
+		},
+	}
+	sc.AddSequenceSupersededHook(supersededHook)
+
+	sc.supersedeQueuedSequences(scope, "delivery")
+
+	require.Len(t, supersededHook.OnSequenceSupersededCalls(), 1)
+	require.Equal(t, "queued-context", supersededHook.OnSequenceSupersededCalls()[0].Superseded.Scope.KeptnContext)
+	require.Equal(t, "new-context", supersededHook.OnSequenceSupersededCalls()[0].SupersededBy.KeptnContext)
+}
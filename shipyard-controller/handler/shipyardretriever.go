@@ -5,8 +5,8 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v3"
 )
 
 // IShipyardRetriever godoc
@@ -15,6 +15,7 @@ type IShipyardRetriever interface {
 	GetShipyard(projectName string) (*keptnv2.Shipyard, error)
 	GetCachedShipyard(projectName string) (*keptnv2.Shipyard, error)
 	GetLatestCommitID(projectName, stageName string) (string, error)
+	GetCachedShipyardExtension(projectName string) (*models.ShipyardExtension, error)
 }
 
 type ShipyardRetriever struct {
@@ -40,13 +41,9 @@ func (sr *ShipyardRetriever) GetShipyard(projectName string) (*keptnv2.Shipyard,
 		return nil, fmt.Errorf("could not unmarshal shipyard.yaml of project %s: %w", projectName, err)
 	}
 
-	// update the shipyard content of the project
-	shipyardContent, err := yaml.Marshal(shipyard)
-	if err != nil {
-		// log the error but continue
-		log.Errorf("could not encode shipyard file of project %s: %v", projectName, err)
-	}
-	if err := sr.projectRepo.UpdateShipyard(projectName, string(shipyardContent)); err != nil {
+	// update the shipyard content of the project - the original content is stored to retain properties that are not part of the
+	// keptn shipyard spec, but are evaluated by the shipyard controller (see models.ShipyardExtension)
+	if err := sr.projectRepo.UpdateShipyard(projectName, resource.ResourceContent); err != nil {
 		// log the error but continue
 		log.Errorf("could not update shipyard content of project %s: %v", projectName, err)
 	}
@@ -75,6 +72,20 @@ func (sr *ShipyardRetriever) GetCachedShipyard(projectName string) (*keptnv2.Shi
 	return shipyard, nil
 }
 
+// GetCachedShipyardExtension returns the shipyard controller specific properties of the shipyard that is stored for the project in the materialized view
+func (sr *ShipyardRetriever) GetCachedShipyardExtension(projectName string) (*models.ShipyardExtension, error) {
+	project, err := sr.projectRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	extension, err := models.DecodeShipyardExtension(project.Shipyard)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode shipyard of project %s: %w", projectName, err)
+	}
+	return extension, nil
+}
+
 func (sr *ShipyardRetriever) GetLatestCommitID(projectName, stageName string) (string, error) {
 	stageMetadata, err := sr.configurationStore.GetStageResource(projectName, stageName, "metadata.yaml")
 	if err != nil {
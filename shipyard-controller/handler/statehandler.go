@@ -4,27 +4,33 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/db"
-	_ "github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
 )
 
 type IStateHandler interface {
 	GetSequenceState(context *gin.Context)
 	ControlSequenceState(context *gin.Context)
+	GetSequenceHistory(context *gin.Context)
 }
 
 type StateHandler struct {
-	StateRepo          db.SequenceStateRepo
-	shipyardController IShipyardController
+	StateRepo           db.SequenceStateRepo
+	SequenceHistoryRepo db.SequenceHistoryRepo
+	shipyardController  IShipyardController
 }
 
-func NewStateHandler(stateRepo db.SequenceStateRepo, shipyardController IShipyardController) *StateHandler {
+func NewStateHandler(stateRepo db.SequenceStateRepo, sequenceHistoryRepo db.SequenceHistoryRepo, shipyardController IShipyardController) *StateHandler {
 	return &StateHandler{
-		StateRepo:          stateRepo,
-		shipyardController: shipyardController,
+		StateRepo:           stateRepo,
+		SequenceHistoryRepo: sequenceHistoryRepo,
+		shipyardController:  shipyardController,
 	}
 }
 
@@ -43,7 +49,7 @@ func NewStateHandler(stateRepo db.SequenceStateRepo, shipyardController IShipyar
 // @Param        pageSize      query     int                       false  "The number of items to return"
 // @Param        nextPageKey   query     string                    false  "Pointer to the next set of items"
 // @Param        keptnContext  query     string                    false  "Comma separated list of keptnContext IDs"
-// @Success      200           {object}  apimodels.SequenceStates  "ok"
+// @Success      200           {object}  models.SequenceStates     "ok"
 // @Failure      400           {object}  models.Error              "Invalid payload"
 // @Failure      500           {object}  models.Error              "Internal error"
 // @Router       /sequence/{project} [get]
@@ -68,15 +74,16 @@ func (sh *StateHandler) GetSequenceState(c *gin.Context) {
 }
 
 // ControlSequenceState godoc
-// @Summary      Pause/Resume/Abort a task sequence
-// @Description  Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence
+// @Summary      Pause/Resume/Abort/Restart a task sequence
+// @Description  Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence.
+// @Description  A finished sequence can be restarted in a specific stage, starting with the task given in fromTask, or with the task that has failed or timed out
 // @Tags         Sequence
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        project          path      string                             true  "The project name"
 // @Param        keptnContext     path      string                             true  "The keptnContext ID of the sequence"
-// @Param        sequenceControl  body      apimodels.SequenceControlCommand   true  "Sequence Control Command"
+// @Param        sequenceControl  body      models.SequenceControlCommand      true  "Sequence Control Command"
 // @Success      200              {object}  apimodels.SequenceControlResponse  "ok"
 // @Failure      400              {object}  models.Error                       "Invalid payload"
 // @Failure      404              {object}  models.Error                       "Not found"
@@ -86,25 +93,89 @@ func (sh *StateHandler) ControlSequenceState(c *gin.Context) {
 	keptnContext := c.Param("keptnContext")
 	project := c.Param("project")
 
-	params := &apimodels.SequenceControlCommand{}
+	params := &models.SequenceControlCommand{}
 	if err := c.ShouldBindJSON(params); err != nil {
 		SetBadRequestErrorResponse(c, fmt.Sprintf(InvalidRequestFormatMsg, err.Error()))
 		return
 	}
 
-	err := sh.shipyardController.ControlSequence(apimodels.SequenceControl{
-		State:        params.State,
-		KeptnContext: keptnContext,
-		Stage:        params.Stage,
-		Project:      project,
+	user := getRequestUser(c)
+	err := sh.shipyardController.ControlSequence(models.SequenceControl{
+		SequenceControl: apimodels.SequenceControl{
+			State:        params.State,
+			KeptnContext: keptnContext,
+			Stage:        params.Stage,
+			Project:      project,
+		},
+		FromTask: params.FromTask,
+		User:     user,
+		Reason:   params.Reason,
 	})
 	if err != nil {
-		if errors.Is(err, ErrSequenceNotFound) {
+		switch {
+		case errors.Is(err, ErrSequenceNotFound):
 			SetNotFoundErrorResponse(c, fmt.Sprintf(UnableFindSequenceMsg, err.Error()))
+		case errors.Is(err, ErrSequenceNotRestartable):
+			SetBadRequestErrorResponse(c, fmt.Sprintf(UnableControleSequenceMsg, err.Error()))
+		default:
+			SetInternalServerErrorResponse(c, fmt.Sprintf(UnableControleSequenceMsg, err.Error()))
 		}
-		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableControleSequenceMsg, err.Error()))
 		return
 	}
 
+	// restarts are recorded by the shipyard controller, since it determines the task from which the sequence is restarted
+	if params.State != models.RestartSequence {
+		transition := models.SequenceStateTransition{
+			Project:      project,
+			Stage:        params.Stage,
+			KeptnContext: keptnContext,
+			State:        getSequenceControlTransitionState(params.State),
+			User:         user,
+			Source:       sequencehooks.SequenceControlSource,
+			Reason:       params.Reason,
+			Time:         time.Now().UTC(),
+		}
+		if err := sh.SequenceHistoryRepo.AppendTransition(transition); err != nil {
+			log.WithError(err).Errorf("could not append state transition to the history of sequence with keptnContext %s", keptnContext)
+		}
+	}
+
 	c.JSON(http.StatusOK, apimodels.SequenceControlResponse{})
 }
+
+// GetSequenceHistory godoc
+// @Summary      Get the history of a task sequence
+// @Description  Get the state transitions of a task sequence execution in chronological order, including who or what caused them and why
+// @Tags         Sequence
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        project       path      string                  true  "The project name"
+// @Param        keptnContext  path      string                  true  "The keptnContext ID of the sequence"
+// @Success      200           {object}  models.SequenceHistory  "ok"
+// @Failure      500           {object}  models.Error            "Internal error"
+// @Router       /sequence/{project}/{keptnContext}/history [get]
+func (sh *StateHandler) GetSequenceHistory(c *gin.Context) {
+	keptnContext := c.Param("keptnContext")
+	project := c.Param("project")
+
+	transitions, err := sh.SequenceHistoryRepo.GetTransitions(project, keptnContext)
+	if err != nil {
+		SetInternalServerErrorResponse(c, fmt.Sprintf(UnableQuerySequenceHistoryMsg, err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SequenceHistory{Transitions: transitions})
+}
+
+func getSequenceControlTransitionState(state apimodels.SequenceControlState) string {
+	switch state {
+	case apimodels.AbortSequence:
+		return apimodels.SequenceAborted
+	case apimodels.PauseSequence:
+		return apimodels.SequencePaused
+	case apimodels.ResumeSequence:
+		return models.SequenceResumedState
+	}
+	return string(state)
+}
@@ -1,12 +1,17 @@
 package handler_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	scmodels "github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -28,22 +33,24 @@ func TestStateHandler_GetState(t *testing.T) {
 			name: "state repo returns states",
 			fields: fields{
 				StateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
 						require.Equal(t, "sequenceName", filter.Name)
 						require.Equal(t, "sequenceState", filter.State)
 						require.Equal(t, "2021-05-10T09:51:00.000Z", filter.FromTime)
 						require.Equal(t, "2021-05-10T09:50:00.000Z", filter.BeforeTime)
 						require.Equal(t, "my-context", filter.KeptnContext)
-						return &models.SequenceStates{
-							States: []models.SequenceState{
+						return &scmodels.SequenceStates{
+							States: []scmodels.SequenceState{
 								{
-									Name:           "delivery",
-									Service:        "my-service",
-									Project:        "my-project",
-									Time:           timeutils.GetKeptnTimeStamp(time.Now()),
-									Shkeptncontext: "my-context",
-									State:          "triggered",
-									Stages:         nil,
+									SequenceState: models.SequenceState{
+										Name:           "delivery",
+										Service:        "my-service",
+										Project:        "my-project",
+										Time:           timeutils.GetKeptnTimeStamp(time.Now()),
+										Shkeptncontext: "my-context",
+										State:          "triggered",
+										Stages:         nil,
+									},
 								},
 							},
 							NextPageKey: 0,
@@ -60,7 +67,7 @@ func TestStateHandler_GetState(t *testing.T) {
 			name: "state repo returns error",
 			fields: fields{
 				StateRepo: &db_mock.SequenceStateRepoMock{
-					FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					FindSequenceStatesFunc: func(filter models.StateFilter) (*scmodels.SequenceStates, error) {
 						return nil, errors.New("oops")
 					},
 				},
@@ -71,7 +78,7 @@ func TestStateHandler_GetState(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sh := handler.NewStateHandler(tt.fields.StateRepo, nil)
+			sh := handler.NewStateHandler(tt.fields.StateRepo, nil, nil)
 
 			router := gin.Default()
 			router.GET("/state/:project", func(c *gin.Context) {
@@ -87,6 +94,181 @@ func TestStateHandler_GetState(t *testing.T) {
 	}
 }
 
+func TestStateHandler_ControlSequenceState(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		payload        string
+		controlErr     error
+		wantStatus     int
+		wantTransition *scmodels.SequenceStateTransition
+		wantControl    *scmodels.SequenceControl
+	}{
+		{
+			name:       "pause sequence requested with API token",
+			headers:    map[string]string{"x-token": "my-token"},
+			payload:    `{"state": "pause", "stage": "dev", "reason": "waiting for the database migration"}`,
+			wantStatus: http.StatusOK,
+			wantTransition: &scmodels.SequenceStateTransition{
+				Project:      "my-project",
+				Stage:        "dev",
+				KeptnContext: "my-context",
+				State:        models.SequencePaused,
+				User:         handler.APITokenUser,
+				Source:       "api",
+				Reason:       "waiting for the database migration",
+			},
+		},
+		{
+			name:       "resume sequence requested with API token",
+			headers:    map[string]string{"x-token": "my-token"},
+			payload:    `{"state": "resume"}`,
+			wantStatus: http.StatusOK,
+			wantTransition: &scmodels.SequenceStateTransition{
+				Project:      "my-project",
+				KeptnContext: "my-context",
+				State:        scmodels.SequenceResumedState,
+				User:         handler.APITokenUser,
+				Source:       "api",
+			},
+		},
+		{
+			name:       "restart sequence from task",
+			headers:    map[string]string{"x-token": "my-token"},
+			payload:    `{"state": "restart", "stage": "dev", "fromTask": "deployment", "reason": "fixed the helm chart"}`,
+			wantStatus: http.StatusOK,
+			// the restart is recorded by the shipyard controller, which receives the user and reason
+			wantControl: &scmodels.SequenceControl{
+				SequenceControl: models.SequenceControl{
+					State:        scmodels.RestartSequence,
+					Stage:        "dev",
+					Project:      "my-project",
+					KeptnContext: "my-context",
+				},
+				FromTask: "deployment",
+				User:     handler.APITokenUser,
+				Reason:   "fixed the helm chart",
+			},
+		},
+		{
+			name:       "sequence cannot be restarted",
+			payload:    `{"state": "restart", "stage": "dev"}`,
+			controlErr: fmt.Errorf("%w: sequence delivery is still active in stage dev", handler.ErrSequenceNotRestartable),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "sequence to restart not found",
+			payload:    `{"state": "restart", "stage": "dev"}`,
+			controlErr: handler.ErrSequenceNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid payload",
+			payload:    `{"stage": "dev"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "sequence could not be aborted",
+			payload:    `{"state": "abort"}`,
+			controlErr: errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := &db_mock.SequenceHistoryRepoMock{
+				AppendTransitionFunc: func(transition scmodels.SequenceStateTransition) error {
+					return nil
+				},
+			}
+			shipyardController := &fake.IShipyardControllerMock{
+				ControlSequenceFunc: func(controlSequence scmodels.SequenceControl) error {
+					return tt.controlErr
+				},
+			}
+			sh := handler.NewStateHandler(nil, historyRepo, shipyardController)
+
+			router := gin.Default()
+			router.POST("/sequence/:project/:keptnContext/control", sh.ControlSequenceState)
+
+			request := httptest.NewRequest(http.MethodPost, "/sequence/my-project/my-context/control", bytes.NewBufferString(tt.payload))
+			for key, value := range tt.headers {
+				request.Header.Set(key, value)
+			}
+			w := performRequest(router, request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantControl != nil {
+				require.Len(t, shipyardController.ControlSequenceCalls(), 1)
+				require.Equal(t, *tt.wantControl, shipyardController.ControlSequenceCalls()[0].ControlSequence)
+			}
+			if tt.wantTransition == nil {
+				require.Empty(t, historyRepo.AppendTransitionCalls())
+				return
+			}
+			require.Len(t, historyRepo.AppendTransitionCalls(), 1)
+			transition := historyRepo.AppendTransitionCalls()[0].Transition
+			require.False(t, transition.Time.IsZero())
+			transition.Time = time.Time{}
+			require.Equal(t, *tt.wantTransition, transition)
+		})
+	}
+}
+
+func TestStateHandler_GetSequenceHistory(t *testing.T) {
+	transitions := []scmodels.SequenceStateTransition{
+		{Project: "my-project", KeptnContext: "my-context", State: models.SequenceTriggeredState, Source: "cli"},
+		{Project: "my-project", KeptnContext: "my-context", State: models.SequenceAborted, User: "jane.doe", Source: "api"},
+	}
+	tests := []struct {
+		name       string
+		repoErr    error
+		wantStatus int
+		wantBody   *scmodels.SequenceHistory
+	}{
+		{
+			name:       "get history",
+			wantStatus: http.StatusOK,
+			wantBody:   &scmodels.SequenceHistory{Transitions: transitions},
+		},
+		{
+			name:       "history repo returns error",
+			repoErr:    errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := &db_mock.SequenceHistoryRepoMock{
+				GetTransitionsFunc: func(project string, keptnContext string) ([]scmodels.SequenceStateTransition, error) {
+					if tt.repoErr != nil {
+						return nil, tt.repoErr
+					}
+					return transitions, nil
+				},
+			}
+			sh := handler.NewStateHandler(nil, historyRepo, nil)
+
+			router := gin.Default()
+			router.GET("/sequence/:project/:keptnContext/history", sh.GetSequenceHistory)
+
+			w := performRequest(router, httptest.NewRequest(http.MethodGet, "/sequence/my-project/my-context/history", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Len(t, historyRepo.GetTransitionsCalls(), 1)
+			require.Equal(t, "my-project", historyRepo.GetTransitionsCalls()[0].Project)
+			require.Equal(t, "my-context", historyRepo.GetTransitionsCalls()[0].KeptnContext)
+
+			if tt.wantBody != nil {
+				history := &scmodels.SequenceHistory{}
+				require.Nil(t, json.Unmarshal(w.Body.Bytes(), history))
+				require.Equal(t, tt.wantBody, history)
+			}
+		})
+	}
+}
+
 func performRequest(r http.Handler, request *http.Request) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, request)
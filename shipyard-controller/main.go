@@ -27,6 +27,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/handler/sequencehooks"
 	_ "github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/keptn/keptn/shipyard-controller/nats"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -49,6 +50,8 @@ import (
 // @BasePath  /v1
 
 const envVarSequenceDispatchIntervalSecDefault = "10s"
+const envVarSequencePriorityAgingIntervalDefault = "5m"
+const envVarSchedulerIntervalDefault = "30s"
 const envVarLogsTTLDefault = "120h" // 5 days
 const envVarUniformTTLDefault = "1m"
 const envVarSequenceWatcherIntervalDefault = "1m"
@@ -103,8 +106,11 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	}
 
 	sequenceExecutionRepo := createSequenceExecutionRepo()
+	scheduleRepo := createScheduleRepo()
+	sequenceHistoryRepo := createSequenceHistoryRepo()
 
 	projectMVRepo := createProjectMVRepo()
+	freezeWindowManager := handler.NewFreezeWindowManager(common.NewGitConfigurationStore(csEndpoint.String()), projectMVRepo)
 	projectManager := handler.NewProjectManager(
 		common.NewGitConfigurationStore(csEndpoint.String()),
 		createSecretStore(kubeAPI),
@@ -112,7 +118,9 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 		sequenceExecutionRepo,
 		createEventsRepo(),
 		createSequenceQueueRepo(),
-		createEventQueueRepo())
+		createEventQueueRepo(),
+		scheduleRepo,
+		sequenceHistoryRepo)
 
 	repositoryProvisioner := handler.NewRepositoryProvisioner(env.AutomaticProvisioningURL, &http.Client{})
 
@@ -135,7 +143,9 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 		createEventsRepo(),
 		createSequenceQueueRepo(),
 		sequenceExecutionRepo,
+		freezeWindowManager,
 		getDurationFromEnvVar(env.SequenceDispatchIntervalSec, envVarSequenceDispatchIntervalSecDefault),
+		getDurationFromEnvVar(env.SequencePriorityAgingInterval, envVarSequencePriorityAgingIntervalDefault),
 		clock.New(),
 		common.SDModeRW,
 	)
@@ -180,6 +190,14 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
 
+	freezeWindowHandler := handler.NewFreezeWindowHandler(freezeWindowManager)
+	freezeWindowController := controller.NewFreezeWindowController(freezeWindowHandler)
+	freezeWindowController.Inject(apiV1)
+
+	scheduleHandler := handler.NewScheduleHandler(handler.NewScheduleManager(scheduleRepo, projectMVRepo, clock.New()))
+	scheduleController := controller.NewScheduleController(scheduleHandler)
+	scheduleController.Inject(apiV1)
+
 	evaluationManager, err := handler.NewEvaluationManager(eventSender, projectMVRepo)
 	if err != nil {
 		log.Fatal(err)
@@ -188,7 +206,7 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	evaluationController := controller.NewEvaluationController(evaluationHandler)
 	evaluationController.Inject(apiV1)
 
-	stateHandler := handler.NewStateHandler(db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance()), shipyardController)
+	stateHandler := handler.NewStateHandler(db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance()), sequenceHistoryRepo, shipyardController)
 	stateController := controller.NewStateController(stateHandler)
 	stateController.Inject(apiV1)
 
@@ -210,6 +228,29 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	shipyardController.AddSequencePausedHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceResumedHook(sequenceStateMaterializedView)
 
+	sequenceHistoryRecorder := sequencehooks.NewSequenceHistoryRecorder(sequenceHistoryRepo)
+	shipyardController.AddSequenceTriggeredHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceStartedHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceWaitingHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceTimeoutHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceFinishedHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceSupersededHook(sequenceHistoryRecorder)
+	shipyardController.AddSequenceRestartedHook(sequenceHistoryRecorder)
+
+	// the waiting reason of queued sequences is refreshed by the sequence dispatcher, e.g. when a freeze window is changed or ends
+	sequenceDispatcher.AddSequenceWaitingHook(sequenceStateMaterializedView)
+	sequenceDispatcher.AddSequenceWaitingHook(sequenceHistoryRecorder)
+
+	sequenceMetricsCollector := sequencehooks.NewSequenceMetricsCollector()
+	shipyardController.AddSequenceTriggeredHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceStartedHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceWaitingHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceTimeoutHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceFinishedHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceAbortedHook(sequenceMetricsCollector)
+	shipyardController.AddSequencePausedHook(sequenceMetricsCollector)
+	shipyardController.AddSequenceResumedHook(sequenceMetricsCollector)
+
 	taskStartedWaitDuration := getDurationFromEnvVar(env.TaskStartedWaitDuration, envVarTaskStartedWaitDurationDefault)
 
 	watcher := handler.NewSequenceWatcher(
@@ -217,6 +258,7 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 		createEventsRepo(),
 		createEventQueueRepo(),
 		createProjectRepo(),
+		sequenceExecutionRepo,
 		taskStartedWaitDuration,
 		getDurationFromEnvVar(env.SequenceWatcherInterval, envVarSequenceWatcherIntervalDefault),
 		clock.New(),
@@ -258,6 +300,7 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 	healthController.Inject(apiHealth)
 
 	engine.Static("/swagger-ui", "./swagger-ui")
+	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	srv := &http.Server{
 		Addr:    ":8080",
 		Handler: engine,
@@ -275,12 +318,28 @@ func _main(env config.EnvConfig, kubeAPI kubernetes.Interface) {
 		}
 	}()
 
+	// the scheduler must only be active on the leader, therefore it is started and stopped together with the dispatchers
+	scheduler := handler.NewScheduler(
+		scheduleRepo,
+		eventSender,
+		getDurationFromEnvVar(env.SchedulerInterval, envVarSchedulerIntervalDefault),
+		clock.New(),
+	)
+	startLeaderTasks := func(ctx context.Context, mode common.SDMode) {
+		shipyardController.StartDispatchers(ctx, mode)
+		scheduler.Run(ctx)
+	}
+	stopLeaderTasks := func() {
+		shipyardController.StopDispatchers()
+		scheduler.Stop()
+	}
+
 	if env.DisableLeaderElection {
 		// single shipyard
-		shipyardController.StartDispatchers(ctx, common.SDModeRW)
+		startLeaderTasks(ctx, common.SDModeRW)
 	} else {
 		// multiple shipyards
-		go leaderelection.LeaderElection(kubeAPI.CoordinationV1(), ctx, shipyardController.StartDispatchers, shipyardController.StopDispatchers)
+		go leaderelection.LeaderElection(kubeAPI.CoordinationV1(), ctx, startLeaderTasks, stopLeaderTasks)
 	}
 
 	operationsEngine := gin.New()
@@ -367,6 +426,14 @@ func createSecretStore(kubeAPI kubernetes.Interface) *common.K8sSecretStore {
 	return common.NewK8sSecretStore(kubeAPI)
 }
 
+func createScheduleRepo() *db.MongoDBScheduleRepo {
+	return db.NewMongoDBScheduleRepo(db.GetMongoDBConnectionInstance())
+}
+
+func createSequenceHistoryRepo() *db.MongoDBSequenceHistoryRepo {
+	return db.NewMongoDBSequenceHistoryRepo(db.GetMongoDBConnectionInstance())
+}
+
 func createLogRepo() *db.MongoDBLogRepo {
 	return db.NewMongoDBLogRepo(db.GetMongoDBConnectionInstance())
 }
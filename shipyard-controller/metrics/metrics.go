@@ -0,0 +1,101 @@
+// Package metrics contains the Prometheus metrics exposed by the shipyard controller via its /metrics endpoint
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	namespace = "keptn"
+	subsystem = "shipyard_controller"
+)
+
+var (
+	// SequenceQueueSize is the number of sequences per project that are waiting in the sequence queue to be started
+	SequenceQueueSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "sequence_queue_size",
+		Help:      "Number of sequences waiting in the sequence queue to be started",
+	}, []string{"project"})
+
+	// BlockedSequences is the number of queued sequences per project that could not be started during the last dispatch cycle,
+	// e.g. because they are paused, their stage is frozen or other sequences are still running in the same stage
+	BlockedSequences = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "blocked_sequences",
+		Help:      "Number of queued sequences that could not be started during the last dispatch cycle",
+	}, []string{"project"})
+
+	// SequenceDispatchLatency is the time between a sequence being queued and being started
+	SequenceDispatchLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "sequence_dispatch_latency_seconds",
+		Help:      "Time between a sequence being queued and being started",
+		Buckets:   []float64{1, 5, 10, 30, 60, 300, 900, 1800, 3600, 7200},
+	}, []string{"project"})
+
+	// EventQueueSize is the number of events per project that are due to be sent by the event dispatcher
+	EventQueueSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "event_queue_size",
+		Help:      "Number of events in the event queue that are due to be sent",
+	}, []string{"project"})
+
+	// EventDispatchLatency is the time between an event from the event queue being due and being sent
+	EventDispatchLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "event_dispatch_latency_seconds",
+		Help:      "Time between an event in the event queue being due and being sent",
+		Buckets:   []float64{1, 5, 10, 30, 60, 300, 900, 1800, 3600},
+	}, []string{"project"})
+
+	// TaskDuration is the time between a task being triggered and being finished
+	TaskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "task_duration_seconds",
+		Help:      "Time between a task being triggered and being finished",
+		Buckets:   []float64{1, 5, 10, 30, 60, 300, 900, 1800, 3600, 7200},
+	}, []string{"project", "stage", "task", "result"})
+
+	// TaskTimeouts is the number of tasks that have not been finished within their timeout
+	TaskTimeouts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "task_timeouts_total",
+		Help:      "Number of tasks that have not been finished within their timeout",
+	}, []string{"project", "stage", "task"})
+
+	// SequenceStateTransitions is the number of sequences that have entered a certain state, e.g. triggered, started, waiting, timedOut or finished
+	SequenceStateTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "sequence_state_transitions_total",
+		Help:      "Number of sequences that have entered a state",
+	}, []string{"project", "stage", "sequence", "state"})
+)
+
+// SetPerProject sets the values of a gauge with a single project label.
+// Projects that are not contained in the given map are reset, so that gauges of projects without any remaining items do not keep their last value
+func SetPerProject(gauge *prometheus.GaugeVec, valuesPerProject map[string]int) {
+	gauge.Reset()
+	for project, value := range valuesPerProject {
+		gauge.WithLabelValues(project).Set(float64(value))
+	}
+}
+
+// ObserveDuration records the time that has passed between the given start and end in the given histogram
+func ObserveDuration(histogram prometheus.Observer, start, end time.Time) {
+	if start.IsZero() || end.Before(start) {
+		return
+	}
+	histogram.Observe(end.Sub(start).Seconds())
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPerProject(t *testing.T) {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge"}, []string{"project"})
+
+	SetPerProject(gauge, map[string]int{"project-a": 2, "project-b": 1})
+	require.Equal(t, 2, testutil.CollectAndCount(gauge))
+	require.Equal(t, float64(2), testutil.ToFloat64(gauge.WithLabelValues("project-a")))
+	require.Equal(t, float64(1), testutil.ToFloat64(gauge.WithLabelValues("project-b")))
+
+	// project-b does not have any items anymore and should not keep its previous value
+	SetPerProject(gauge, map[string]int{"project-a": 3})
+	require.Equal(t, 1, testutil.CollectAndCount(gauge))
+	require.Equal(t, float64(3), testutil.ToFloat64(gauge.WithLabelValues("project-a")))
+}
+
+func TestObserveDuration(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		start         time.Time
+		end           time.Time
+		expectedCount int
+	}{
+		{
+			name:          "observe duration",
+			start:         now.Add(-10 * time.Second),
+			end:           now,
+			expectedCount: 1,
+		},
+		{
+			name:          "zero start time - do not observe",
+			start:         time.Time{},
+			end:           now,
+			expectedCount: 0,
+		},
+		{
+			name:          "end before start - do not observe",
+			start:         now,
+			end:           now.Add(-10 * time.Second),
+			expectedCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram"})
+
+			ObserveDuration(histogram, tt.start, tt.end)
+
+			metric := &dto.Metric{}
+			require.Nil(t, histogram.Write(metric))
+			require.Equal(t, uint64(tt.expectedCount), metric.Histogram.GetSampleCount())
+			if tt.expectedCount > 0 {
+				require.Equal(t, float64(10), metric.Histogram.GetSampleSum())
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// freezeWindowDateLayouts are the supported layouts for the start and end of a freeze window that do not contain an explicit UTC offset
+var freezeWindowDateLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+var ErrInvalidFreezeWindow = errors.New("invalid freeze window")
+
+// FreezeWindow describes a period of time during which no sequences are started in a stage of a project.
+// A freeze window is either a fixed date range (Start and End), or a recurring window that starts according to a cron expression and lasts for the given Duration.
+// The freeze windows of a project are declared in the spec.freezeWindows property of its shipyard
+type FreezeWindow struct {
+	ID string `json:"id" yaml:"id"`
+	// Project is the project the freeze window belongs to. It is not stored in the shipyard, since it is given by the project of the shipyard
+	Project string `json:"project" yaml:"-"`
+	// Stage is the stage the freeze window applies to. If empty, the freeze window applies to all stages of the project
+	Stage       string `json:"stage,omitempty" yaml:"stage,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// TimeZone is the IANA time zone name (e.g. Europe/Vienna) used to evaluate Start, End and Cron. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	// Start is the beginning of a fixed freeze window, either in RFC3339 format, or in the format 2006-01-02T15:04:05 in the given time zone
+	Start string `json:"start,omitempty" yaml:"start,omitempty"`
+	// End is the end of a fixed freeze window, either in RFC3339 format, or in the format 2006-01-02T15:04:05 in the given time zone
+	End string `json:"end,omitempty" yaml:"end,omitempty"`
+	// Cron is a standard cron expression (minute, hour, day of month, month, day of week) that determines when a recurring freeze window starts
+	Cron string `json:"cron,omitempty" yaml:"cron,omitempty"`
+	// Duration is the duration of a recurring freeze window, e.g. 12h
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// FreezeWindows contains a list of freeze windows
+type FreezeWindows struct {
+	FreezeWindows []FreezeWindow `json:"freezeWindows"`
+}
+
+// GetFreezeWindowParams contains the parameters for retrieving freeze windows
+type GetFreezeWindowParams struct {
+	Project string `form:"-"`
+	Stage   string `form:"stage"`
+	ID      string `form:"-"`
+}
+
+// Validate checks whether the freeze window contains a valid fixed date range or recurring schedule
+func (w FreezeWindow) Validate() error {
+	if w.Project == "" {
+		return fmt.Errorf("%w: project must be set", ErrInvalidFreezeWindow)
+	}
+	return w.validateSchedule()
+}
+
+// validateSchedule checks whether the time zone, and either the fixed date range or the recurring schedule of the freeze window are valid
+func (w FreezeWindow) validateSchedule() error {
+	location, err := w.getLocation()
+	if err != nil {
+		return fmt.Errorf("%w: unknown time zone %s", ErrInvalidFreezeWindow, w.TimeZone)
+	}
+
+	isFixed := w.Start != "" || w.End != ""
+	isRecurring := w.Cron != "" || w.Duration != ""
+	if isFixed == isRecurring {
+		return fmt.Errorf("%w: either start and end, or cron and duration must be set", ErrInvalidFreezeWindow)
+	}
+
+	if isFixed {
+		start, err := parseFreezeWindowDate(w.Start, location)
+		if err != nil {
+			return fmt.Errorf("%w: could not parse start: %v", ErrInvalidFreezeWindow, err)
+		}
+		end, err := parseFreezeWindowDate(w.End, location)
+		if err != nil {
+			return fmt.Errorf("%w: could not parse end: %v", ErrInvalidFreezeWindow, err)
+		}
+		if !end.After(start) {
+			return fmt.Errorf("%w: end must be after start", ErrInvalidFreezeWindow)
+		}
+		return nil
+	}
+
+	if _, err := cronParser.Parse(w.Cron); err != nil {
+		return fmt.Errorf("%w: could not parse cron expression: %v", ErrInvalidFreezeWindow, err)
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return fmt.Errorf("%w: could not parse duration: %v", ErrInvalidFreezeWindow, err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidFreezeWindow)
+	}
+	return nil
+}
+
+// AppliesToStage determines whether the freeze window is relevant for the given stage
+func (w FreezeWindow) AppliesToStage(stage string) bool {
+	return w.Stage == "" || w.Stage == stage
+}
+
+// GetActiveUntil checks whether the freeze window is active at the given point in time.
+// If it is active, the end of the freeze window is returned. Otherwise, nil is returned
+func (w FreezeWindow) GetActiveUntil(t time.Time) (*time.Time, error) {
+	location, err := w.getLocation()
+	if err != nil {
+		return nil, err
+	}
+	t = t.In(location)
+
+	if w.Cron == "" {
+		start, err := parseFreezeWindowDate(w.Start, location)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseFreezeWindowDate(w.End, location)
+		if err != nil {
+			return nil, err
+		}
+		if !t.Before(start) && t.Before(end) {
+			return &end, nil
+		}
+		return nil, nil
+	}
+
+	schedule, err := cronParser.Parse(w.Cron)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return nil, err
+	}
+	// the window is active if it has been started within the last <duration>. Since Next() returns the first activation strictly after
+	// the given time, we start the lookup one second before the earliest possible start of a currently active window
+	start := schedule.Next(t.Add(-duration).Add(-time.Second))
+	if start.After(t) {
+		return nil, nil
+	}
+	end := start.Add(duration)
+	if !t.Before(end) {
+		return nil, nil
+	}
+	return &end, nil
+}
+
+func (w FreezeWindow) getLocation() (*time.Location, error) {
+	if w.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.TimeZone)
+}
+
+func parseFreezeWindowDate(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(location), nil
+	}
+	for _, layout := range freezeWindowDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format: '%s'", value)
+}
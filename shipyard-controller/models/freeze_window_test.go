@@ -0,0 +1,155 @@
+package models
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFreezeWindow_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		freezeWindow FreezeWindow
+		wantErr      bool
+	}{
+		{
+			name:         "valid date range",
+			freezeWindow: FreezeWindow{Project: "my-project", Start: "2022-12-24", End: "2022-12-27T08:00"},
+		},
+		{
+			name:         "valid date range with time zone",
+			freezeWindow: FreezeWindow{Project: "my-project", TimeZone: "America/New_York", Start: "2022-12-24T00:00:00", End: "2022-12-27T00:00:00"},
+		},
+		{
+			name:         "valid recurring window",
+			freezeWindow: FreezeWindow{Project: "my-project", Stage: "production", Cron: "0 18 * * 5", Duration: "62h"},
+		},
+		{
+			name:         "missing project",
+			freezeWindow: FreezeWindow{Start: "2022-12-24", End: "2022-12-27"},
+			wantErr:      true,
+		},
+		{
+			name:         "unknown time zone",
+			freezeWindow: FreezeWindow{Project: "my-project", TimeZone: "Mars/Olympus_Mons", Start: "2022-12-24", End: "2022-12-27"},
+			wantErr:      true,
+		},
+		{
+			name:         "end before start",
+			freezeWindow: FreezeWindow{Project: "my-project", Start: "2022-12-27", End: "2022-12-24"},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid date",
+			freezeWindow: FreezeWindow{Project: "my-project", Start: "christmas", End: "2022-12-24"},
+			wantErr:      true,
+		},
+		{
+			name:         "both date range and cron",
+			freezeWindow: FreezeWindow{Project: "my-project", Start: "2022-12-24", End: "2022-12-27", Cron: "0 18 * * 5", Duration: "62h"},
+			wantErr:      true,
+		},
+		{
+			name:         "neither date range nor cron",
+			freezeWindow: FreezeWindow{Project: "my-project"},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid cron",
+			freezeWindow: FreezeWindow{Project: "my-project", Cron: "every friday", Duration: "62h"},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid duration",
+			freezeWindow: FreezeWindow{Project: "my-project", Cron: "0 18 * * 5", Duration: "-1h"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.freezeWindow.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidFreezeWindow)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestFreezeWindow_GetActiveUntil(t *testing.T) {
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	require.Nil(t, err)
+
+	dateRange := FreezeWindow{TimeZone: "Europe/Vienna", Start: "2022-12-24T00:00", End: "2022-12-27T00:00"}
+	// every friday at 18:00 until monday 08:00
+	weekend := FreezeWindow{TimeZone: "Europe/Vienna", Cron: "0 18 * * 5", Duration: "62h"}
+
+	tests := []struct {
+		name         string
+		freezeWindow FreezeWindow
+		time         time.Time
+		want         *time.Time
+	}{
+		{
+			name:         "before date range",
+			freezeWindow: dateRange,
+			time:         time.Date(2022, 12, 23, 22, 59, 0, 0, time.UTC),
+		},
+		{
+			name:         "within date range, considering the time zone",
+			freezeWindow: dateRange,
+			time:         time.Date(2022, 12, 23, 23, 30, 0, 0, time.UTC),
+			want:         timePtr(time.Date(2022, 12, 27, 0, 0, 0, 0, vienna)),
+		},
+		{
+			name:         "after date range",
+			freezeWindow: dateRange,
+			time:         time.Date(2022, 12, 27, 0, 0, 0, 0, vienna),
+		},
+		{
+			name:         "before recurring window",
+			freezeWindow: weekend,
+			time:         time.Date(2022, 6, 3, 17, 59, 0, 0, vienna),
+		},
+		{
+			name:         "at the start of recurring window",
+			freezeWindow: weekend,
+			time:         time.Date(2022, 6, 3, 18, 0, 0, 0, vienna),
+			want:         timePtr(time.Date(2022, 6, 6, 8, 0, 0, 0, vienna)),
+		},
+		{
+			name:         "within recurring window",
+			freezeWindow: weekend,
+			time:         time.Date(2022, 6, 5, 12, 0, 0, 0, vienna),
+			want:         timePtr(time.Date(2022, 6, 6, 8, 0, 0, 0, vienna)),
+		},
+		{
+			name:         "after recurring window",
+			freezeWindow: weekend,
+			time:         time.Date(2022, 6, 6, 8, 0, 0, 0, vienna),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.freezeWindow.GetActiveUntil(tt.time)
+			require.Nil(t, err)
+			if tt.want == nil {
+				require.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.True(t, tt.want.Equal(*got), "expected %v, got %v", *tt.want, *got)
+		})
+	}
+}
+
+func TestFreezeWindow_AppliesToStage(t *testing.T) {
+	require.True(t, FreezeWindow{}.AppliesToStage("dev"))
+	require.True(t, FreezeWindow{Stage: "dev"}.AppliesToStage("dev"))
+	require.False(t, FreezeWindow{Stage: "production"}.AppliesToStage("dev"))
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -46,6 +46,9 @@ type UpdateProjectParams struct {
 
 	// shipyard
 	Shipyard *string `json:"shipyard,omitempty"`
+
+	// stage layout the project is migrated to, i.e. either "branch" or "directory". If not set, the stage layout is not changed
+	StageLayout string `json:"stageLayout,omitempty"`
 }
 
 type CreateProjectParams struct {
@@ -91,6 +94,9 @@ type CreateProjectParams struct {
 
 	// shipyard
 	Shipyard *string `json:"shipyard"`
+
+	// stage layout of the project, i.e. either "branch" or "directory". If not set, the default stage layout of the resource-service is used
+	StageLayout string `json:"stageLayout,omitempty"`
 }
 
 type GetProjectParams struct {
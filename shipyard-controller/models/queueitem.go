@@ -1,14 +1,69 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"sort"
 	"time"
 )
 
+// ErrInvalidSequencePriority indicates that the priority provided for a sequence is not valid
+var ErrInvalidSequencePriority = errors.New("sequence priority must not be negative")
+
 // QueueItem is a type used to persist events that are queued for dispatching
 type QueueItem struct {
 	Scope     EventScope `json:"scope" bson:"scope"`
 	EventID   string     `json:"eventID" bson:"eventID"`
 	Timestamp time.Time  `json:"timestamp" bson:"timestamp"`
+	// Concurrency contains the concurrency policy of the stage the sequence has been queued for
+	Concurrency ConcurrencyPolicy `json:"concurrency" bson:"concurrency"`
+	// Priority is the priority of the queued sequence. Sequences with a higher priority are dispatched before sequences with a lower priority
+	Priority int `json:"priority" bson:"priority"`
+}
+
+// GetEffectivePriority returns the priority of the queue item, increased by one for every agingInterval the item has been waiting in the queue.
+// This ensures that sequences with a low priority are not starving if sequences with a higher priority are triggered continuously
+func (q QueueItem) GetEffectivePriority(now time.Time, agingInterval time.Duration) int {
+	if agingInterval <= 0 || !now.After(q.Timestamp) {
+		return q.Priority
+	}
+	return q.Priority + int(now.Sub(q.Timestamp)/agingInterval)
+}
+
+// SortQueueItemsByPriority sorts the given queue items by their effective priority in descending order.
+// Items with the same effective priority are sorted by their timestamp in ascending order
+func SortQueueItemsByPriority(items []QueueItem, now time.Time, agingInterval time.Duration) {
+	sort.SliceStable(items, func(i, j int) bool {
+		priorityI := items[i].GetEffectivePriority(now, agingInterval)
+		priorityJ := items[j].GetEffectivePriority(now, agingInterval)
+		if priorityI != priorityJ {
+			return priorityI > priorityJ
+		}
+		return items[i].Timestamp.Before(items[j].Timestamp)
+	})
+}
+
+type sequencePriorityData struct {
+	Priority *int `json:"priority,omitempty"`
+}
+
+// GetSequencePriority retrieves the priority from the data block of an event that triggered a sequence. If no priority is set, 0 is returned
+func GetSequencePriority(eventData interface{}) (int, error) {
+	marshal, err := json.Marshal(eventData)
+	if err != nil {
+		return 0, err
+	}
+	data := &sequencePriorityData{}
+	if err := json.Unmarshal(marshal, data); err != nil {
+		return 0, err
+	}
+	if data.Priority == nil {
+		return 0, nil
+	}
+	if *data.Priority < 0 {
+		return 0, ErrInvalidSequencePriority
+	}
+	return *data.Priority, nil
 }
 
 type EventQueueSequenceState struct {
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestQueueItem_GetEffectivePriority(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		item          QueueItem
+		agingInterval time.Duration
+		want          int
+	}{
+		{
+			name:          "item has just been queued",
+			item:          QueueItem{Priority: 2, Timestamp: now},
+			agingInterval: 5 * time.Minute,
+			want:          2,
+		},
+		{
+			name:          "item has been waiting for more than two intervals",
+			item:          QueueItem{Priority: 2, Timestamp: now.Add(-11 * time.Minute)},
+			agingInterval: 5 * time.Minute,
+			want:          4,
+		},
+		{
+			name:          "aging disabled",
+			item:          QueueItem{Priority: 2, Timestamp: now.Add(-11 * time.Minute)},
+			agingInterval: 0,
+			want:          2,
+		},
+		{
+			name:          "timestamp in the future",
+			item:          QueueItem{Priority: 1, Timestamp: now.Add(time.Minute)},
+			agingInterval: 5 * time.Minute,
+			want:          1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.item.GetEffectivePriority(now, tt.agingInterval))
+		})
+	}
+}
+
+func TestSortQueueItemsByPriority(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	items := []QueueItem{
+		{EventID: "old-low", Priority: 0, Timestamp: now.Add(-20 * time.Minute)},
+		{EventID: "older-low", Priority: 0, Timestamp: now.Add(-21 * time.Minute)},
+		{EventID: "new-high", Priority: 10, Timestamp: now},
+		{EventID: "starving", Priority: 0, Timestamp: now.Add(-60 * time.Minute)},
+	}
+
+	SortQueueItemsByPriority(items, now, 5*time.Minute)
+
+	eventIDs := []string{}
+	for _, item := range items {
+		eventIDs = append(eventIDs, item.EventID)
+	}
+	require.Equal(t, []string{"starving", "new-high", "older-low", "old-low"}, eventIDs)
+}
+
+func TestGetSequencePriority(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventData interface{}
+		want      int
+		wantErr   bool
+	}{
+		{
+			name:      "no priority set",
+			eventData: map[string]interface{}{"project": "my-project"},
+			want:      0,
+		},
+		{
+			name:      "priority set",
+			eventData: map[string]interface{}{"project": "my-project", "priority": 5},
+			want:      5,
+		},
+		{
+			name:      "negative priority",
+			eventData: map[string]interface{}{"priority": -1},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid priority",
+			eventData: map[string]interface{}{"priority": "high"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSequencePriority(tt.eventData)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule describes a sequence that is triggered automatically for a service according to a cron expression
+type Schedule struct {
+	ID          string `json:"id" bson:"_id"`
+	Project     string `json:"project" bson:"project"`
+	Stage       string `json:"stage" bson:"stage"`
+	Service     string `json:"service" bson:"service"`
+	Sequence    string `json:"sequence" bson:"sequence"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+	// Cron is a standard cron expression (minute, hour, day of month, month, day of week) that determines when the sequence is triggered
+	Cron string `json:"cron" bson:"cron"`
+	// TimeZone is the IANA time zone name (e.g. Europe/Vienna) used to evaluate Cron. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty" bson:"timeZone,omitempty"`
+	// Labels are added to the sequence.triggered events created by the schedule
+	Labels map[string]string `json:"labels,omitempty" bson:"labels,omitempty"`
+	// CreatedAt is the point in time at which the schedule has been created
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	// LastTriggered is the point in time at which the schedule has triggered a sequence for the last time
+	LastTriggered *time.Time `json:"lastTriggered,omitempty" bson:"lastTriggered,omitempty"`
+	// LastKeptnContext is the keptn context of the sequence that has been triggered most recently by the schedule
+	LastKeptnContext string `json:"lastKeptnContext,omitempty" bson:"lastKeptnContext,omitempty"`
+	// NextTrigger is the point in time at which the schedule will trigger the next sequence. It is calculated when the schedule is retrieved
+	NextTrigger *time.Time `json:"nextTrigger,omitempty" bson:"-"`
+}
+
+// Schedules contains a list of schedules
+type Schedules struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+// GetScheduleParams contains the parameters for retrieving schedules
+type GetScheduleParams struct {
+	Project string `form:"-"`
+	Stage   string `form:"stage"`
+	Service string `form:"service"`
+	ID      string `form:"-"`
+}
+
+// Validate checks whether the schedule contains all required properties and a valid cron expression
+func (s Schedule) Validate() error {
+	if s.Project == "" {
+		return fmt.Errorf("%w: project must be set", ErrInvalidSchedule)
+	}
+	if s.Stage == "" {
+		return fmt.Errorf("%w: stage must be set", ErrInvalidSchedule)
+	}
+	if s.Service == "" {
+		return fmt.Errorf("%w: service must be set", ErrInvalidSchedule)
+	}
+	if s.Sequence == "" {
+		return fmt.Errorf("%w: sequence must be set", ErrInvalidSchedule)
+	}
+	if _, err := s.getLocation(); err != nil {
+		return fmt.Errorf("%w: unknown time zone %s", ErrInvalidSchedule, s.TimeZone)
+	}
+	if _, err := cronParser.Parse(s.Cron); err != nil {
+		return fmt.Errorf("%w: could not parse cron expression: %v", ErrInvalidSchedule, err)
+	}
+	return nil
+}
+
+// GetNextTrigger returns the first point in time after the most recent trigger (or the creation of the schedule, if it has never been triggered)
+// at which the schedule should trigger a sequence
+func (s Schedule) GetNextTrigger() (*time.Time, error) {
+	location, err := s.getLocation()
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := cronParser.Parse(s.Cron)
+	if err != nil {
+		return nil, err
+	}
+	reference := s.CreatedAt
+	if s.LastTriggered != nil && s.LastTriggered.After(reference) {
+		reference = *s.LastTriggered
+	}
+	next := schedule.Next(reference.In(location)).UTC()
+	return &next, nil
+}
+
+func (s Schedule) getLocation() (*time.Location, error) {
+	if s.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.TimeZone)
+}
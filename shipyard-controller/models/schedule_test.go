@@ -0,0 +1,112 @@
+package models
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	validSchedule := Schedule{Project: "my-project", Stage: "dev", Service: "my-service", Sequence: "evaluation", Cron: "0 2 * * *"}
+	tests := []struct {
+		name     string
+		modifier func(s *Schedule)
+		wantErr  bool
+	}{
+		{
+			name:     "valid schedule",
+			modifier: func(s *Schedule) {},
+		},
+		{
+			name:     "valid schedule with time zone and descriptor",
+			modifier: func(s *Schedule) { s.TimeZone = "Europe/Vienna"; s.Cron = "@weekly" },
+		},
+		{
+			name:     "missing project",
+			modifier: func(s *Schedule) { s.Project = "" },
+			wantErr:  true,
+		},
+		{
+			name:     "missing stage",
+			modifier: func(s *Schedule) { s.Stage = "" },
+			wantErr:  true,
+		},
+		{
+			name:     "missing service",
+			modifier: func(s *Schedule) { s.Service = "" },
+			wantErr:  true,
+		},
+		{
+			name:     "missing sequence",
+			modifier: func(s *Schedule) { s.Sequence = "" },
+			wantErr:  true,
+		},
+		{
+			name:     "unknown time zone",
+			modifier: func(s *Schedule) { s.TimeZone = "Mars/Olympus_Mons" },
+			wantErr:  true,
+		},
+		{
+			name:     "invalid cron",
+			modifier: func(s *Schedule) { s.Cron = "every night" },
+			wantErr:  true,
+		},
+		{
+			name:     "missing cron",
+			modifier: func(s *Schedule) { s.Cron = "" },
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := validSchedule
+			tt.modifier(&schedule)
+			err := schedule.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
+
+func TestSchedule_GetNextTrigger(t *testing.T) {
+	createdAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastTriggered := time.Date(2022, 3, 3, 1, 0, 0, 0, time.UTC)
+	lastTriggeredBeforeCreation := time.Date(2022, 2, 1, 1, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		want     time.Time
+	}{
+		{
+			name:     "never triggered",
+			schedule: Schedule{Cron: "0 2 * * *", CreatedAt: createdAt},
+			want:     time.Date(2022, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "triggered before",
+			schedule: Schedule{Cron: "0 2 * * *", CreatedAt: createdAt, LastTriggered: &lastTriggered},
+			want:     time.Date(2022, 3, 3, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "last trigger before creation is ignored",
+			schedule: Schedule{Cron: "0 2 * * *", CreatedAt: createdAt, LastTriggered: &lastTriggeredBeforeCreation},
+			want:     time.Date(2022, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "cron is evaluated in time zone",
+			schedule: Schedule{Cron: "0 2 * * *", TimeZone: "Europe/Vienna", CreatedAt: createdAt},
+			want:     time.Date(2022, 3, 2, 1, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schedule.GetNextTrigger()
+			require.Nil(t, err)
+			require.Equal(t, tt.want, *got)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// RestartSequence is the sequence control state used to execute a finished sequence again, starting with a given task
+const RestartSequence apimodels.SequenceControlState = "restart"
+
+// SequenceControl represents the wanted SequenceControlState for a certain Project, Stage and Context.
+// For the RestartSequence state, FromTask optionally specifies the task from which the sequence should be executed again.
+// User and Reason describe who requested the state change and why. They are recorded in the history of the sequence
+type SequenceControl struct {
+	apimodels.SequenceControl `bson:",inline"`
+	FromTask                  string `json:"fromTask,omitempty"`
+	User                      string `json:"-"`
+	Reason                    string `json:"-"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/keptn/go-utils/pkg/api/models"
@@ -42,6 +43,19 @@ type TaskExecutionResult struct {
 	Status      keptnv2.StatusType `json:"status" bson:"status"`
 	// Properties contains the aggregated results of the task's executors
 	Properties map[string]interface{} `json:"properties" bson:"properties"`
+	// Skipped indicates that the task has not been executed because its 'when' condition was not met
+	Skipped bool `json:"skipped,omitempty" bson:"skipped,omitempty"`
+	// Attempts contains the outcome of the attempts that have been retried before the task has been completed
+	Attempts []TaskAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
+}
+
+// TaskAttempt contains the outcome of an attempt to execute a task that has been retried
+type TaskAttempt struct {
+	TriggeredID string             `json:"triggeredID" bson:"triggeredID"`
+	Result      keptnv2.ResultType `json:"result" bson:"result"`
+	Status      keptnv2.StatusType `json:"status" bson:"status"`
+	// TimedOut indicates that the attempt has been cancelled because the task has not been finished in time
+	TimedOut bool `json:"timedOut,omitempty" bson:"timedOut,omitempty"`
 }
 
 func (r TaskExecutionResult) IsFailed() bool {
@@ -56,72 +70,149 @@ type TaskExecutionState struct {
 	Name        string      `json:"name" bson:"name"`
 	TriggeredID string      `json:"triggeredID" bson:"triggeredID"`
 	Events      []TaskEvent `json:"events" bson:"events"`
+	// ParallelTasks contains all tasks of the parallel group the current task belongs to. It is empty if the task is not part of a parallel group
+	ParallelTasks []ParallelTask `json:"parallelTasks,omitempty" bson:"parallelTasks,omitempty"`
+	// Timeout is the maximum duration the task may run after it has been started
+	Timeout string `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	// Attempts contains the outcome of the previous attempts to execute the task, if it has been retried
+	Attempts []TaskAttempt `json:"attempts,omitempty" bson:"attempts,omitempty"`
+}
+
+// ParallelTask represents a task that is executed as part of a parallel group
+type ParallelTask struct {
+	Name        string `json:"name" bson:"name"`
+	TriggeredID string `json:"triggeredID,omitempty" bson:"triggeredID,omitempty"`
+	// Skipped indicates that the task is not executed because its 'when' condition was not met
+	Skipped bool `json:"skipped,omitempty" bson:"skipped,omitempty"`
+	// Timeout is the maximum duration the task may run after it has been started
+	Timeout string `json:"timeout,omitempty" bson:"timeout,omitempty"`
 }
 
 // GetNextTaskOfSequence returns the next task of a sequence, based on its current execution state. If no task is remaining, or if a previous task
 // could not be completed successfully, it will return nil.
 func (e *SequenceExecution) GetNextTaskOfSequence() *keptnv2.Task {
-	if e.GetLastTaskExecutionResult().IsFailed() || e.GetLastTaskExecutionResult().IsErrored() {
+	if e.HasFailedTask() {
 		return nil
 	}
-	nextTaskIndex := 0
-	if e.Status.PreviousTasks != nil && len(e.Status.PreviousTasks) > 0 {
-		nextTaskIndex = len(e.Status.PreviousTasks)
-	}
+	return e.GetRemainingTask()
+}
 
+// GetRemainingTask returns the next task of a sequence that has neither been executed nor skipped, regardless of the results of the previous tasks.
+// If no task is remaining, it will return nil.
+func (e *SequenceExecution) GetRemainingTask() *keptnv2.Task {
+	nextTaskIndex := e.GetNextTaskIndex()
 	if len(e.Sequence.Tasks) > nextTaskIndex {
 		return &e.Sequence.Tasks[nextTaskIndex]
 	}
 	return nil
 }
 
+// GetNextTaskIndex returns the position of the next task within the sequence definition
+func (e *SequenceExecution) GetNextTaskIndex() int {
+	return len(e.Status.PreviousTasks)
+}
+
+// HasFailedTask indicates whether any of the executed tasks of the sequence has failed or errored
+func (e *SequenceExecution) HasFailedTask() bool {
+	for _, previousTask := range e.Status.PreviousTasks {
+		if !previousTask.Skipped && (previousTask.IsFailed() || previousTask.IsErrored()) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLastTaskExecutionResult returns the result of the last task that has been executed. Skipped tasks are not considered
 func (e *SequenceExecution) GetLastTaskExecutionResult() TaskExecutionResult {
-	if len(e.Status.PreviousTasks) == 0 {
-		return TaskExecutionResult{}
+	for i := len(e.Status.PreviousTasks) - 1; i >= 0; i-- {
+		if !e.Status.PreviousTasks[i].Skipped {
+			return e.Status.PreviousTasks[i]
+		}
 	}
-	return e.Status.PreviousTasks[len(e.Status.PreviousTasks)-1]
+	return TaskExecutionResult{}
 }
 
-// CompleteCurrentTask completes the current task and appends the aggregated result of the current task to the list of already completed tasks.
-func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.StatusType) {
-	var result keptnv2.ResultType
-	var status keptnv2.StatusType
-	if e.Status.CurrentTask.IsFailed() {
-		result = keptnv2.ResultFailed
-	} else if e.Status.CurrentTask.IsWarning() {
-		result = keptnv2.ResultWarning
-	} else {
-		result = keptnv2.ResultPass
+// GetSequenceResult returns the result that determines the outcome of the sequence. This is the first task that failed or errored,
+// since conditional tasks can still be executed after a failure. If no task failed, the result of the last executed task is returned
+func (e *SequenceExecution) GetSequenceResult() TaskExecutionResult {
+	for _, previousTask := range e.Status.PreviousTasks {
+		if !previousTask.Skipped && (previousTask.IsFailed() || previousTask.IsErrored()) {
+			return previousTask
+		}
 	}
-	if e.Status.CurrentTask.IsErrored() {
-		status = keptnv2.StatusErrored
-	} else {
-		status = keptnv2.StatusSucceeded
+	return e.GetLastTaskExecutionResult()
+}
+
+// SkipNextTask marks the next remaining task as skipped, so that the sequence can proceed with the task after it
+func (e *SequenceExecution) SkipNextTask() {
+	task := e.GetRemainingTask()
+	if task == nil {
+		return
 	}
+	e.Status.PreviousTasks = append(e.Status.PreviousTasks, TaskExecutionResult{
+		Name:    task.Name,
+		Result:  keptnv2.ResultPass,
+		Status:  keptnv2.StatusSucceeded,
+		Skipped: true,
+	})
+}
 
-	var mergedProperties interface{}
+// CompleteCurrentTask completes the current task and appends the aggregated result of the current task to the list of already completed tasks.
+// If the current task is part of a parallel group, the results of all tasks of the group are appended, and the returned result and status
+// represent the worst result and status of the group
+func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.StatusType) {
+	currentTask := e.Status.CurrentTask
+	aggregatedResult := currentTask.getExecutionResult()
 
-	for _, taskEvent := range e.Status.CurrentTask.Events {
-		if keptnv2.IsFinishedEventType(taskEvent.EventType) && taskEvent.Properties != nil {
-			mergedProperties = common.Merge(mergedProperties, taskEvent.Properties)
+	if len(currentTask.ParallelTasks) == 0 {
+		aggregatedResult.Attempts = currentTask.Attempts
+		e.Status.PreviousTasks = append(e.Status.PreviousTasks, aggregatedResult)
+	} else {
+		for _, parallelTask := range currentTask.ParallelTasks {
+			if parallelTask.Skipped {
+				e.Status.PreviousTasks = append(e.Status.PreviousTasks, TaskExecutionResult{
+					Name:    parallelTask.Name,
+					Result:  keptnv2.ResultPass,
+					Status:  keptnv2.StatusSucceeded,
+					Skipped: true,
+				})
+				continue
+			}
+			taskState := TaskExecutionState{
+				Name:        parallelTask.Name,
+				TriggeredID: parallelTask.TriggeredID,
+				Events:      currentTask.getEventsOfTask(parallelTask.TriggeredID),
+			}
+			e.Status.PreviousTasks = append(e.Status.PreviousTasks, taskState.getExecutionResult())
 		}
 	}
+	e.Status.CurrentTask = TaskExecutionState{}
+	return aggregatedResult.Result, aggregatedResult.Status
+}
 
-	executionResult := TaskExecutionResult{
-		Name:        e.Status.CurrentTask.Name,
-		TriggeredID: e.Status.CurrentTask.TriggeredID,
-		Result:      result,
-		Status:      status,
+// FailCurrentAttempt records the outcome of the current attempt to execute the current task, so that the task can be retried.
+// If the attempt has timed out, it is considered as failed and errored
+func (e *SequenceExecution) FailCurrentAttempt(timedOut bool) {
+	currentTask := &e.Status.CurrentTask
+	attempt := TaskAttempt{
+		TriggeredID: currentTask.TriggeredID,
+		TimedOut:    timedOut,
 	}
-	if mergedPropertiesMap, ok := mergedProperties.(map[string]interface{}); ok {
-		executionResult.Properties = mergedPropertiesMap
+	if timedOut {
+		attempt.Result = keptnv2.ResultFailed
+		attempt.Status = keptnv2.StatusErrored
+	} else {
+		executionResult := currentTask.getExecutionResult()
+		attempt.Result = executionResult.Result
+		attempt.Status = executionResult.Status
 	}
-	e.Status.PreviousTasks = append(
-		e.Status.PreviousTasks,
-		executionResult,
-	)
-	e.Status.CurrentTask = TaskExecutionState{}
-	return result, status
+	currentTask.Attempts = append(currentTask.Attempts, attempt)
+}
+
+// RetryCurrentTask starts a new attempt to execute the current task with the given .triggered event. The outcome of the previous attempts is retained
+func (e *SequenceExecution) RetryCurrentTask(triggeredEventID string) {
+	e.Status.CurrentTask.TriggeredID = triggeredEventID
+	e.Status.CurrentTask.Events = []TaskEvent{}
 }
 
 // GetNextTriggeredEventData generates a map representing the event payload for the next task.triggered event. For this, it will merge the following properties:
@@ -129,6 +220,12 @@ func (e *SequenceExecution) CompleteCurrentTask() (keptnv2.ResultType, keptnv2.S
 // - The properties of the task, defined in the sequence definition
 // - The results of the already completed tasks of the sequence
 func (e *SequenceExecution) GetNextTriggeredEventData() map[string]interface{} {
+	return e.GetTriggeredEventData(e.GetRemainingTask())
+}
+
+// GetTriggeredEventData generates a map representing the event payload for the task.triggered event of the given task.
+// This is needed for tasks of a parallel group, since each of them receives its own task properties
+func (e *SequenceExecution) GetTriggeredEventData(nextTask *keptnv2.Task) map[string]interface{} {
 	eventPayload := map[string]interface{}{}
 
 	if e.InputProperties != nil {
@@ -140,16 +237,18 @@ func (e *SequenceExecution) GetNextTriggeredEventData() map[string]interface{} {
 	eventPayload["stage"] = e.Scope.Stage
 	eventPayload["service"] = e.Scope.Service
 
-	if len(e.Status.PreviousTasks) > 0 {
+	if lastTask := e.GetLastTaskExecutionResult(); lastTask.Name != "" {
 		for _, previousTask := range e.Status.PreviousTasks {
-			eventPayload = common.Merge(eventPayload, previousTask.Properties).(map[string]interface{})
+			if previousTask.Skipped {
+				continue
+			}
+			// copy the properties to make sure that the results of the previous tasks are not modified when merging the properties of the next task
+			eventPayload = common.Merge(eventPayload, common.CopyMap(previousTask.Properties)).(map[string]interface{})
 		}
-		lastTaskIndex := len(e.Status.PreviousTasks) - 1
-		eventPayload["result"] = e.Status.PreviousTasks[lastTaskIndex].Result
-		eventPayload["status"] = e.Status.PreviousTasks[lastTaskIndex].Status
+		eventPayload["result"] = lastTask.Result
+		eventPayload["status"] = lastTask.Status
 	}
 
-	nextTask := e.GetNextTaskOfSequence()
 	if nextTask != nil && nextTask.Properties != nil {
 		eventPayload[nextTask.Name] = common.Merge(eventPayload[nextTask.Name], nextTask.Properties)
 	}
@@ -162,6 +261,28 @@ func (e *SequenceExecution) GetNextTriggeredEventData() map[string]interface{} {
 	return eventPayload
 }
 
+// GetTaskConditionData returns the data against which the 'when' condition of the next task is evaluated. It contains the accumulated
+// payload of the sequence, and additionally the result and status of each executed task, accessible via '<task>.result' and '<task>.status'
+func (e *SequenceExecution) GetTaskConditionData() map[string]interface{} {
+	conditionData := e.GetNextTriggeredEventData()
+
+	for _, previousTask := range e.Status.PreviousTasks {
+		if previousTask.Skipped {
+			continue
+		}
+		taskData, ok := conditionData[previousTask.Name].(map[string]interface{})
+		if !ok {
+			taskData = map[string]interface{}{}
+		} else {
+			taskData = common.CopyMap(taskData)
+		}
+		taskData["result"] = previousTask.Result
+		taskData["status"] = previousTask.Status
+		conditionData[previousTask.Name] = taskData
+	}
+	return conditionData
+}
+
 func (e *SequenceExecution) IsPaused() bool {
 	return e.Status.State == models.SequencePaused
 }
@@ -190,6 +311,52 @@ func (e *SequenceExecution) Resume() bool {
 	return true
 }
 
+// CanBeRestarted determines whether a sequence can be restarted, based on its current state. Only sequences that are not active anymore can be restarted
+func (e *SequenceExecution) CanBeRestarted() bool {
+	return e.Status.State == models.SequenceFinished || e.Status.State == models.TimedOut
+}
+
+// GetRestartTaskIndex returns the position of the task from which the sequence should be restarted. Only tasks that have already been executed,
+// or the task at which the sequence has stopped, can be chosen. If no task name is given, the first task that has failed or errored is used.
+// If no task has failed, but the sequence has timed out, the task that has timed out is used
+func (e *SequenceExecution) GetRestartTaskIndex(taskName string) (int, error) {
+	lastIndex := len(e.Status.PreviousTasks)
+	if lastIndex >= len(e.Sequence.Tasks) {
+		lastIndex = len(e.Sequence.Tasks) - 1
+	}
+
+	if taskName != "" {
+		for index := 0; index <= lastIndex; index++ {
+			if e.Sequence.Tasks[index].Name == taskName {
+				return index, nil
+			}
+		}
+		return 0, fmt.Errorf("task %s has not been executed in sequence %s", taskName, e.Sequence.Name)
+	}
+
+	for index, previousTask := range e.Status.PreviousTasks {
+		if !previousTask.Skipped && (previousTask.IsFailed() || previousTask.IsErrored()) {
+			return index, nil
+		}
+	}
+	if e.Status.State == models.TimedOut && len(e.Status.PreviousTasks) < len(e.Sequence.Tasks) {
+		return len(e.Status.PreviousTasks), nil
+	}
+	return 0, fmt.Errorf("sequence %s does not contain a failed task", e.Sequence.Name)
+}
+
+// Restart resets the sequence execution, so that it is triggered again starting with the task at the given position.
+// The results of the tasks before that position are retained, which means that their data is passed on to the tasks that are executed again
+func (e *SequenceExecution) Restart(taskIndex int, restartedAt time.Time) {
+	if taskIndex < len(e.Status.PreviousTasks) {
+		e.Status.PreviousTasks = append([]TaskExecutionResult{}, e.Status.PreviousTasks[:taskIndex]...)
+	}
+	e.Status.CurrentTask = TaskExecutionState{}
+	e.Status.State = models.SequenceTriggeredState
+	e.Status.StateBeforePause = ""
+	e.TriggeredAt = restartedAt
+}
+
 // SetNextCurrentTask updates the Current task of the sequence and sets the current state appropriately, considering the special logic that should be applied for approval tasks
 func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string) {
 	e.Status.CurrentTask = TaskExecutionState{
@@ -197,10 +364,36 @@ func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string
 		TriggeredID: triggeredEventID,
 		Events:      []TaskEvent{},
 	}
+	e.setNextState(taskName == keptnv2.ApprovalTaskName)
+}
 
+// SetNextParallelTasks updates the Current task of the sequence to a group of tasks that are executed in parallel. The first task of the group that is not skipped
+// becomes the Current task, while all tasks of the group are kept in its ParallelTasks. If one of the tasks is an approval task, the sequence will wait for the approval
+func (e *SequenceExecution) SetNextParallelTasks(tasks []ParallelTask) {
+	e.Status.CurrentTask = TaskExecutionState{
+		Events:        []TaskEvent{},
+		ParallelTasks: tasks,
+	}
+	waitForApproval := false
+	for _, task := range tasks {
+		if task.Skipped {
+			continue
+		}
+		if e.Status.CurrentTask.TriggeredID == "" {
+			e.Status.CurrentTask.Name = task.Name
+			e.Status.CurrentTask.TriggeredID = task.TriggeredID
+		}
+		if task.Name == keptnv2.ApprovalTaskName {
+			waitForApproval = true
+		}
+	}
+	e.setNextState(waitForApproval)
+}
+
+func (e *SequenceExecution) setNextState(waitForApproval bool) {
 	// special handling for approval events
 	nextState := models.SequenceStartedState
-	if taskName == keptnv2.ApprovalTaskName {
+	if waitForApproval {
 		nextState = models.SequenceWaitingForApprovalState
 	}
 
@@ -211,14 +404,128 @@ func (e *SequenceExecution) SetNextCurrentTask(taskName, triggeredEventID string
 	}
 }
 
-// IsFinished indicates if a task is finished, i.e. the number of task.started and task.finished events line up
+// HasTriggeredID indicates whether the given ID belongs to the .triggered event of the current task, or of any task of its parallel group
+func (e *TaskExecutionState) HasTriggeredID(triggeredID string) bool {
+	if e.TriggeredID == triggeredID {
+		return true
+	}
+	for _, task := range e.ParallelTasks {
+		if !task.Skipped && task.TriggeredID == triggeredID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTimeout returns the timeout of the task with the given triggered ID, which can be the current task or any task of its parallel group
+func (e *TaskExecutionState) GetTimeout(triggeredID string) string {
+	if len(e.ParallelTasks) == 0 {
+		if e.TriggeredID == triggeredID {
+			return e.Timeout
+		}
+		return ""
+	}
+	for _, task := range e.ParallelTasks {
+		if !task.Skipped && task.TriggeredID == triggeredID {
+			return task.Timeout
+		}
+	}
+	return ""
+}
+
+// IsStarted indicates whether the task with the given triggered ID has received a .started event
+func (e *TaskExecutionState) IsStarted(triggeredID string) bool {
+	for _, event := range e.getEventsOfTask(triggeredID) {
+		if keptnv2.IsStartedEventType(event.EventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTriggeredIDs returns the IDs of the .triggered events of the current task and all tasks of its parallel group
+func (e *TaskExecutionState) GetTriggeredIDs() []string {
+	if len(e.ParallelTasks) == 0 {
+		return []string{e.TriggeredID}
+	}
+	triggeredIDs := []string{}
+	for _, task := range e.ParallelTasks {
+		if !task.Skipped {
+			triggeredIDs = append(triggeredIDs, task.TriggeredID)
+		}
+	}
+	return triggeredIDs
+}
+
+// getEventsOfTask returns the events that belong to the task with the given triggered ID.
+// Events that have been stored without a triggered ID belong to the Current task
+func (e *TaskExecutionState) getEventsOfTask(triggeredID string) []TaskEvent {
+	events := []TaskEvent{}
+	for _, event := range e.Events {
+		if event.TriggeredID == triggeredID || (event.TriggeredID == "" && triggeredID == e.TriggeredID) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func (e *TaskExecutionState) getExecutionResult() TaskExecutionResult {
+	var result keptnv2.ResultType
+	var status keptnv2.StatusType
+	if e.IsFailed() {
+		result = keptnv2.ResultFailed
+	} else if e.IsWarning() {
+		result = keptnv2.ResultWarning
+	} else {
+		result = keptnv2.ResultPass
+	}
+	if e.IsErrored() {
+		status = keptnv2.StatusErrored
+	} else {
+		status = keptnv2.StatusSucceeded
+	}
+
+	var mergedProperties interface{}
+
+	for _, taskEvent := range e.Events {
+		if keptnv2.IsFinishedEventType(taskEvent.EventType) && taskEvent.Properties != nil {
+			mergedProperties = common.Merge(mergedProperties, taskEvent.Properties)
+		}
+	}
+
+	executionResult := TaskExecutionResult{
+		Name:        e.Name,
+		TriggeredID: e.TriggeredID,
+		Result:      result,
+		Status:      status,
+	}
+	if mergedPropertiesMap, ok := mergedProperties.(map[string]interface{}); ok {
+		executionResult.Properties = mergedPropertiesMap
+	}
+	return executionResult
+}
+
+// IsFinished indicates if a task is finished, i.e. the number of task.started and task.finished events line up.
+// If the task is part of a parallel group, this needs to be the case for every task of the group
 func (e *TaskExecutionState) IsFinished() bool {
-	if len(e.Events) == 0 {
+	if len(e.ParallelTasks) == 0 {
+		return areTaskEventsFinished(e.Events)
+	}
+	for _, task := range e.ParallelTasks {
+		if !task.Skipped && !areTaskEventsFinished(e.getEventsOfTask(task.TriggeredID)) {
+			return false
+		}
+	}
+	return true
+}
+
+func areTaskEventsFinished(events []TaskEvent) bool {
+	if len(events) == 0 {
 		return false
 	}
 	nrStartedEvents := 0
 	nrFinishedEvents := 0
-	for _, event := range e.Events {
+	for _, event := range events {
 		if keptnv2.IsStartedEventType(event.EventType) {
 			nrStartedEvents++
 		} else if keptnv2.IsFinishedEventType(event.EventType) {
@@ -283,6 +590,8 @@ type TaskEvent struct {
 	Status     keptnv2.StatusType     `json:"status" bson:"status"`
 	Time       string                 `json:"time" bson:"time"`
 	Properties map[string]interface{} `json:"properties" bson:"properties"`
+	// TriggeredID is the ID of the .triggered event the event belongs to. This is needed to assign the event to a task of a parallel group
+	TriggeredID string `json:"triggeredID,omitempty" bson:"triggeredID,omitempty"`
 }
 
 type SequenceExecutionFilter struct {
@@ -291,6 +600,8 @@ type SequenceExecutionFilter struct {
 	Name               string
 	CurrentTriggeredID string
 	TriggeredAt        time.Time
+	// WithTaskTimeout only matches sequence executions whose current task, or a task of its parallel group, declares a timeout
+	WithTaskTimeout bool
 }
 
 type SequenceExecutionUpsertOptions struct {
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSequenceExecution_GetNextTriggeredEventData(t *testing.T) {
@@ -1060,6 +1061,47 @@ func TestTaskExecutionState_IsFinished(t *testing.T) {
 	}
 }
 
+func TestTaskExecutionState_IsStarted(t *testing.T) {
+	tests := []struct {
+		name        string
+		triggeredID string
+		events      []TaskEvent
+		want        bool
+	}{
+		{
+			name:        "no events received yet",
+			triggeredID: "my-triggered-id",
+			want:        false,
+		},
+		{
+			name:        "received .started event",
+			triggeredID: "my-triggered-id",
+			events: []TaskEvent{
+				{EventType: keptnv2.GetStartedEventType("task"), TriggeredID: "my-triggered-id"},
+			},
+			want: true,
+		},
+		{
+			name:        "received .started event of another task of the parallel group",
+			triggeredID: "my-triggered-id",
+			events: []TaskEvent{
+				{EventType: keptnv2.GetStartedEventType("task"), TriggeredID: "my-other-triggered-id"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &TaskExecutionState{
+				Name:        "task",
+				TriggeredID: "my-triggered-id",
+				Events:      tt.events,
+			}
+			require.Equal(t, tt.want, e.IsStarted(tt.triggeredID))
+		})
+	}
+}
+
 func TestTaskExecutionState_IsPassed(t *testing.T) {
 	type fields struct {
 		Name        string
@@ -1236,3 +1278,288 @@ func TestSequenceExecution_SetNextCurrentTask(t *testing.T) {
 		})
 	}
 }
+
+func TestSequenceExecution_SkipNextTask(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "evaluation"}},
+		},
+		Status: SequenceExecutionStatus{
+			PreviousTasks: []TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultWarning, Status: keptnv2.StatusSucceeded},
+			},
+		},
+	}
+
+	e.SkipNextTask()
+
+	require.Len(t, e.Status.PreviousTasks, 2)
+	require.True(t, e.Status.PreviousTasks[1].Skipped)
+	require.Equal(t, "test", e.Status.PreviousTasks[1].Name)
+	require.Equal(t, "evaluation", e.GetNextTaskOfSequence().Name)
+	// skipped tasks are not considered as the last executed task
+	require.Equal(t, "deployment", e.GetLastTaskExecutionResult().Name)
+	require.Equal(t, keptnv2.ResultWarning, e.GetNextTriggeredEventData()["result"])
+}
+
+func TestSequenceExecution_GetSequenceResult(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "evaluation"}, {Name: "rollback"}, {Name: "release"}},
+		},
+		Status: SequenceExecutionStatus{
+			PreviousTasks: []TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+				{Name: "evaluation", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+				{Name: "rollback", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+		},
+	}
+
+	require.True(t, e.HasFailedTask())
+	require.Nil(t, e.GetNextTaskOfSequence())
+	require.Equal(t, "release", e.GetRemainingTask().Name)
+	require.Equal(t, "rollback", e.GetLastTaskExecutionResult().Name)
+	require.Equal(t, "evaluation", e.GetSequenceResult().Name)
+}
+
+func TestSequenceExecution_GetTaskConditionData(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "evaluation"}},
+		},
+		Scope: EventScope{EventData: keptnv2.EventData{Project: "sockshop", Stage: "dev", Service: "carts"}},
+		Status: SequenceExecutionStatus{
+			PreviousTasks: []TaskExecutionResult{
+				{
+					Name:       "deployment",
+					Result:     keptnv2.ResultPass,
+					Status:     keptnv2.StatusSucceeded,
+					Properties: map[string]interface{}{"deployment": map[string]interface{}{"deploymentstrategy": "direct"}},
+				},
+				{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true},
+			},
+		},
+	}
+
+	data := e.GetTaskConditionData()
+
+	require.Equal(t, map[string]interface{}{
+		"deploymentstrategy": "direct",
+		"result":             keptnv2.ResultPass,
+		"status":             keptnv2.StatusSucceeded,
+	}, data["deployment"])
+	require.Nil(t, data["test"])
+	// the task properties of the completed task must not be modified
+	require.Equal(t, map[string]interface{}{"deploymentstrategy": "direct"}, e.Status.PreviousTasks[0].Properties["deployment"])
+}
+
+func TestSequenceExecution_CompleteParallelTasks(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "securityscan"}, {Name: "test"}, {Name: "evaluation"}},
+		},
+		Status: SequenceExecutionStatus{
+			State: models.SequenceStartedState,
+			PreviousTasks: []TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+		},
+	}
+
+	e.SetNextParallelTasks([]ParallelTask{
+		{Name: "test", TriggeredID: "test-id"},
+		{Name: "securityscan", TriggeredID: "scan-id"},
+		{Name: "test", Skipped: true},
+	})
+	require.Equal(t, "test", e.Status.CurrentTask.Name)
+	require.Equal(t, "test-id", e.Status.CurrentTask.TriggeredID)
+	require.Equal(t, []string{"test-id", "scan-id"}, e.Status.CurrentTask.GetTriggeredIDs())
+
+	e.Status.CurrentTask.Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("test"), TriggeredID: "test-id"},
+		{EventType: keptnv2.GetStartedEventType("securityscan"), TriggeredID: "scan-id"},
+		{EventType: keptnv2.GetFinishedEventType("test"), TriggeredID: "test-id", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+	}
+	// the security scan is still running
+	require.False(t, e.Status.CurrentTask.IsFinished())
+
+	e.Status.CurrentTask.Events = append(e.Status.CurrentTask.Events, TaskEvent{
+		EventType:   keptnv2.GetFinishedEventType("securityscan"),
+		TriggeredID: "scan-id",
+		Result:      keptnv2.ResultWarning,
+		Status:      keptnv2.StatusSucceeded,
+		Properties:  map[string]interface{}{"securityscan": map[string]interface{}{"findings": 2}},
+	})
+	require.True(t, e.Status.CurrentTask.IsFinished())
+
+	result, status := e.CompleteCurrentTask()
+
+	// the worst result of the group is returned
+	require.Equal(t, keptnv2.ResultWarning, result)
+	require.Equal(t, keptnv2.StatusSucceeded, status)
+	require.Equal(t, []TaskExecutionResult{
+		{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		{Name: "test", TriggeredID: "test-id", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+		{
+			Name:        "securityscan",
+			TriggeredID: "scan-id",
+			Result:      keptnv2.ResultWarning,
+			Status:      keptnv2.StatusSucceeded,
+			Properties:  map[string]interface{}{"securityscan": map[string]interface{}{"findings": 2}},
+		},
+		{Name: "test", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Skipped: true},
+	}, e.Status.PreviousTasks)
+	require.Equal(t, "evaluation", e.GetNextTaskOfSequence().Name)
+}
+
+func TestSequenceExecution_RetryCurrentTask(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "test"}},
+		},
+		Status: SequenceExecutionStatus{
+			CurrentTask: TaskExecutionState{
+				Name:        "test",
+				TriggeredID: "first-attempt",
+				Events: []TaskEvent{
+					{EventType: keptnv2.GetStartedEventType("test"), Source: "jmeter-service"},
+					{EventType: keptnv2.GetFinishedEventType("test"), Source: "jmeter-service", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+				},
+			},
+		},
+	}
+
+	e.FailCurrentAttempt(false)
+	e.RetryCurrentTask("second-attempt")
+
+	require.Equal(t, "second-attempt", e.Status.CurrentTask.TriggeredID)
+	require.Empty(t, e.Status.CurrentTask.Events)
+
+	e.FailCurrentAttempt(true)
+	e.RetryCurrentTask("third-attempt")
+
+	e.Status.CurrentTask.Events = []TaskEvent{
+		{EventType: keptnv2.GetStartedEventType("test"), Source: "jmeter-service"},
+		{EventType: keptnv2.GetFinishedEventType("test"), Source: "jmeter-service", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+	}
+	result, status := e.CompleteCurrentTask()
+
+	require.Equal(t, keptnv2.ResultPass, result)
+	require.Equal(t, keptnv2.StatusSucceeded, status)
+	require.Len(t, e.Status.PreviousTasks, 1)
+	require.Equal(t, "third-attempt", e.Status.PreviousTasks[0].TriggeredID)
+	require.Equal(t, []TaskAttempt{
+		{TriggeredID: "first-attempt", Result: keptnv2.ResultFailed, Status: keptnv2.StatusSucceeded},
+		{TriggeredID: "second-attempt", Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored, TimedOut: true},
+	}, e.Status.PreviousTasks[0].Attempts)
+}
+
+func TestSequenceExecution_GetRestartTaskIndex(t *testing.T) {
+	tasks := []keptnv2.Task{{Name: "deployment"}, {Name: "test"}, {Name: "evaluation"}, {Name: "release"}}
+
+	tests := []struct {
+		name          string
+		state         string
+		previousTasks []TaskExecutionResult
+		taskName      string
+		wantIndex     int
+		wantErr       bool
+	}{
+		{
+			name:          "given task",
+			state:         models.SequenceFinished,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}, {Name: "test", Result: keptnv2.ResultFailed}},
+			taskName:      "deployment",
+			wantIndex:     0,
+		},
+		{
+			name:          "given task has not been executed",
+			state:         models.SequenceFinished,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}, {Name: "test", Result: keptnv2.ResultFailed}},
+			taskName:      "release",
+			wantErr:       true,
+		},
+		{
+			name:          "failed task",
+			state:         models.SequenceFinished,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}, {Name: "test", Result: keptnv2.ResultFailed}},
+			wantIndex:     1,
+		},
+		{
+			name:          "timed out task",
+			state:         models.TimedOut,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}},
+			wantIndex:     1,
+		},
+		{
+			name:          "timed out task can be given explicitly",
+			state:         models.TimedOut,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}},
+			taskName:      "test",
+			wantIndex:     1,
+		},
+		{
+			name:          "no failed task",
+			state:         models.SequenceFinished,
+			previousTasks: []TaskExecutionResult{{Name: "deployment", Result: keptnv2.ResultPass}, {Name: "test", Result: keptnv2.ResultPass}, {Name: "evaluation", Result: keptnv2.ResultPass}, {Name: "release", Result: keptnv2.ResultPass}},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SequenceExecution{
+				Sequence: keptnv2.Sequence{Name: "delivery", Tasks: tasks},
+				Status: SequenceExecutionStatus{
+					State:         tt.state,
+					PreviousTasks: tt.previousTasks,
+				},
+			}
+			index, err := e.GetRestartTaskIndex(tt.taskName)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantIndex, index)
+		})
+	}
+}
+
+func TestSequenceExecution_Restart(t *testing.T) {
+	e := &SequenceExecution{
+		Sequence: keptnv2.Sequence{
+			Name:  "delivery",
+			Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "test"}},
+		},
+		Status: SequenceExecutionStatus{
+			State:            models.TimedOut,
+			StateBeforePause: models.SequenceStartedState,
+			PreviousTasks: []TaskExecutionResult{
+				{Name: "deployment", Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded, Properties: map[string]interface{}{"deployment": map[string]interface{}{"deploymentURIsLocal": []interface{}{"carts.sockshop-dev"}}}},
+			},
+			CurrentTask: TaskExecutionState{Name: "test", TriggeredID: "test-id"},
+		},
+	}
+	restartedAt := time.Now().UTC()
+
+	e.Restart(1, restartedAt)
+
+	require.Equal(t, models.SequenceTriggeredState, e.Status.State)
+	require.Empty(t, e.Status.StateBeforePause)
+	require.Equal(t, TaskExecutionState{}, e.Status.CurrentTask)
+	require.Equal(t, restartedAt, e.TriggeredAt)
+	require.Len(t, e.Status.PreviousTasks, 1)
+	require.Equal(t, "test", e.GetNextTaskOfSequence().Name)
+	require.Equal(t, map[string]interface{}{"deploymentURIsLocal": []interface{}{"carts.sockshop-dev"}}, e.GetNextTriggeredEventData()["deployment"])
+
+	e.Restart(0, restartedAt)
+
+	require.Empty(t, e.Status.PreviousTasks)
+	require.Equal(t, "deployment", e.GetNextTaskOfSequence().Name)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// SequenceResumedState is the state recorded in the history of a sequence when it has been resumed after being paused
+const SequenceResumedState = "resumed"
+
+// SequenceRestartedState is the state recorded in the history of a sequence when it has been restarted from one of its tasks
+const SequenceRestartedState = "restarted"
+
+// SequenceStateTransition is an entry of the audit trail of a sequence execution.
+// It describes which state a sequence has entered and when, as well as who or what caused the transition and why
+type SequenceStateTransition struct {
+	Project      string `json:"project" bson:"project"`
+	Stage        string `json:"stage,omitempty" bson:"stage,omitempty"`
+	Service      string `json:"service,omitempty" bson:"service,omitempty"`
+	Sequence     string `json:"sequence,omitempty" bson:"sequence,omitempty"`
+	KeptnContext string `json:"keptnContext" bson:"keptnContext"`
+	// State is the state the sequence has entered, e.g. triggered, paused, resumed, aborted, timedOut or finished
+	State string `json:"state" bson:"state"`
+	// User is the API user that requested the transition, i.e. 'keptn-api-token' for requests authenticated with the Keptn API token, which is not bound to a specific user.
+	// It is empty for transitions caused by Keptn itself
+	User string `json:"user,omitempty" bson:"user,omitempty"`
+	// Source is the component that caused the transition, e.g. the source of the event that triggered the sequence
+	Source string `json:"source" bson:"source"`
+	// Reason optionally describes why the transition has happened
+	Reason string    `json:"reason,omitempty" bson:"reason,omitempty"`
+	Time   time.Time `json:"time" bson:"time"`
+}
+
+// SequenceHistory contains the state transitions of a sequence execution in chronological order
+type SequenceHistory struct {
+	Transitions []SequenceStateTransition `json:"transitions"`
+}
+
+// SequenceControlCommand contains instructions to issue a sequence state change request, optionally including the reason for the state change
+type SequenceControlCommand struct {
+	apimodels.SequenceControlCommand `bson:",inline"`
+	// Reason describes why the state of the sequence is changed. It will be stored in the history of the sequence
+	Reason string `json:"reason,omitempty"`
+	// FromTask is the name of the task from which the sequence should be restarted. It is only considered for the 'restart' state.
+	// If it is not set, the sequence is restarted from the task that has failed or timed out
+	FromTask string `json:"fromTask,omitempty"`
+}
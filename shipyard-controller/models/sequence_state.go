@@ -0,0 +1,26 @@
+package models
+
+import (
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+)
+
+// SequenceState represents the current state of a sequence. In addition to the properties of the Keptn API model,
+// it contains information that is maintained by the shipyard controller, such as the reason why a sequence is currently waiting
+type SequenceState struct {
+	apimodels.SequenceState `bson:",inline"`
+	// WaitingReason describes why the sequence is currently waiting to be started
+	WaitingReason string `json:"waitingReason,omitempty" bson:"waitingReason,omitempty"`
+}
+
+// SequenceStates collects all states of a sequence
+type SequenceStates struct {
+	States []SequenceState `json:"states"`
+	// Pointer to next page
+	NextPageKey int64 `json:"nextPageKey,omitempty"`
+
+	// Size of returned page
+	PageSize int64 `json:"pageSize,omitempty"`
+
+	// Total number of events
+	TotalCount int64 `json:"totalCount,omitempty"`
+}
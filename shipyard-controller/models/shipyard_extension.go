@@ -0,0 +1,281 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConcurrencyPolicyType defines how the shipyard controller handles multiple sequences for the same service within a stage
+type ConcurrencyPolicyType string
+
+const (
+	// ConcurrencySerial only allows one sequence per service and stage to be executed at a time. This is the default behavior
+	ConcurrencySerial ConcurrencyPolicyType = "serial"
+	// ConcurrencyParallel allows up to MaxParallel sequences per service and stage to be executed at the same time
+	ConcurrencyParallel ConcurrencyPolicyType = "parallel"
+	// ConcurrencySupersede replaces older sequences that are still queued with the newest one
+	ConcurrencySupersede ConcurrencyPolicyType = "supersede"
+	// ConcurrencySkipDuplicate skips a sequence if a sequence with the same name is already queued or running for the service in the stage
+	ConcurrencySkipDuplicate ConcurrencyPolicyType = "skipDuplicate"
+)
+
+// ErrInvalidConcurrencyPolicy indicates that the concurrency policy declared in the shipyard is not supported
+var ErrInvalidConcurrencyPolicy = errors.New("invalid concurrency policy")
+
+// ErrRetriesInParallelGroup indicates that a task of a parallel group declares retries, which are only supported for tasks that are not part of a group
+var ErrRetriesInParallelGroup = errors.New("retries are not supported for tasks of a parallel group")
+
+// ShipyardExtension contains the properties of a shipyard that are evaluated by the shipyard controller,
+// but are not part of the Keptn shipyard spec provided by go-utils
+type ShipyardExtension struct {
+	Spec ShipyardExtensionSpec `json:"spec" yaml:"spec"`
+}
+
+// ShipyardExtensionSpec godoc
+type ShipyardExtensionSpec struct {
+	Stages []StageExtension `json:"stages" yaml:"stages"`
+	// FreezeWindows are the periods of time during which no sequences are started in the stages of the project
+	FreezeWindows []FreezeWindow `json:"freezeWindows,omitempty" yaml:"freezeWindows,omitempty"`
+}
+
+// StageExtension contains the shipyard controller specific properties of a stage
+type StageExtension struct {
+	Name        string              `json:"name" yaml:"name"`
+	Concurrency *ConcurrencyPolicy  `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	Sequences   []SequenceExtension `json:"sequences,omitempty" yaml:"sequences,omitempty"`
+}
+
+// SequenceExtension contains the shipyard controller specific properties of a sequence
+type SequenceExtension struct {
+	Name  string          `json:"name" yaml:"name"`
+	Tasks []TaskExtension `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+}
+
+// TaskExtension contains the shipyard controller specific properties of a task
+type TaskExtension struct {
+	Name string `json:"name" yaml:"name"`
+	// When is a condition that is evaluated against the accumulated data of the sequence before the task is triggered. If the condition is not met, the task is skipped
+	When string `json:"when,omitempty" yaml:"when,omitempty"`
+	// Group is the name of a parallel group. Consecutive tasks of a sequence with the same group are triggered at the same time,
+	// and the sequence proceeds once all of them are finished
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	// Timeout is the maximum duration a task may run after it has been started, e.g. '30m'. If the task is not finished in time, it is considered as timed out
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Retries is the number of times a task is triggered again if it failed or timed out
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+	// Backoff is the duration to wait before the first retry, e.g. '1m'. The duration is doubled for each subsequent retry
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+}
+
+// Validate checks whether the shipyard controller specific properties of the tasks of the sequence are supported
+func (s SequenceExtension) Validate() error {
+	for _, task := range s.Tasks {
+		if task.Group != "" && (task.Retries > 0 || task.Backoff != "") {
+			return fmt.Errorf("%w: task %s of sequence %s belongs to group %s", ErrRetriesInParallelGroup, task.Name, s.Name, task.Group)
+		}
+	}
+	return nil
+}
+
+// GetBackoff returns the duration to wait before the given retry attempt, starting at 0 for the first retry. If no valid backoff has been declared, 0 is returned
+func (t TaskExtension) GetBackoff(retry int) time.Duration {
+	if t.Backoff == "" {
+		return 0
+	}
+	backoff, err := time.ParseDuration(t.Backoff)
+	if err != nil || backoff < 0 {
+		return 0
+	}
+	for i := 0; i < retry; i++ {
+		backoff = backoff * 2
+	}
+	return backoff
+}
+
+// ConcurrencyPolicy defines how sequences for the same service are dispatched within a stage
+type ConcurrencyPolicy struct {
+	Policy ConcurrencyPolicyType `json:"policy,omitempty" bson:"policy,omitempty" yaml:"policy,omitempty"`
+	// MaxParallel is the maximum number of sequences that can run at the same time if the 'parallel' policy is used. A value <= 0 means no limit
+	MaxParallel int `json:"maxParallel,omitempty" bson:"maxParallel,omitempty" yaml:"maxParallel,omitempty"`
+}
+
+// GetPolicy returns the type of the concurrency policy, or ConcurrencySerial if no policy has been set
+func (c ConcurrencyPolicy) GetPolicy() ConcurrencyPolicyType {
+	if c.Policy == "" {
+		return ConcurrencySerial
+	}
+	return c.Policy
+}
+
+// Validate checks whether the concurrency policy is supported
+func (c ConcurrencyPolicy) Validate() error {
+	switch c.GetPolicy() {
+	case ConcurrencySerial, ConcurrencyParallel, ConcurrencySupersede, ConcurrencySkipDuplicate:
+		return nil
+	}
+	return ErrInvalidConcurrencyPolicy
+}
+
+// DecodeShipyardExtension decodes the shipyard controller specific properties of a shipyard file
+func DecodeShipyardExtension(shipyardContent string) (*ShipyardExtension, error) {
+	extension := &ShipyardExtension{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), extension); err != nil {
+		return nil, err
+	}
+	return extension, nil
+}
+
+// UpdateShipyardFreezeWindows replaces the freeze windows declared in the given shipyard file, and returns the updated shipyard file.
+// The shipyard is edited as a YAML document, so that all other properties and comments are retained
+func UpdateShipyardFreezeWindows(shipyardContent string, freezeWindows []FreezeWindow) (string, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), document); err != nil {
+		return "", err
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return "", errors.New("shipyard is empty")
+	}
+	spec := getMappingValue(document.Content[0], "spec")
+	if spec == nil || spec.Kind != yaml.MappingNode {
+		return "", errors.New("shipyard does not contain a spec")
+	}
+
+	for index := 0; index < len(spec.Content); index += 2 {
+		if spec.Content[index].Value == "freezeWindows" {
+			spec.Content = append(spec.Content[:index], spec.Content[index+2:]...)
+			break
+		}
+	}
+	if len(freezeWindows) > 0 {
+		freezeWindowsNode := &yaml.Node{}
+		if err := freezeWindowsNode.Encode(freezeWindows); err != nil {
+			return "", err
+		}
+		spec.Content = append(spec.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "freezeWindows"}, freezeWindowsNode)
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if node.Content[index].Value == key {
+			return node.Content[index+1]
+		}
+	}
+	return nil
+}
+
+// Validate checks whether the shipyard controller specific properties of the sequences of all stages, and the freeze windows are supported
+func (s *ShipyardExtension) Validate() error {
+	for _, stage := range s.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			if err := sequence.Validate(); err != nil {
+				return fmt.Errorf("invalid sequence in stage %s: %w", stage.Name, err)
+			}
+		}
+	}
+	freezeWindowIDs := map[string]bool{}
+	for _, freezeWindow := range s.Spec.FreezeWindows {
+		if freezeWindow.ID == "" {
+			return fmt.Errorf("%w: id must be set", ErrInvalidFreezeWindow)
+		}
+		if freezeWindowIDs[freezeWindow.ID] {
+			return fmt.Errorf("%w: id %s is used by multiple freeze windows", ErrInvalidFreezeWindow, freezeWindow.ID)
+		}
+		freezeWindowIDs[freezeWindow.ID] = true
+		if freezeWindow.Stage != "" && s.GetStage(freezeWindow.Stage) == nil {
+			return fmt.Errorf("%w: freeze window %s refers to unknown stage %s", ErrInvalidFreezeWindow, freezeWindow.ID, freezeWindow.Stage)
+		}
+		if err := freezeWindow.validateSchedule(); err != nil {
+			return fmt.Errorf("freeze window %s: %w", freezeWindow.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetFreezeWindows returns the freeze windows declared in the shipyard of the given project
+func (s *ShipyardExtension) GetFreezeWindows(projectName string) []FreezeWindow {
+	freezeWindows := make([]FreezeWindow, 0, len(s.Spec.FreezeWindows))
+	for _, freezeWindow := range s.Spec.FreezeWindows {
+		freezeWindow.Project = projectName
+		freezeWindows = append(freezeWindows, freezeWindow)
+	}
+	return freezeWindows
+}
+
+// GetStage returns the extension of the stage with the given name. If the stage is not available, nil is returned
+func (s *ShipyardExtension) GetStage(stageName string) *StageExtension {
+	for index := range s.Spec.Stages {
+		if s.Spec.Stages[index].Name == stageName {
+			return &s.Spec.Stages[index]
+		}
+	}
+	return nil
+}
+
+// GetConcurrencyPolicy returns the concurrency policy of the given stage. If no policy has been declared, the default (serial) policy is returned
+func (s *ShipyardExtension) GetConcurrencyPolicy(stageName string) ConcurrencyPolicy {
+	stage := s.GetStage(stageName)
+	if stage == nil || stage.Concurrency == nil {
+		return ConcurrencyPolicy{Policy: ConcurrencySerial}
+	}
+	return *stage.Concurrency
+}
+
+// GetSequence returns the extension of the sequence with the given name within a stage. If the sequence is not available, nil is returned
+func (s *ShipyardExtension) GetSequence(stageName, sequenceName string) *SequenceExtension {
+	stage := s.GetStage(stageName)
+	if stage == nil {
+		return nil
+	}
+	for index := range stage.Sequences {
+		if stage.Sequences[index].Name == sequenceName {
+			return &stage.Sequences[index]
+		}
+	}
+	return nil
+}
+
+// GetTask returns the extension of the task at the given position within a sequence of a stage. If the task is not available, nil is returned
+func (s *ShipyardExtension) GetTask(stageName, sequenceName string, taskIndex int) *TaskExtension {
+	sequence := s.GetSequence(stageName, sequenceName)
+	if sequence == nil || taskIndex < 0 || taskIndex >= len(sequence.Tasks) {
+		return nil
+	}
+	return &sequence.Tasks[taskIndex]
+}
+
+// GetTaskCondition returns the 'when' condition of the task at the given position within a sequence of a stage. If no condition has been declared, an empty string is returned
+func (s *ShipyardExtension) GetTaskCondition(stageName, sequenceName string, taskIndex int) string {
+	task := s.GetTask(stageName, sequenceName, taskIndex)
+	if task == nil {
+		return ""
+	}
+	return task.When
+}
+
+// GetTaskGroup returns the name of the parallel group of the task at the given position within a sequence of a stage. If the task is not part of a group, an empty string is returned
+func (s *ShipyardExtension) GetTaskGroup(stageName, sequenceName string, taskIndex int) string {
+	task := s.GetTask(stageName, sequenceName, taskIndex)
+	if task == nil {
+		return ""
+	}
+	return task.Group
+}
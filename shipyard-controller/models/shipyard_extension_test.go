@@ -0,0 +1,236 @@
+package models
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testShipyardWithConcurrency = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      concurrency:
+        policy: "parallel"
+        maxParallel: 3
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "hardening"
+      concurrency:
+        policy: "supersede"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"`
+
+func TestShipyardExtension_GetConcurrencyPolicy(t *testing.T) {
+	extension, err := DecodeShipyardExtension(testShipyardWithConcurrency)
+	require.Nil(t, err)
+
+	tests := []struct {
+		name  string
+		stage string
+		want  ConcurrencyPolicy
+	}{
+		{
+			name:  "parallel policy with limit",
+			stage: "dev",
+			want:  ConcurrencyPolicy{Policy: ConcurrencyParallel, MaxParallel: 3},
+		},
+		{
+			name:  "supersede policy",
+			stage: "hardening",
+			want:  ConcurrencyPolicy{Policy: ConcurrencySupersede},
+		},
+		{
+			name:  "no policy declared - use default",
+			stage: "production",
+			want:  ConcurrencyPolicy{Policy: ConcurrencySerial},
+		},
+		{
+			name:  "unknown stage - use default",
+			stage: "unknown",
+			want:  ConcurrencyPolicy{Policy: ConcurrencySerial},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, extension.GetConcurrencyPolicy(tt.stage))
+		})
+	}
+}
+
+func TestConcurrencyPolicy_Validate(t *testing.T) {
+	require.Nil(t, ConcurrencyPolicy{}.Validate())
+	require.Nil(t, ConcurrencyPolicy{Policy: ConcurrencySkipDuplicate}.Validate())
+	require.ErrorIs(t, ConcurrencyPolicy{Policy: "unknown"}.Validate(), ErrInvalidConcurrencyPolicy)
+}
+
+func TestDecodeShipyardExtension_InvalidContent(t *testing.T) {
+	_, err := DecodeShipyardExtension("invalid: [")
+	require.NotNil(t, err)
+}
+
+const testShipyardWithConditions = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "test"
+              when: "deployment.deploymentstrategy != blue_green_service"
+            - name: "evaluation"
+            - name: "rollback"
+              when: "evaluation.result == fail"`
+
+func TestShipyardExtension_GetTaskCondition(t *testing.T) {
+	extension, err := DecodeShipyardExtension(testShipyardWithConditions)
+	require.Nil(t, err)
+
+	require.Equal(t, "", extension.GetTaskCondition("production", "delivery", 0))
+	require.Equal(t, "deployment.deploymentstrategy != blue_green_service", extension.GetTaskCondition("production", "delivery", 1))
+	require.Equal(t, "evaluation.result == fail", extension.GetTaskCondition("production", "delivery", 3))
+	require.Equal(t, "", extension.GetTaskCondition("production", "delivery", 4))
+	require.Equal(t, "", extension.GetTaskCondition("production", "remediation", 1))
+	require.Equal(t, "", extension.GetTaskCondition("dev", "delivery", 1))
+}
+
+func TestTaskExtension_GetBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff string
+		retry   int
+		want    time.Duration
+	}{
+		{name: "no backoff", backoff: "", retry: 0, want: 0},
+		{name: "invalid backoff", backoff: "soon", retry: 1, want: 0},
+		{name: "first retry", backoff: "30s", retry: 0, want: 30 * time.Second},
+		{name: "third retry", backoff: "30s", retry: 2, want: 2 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, TaskExtension{Backoff: tt.backoff}.GetBackoff(tt.retry))
+		})
+	}
+}
+
+const testShipyardWithRetriesInParallelGroup = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              retries: 2
+            - name: "test"
+              group: "tests"
+            - name: "securityscan"
+              group: "tests"
+              retries: 1`
+
+func TestShipyardExtension_Validate(t *testing.T) {
+	extension, err := DecodeShipyardExtension(testShipyardWithConditions)
+	require.Nil(t, err)
+	require.Nil(t, extension.Validate())
+
+	extension, err = DecodeShipyardExtension(testShipyardWithRetriesInParallelGroup)
+	require.Nil(t, err)
+	require.ErrorIs(t, extension.Validate(), ErrRetriesInParallelGroup)
+	require.ErrorIs(t, extension.GetSequence("dev", "delivery").Validate(), ErrRetriesInParallelGroup)
+
+	require.ErrorIs(t, SequenceExtension{Tasks: []TaskExtension{{Name: "test", Group: "tests", Backoff: "1m"}}}.Validate(), ErrRetriesInParallelGroup)
+	require.Nil(t, SequenceExtension{Tasks: []TaskExtension{{Name: "test", Retries: 2, Backoff: "1m"}}}.Validate())
+}
+
+const testShipyardWithFreezeWindows = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    # the freeze windows only apply to production
+    - name: "dev"
+    - name: "production"
+      concurrency:
+        policy: "supersede"
+  freezeWindows:
+    - id: "christmas"
+      stage: "production"
+      start: "2022-12-24"
+      end: "2022-12-27"
+`
+
+func TestShipyardExtension_ValidateFreezeWindows(t *testing.T) {
+	extension, err := DecodeShipyardExtension(testShipyardWithFreezeWindows)
+	require.Nil(t, err)
+	require.Nil(t, extension.Validate())
+	require.Equal(t, []FreezeWindow{{ID: "christmas", Project: "sockshop", Stage: "production", Start: "2022-12-24", End: "2022-12-27"}}, extension.GetFreezeWindows("sockshop"))
+
+	tests := []struct {
+		name          string
+		freezeWindows []FreezeWindow
+	}{
+		{
+			name:          "missing id",
+			freezeWindows: []FreezeWindow{{Start: "2022-12-24", End: "2022-12-27"}},
+		},
+		{
+			name:          "duplicate id",
+			freezeWindows: []FreezeWindow{{ID: "christmas", Start: "2022-12-24", End: "2022-12-27"}, {ID: "christmas", Cron: "0 18 * * 5", Duration: "62h"}},
+		},
+		{
+			name:          "unknown stage",
+			freezeWindows: []FreezeWindow{{ID: "christmas", Stage: "staging", Start: "2022-12-24", End: "2022-12-27"}},
+		},
+		{
+			name:          "invalid schedule",
+			freezeWindows: []FreezeWindow{{ID: "christmas", Start: "2022-12-24"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extension.Spec.FreezeWindows = tt.freezeWindows
+			require.ErrorIs(t, extension.Validate(), ErrInvalidFreezeWindow)
+		})
+	}
+}
+
+func TestUpdateShipyardFreezeWindows(t *testing.T) {
+	updatedShipyard, err := UpdateShipyardFreezeWindows(testShipyardWithFreezeWindows, []FreezeWindow{
+		{ID: "weekend", Project: "sockshop", Cron: "0 18 * * 5", Duration: "62h"},
+	})
+	require.Nil(t, err)
+
+	extension, err := DecodeShipyardExtension(updatedShipyard)
+	require.Nil(t, err)
+	require.Equal(t, []FreezeWindow{{ID: "weekend", Cron: "0 18 * * 5", Duration: "62h"}}, extension.Spec.FreezeWindows)
+	require.Equal(t, ConcurrencySupersede, extension.GetConcurrencyPolicy("production").Policy)
+	require.Contains(t, updatedShipyard, "# the freeze windows only apply to production")
+	require.NotContains(t, updatedShipyard, "project")
+
+	updatedShipyard, err = UpdateShipyardFreezeWindows(updatedShipyard, nil)
+	require.Nil(t, err)
+	require.NotContains(t, updatedShipyard, "freezeWindows")
+
+	_, err = UpdateShipyardFreezeWindows("kind: Shipyard", nil)
+	require.NotNil(t, err)
+}
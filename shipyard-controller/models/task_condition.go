@@ -0,0 +1,90 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidTaskCondition indicates that the 'when' condition of a task cannot be parsed
+var ErrInvalidTaskCondition = errors.New("invalid task condition")
+
+const (
+	conditionOr        = "||"
+	conditionAnd       = "&&"
+	conditionEquals    = "=="
+	conditionNotEquals = "!="
+)
+
+// EvaluateTaskCondition evaluates the 'when' condition of a task against the given data.
+// A condition consists of one or more comparisons of the form '<path> == <value>' or '<path> != <value>', which can be combined using '&&' and '||'.
+// '&&' takes precedence over '||'. The path refers to a property within the data, e.g. 'evaluation.result' or 'deployment.deploymentstrategy'.
+// Values can optionally be quoted. Properties that are not available are treated as empty strings.
+// An empty condition is always met.
+func EvaluateTaskCondition(condition string, data map[string]interface{}) (bool, error) {
+	if strings.TrimSpace(condition) == "" {
+		return true, nil
+	}
+	for _, alternative := range strings.Split(condition, conditionOr) {
+		met := true
+		for _, comparison := range strings.Split(alternative, conditionAnd) {
+			comparisonMet, err := evaluateComparison(comparison, data)
+			if err != nil {
+				return false, err
+			}
+			met = met && comparisonMet
+		}
+		if met {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func evaluateComparison(comparison string, data map[string]interface{}) (bool, error) {
+	operator := conditionEquals
+	operands := strings.SplitN(comparison, conditionEquals, 2)
+	if len(operands) != 2 {
+		operator = conditionNotEquals
+		operands = strings.SplitN(comparison, conditionNotEquals, 2)
+	}
+	if len(operands) != 2 {
+		return false, fmt.Errorf("%w: expected a comparison using '%s' or '%s' but got '%s'", ErrInvalidTaskCondition, conditionEquals, conditionNotEquals, strings.TrimSpace(comparison))
+	}
+
+	path := strings.TrimSpace(operands[0])
+	expected := unquoteConditionValue(strings.TrimSpace(operands[1]))
+	if path == "" {
+		return false, fmt.Errorf("%w: missing property in comparison '%s'", ErrInvalidTaskCondition, strings.TrimSpace(comparison))
+	}
+
+	actual := lookupConditionValue(path, data)
+	if operator == conditionEquals {
+		return actual == expected, nil
+	}
+	return actual != expected, nil
+}
+
+func unquoteConditionValue(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+func lookupConditionValue(path string, data map[string]interface{}) string {
+	var current interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current, ok = currentMap[key]
+		if !ok || current == nil {
+			return ""
+		}
+	}
+	return fmt.Sprint(current)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEvaluateTaskCondition(t *testing.T) {
+	data := map[string]interface{}{
+		"result": "fail",
+		"evaluation": map[string]interface{}{
+			"result": "fail",
+			"score":  42,
+		},
+		"deployment": map[string]interface{}{
+			"deploymentstrategy": "blue_green_service",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "empty condition",
+			condition: "",
+			want:      true,
+		},
+		{
+			name:      "equals",
+			condition: "evaluation.result == fail",
+			want:      true,
+		},
+		{
+			name:      "equals with quoted value",
+			condition: `evaluation.result == "pass"`,
+			want:      false,
+		},
+		{
+			name:      "not equals",
+			condition: "deployment.deploymentstrategy != 'blue_green_service'",
+			want:      false,
+		},
+		{
+			name:      "numeric value",
+			condition: "evaluation.score == 42",
+			want:      true,
+		},
+		{
+			name:      "missing property is treated as empty string",
+			condition: "test.teststrategy != performance",
+			want:      true,
+		},
+		{
+			name:      "and",
+			condition: "evaluation.result == fail && deployment.deploymentstrategy == direct",
+			want:      false,
+		},
+		{
+			name:      "or",
+			condition: "evaluation.result == warning || evaluation.result == fail",
+			want:      true,
+		},
+		{
+			name:      "and takes precedence over or",
+			condition: "evaluation.result == pass && result == pass || result == fail",
+			want:      true,
+		},
+		{
+			name:      "missing operator",
+			condition: "evaluation.result",
+			wantErr:   true,
+		},
+		{
+			name:      "missing property",
+			condition: "== fail",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateTaskCondition(tt.condition, data)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTaskCondition)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
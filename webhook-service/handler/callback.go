@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+const maxCallbackPayloadSize = 1024 * 1024
+
+// pendingTask is a task of an asynchronous webhook that waits for the callback of the external system
+type pendingTask struct {
+	keptnHandler sdk.IKeptn
+	event        sdk.KeptnEvent
+	taskName     string
+	result       map[string]interface{}
+	responses    []string
+	data         map[string]interface{}
+	expiresAt    time.Time
+	timer        *time.Timer
+}
+
+// CallbackHandler keeps track of the pending tasks of asynchronous webhooks and sends their .finished events when the callback is received or the timeout is reached.
+// Pending tasks are kept in memory, i.e., they cannot be completed anymore when the webhook service is restarted. If a store is set, they are recorded in it,
+// so that their tasks can be failed by FailOrphanedTasks after the restart
+type CallbackHandler struct {
+	baseURL      string
+	store        lib.ICallbackStore
+	pendingTasks map[string]*pendingTask
+	mutex        sync.Mutex
+}
+
+type CallbackHandlerOption func(*CallbackHandler)
+
+// WithCallbackStore sets the store the pending tasks are recorded in
+func WithCallbackStore(store lib.ICallbackStore) CallbackHandlerOption {
+	return func(ch *CallbackHandler) {
+		ch.store = store
+	}
+}
+
+func NewCallbackHandler(baseURL string, opts ...CallbackHandlerOption) *CallbackHandler {
+	ch := &CallbackHandler{
+		baseURL:      baseURL,
+		pendingTasks: map[string]*pendingTask{},
+	}
+	for _, opt := range opts {
+		opt(ch)
+	}
+	return ch
+}
+
+// register adds a pending task and returns the one-time callback URL and token, which can be used in the templates of the webhook requests
+func (ch *CallbackHandler) register(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, taskName string, result map[string]interface{}, timeout time.Duration) (string, string, error) {
+	token, err := lib.NewCallbackToken()
+	if err != nil {
+		return "", "", err
+	}
+	task := &pendingTask{
+		keptnHandler: keptnHandler,
+		event:        event,
+		taskName:     taskName,
+		result:       result,
+		responses:    []string{},
+		expiresAt:    time.Now().Add(timeout),
+	}
+	if ch.store != nil {
+		err := ch.store.Add(lib.PendingCallback{
+			TokenHash: lib.HashCallbackToken(token),
+			Event:     models.KeptnContextExtendedCE(event),
+			TaskName:  taskName,
+			Result:    result,
+			ExpiresAt: task.expiresAt,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("could not store pending callback: %w", err)
+		}
+	}
+
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+	ch.pendingTasks[token] = task
+	task.timer = time.AfterFunc(timeout, func() {
+		ch.onTimeout(token, timeout)
+	})
+	return lib.GetCallbackURL(ch.baseURL, token), token, nil
+}
+
+// setResponses adds the responses of the webhook requests and the data extracted from them to the .finished event of the pending task
+func (ch *CallbackHandler) setResponses(token string, responses *webhookResponses) {
+	ch.mutex.Lock()
+	defer ch.mutex.Unlock()
+	if task, ok := ch.pendingTasks[token]; ok {
+		task.responses = responses.responses
+		task.data = responses.data
+	}
+}
+
+// cancel removes a pending task without sending a .finished event, e.g. because the webhook requests have failed
+func (ch *CallbackHandler) cancel(token string) {
+	if task := ch.remove(token); task != nil {
+		task.timer.Stop()
+	}
+}
+
+// remove removes a pending task and returns it, unless it is unknown or has already been completed after a restart of the webhook service
+func (ch *CallbackHandler) remove(token string) *pendingTask {
+	ch.mutex.Lock()
+	task, ok := ch.pendingTasks[token]
+	if !ok {
+		ch.mutex.Unlock()
+		return nil
+	}
+	delete(ch.pendingTasks, token)
+	ch.mutex.Unlock()
+
+	if ch.store != nil {
+		removed, err := ch.store.Remove(lib.HashCallbackToken(token))
+		if err != nil {
+			// the task is still tracked in memory, hence it can be completed anyway
+			logger.WithError(err).Error("could not remove pending callback from store")
+		} else if !removed {
+			logger.Infof("the task of event %s has already been completed after a restart of the webhook service", task.event.ID)
+			task.timer.Stop()
+			return nil
+		}
+	}
+	return task
+}
+
+func (ch *CallbackHandler) onTimeout(token string, timeout time.Duration) {
+	task := ch.remove(token)
+	if task == nil {
+		return
+	}
+	logger.Infof("no callback received for event %s within %s", task.event.ID, timeout)
+	task.fail(fmt.Sprintf("no callback received within %s", timeout))
+}
+
+func (task *pendingTask) fail(message string) {
+	task.result["result"] = keptnv2.ResultFailed
+	task.result["status"] = keptnv2.StatusErrored
+	task.result["message"] = message
+	task.result[task.taskName] = map[string]interface{}{"responses": task.responses}
+	if err := task.keptnHandler.SendFinishedEvent(task.event, task.result); err != nil {
+		logger.WithError(err).Error("could not send .finished event")
+	}
+}
+
+// FailOrphanedTasks fails the tasks that have been recorded in the store before the webhook service was restarted, since their callbacks cannot be received anymore.
+// It has to be called before the webhook service receives events, otherwise the tasks registered in the meantime are failed as well
+func (ch *CallbackHandler) FailOrphanedTasks(keptnHandler sdk.IKeptn) {
+	if ch.store == nil {
+		return
+	}
+	callbacks, err := ch.store.List()
+	if err != nil {
+		logger.WithError(err).Error("could not list pending callbacks")
+		return
+	}
+	for _, callback := range callbacks {
+		if removed, err := ch.store.Remove(callback.TokenHash); err != nil {
+			logger.WithError(err).Error("could not remove pending callback from store")
+			continue
+		} else if !removed {
+			continue
+		}
+		logger.Infof("failing the task of event %s, which has been waiting for a callback before the webhook service was restarted", callback.Event.ID)
+		task := &pendingTask{
+			keptnHandler: keptnHandler,
+			event:        sdk.KeptnEvent(callback.Event),
+			taskName:     callback.TaskName,
+			result:       callback.Result,
+			responses:    []string{},
+		}
+		if task.result == nil {
+			task.result = map[string]interface{}{}
+		}
+		task.fail("the webhook service has been restarted while waiting for the callback")
+	}
+}
+
+// ServeHTTP receives the callback of the external system and converts its payload into the .finished event of the pending task. Each callback URL can only be used once
+func (ch *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.URL.Path, lib.CallbackPath)
+
+	payload := &lib.CallbackPayload{}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackPayloadSize))
+	if err != nil {
+		http.Error(w, "could not read payload", http.StatusBadRequest)
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, payload); err != nil {
+			http.Error(w, "could not parse payload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := payload.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// the payload is validated first, so that the external system can retry the callback with a valid payload
+	task := ch.remove(token)
+	if task == nil {
+		http.Error(w, "no pending task found for callback", http.StatusNotFound)
+		return
+	}
+	task.timer.Stop()
+	if !time.Now().Before(task.expiresAt) {
+		// the timer may not have fired yet, but the token must not be accepted after it has expired
+		task.fail("no callback received before the callback URL expired")
+		http.Error(w, "callback URL has expired", http.StatusGone)
+		return
+	}
+
+	taskData := map[string]interface{}{
+		"responses": task.responses,
+	}
+	for field, value := range task.data {
+		taskData[field] = value
+	}
+	for field, value := range payload.Data {
+		if field != "responses" {
+			taskData[field] = value
+		}
+	}
+	task.result[task.taskName] = taskData
+	task.result["result"] = payload.Result
+	task.result["status"] = payload.Status
+	task.result["message"] = payload.Message
+	if err := task.keptnHandler.SendFinishedEvent(task.event, task.result); err != nil {
+		logger.WithError(err).Error("could not send .finished event")
+		http.Error(w, "could not send .finished event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
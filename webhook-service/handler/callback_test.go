@@ -0,0 +1,279 @@
+package handler_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const webHookContentWithCallback = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      executor: http
+      callback:
+        timeout: %s
+      requests:
+        - url: http://local:8080/{{.data.project}}
+          method: POST
+          payload: '{"callbackUrl": "{{.callback.url}}"}'`
+
+// syncKeptn synchronizes the .finished events that are sent when the timeout of a callback is reached with the test
+type syncKeptn struct {
+	sdk.IKeptn
+	mutex *sync.Mutex
+}
+
+func (s syncKeptn) SendFinishedEvent(event sdk.KeptnEvent, result interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.IKeptn.SendFinishedEvent(event, result)
+}
+
+type syncTaskHandler struct {
+	taskHandler sdk.TaskHandler
+	mutex       *sync.Mutex
+}
+
+func (s syncTaskHandler) Execute(keptnHandle sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
+	return s.taskHandler.Execute(syncKeptn{IKeptn: keptnHandle, mutex: s.mutex}, event)
+}
+
+func setupCallbackTest(t *testing.T, timeout string, httpExecutorMock *fake.IHTTPExecutorMock, mutex *sync.Mutex, opts ...handler.CallbackHandlerOption) (*sdk.FakeKeptn, *handler.CallbackHandler) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	callbackHandler := handler.NewCallbackHandler("https://keptn.example.com/api/webhook-service", opts...)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.RequestValidatorMock{}, &fake.ISecretReaderMock{}, handler.WithCallbackHandler(callbackHandler))
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithCallback, timeout)})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", syncTaskHandler{taskHandler: taskHandler, mutex: mutex}, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+	return fakeKeptn, callbackHandler
+}
+
+// getCallbackPath returns the path of the callback URL that has been sent to the external system
+func getCallbackPath(t *testing.T, httpExecutorMock *fake.IHTTPExecutorMock) string {
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	payload := httpExecutorMock.ExecuteCalls()[0].Request.Payload
+	require.Contains(t, payload, "https://keptn.example.com/api/webhook-service/v1/callback/")
+	path := strings.TrimPrefix(payload, `{"callbackUrl": "https://keptn.example.com/api/webhook-service`)
+	return strings.TrimSuffix(path, `"}`)
+}
+
+func sendCallback(callbackHandler *handler.CallbackHandler, path string, payload string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	callbackHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, path, strings.NewReader(payload)))
+	return recorder
+}
+
+func TestCallbackHandler_Callback(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 202, Body: "accepted"}, nil
+	}}
+	fakeKeptn, callbackHandler := setupCallbackTest(t, "1h", httpExecutorMock, &sync.Mutex{})
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	// only the .started event is sent before the callback has been received
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+	fakeKeptn.AssertSentEventType(t, 0, "sh.keptn.event.webhook.started")
+	path := getCallbackPath(t, httpExecutorMock)
+
+	recorder := sendCallback(callbackHandler, path, `{"result": "warning"`)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+
+	recorder = sendCallback(callbackHandler, path, `{"result": "warning", "message": "2 tests skipped", "data": {"buildId": "42"}}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultWarning)
+	eventData := map[string]interface{}{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData)
+	require.Equal(t, "2 tests skipped", eventData["message"])
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{"accepted"},
+		"buildId":   "42",
+	}, eventData["webhook"])
+
+	// the callback URL can only be used once
+	recorder = sendCallback(callbackHandler, path, `{}`)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+}
+
+func TestCallbackHandler_Timeout(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 202, Body: "accepted"}, nil
+	}}
+	mutex := &sync.Mutex{}
+	fakeKeptn, callbackHandler := setupCallbackTest(t, "50ms", httpExecutorMock, mutex)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	path := getCallbackPath(t, httpExecutorMock)
+
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(fakeKeptn.SentEvents) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	mutex.Lock()
+	defer mutex.Unlock()
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+
+	// callbacks received after the timeout are rejected
+	recorder := sendCallback(callbackHandler, path, `{}`)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestCallbackHandler_FailedRequest(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return nil, errors.New("unreachable")
+	}}
+	fakeKeptn, callbackHandler := setupCallbackTest(t, "50ms", httpExecutorMock, &sync.Mutex{})
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	path := getCallbackPath(t, httpExecutorMock)
+
+	// the task fails immediately, and the pending task is removed
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	recorder := sendCallback(callbackHandler, path, `{}`)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+
+	time.Sleep(100 * time.Millisecond)
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+}
+
+// newCallbackStoreMock returns a store that keeps the pending callbacks in a map
+func newCallbackStoreMock(callbacks map[string]lib.PendingCallback) *fake.ICallbackStoreMock {
+	mutex := &sync.Mutex{}
+	return &fake.ICallbackStoreMock{
+		AddFunc: func(callback lib.PendingCallback) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			callbacks[callback.TokenHash] = callback
+			return nil
+		},
+		RemoveFunc: func(tokenHash string) (bool, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			_, ok := callbacks[tokenHash]
+			delete(callbacks, tokenHash)
+			return ok, nil
+		},
+		ListFunc: func() ([]lib.PendingCallback, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			result := []lib.PendingCallback{}
+			for _, callback := range callbacks {
+				result = append(result, callback)
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestCallbackHandler_CallbackWithStore(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 202, Body: "accepted"}, nil
+	}}
+	callbacks := map[string]lib.PendingCallback{}
+	store := newCallbackStoreMock(callbacks)
+	fakeKeptn, callbackHandler := setupCallbackTest(t, "1h", httpExecutorMock, &sync.Mutex{}, handler.WithCallbackStore(store))
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	path := getCallbackPath(t, httpExecutorMock)
+	token := strings.TrimPrefix(path, lib.CallbackPath)
+
+	// only the hash of the token is stored
+	require.Len(t, store.AddCalls(), 1)
+	stored := store.AddCalls()[0].Callback
+	require.Equal(t, lib.HashCallbackToken(token), stored.TokenHash)
+	require.Equal(t, "webhook", stored.TaskName)
+	require.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+
+	recorder := sendCallback(callbackHandler, path, `{}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	require.Empty(t, callbacks)
+}
+
+func TestCallbackHandler_CallbackAfterTaskHasBeenFailedByRestart(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 202, Body: "accepted"}, nil
+	}}
+	callbacks := map[string]lib.PendingCallback{}
+	fakeKeptn, callbackHandler := setupCallbackTest(t, "1h", httpExecutorMock, &sync.Mutex{}, handler.WithCallbackStore(newCallbackStoreMock(callbacks)))
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	path := getCallbackPath(t, httpExecutorMock)
+
+	// another instance of the webhook service has failed the task after a restart
+	for tokenHash := range callbacks {
+		delete(callbacks, tokenHash)
+	}
+
+	recorder := sendCallback(callbackHandler, path, `{}`)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+}
+
+func TestCallbackHandler_FailOrphanedTasks(t *testing.T) {
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+	callbacks := map[string]lib.PendingCallback{
+		"my-hash": {
+			TokenHash: "my-hash",
+			Event:     event,
+			TaskName:  "webhook",
+			Result:    map[string]interface{}{"project": "myproject", "stage": "dev", "service": "myservice"},
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+	store := newCallbackStoreMock(callbacks)
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	callbackHandler := handler.NewCallbackHandler("https://keptn.example.com/api/webhook-service", handler.WithCallbackStore(store))
+
+	callbackHandler.FailOrphanedTasks(fakeKeptn.Keptn)
+
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+	fakeKeptn.AssertSentEventType(t, 0, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 0, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 0, keptnv2.ResultFailed)
+	eventData := map[string]interface{}{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[0], &eventData)
+	require.Equal(t, "myproject", eventData["project"])
+	require.Equal(t, "the webhook service has been restarted while waiting for the callback", eventData["message"])
+	require.Empty(t, callbacks)
+
+	// tasks are only failed once
+	callbackHandler.FailOrphanedTasks(fakeKeptn.Keptn)
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	keptn "github.com/keptn/go-utils/pkg/api/utils"
 
@@ -24,17 +25,41 @@ type SecretEnv struct {
 type TaskHandler struct {
 	templateEngine   lib.ITemplateEngine
 	curlExecutor     lib.ICurlExecutor
+	httpExecutor     lib.IHTTPExecutor
 	requestValidator lib.RequestValidator
 	secretReader     lib.ISecretReader
+	integrationID    string
+	callbackHandler  *CallbackHandler
 }
 
-func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader) *TaskHandler {
-	return &TaskHandler{
+type TaskHandlerOption func(handler *TaskHandler)
+
+// WithIntegrationID sets the ID of the integration that is used to forward log messages, e.g. about retried requests, to the uniform log
+func WithIntegrationID(integrationID string) TaskHandlerOption {
+	return func(handler *TaskHandler) {
+		handler.integrationID = integrationID
+	}
+}
+
+// WithCallbackHandler sets the handler that keeps track of the pending tasks of webhooks in callback mode
+func WithCallbackHandler(callbackHandler *CallbackHandler) TaskHandlerOption {
+	return func(handler *TaskHandler) {
+		handler.callbackHandler = callbackHandler
+	}
+}
+
+func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, httpExecutor lib.IHTTPExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader, opts ...TaskHandlerOption) *TaskHandler {
+	handler := &TaskHandler{
 		templateEngine:   templateEngine,
 		curlExecutor:     curlExecutor,
+		httpExecutor:     httpExecutor,
 		requestValidator: requestValidator,
 		secretReader:     secretReader,
 	}
+	for _, o := range opts {
+		o(handler)
+	}
+	return handler
 }
 
 func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
@@ -53,8 +78,6 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return th.onPreExecutionError(keptnHandler, event, eventAdapter, fmt.Errorf("could not retrieve Webhook config: %s", err.Error()))
 	}
 
-	responses := []string{}
-
 	if sdkErr := th.onStartedWebhookExecution(keptnHandler, event, webhook); sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -67,12 +90,33 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 	eventAdapter.Add("env", secretEnvVars)
-	responses, err = th.performWebhookRequests(*webhook, eventAdapter, responses)
+
+	callbackToken := ""
+	if keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type) && webhook.UsesCallback() {
+		// the pending task is registered before the requests are executed, since the external system may call back before the requests have returned
+		callbackToken, err = th.registerCallback(keptnHandler, event, eventAdapter, webhook)
+		if err != nil {
+			err = lib.NewWebhookExecutionError(true, err)
+			onError(err, secretEnvVars)
+			return nil, sdkError(err.Error(), err)
+		}
+	}
+
+	responses, err := th.performWebhookRequests(keptnHandler, event, *webhook, eventAdapter, secretEnvVars)
 	if err != nil {
+		if callbackToken != "" {
+			th.callbackHandler.cancel(callbackToken)
+		}
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 
+	if callbackToken != "" {
+		// the .finished event is sent by the callback handler
+		th.callbackHandler.setResponses(callbackToken, responses)
+		return nil, nil
+	}
+
 	// check if the incoming event was a task.triggered event, and if the 'sendFinished'  property of the webhook was set to true
 	// only in this case, the result should be sent back to Keptn in the form of a .finished event
 	if keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type) && webhook.ShouldSendFinishedEvent() {
@@ -80,14 +124,22 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		if err != nil {
 			return nil, sdkError(fmt.Sprintf("could not derive task name from event type %s", *event.Type), err)
 		}
+		taskData := map[string]interface{}{
+			"responses": responses.responses,
+		}
+		for field, value := range responses.data {
+			taskData[field] = value
+		}
 		result := map[string]interface{}{
 			"project": eventAdapter.Project(),
 			"stage":   eventAdapter.Stage(),
 			"service": eventAdapter.Service(),
 			"labels":  eventAdapter.Labels(),
-			taskName: map[string]interface{}{
-				"responses": responses,
-			},
+			taskName:  taskData,
+		}
+		if responses.result != keptnv2.ResultPass {
+			result["result"] = responses.result
+			result["message"] = strings.Join(responses.messages, "; ")
 		}
 		err = keptnHandler.SendFinishedEvent(event, result)
 		if err != nil {
@@ -99,6 +151,32 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 	return nil, nil
 }
 
+// registerCallback registers the pending task of a webhook in callback mode, and adds the callback URL and token to the data that is available in the templates of the requests
+func (th *TaskHandler) registerCallback(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, webhook *lib.Webhook) (string, error) {
+	if th.callbackHandler == nil {
+		return "", errors.New("webhooks with a callback are not supported by this webhook service")
+	}
+	taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
+	if err != nil {
+		return "", fmt.Errorf("could not derive task name from event type %s", *event.Type)
+	}
+	result := map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+	}
+	callbackURL, token, err := th.callbackHandler.register(keptnHandler, event, taskName, result, webhook.Callback.GetTimeout())
+	if err != nil {
+		return "", err
+	}
+	eventAdapter.Add("callback", map[string]interface{}{
+		"url":   callbackURL,
+		"token": token,
+	})
+	return token, nil
+}
+
 func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, err error) (interface{}, *sdk.Error) {
 	// in this case, send .started and .finished event immediately
 	if err := keptnHandler.SendStartedEvent(event); err != nil {
@@ -139,7 +217,8 @@ func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn,
 				// the webhook service will then send a correlating .finished event with the aggregated response payloads
 				th.sendFinishedEvent(keptnHandler, event, result)
 			} else {
-				nrOfFinishedEvents := len(webhook.Requests) - whe.ExecutedRequests
+				// retry attempts do not have their own .started events
+				nrOfFinishedEvents := len(webhook.Requests) - (whe.ExecutedRequests - whe.RetryAttempts)
 				// if sendFinished is set to false, we need to send a .started event for each webhook request to be executed
 				for i := 0; i < nrOfFinishedEvents; i++ {
 					th.sendFinishedEvent(keptnHandler, event, result)
@@ -195,31 +274,196 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	return nil
 }
 
-func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string) ([]string, error) {
+// webhookResponses contains the responses of the requests of a webhook, as well as the data and the result that have been derived from them
+type webhookResponses struct {
+	responses []string
+	data      map[string]interface{}
+	result    keptnv2.ResultType
+	messages  []string
+}
+
+func (wr *webhookResponses) add(request int, response *lib.ParsedResponse) {
+	wr.responses = append(wr.responses, response.Body)
+	for field, value := range response.Data {
+		wr.data[field] = value
+	}
+	// the result of the webhook is the worst result of its requests
+	if response.Result == keptnv2.ResultFailed || response.Result == keptnv2.ResultWarning && wr.result == keptnv2.ResultPass {
+		wr.result = response.Result
+	}
+	if response.Message != "" {
+		wr.messages = append(wr.messages, fmt.Sprintf("request %d: %s", request+1, response.Message))
+	}
+}
+
+func (th *TaskHandler) performWebhookRequests(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, secrets map[string]string) (*webhookResponses, error) {
+	responses := &webhookResponses{
+		responses: []string{},
+		data:      map[string]interface{}{},
+		result:    keptnv2.ResultPass,
+	}
 	executedRequests := 0
+	retryAttempts := 0
+	previousResponses := []interface{}{}
 	logger.Infof("executing webhooks for subscriptionID %s", webhook.SubscriptionID)
-	for _, req := range webhook.Requests {
-		request, err := th.CreateRequest(req)
-		if err != nil {
-			logger.Infof("creating CURL request failed: %s", err.Error())
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating CURL request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+	for i, req := range webhook.Requests {
+		retryConfig := getRetryConfig(req)
+		var response *lib.HTTPResponse
+		var err error
+		for attempt := 0; ; attempt++ {
+			if attempt > 0 {
+				// each retry attempt is an executed request on its own
+				executedRequests = executedRequests + 1
+				retryAttempts = retryAttempts + 1
+				backoff := retryConfig.GetBackoff(attempt)
+				message := fmt.Sprintf("request %d failed: %s. Retrying in %s (attempt %d of %d)", i+1, describeFailedAttempt(response, err), backoff, attempt, retryConfig.Retries)
+				th.logRetryAttempt(keptnHandler, event, removeSecretsFromMessage(message, secrets))
+				time.Sleep(backoff)
+			}
+			if webhook.UsesHTTPExecutor() {
+				response, err = th.performHTTPRequest(req, eventAdapter)
+			} else {
+				response, err = th.performCurlRequest(req, eventAdapter)
+			}
+			if attempt >= retryConfig.Retries || !retryConfig.ShouldRetry(response, err) {
+				break
+			}
 		}
-		// parse the data from the event, together with the secret env vars
-		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(executedRequests), lib.WithNrOfRetryAttempts(retryAttempts))
 		}
-		// perform the request
-		response, err := th.curlExecutor.Curl(parsedCurlCommand)
+		executedRequests = executedRequests + 1
+		parsedResponse, err := lib.ParseResponse(response, getResponseConfig(req))
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s': %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not process response of request %d: %s", i+1, err.Error()), lib.WithNrOfExecutedRequests(executedRequests), lib.WithNrOfRetryAttempts(retryAttempts))
 		}
-		executedRequests = executedRequests + 1
-		responses = append(responses, response)
+		responses.add(i, parsedResponse)
+		// the responses of the previous requests can be used in the templates of the subsequent requests
+		previousResponses = append(previousResponses, parsedResponse.TemplateData())
+		eventAdapter.Add("responses", previousResponses)
 	}
 	return responses, nil
 }
 
+func describeFailedAttempt(response *lib.HTTPResponse, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("status code %d", response.StatusCode)
+}
+
+// logRetryAttempt logs the retry attempt of a request. If the integration ID is known, the message is also forwarded to the uniform log
+func (th *TaskHandler) logRetryAttempt(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, message string) {
+	logger.Info(message)
+	if th.integrationID == "" {
+		return
+	}
+	triggeredID := event.Triggeredid
+	if keptnv2.IsTriggeredEventType(*event.Type) {
+		triggeredID = event.ID
+	}
+	taskName, _, _ := keptnv2.ParseTaskEventType(*event.Type)
+	logsAPI := keptnHandler.APIV1().LogsV1()
+	logsAPI.Log([]models.LogEntry{{
+		IntegrationID: th.integrationID,
+		Message:       message,
+		Time:          time.Now().UTC(),
+		KeptnContext:  event.Shkeptncontext,
+		Task:          taskName,
+		TriggeredID:   triggeredID,
+	}})
+	if err := logsAPI.Flush(); err != nil {
+		logger.WithError(err).Error("could not forward retry attempt to the uniform log")
+	}
+}
+
+func (th *TaskHandler) performCurlRequest(req interface{}, eventAdapter *lib.EventDataAdapter) (*lib.HTTPResponse, error) {
+	request, err := th.CreateRequest(req)
+	if err != nil {
+		logger.Infof("creating CURL request failed: %s", err.Error())
+		return nil, fmt.Errorf("creating CURL request failed: %s", err.Error())
+	}
+	// parse the data from the event, together with the secret env vars
+	parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse request '%s' : %s", request, err.Error())
+	}
+	// perform the request
+	output, err := th.curlExecutor.Curl(parsedCurlCommand)
+	requiresStatusCode := getRetryConfig(req).RequiresStatusCode()
+	if err != nil {
+		var curlErr *lib.CurlError
+		if !requiresStatusCode || !lib.IsRequestError(err) || !errors.As(err, &curlErr) {
+			return nil, fmt.Errorf("could not execute request '%s': %s", request, err.Error())
+		}
+		// if the status code has been written, a response has been received, and its status code is evaluated the same way as for successful requests
+		if response := lib.ParseCurlOutput(curlErr.Output()); response.StatusCode != 0 {
+			return response, nil
+		}
+		return nil, lib.NewConnectionError(fmt.Errorf("could not execute request '%s': %s", request, err.Error()))
+	}
+	if requiresStatusCode {
+		return lib.ParseCurlOutput(output), nil
+	}
+	return &lib.HTTPResponse{Body: output}, nil
+}
+
+func (th *TaskHandler) performHTTPRequest(req interface{}, eventAdapter *lib.EventDataAdapter) (*lib.HTTPResponse, error) {
+	request := lib.ConvertToRequest(req)
+	// parse the data from the event, together with the secret env vars
+	parsedRequest, err := th.parseRequestTemplates(request, eventAdapter.Get())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse request '%s %s' : %s", request.Method, request.URL, err.Error())
+	}
+	// the request is validated by the executor, since the URL may contain templates
+	response, err := th.httpExecutor.Execute(parsedRequest)
+	if err != nil {
+		executionErr := fmt.Errorf("could not execute request '%s %s': %s", request.Method, request.URL, err.Error())
+		if lib.IsConnectionError(err) {
+			return nil, lib.NewConnectionError(executionErr)
+		}
+		return nil, executionErr
+	}
+	return response, nil
+}
+
+func (th *TaskHandler) parseRequestTemplates(request lib.Request, data interface{}) (lib.Request, error) {
+	parsedRequest := request
+	var err error
+	if parsedRequest.URL, err = th.templateEngine.ParseTemplate(data, request.URL); err != nil {
+		return lib.Request{}, err
+	}
+	if parsedRequest.Payload, err = th.templateEngine.ParseTemplate(data, request.Payload); err != nil {
+		return lib.Request{}, err
+	}
+	parsedRequest.Headers = make([]lib.Header, len(request.Headers))
+	for i, header := range request.Headers {
+		if parsedRequest.Headers[i].Key, err = th.templateEngine.ParseTemplate(data, header.Key); err != nil {
+			return lib.Request{}, err
+		}
+		if parsedRequest.Headers[i].Value, err = th.templateEngine.ParseTemplate(data, header.Value); err != nil {
+			return lib.Request{}, err
+		}
+	}
+	return parsedRequest, nil
+}
+
+// getResponseConfig returns the response configuration of a request. Only v1beta1 requests can define how their response is processed
+func getResponseConfig(request interface{}) *lib.Response {
+	if _, ok := request.(string); ok {
+		return nil
+	}
+	return lib.ConvertToRequest(request).Response
+}
+
+// getRetryConfig returns the request that defines the retry settings of a request. Only v1beta1 requests can be retried
+func getRetryConfig(request interface{}) lib.Request {
+	if _, ok := request.(string); ok {
+		return lib.Request{}
+	}
+	return lib.ConvertToRequest(request)
+}
+
 func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
 	secretEnvVars := map[string]string{}
 	for _, secretRef := range webhook.EnvFrom {
@@ -287,6 +531,10 @@ func buildBetaCurlRequest(req lib.Request) string {
 	if req.URL != "" {
 		tmpReq = fmt.Sprintf(tmpReq+" %s", req.URL)
 	}
+	// the option contains formatting directives, therefore it is appended without using it as format string
+	if req.RequiresStatusCode() {
+		tmpReq = tmpReq + " " + lib.StatusCodeWriteOutOption
+	}
 	return tmpReq
 }
 
@@ -3,8 +3,10 @@ package handler_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/keptn/go-utils/pkg/api/models"
 	api "github.com/keptn/go-utils/pkg/api/utils"
+	utils_mock "github.com/keptn/go-utils/pkg/api/utils/fake"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/go-sdk/pkg/sdk"
 	"github.com/keptn/keptn/webhook-service/handler"
@@ -168,6 +170,87 @@ spec:
       requests:
         - "curl http://local:8080 {{.data.project}} {{.env.mysecret}}"`
 
+const webHookContentWithResponseMapping = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - url: http://local:8080/{{.data.project}}
+          method: GET
+          response:
+            statusCodes: [200]
+            data:
+              buildId: "$.build.id"
+              state: "$.build.state"
+            result:
+              field: state
+              pass: ["SUCCESS"]
+              warning: ["UNSTABLE"]`
+
+const webHookContentWithHTTPExecutor = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      executor: http
+      envFrom:
+        - name: mysecret
+          secretRef:
+            name: mysecret
+            key: token
+      requests:
+        - url: http://local:8080/{{.data.project}}
+          method: POST
+          timeout: 10s
+          headers:
+            - key: x-token
+              value: "{{.env.mysecret}}"
+          payload: '{"stage": "{{.data.stage}}"}'
+          response:
+            data:
+              id: "$.id"`
+
+const webHookContentWithRetries = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: %t
+      executor: http
+      requests:
+        - url: http://local:8080/{{.data.project}}
+          method: POST
+          retries: 2
+          retryOn:
+            - connection-error
+            - 5xx
+          backoff:
+            initial: 1ms
+        - url: http://local:8080/{{.data.stage}}
+          method: POST`
+
+// fakeLogsAPI provides the logs API of the Keptn API set, which is used to forward messages to the uniform log
+type fakeLogsAPI struct {
+	api.KeptnInterface
+	logHandler *utils_mock.ILogHandlerMock
+}
+
+func (f fakeLogsAPI) LogsV1() api.LogsV1Interface {
+	return f.logHandler
+}
+
 func newWebhookTriggeredEvent(filename string) models.KeptnContextExtendedCE {
 	content, err := ioutil.ReadFile(filename)
 	if err != nil {
@@ -197,7 +280,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent})
@@ -236,7 +319,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStartedEvent})
@@ -268,7 +351,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -302,7 +385,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -336,7 +419,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -370,7 +453,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -410,7 +493,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -453,7 +536,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -495,7 +578,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -542,7 +625,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -568,7 +651,7 @@ func TestTaskHandler_Execute_WebhookCannotBeRetrieved(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -593,7 +676,7 @@ func TestTaskHandler_Execute_NoSubscriptionIDInEvent(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -613,7 +696,7 @@ func TestTaskHandler_Execute_InvalidEvent(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -637,7 +720,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -668,7 +751,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithMissingTemplateData})
@@ -705,7 +788,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 		return "", errors.New("unable to execute curl call")
 	}
 	requestValidatorMock := &fake.RequestValidatorMock{}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -747,7 +830,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 	requestValidatorMock.ValidateFunc = func(request lib.Request) error {
 		return errors.New("validation failed")
 	}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -784,7 +867,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 		return "", errors.New("unable to execute curl call containing secret my-secret-value")
 	}
 	requestValidatorMock := fake.RequestValidatorMock{}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -837,7 +920,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -896,7 +979,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -961,7 +1044,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1000,6 +1083,463 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 	require.Equal(t, "myproject", scopeVals3.FieldByName("project").String())
 }
 
+func TestTaskHandler_Execute_ResponseMapping(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		return `{"build":{"id":42,"state":"UNSTABLE"}}` + "\n200", nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseMapping})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, curlExecutorMock.CurlCalls(), 1)
+	require.Equal(t, `curl --request GET http://local:8080/myproject --write-out '\n%{http_code}'`, curlExecutorMock.CurlCalls()[0].CurlCmd)
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultWarning)
+
+	eventData := map[string]interface{}{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData)
+	require.Equal(t, "request 1: field 'state' has the value 'UNSTABLE'", eventData["message"])
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{`{"build":{"id":42,"state":"UNSTABLE"}}`},
+		"buildId":   float64(42),
+		"state":     "UNSTABLE",
+	}, eventData["webhook"])
+}
+
+func TestTaskHandler_Execute_UnexpectedStatusCode(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		return `{"build":{"id":42,"state":"SUCCESS"}}` + "\n202", nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseMapping})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData)
+	require.Contains(t, eventData.Message, "unexpected status code 202")
+}
+
+func TestTaskHandler_Execute_HTTPExecutor(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: `{"id":"42"}`}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithHTTPExecutor})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Empty(t, curlExecutorMock.CurlCalls())
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	require.Equal(t, lib.Request{
+		URL:     "http://local:8080/myproject",
+		Method:  "POST",
+		Headers: []lib.Header{{Key: "x-token", Value: "my-secret-value"}},
+		Payload: `{"stage": "mystage"}`,
+		Timeout: "10s",
+		Response: &lib.Response{
+			Data: map[string]string{"id": "$.id"},
+		},
+	}, httpExecutorMock.ExecuteCalls()[0].Request)
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+
+	eventData := map[string]interface{}{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData)
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{`{"id":"42"}`},
+		"id":        "42",
+	}, eventData["webhook"])
+}
+
+func TestTaskHandler_Execute_HTTPExecutorFailedRequest(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 401, Body: "invalid token my-secret-value"}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithHTTPExecutor})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData)
+	require.Contains(t, eventData.Message, "request failed with status code 401")
+	require.NotContains(t, eventData.Message, "my-secret-value")
+}
+
+func TestTaskHandler_Execute_RetryFailedRequest(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if len(httpExecutorMock.ExecuteCalls()) == 1 {
+			return &lib.HTTPResponse{StatusCode: 502, Body: "bad gateway"}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock, handler.WithIntegrationID("my-integration-id"))
+
+	logHandlerMock := &utils_mock.ILogHandlerMock{
+		LogFunc:   func(logs []models.LogEntry) {},
+		FlushFunc: func() error { return nil },
+	}
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetAPI(fakeLogsAPI{logHandler: logHandlerMock})
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithRetries, true)})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	// the first request is executed twice, the second one once
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 3)
+
+	// the retry attempt is forwarded to the uniform log
+	require.Len(t, logHandlerMock.LogCalls(), 1)
+	require.Len(t, logHandlerMock.LogCalls()[0].Logs, 1)
+	logEntry := logHandlerMock.LogCalls()[0].Logs[0]
+	require.Equal(t, "my-integration-id", logEntry.IntegrationID)
+	require.Equal(t, "webhook", logEntry.Task)
+	require.Contains(t, logEntry.Message, "request 1 failed: status code 502")
+	require.Len(t, logHandlerMock.FlushCalls(), 1)
+
+	//verify sent events
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+}
+
+func TestTaskHandler_Execute_RetryAttemptDoesNotContainSecrets(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if len(httpExecutorMock.ExecuteCalls()) == 1 {
+			return nil, lib.NewConnectionError(fmt.Errorf("Post %s: connection refused", request.URL))
+		}
+		return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+	}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.RequestValidatorMock{}, secretReaderMock, handler.WithIntegrationID("my-integration-id"))
+
+	logHandlerMock := &utils_mock.ILogHandlerMock{
+		LogFunc:   func(logs []models.LogEntry) {},
+		FlushFunc: func() error { return nil },
+	}
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetAPI(fakeLogsAPI{logHandler: logHandlerMock})
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      executor: http
+      envFrom:
+        - name: secretKey
+          secretRef:
+            name: mysecret
+            key: my-key
+      requests:
+        - url: http://local:8080/{{.env.secretKey}}
+          method: POST
+          retries: 1
+          retryOn:
+            - connection-error
+          backoff:
+            initial: 1ms`})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	require.Len(t, logHandlerMock.LogCalls(), 1)
+	message := logHandlerMock.LogCalls()[0].Logs[0].Message
+	require.Contains(t, message, "request 1 failed")
+	require.NotContains(t, message, "my-secret-value")
+}
+
+func TestTaskHandler_Execute_RetryFailedCurlRequest(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	commandExecutorMock := &fake.ICommandExecutorMock{}
+	commandExecutorMock.ExecuteCommandFunc = func(cmd string, args ...string) (string, error) {
+		// curl fails with --fail-with-body, but still writes the status code
+		if len(commandExecutorMock.ExecuteCommandCalls()) == 1 {
+			return "service unavailable\n503", errors.New("exit status 22")
+		}
+		return "success\n200", nil
+	}
+	curlExecutor := lib.NewCmdCurlExecutor(commandExecutorMock)
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutor, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - url: http://local:8080
+          method: GET
+          retries: 1
+          backoff:
+            initial: 1ms`})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, commandExecutorMock.ExecuteCommandCalls(), 2)
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 1, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	eventData := map[string]interface{}{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData)
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{"success"},
+	}, eventData["webhook"])
+}
+
+func TestTaskHandler_Execute_RetriesExhausted(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return nil, lib.NewConnectionError(errors.New("connection refused"))
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithRetries, false)})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	// the first request is executed once and retried twice, the second one is not executed
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 3)
+
+	// one .started event and one .finished event per request, since none of the requests succeeded
+	fakeKeptn.AssertNumberOfEventSent(t, 4)
+	fakeKeptn.AssertSentEventType(t, 2, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 2, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventType(t, 3, "sh.keptn.event.webhook.finished")
+	fakeKeptn.AssertSentEventStatus(t, 3, keptnv2.StatusErrored)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[3], eventData)
+	require.Contains(t, eventData.Message, "connection refused")
+}
+
+const webHookContentWithChainedRequests = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      executor: http
+      envFrom:
+        - name: mysecret
+          secretRef:
+            name: mysecret
+            key: token
+      requests:
+        - url: http://local:8080/tickets
+          method: POST
+          headers:
+            - key: x-token
+              value: "{{.env.mysecret}}"
+          payload: '{"project": "{{.data.project}}"}'
+        - url: http://local:8080/tickets/{{.responses[0].body.id}}/comments
+          method: POST
+          headers:
+            - key: x-token
+              value: "{{.env.mysecret}}"
+          payload: '{"text": "created by {{.responses[0].body.author}} with status {{.responses[0].statusCode}}"}'`
+
+func TestTaskHandler_Execute_ChainedRequests(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if len(httpExecutorMock.ExecuteCalls()) == 1 {
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": 4711, "author": "keptn"}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: 201, Body: "comment added"}, nil
+	}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.RequestValidatorMock{}, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithChainedRequests})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	secondRequest := httpExecutorMock.ExecuteCalls()[1].Request
+	require.Equal(t, "http://local:8080/tickets/4711/comments", secondRequest.URL)
+	require.Equal(t, `{"text": "created by keptn with status 201"}`, secondRequest.Payload)
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+}
+
+func TestTaskHandler_Execute_ChainedRequestsHideSecret(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		if len(httpExecutorMock.ExecuteCalls()) == 1 {
+			return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": 4711, "author": "keptn"}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: 403, Body: "token my-secret-value is not allowed to comment on ticket 4711"}, nil
+	}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.RequestValidatorMock{}, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithChainedRequests})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData)
+	require.Contains(t, eventData.Message, "could not process response of request 2")
+	require.Contains(t, eventData.Message, "ticket 4711")
+	require.NotContains(t, eventData.Message, "my-secret-value")
+}
+
 func Test_createRequest(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
@@ -1009,7 +1549,7 @@ func Test_createRequest(t *testing.T) {
 		return nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
 
 	tests := []struct {
 		name    string
@@ -1076,6 +1616,26 @@ func Test_createRequest(t *testing.T) {
 			want:    "curl --request POST http://local:8080",
 			wantErr: false,
 		},
+		{
+			name: "valid beta input with expected status codes",
+			data: lib.Request{
+				Method:   "GET",
+				URL:      "http://local:8080",
+				Response: &lib.Response{StatusCodes: []int{200}},
+			},
+			want:    "curl --request GET http://local:8080 --write-out '\\n%{http_code}'",
+			wantErr: false,
+		},
+		{
+			name: "valid beta input with retries",
+			data: lib.Request{
+				Method:  "GET",
+				URL:     "http://local:8080",
+				Retries: 2,
+			},
+			want:    "curl --request GET http://local:8080 --write-out '\\n%{http_code}'",
+			wantErr: false,
+		},
 		{
 			name:    "invalid input",
 			data:    1,
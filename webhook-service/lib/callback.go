@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// CallbackPath is the path of the endpoint that receives the callbacks of asynchronous webhooks. The token of the callback is appended to the path
+const CallbackPath = "/v1/callback/"
+
+// CallbackBaseURLEnvVar is the env var containing the URL under which the callback endpoint of the webhook service can be reached by external systems
+const CallbackBaseURLEnvVar = "CALLBACK_BASE_URL"
+
+const defaultCallbackTimeout = 1 * time.Hour
+
+// maxCallbackTimeout limits how long a callback token is valid, since the token is the only authentication of the callback endpoint
+const maxCallbackTimeout = 24 * time.Hour
+
+// Callback enables the callback mode of a webhook. Instead of sending the .finished event after the requests have been executed,
+// the webhook service waits for the external system to call the callback URL
+type Callback struct {
+	// Timeout is the time the webhook service waits for the callback, e.g. '30m', at most '24h'. If no callback is received, the task fails
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// CallbackPayload is the payload the external system sends to the callback URL
+type CallbackPayload struct {
+	Result  keptnv2.ResultType     `json:"result,omitempty"`
+	Status  keptnv2.StatusType     `json:"status,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// GetTimeout returns the time the webhook service waits for the callback
+func (c *Callback) GetTimeout() time.Duration {
+	if c == nil || c.Timeout == "" {
+		return defaultCallbackTimeout
+	}
+	// the timeout is validated when the webhook config is decoded
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return defaultCallbackTimeout
+	}
+	if timeout > maxCallbackTimeout {
+		return maxCallbackTimeout
+	}
+	return timeout
+}
+
+// Validate checks the result and the status of the callback payload and sets their default values, which are 'pass' and 'succeeded'
+func (p *CallbackPayload) Validate() error {
+	switch p.Result {
+	case "":
+		p.Result = keptnv2.ResultPass
+	case keptnv2.ResultPass, keptnv2.ResultWarning, keptnv2.ResultFailed:
+	default:
+		return fmt.Errorf("invalid result '%s'", p.Result)
+	}
+	switch p.Status {
+	case "":
+		p.Status = keptnv2.StatusSucceeded
+	case keptnv2.StatusSucceeded, keptnv2.StatusErrored:
+	default:
+		return fmt.Errorf("invalid status '%s'", p.Status)
+	}
+	return nil
+}
+
+// NewCallbackToken generates a random token that identifies a pending callback
+func NewCallbackToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate callback token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GetCallbackURL returns the URL the external system has to call to complete the task identified by the token
+func GetCallbackURL(baseURL string, token string) string {
+	return strings.TrimSuffix(baseURL, "/") + CallbackPath + token
+}
+
+// GetCallbackBaseURL returns the URL of the callback endpoint. If it is not set via the CALLBACK_BASE_URL env var, the cluster internal URL of the webhook service is used
+func GetCallbackBaseURL(env map[string]string) string {
+	if env[CallbackBaseURLEnvVar] != "" {
+		return env[CallbackBaseURLEnvVar]
+	}
+	return fmt.Sprintf("http://webhook-service.%s:8080", env["POD_NAMESPACE"])
+}
+
+func verifyCallback(webhook Webhook) error {
+	if webhook.Callback == nil {
+		return nil
+	}
+	if !webhook.SendFinished {
+		return errors.New(webhookConfInvalid + "webhooks with a callback require 'sendFinished' to be set")
+	}
+	if webhook.Callback.Timeout != "" {
+		timeout, err := time.ParseDuration(webhook.Callback.Timeout)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid callback timeout '%s'", webhook.Callback.Timeout)
+		}
+		if timeout > maxCallbackTimeout {
+			return fmt.Errorf(webhookConfInvalid+"callback timeout '%s' exceeds the maximum of %s", webhook.Callback.Timeout, maxCallbackTimeout)
+		}
+	}
+	return nil
+}
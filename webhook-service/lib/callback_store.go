@@ -0,0 +1,115 @@
+package lib
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// CallbacksConfigMap is the ConfigMap the pending callbacks are stored in, so that their tasks can be failed after a restart of the webhook service
+const CallbacksConfigMap = "keptn-webhook-callbacks"
+
+// PendingCallback is the stored record of a task that waits for a callback. It contains only the hash of the token, since the token is the only authentication of the callback endpoint
+type PendingCallback struct {
+	TokenHash string                        `json:"tokenHash"`
+	Event     models.KeptnContextExtendedCE `json:"event"`
+	TaskName  string                        `json:"taskName"`
+	Result    map[string]interface{}        `json:"result"`
+	ExpiresAt time.Time                     `json:"expiresAt"`
+}
+
+//go:generate moq  -pkg fake -out ./fake/callback_store_mock.go . ICallbackStore
+type ICallbackStore interface {
+	// Add stores a pending callback
+	Add(callback PendingCallback) error
+	// Remove deletes the pending callback with the given token hash, and returns whether it has been stored. Only the caller that removed a callback may send the .finished event of its task
+	Remove(tokenHash string) (bool, error)
+	// List returns all stored pending callbacks
+	List() ([]PendingCallback, error)
+}
+
+// HashCallbackToken returns the hash of a callback token, which is used to identify a stored pending callback
+func HashCallbackToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// K8sCallbackStore stores the pending callbacks in the keptn-webhook-callbacks ConfigMap, which is created by the Helm chart
+type K8sCallbackStore struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func NewK8sCallbackStore(kubeClient kubernetes.Interface) *K8sCallbackStore {
+	return &K8sCallbackStore{
+		kubeClient: kubeClient,
+		namespace:  GetNamespaceFromEnvVar(),
+	}
+}
+
+func (s *K8sCallbackStore) Add(callback PendingCallback) error {
+	value, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("could not marshal pending callback: %w", err)
+	}
+	return s.update(func(data map[string]string) bool {
+		data[callback.TokenHash] = string(value)
+		return true
+	})
+}
+
+func (s *K8sCallbackStore) Remove(tokenHash string) (bool, error) {
+	removed := false
+	err := s.update(func(data map[string]string) bool {
+		_, removed = data[tokenHash]
+		delete(data, tokenHash)
+		return removed
+	})
+	return removed, err
+}
+
+func (s *K8sCallbackStore) List() ([]PendingCallback, error) {
+	configMap, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), CallbacksConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get ConfigMap %s: %w", CallbacksConfigMap, err)
+	}
+	callbacks := make([]PendingCallback, 0, len(configMap.Data))
+	for tokenHash, value := range configMap.Data {
+		callback := PendingCallback{}
+		if err := json.Unmarshal([]byte(value), &callback); err != nil {
+			return nil, fmt.Errorf("could not parse pending callback %s: %w", tokenHash, err)
+		}
+		callbacks = append(callbacks, callback)
+	}
+	return callbacks, nil
+}
+
+// update applies the change to the data of the ConfigMap, and retries it if the ConfigMap has been updated concurrently. The ConfigMap is only updated if the change returns true
+func (s *K8sCallbackStore) update(change func(data map[string]string) bool) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.kubeClient.CoreV1().ConfigMaps(s.namespace).Get(context.TODO(), CallbacksConfigMap, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		if !change(configMap.Data) {
+			return nil
+		}
+		_, err = s.kubeClient.CoreV1().ConfigMaps(s.namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not update ConfigMap %s: %w", CallbacksConfigMap, err)
+	}
+	return nil
+}
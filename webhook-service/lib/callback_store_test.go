@@ -0,0 +1,65 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sCallbackStore(t *testing.T) {
+	store := NewK8sCallbackStore(fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: CallbacksConfigMap,
+			},
+		}))
+
+	callbacks, err := store.List()
+	require.Nil(t, err)
+	require.Empty(t, callbacks)
+
+	callback := PendingCallback{
+		TokenHash: HashCallbackToken("my-token"),
+		Event:     models.KeptnContextExtendedCE{ID: "my-event-id"},
+		TaskName:  "webhook",
+		Result:    map[string]interface{}{"project": "my-project"},
+		ExpiresAt: time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.Nil(t, store.Add(callback))
+
+	callbacks, err = store.List()
+	require.Nil(t, err)
+	require.Equal(t, []PendingCallback{callback}, callbacks)
+
+	removed, err := store.Remove(callback.TokenHash)
+	require.Nil(t, err)
+	require.True(t, removed)
+
+	// a callback can only be removed once
+	removed, err = store.Remove(callback.TokenHash)
+	require.Nil(t, err)
+	require.False(t, removed)
+
+	callbacks, err = store.List()
+	require.Nil(t, err)
+	require.Empty(t, callbacks)
+}
+
+func TestK8sCallbackStore_MissingConfigMap(t *testing.T) {
+	store := NewK8sCallbackStore(fake.NewSimpleClientset())
+
+	require.NotNil(t, store.Add(PendingCallback{TokenHash: HashCallbackToken("my-token")}))
+	_, err := store.List()
+	require.NotNil(t, err)
+}
+
+func TestHashCallbackToken(t *testing.T) {
+	require.Equal(t, HashCallbackToken("my-token"), HashCallbackToken("my-token"))
+	require.NotEqual(t, HashCallbackToken("my-token"), HashCallbackToken("other-token"))
+	require.NotContains(t, HashCallbackToken("my-token"), "my-token")
+}
@@ -0,0 +1,38 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackPayload_Validate(t *testing.T) {
+	payload := &CallbackPayload{}
+	require.Nil(t, payload.Validate())
+	require.Equal(t, keptnv2.ResultPass, payload.Result)
+	require.Equal(t, keptnv2.StatusSucceeded, payload.Status)
+
+	payload = &CallbackPayload{Result: keptnv2.ResultWarning, Status: keptnv2.StatusErrored}
+	require.Nil(t, payload.Validate())
+	require.Equal(t, keptnv2.ResultWarning, payload.Result)
+	require.Equal(t, keptnv2.StatusErrored, payload.Status)
+
+	require.NotNil(t, (&CallbackPayload{Result: "ok"}).Validate())
+	require.NotNil(t, (&CallbackPayload{Status: "done"}).Validate())
+}
+
+func TestCallback_GetTimeout(t *testing.T) {
+	var callback *Callback
+	require.Equal(t, 1*time.Hour, callback.GetTimeout())
+	require.Equal(t, 1*time.Hour, (&Callback{}).GetTimeout())
+	require.Equal(t, 10*time.Minute, (&Callback{Timeout: "10m"}).GetTimeout())
+	require.Equal(t, 24*time.Hour, (&Callback{Timeout: "48h"}).GetTimeout())
+}
+
+func TestGetCallbackURL(t *testing.T) {
+	require.Equal(t, "https://keptn.example.com/api/webhook-service/v1/callback/my-token", GetCallbackURL("https://keptn.example.com/api/webhook-service/", "my-token"))
+	require.Equal(t, "http://webhook-service.keptn:8080", GetCallbackBaseURL(map[string]string{"POD_NAMESPACE": "keptn"}))
+	require.Equal(t, "https://keptn.example.com", GetCallbackBaseURL(map[string]string{"POD_NAMESPACE": "keptn", CallbackBaseURLEnvVar: "https://keptn.example.com"}))
+}
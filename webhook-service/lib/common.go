@@ -3,6 +3,8 @@ package lib
 import (
 	"os"
 	"strings"
+
+	"github.com/keptn/go-utils/pkg/api/models"
 )
 
 const (
@@ -17,6 +19,20 @@ func GetNamespaceFromEnvVar() string {
 	return os.Getenv("POD_NAMESPACE")
 }
 
+// GetIntegrationID returns the ID of the integration with the given name, which is derived from the same env vars that are used to register the integration.
+// If the env vars are not set, an empty string is returned
+func GetIntegrationID(integrationName string) string {
+	integrationID, err := models.IntegrationID{
+		Name:      integrationName,
+		Namespace: os.Getenv("K8S_NAMESPACE"),
+		NodeName:  os.Getenv("K8S_NODE_NAME"),
+	}.Hash()
+	if err != nil {
+		return ""
+	}
+	return integrationID
+}
+
 func GetEnv() map[string]string {
 	envMap := make(map[string]string)
 	for _, e := range os.Environ() {
@@ -18,12 +18,18 @@ const (
 type CurlError struct {
 	err    error
 	reason errType
+	output string
 }
 
 func (c *CurlError) Error() string {
 	return c.err.Error()
 }
 
+// Output returns the output of a failed curl request
+func (c *CurlError) Output() string {
+	return c.output
+}
+
 func NewCurlError(err error, reason errType) *CurlError {
 	return &CurlError{
 		err:    err,
@@ -98,7 +104,7 @@ func (ce *CmdCurlExecutor) Curl(curlCmd string) (string, error) {
 
 	resp, err := ce.commandExecutor.ExecuteCommand("curl", args[1:]...)
 	if err != nil {
-		return "", &CurlError{err: fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp), reason: RequestError}
+		return "", &CurlError{err: fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp), reason: RequestError, output: resp}
 	}
 	return resp, nil
 }
@@ -3,7 +3,10 @@ package lib
 type WebhookExecutionError struct {
 	PreExecutionError bool
 	ErrorObj          error
-	ExecutedRequests  int
+	// ExecutedRequests is the number of executed requests, including retry attempts
+	ExecutedRequests int
+	// RetryAttempts is the number of retry attempts that are included in ExecutedRequests
+	RetryAttempts int
 }
 
 type WebhookExecutionErrorOpt func(executionError *WebhookExecutionError)
@@ -14,6 +17,12 @@ func WithNrOfExecutedRequests(nrRequests int) WebhookExecutionErrorOpt {
 	}
 }
 
+func WithNrOfRetryAttempts(nrRetryAttempts int) WebhookExecutionErrorOpt {
+	return func(executionError *WebhookExecutionError) {
+		executionError.RetryAttempts = nrRetryAttempts
+	}
+}
+
 func NewWebhookExecutionError(preExec bool, err error, opts ...WebhookExecutionErrorOpt) *WebhookExecutionError {
 	whe := &WebhookExecutionError{
 		PreExecutionError: preExec,
@@ -0,0 +1,156 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package fake
+
+import (
+	"github.com/keptn/keptn/webhook-service/lib"
+	"sync"
+)
+
+// Ensure, that ICallbackStoreMock does implement lib.ICallbackStore.
+// If this is not the case, regenerate this file with moq.
+var _ lib.ICallbackStore = &ICallbackStoreMock{}
+
+// ICallbackStoreMock is a mock implementation of lib.ICallbackStore.
+//
+// 	func TestSomethingThatUsesICallbackStore(t *testing.T) {
+//
+// 		// make and configure a mocked lib.ICallbackStore
+// 		mockedICallbackStore := &ICallbackStoreMock{
+// 			AddFunc: func(callback lib.PendingCallback) error {
+// 				panic("mock out the Add method")
+// 			},
+// 			ListFunc: func() ([]lib.PendingCallback, error) {
+// 				panic("mock out the List method")
+// 			},
+// 			RemoveFunc: func(tokenHash string) (bool, error) {
+// 				panic("mock out the Remove method")
+// 			},
+// 		}
+//
+// 		// use mockedICallbackStore in code that requires lib.ICallbackStore
+// 		// and then make assertions.
+//
+// 	}
+type ICallbackStoreMock struct {
+	// AddFunc mocks the Add method.
+	AddFunc func(callback lib.PendingCallback) error
+
+	// ListFunc mocks the List method.
+	ListFunc func() ([]lib.PendingCallback, error)
+
+	// RemoveFunc mocks the Remove method.
+	RemoveFunc func(tokenHash string) (bool, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Add holds details about calls to the Add method.
+		Add []struct {
+			// Callback is the callback argument value.
+			Callback lib.PendingCallback
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+		}
+		// Remove holds details about calls to the Remove method.
+		Remove []struct {
+			// TokenHash is the tokenHash argument value.
+			TokenHash string
+		}
+	}
+	lockAdd    sync.RWMutex
+	lockList   sync.RWMutex
+	lockRemove sync.RWMutex
+}
+
+// Add calls AddFunc.
+func (mock *ICallbackStoreMock) Add(callback lib.PendingCallback) error {
+	if mock.AddFunc == nil {
+		panic("ICallbackStoreMock.AddFunc: method is nil but ICallbackStore.Add was just called")
+	}
+	callInfo := struct {
+		Callback lib.PendingCallback
+	}{
+		Callback: callback,
+	}
+	mock.lockAdd.Lock()
+	mock.calls.Add = append(mock.calls.Add, callInfo)
+	mock.lockAdd.Unlock()
+	return mock.AddFunc(callback)
+}
+
+// AddCalls gets all the calls that were made to Add.
+// Check the length with:
+//
+//     len(mockedICallbackStore.AddCalls())
+func (mock *ICallbackStoreMock) AddCalls() []struct {
+	Callback lib.PendingCallback
+} {
+	var calls []struct {
+		Callback lib.PendingCallback
+	}
+	mock.lockAdd.RLock()
+	calls = mock.calls.Add
+	mock.lockAdd.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ICallbackStoreMock) List() ([]lib.PendingCallback, error) {
+	if mock.ListFunc == nil {
+		panic("ICallbackStoreMock.ListFunc: method is nil but ICallbackStore.List was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	return mock.ListFunc()
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//     len(mockedICallbackStore.ListCalls())
+func (mock *ICallbackStoreMock) ListCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// Remove calls RemoveFunc.
+func (mock *ICallbackStoreMock) Remove(tokenHash string) (bool, error) {
+	if mock.RemoveFunc == nil {
+		panic("ICallbackStoreMock.RemoveFunc: method is nil but ICallbackStore.Remove was just called")
+	}
+	callInfo := struct {
+		TokenHash string
+	}{
+		TokenHash: tokenHash,
+	}
+	mock.lockRemove.Lock()
+	mock.calls.Remove = append(mock.calls.Remove, callInfo)
+	mock.lockRemove.Unlock()
+	return mock.RemoveFunc(tokenHash)
+}
+
+// RemoveCalls gets all the calls that were made to Remove.
+// Check the length with:
+//
+//     len(mockedICallbackStore.RemoveCalls())
+func (mock *ICallbackStoreMock) RemoveCalls() []struct {
+	TokenHash string
+} {
+	var calls []struct {
+		TokenHash string
+	}
+	mock.lockRemove.RLock()
+	calls = mock.calls.Remove
+	mock.lockRemove.RUnlock()
+	return calls
+}
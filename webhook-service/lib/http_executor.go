@@ -0,0 +1,159 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+const defaultMaxResponseSize = 10 * 1024 * 1024
+
+const maxRedirects = 10
+
+// HTTPResponse contains the status code and the body of the response of a webhook request
+type HTTPResponse struct {
+	StatusCode int
+	Body       string
+}
+
+//go:generate moq  -pkg fake -out ./fake/http_executor_mock.go . IHTTPExecutor
+type IHTTPExecutor interface {
+	Execute(request Request) (*HTTPResponse, error)
+}
+
+// HTTPExecutor performs webhook requests using the net/http package, without relying on a curl binary
+type HTTPExecutor struct {
+	requestValidator RequestValidator
+	denyListProvider DenyListProvider
+	defaultTimeout   time.Duration
+	maxResponseSize  int64
+}
+
+type HTTPExecutorOption func(executor *HTTPExecutor)
+
+// WithDefaultTimeout sets the timeout of requests that do not define a timeout
+func WithDefaultTimeout(timeout time.Duration) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.defaultTimeout = timeout
+	}
+}
+
+// WithMaxResponseSize sets the maximum number of bytes that are read from the response body
+func WithMaxResponseSize(maxResponseSize int64) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.maxResponseSize = maxResponseSize
+	}
+}
+
+func NewHTTPExecutor(requestValidator RequestValidator, denyListProvider DenyListProvider, opts ...HTTPExecutorOption) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		requestValidator: requestValidator,
+		denyListProvider: denyListProvider,
+		defaultTimeout:   defaultHTTPTimeout,
+		maxResponseSize:  defaultMaxResponseSize,
+	}
+	for _, o := range opts {
+		o(executor)
+	}
+	return executor
+}
+
+// Execute validates and performs the request. Responses with any status code are returned, the status code is evaluated by the caller
+func (he *HTTPExecutor) Execute(request Request) (*HTTPResponse, error) {
+	if err := he.requestValidator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	timeout := he.defaultTimeout
+	if request.Timeout != "" {
+		parsedTimeout, err := time.ParseDuration(request.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout '%s'", request.Timeout)
+		}
+		timeout = parsedTimeout
+	}
+
+	var body io.Reader
+	if request.Payload != "" {
+		body = strings.NewReader(request.Payload)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	httpRequest, err := http.NewRequestWithContext(ctx, request.Method, request.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	for _, header := range request.Headers {
+		httpRequest.Header.Add(header.Key, header.Value)
+	}
+
+	resp, err := he.newClient(request).Do(httpRequest)
+	if err != nil {
+		var deniedErr *deniedRequestError
+		if errors.As(err, &deniedErr) {
+			return nil, fmt.Errorf("error during request execution: %w", err)
+		}
+		return nil, NewConnectionError(fmt.Errorf("error during request execution: %w", err))
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, he.maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+	return &HTTPResponse{StatusCode: resp.StatusCode, Body: string(responseBody)}, nil
+}
+
+// deniedRequestError indicates that a request has been rejected by the executor. In contrast to connection errors, these errors are not retried
+type deniedRequestError struct {
+	err error
+}
+
+func (d *deniedRequestError) Error() string {
+	return d.err.Error()
+}
+
+func (he *HTTPExecutor) newClient(request Request) *http.Client {
+	denyList := he.denyListProvider.Get()
+	dialer := &net.Dialer{
+		// check the address that is actually connected to, since the resolved IP address may differ from the one that has been validated
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			for _, denied := range denyList {
+				if strings.Contains(host, denied) {
+					return &deniedRequestError{err: fmt.Errorf("request url resolves to denied IP address '%s'", denied)}
+				}
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		DialContext:     dialer.DialContext,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: request.InsecureSkipTLSVerify}, //nolint:gosec
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return &deniedRequestError{err: errors.New("too many redirects")}
+			}
+			if err := he.requestValidator.Validate(Request{URL: req.URL.String(), Method: req.Method}); err != nil {
+				return &deniedRequestError{err: err}
+			}
+			return nil
+		},
+	}
+}
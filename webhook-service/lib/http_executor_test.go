@@ -0,0 +1,148 @@
+package lib_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func newHTTPExecutor(denyList []string, opts ...lib.HTTPExecutorOption) *lib.HTTPExecutor {
+	requestValidator := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		if strings.Contains(request.URL, "denied") {
+			return errors.New("curl command contains denied URL 'denied'")
+		}
+		return nil
+	}}
+	denyListProvider := fake.DenyListProviderMock{GetDenyListFunc: func() []string {
+		return denyList
+	}}
+	return lib.NewHTTPExecutor(requestValidator, denyListProvider, opts...)
+}
+
+func TestHTTPExecutor_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "my-token", r.Header.Get("x-token"))
+		require.Equal(t, `{"project":"myproject"}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"42"}`))
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil)
+	response, err := executor.Execute(lib.Request{
+		URL:     server.URL,
+		Method:  http.MethodPost,
+		Headers: []lib.Header{{Key: "x-token", Value: "my-token"}},
+		Payload: `{"project":"myproject"}`,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusCreated, Body: `{"id":"42"}`}, response)
+}
+
+func TestHTTPExecutor_ExecuteReturnsFailedResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("not found"))
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil)
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusNotFound, Body: "not found"}, response)
+}
+
+func TestHTTPExecutor_ExecuteLimitsResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil, lib.WithMaxResponseSize(4))
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.Nil(t, err)
+	require.Equal(t, "0123", response.Body)
+}
+
+func TestHTTPExecutor_ExecuteInvalidRequest(t *testing.T) {
+	executor := newHTTPExecutor(nil)
+	_, err := executor.Execute(lib.Request{URL: "http://denied.com", Method: http.MethodGet})
+	require.EqualError(t, err, "curl command contains denied URL 'denied'")
+}
+
+func TestHTTPExecutor_ExecuteDeniedIPAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request should not be received")
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor([]string{"127.0.0.1"})
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "request url resolves to denied IP address '127.0.0.1'")
+	require.False(t, lib.IsConnectionError(err))
+}
+
+func TestHTTPExecutor_ExecuteDeniedRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://denied.com", http.StatusFound)
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil)
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "curl command contains denied URL 'denied'")
+	require.False(t, lib.IsConnectionError(err))
+}
+
+func TestHTTPExecutor_ExecuteTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil)
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "50ms"})
+	require.NotNil(t, err)
+	require.True(t, lib.IsConnectionError(err))
+
+	executor = newHTTPExecutor(nil, lib.WithDefaultTimeout(50*time.Millisecond))
+	_, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+}
+
+func TestHTTPExecutor_ExecuteConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	executor := newHTTPExecutor(nil)
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+	require.True(t, lib.IsConnectionError(err))
+}
+
+func TestHTTPExecutor_ExecuteTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("success"))
+	}))
+	defer server.Close()
+
+	executor := newHTTPExecutor(nil)
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, InsecureSkipTLSVerify: true})
+	require.Nil(t, err)
+	require.Equal(t, "success", response.Body)
+}
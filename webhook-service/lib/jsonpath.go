@@ -0,0 +1,144 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// EvaluateJSONPath evaluates a JSONPath expression on a decoded JSON document. The following expressions are supported:
+// $ (root), .key, ['key'], [index] (negative indices count from the end) and [*] or .* (all elements).
+// If the expression contains a wildcard, a list of all matching values is returned
+func EvaluateJSONPath(document interface{}, path string) (interface{}, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []interface{}{document}
+	hasWildcard := false
+	for _, segment := range segments {
+		next := []interface{}{}
+		for _, node := range nodes {
+			values, err := segment.apply(node)
+			if err != nil {
+				return nil, fmt.Errorf("could not evaluate JSONPath '%s': %w", path, err)
+			}
+			next = append(next, values...)
+		}
+		nodes = next
+		hasWildcard = hasWildcard || segment.wildcard
+	}
+
+	if hasWildcard {
+		return nodes, nil
+	}
+	return nodes[0], nil
+}
+
+func (s jsonPathSegment) apply(node interface{}) ([]interface{}, error) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if s.wildcard {
+			// return the values ordered by their keys to get a deterministic result
+			keys := make([]string, 0, len(value))
+			for k := range value {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := []interface{}{}
+			for _, k := range keys {
+				values = append(values, value[k])
+			}
+			return values, nil
+		}
+		if s.isIndex {
+			return nil, fmt.Errorf("cannot access index %d of an object", s.index)
+		}
+		v, ok := value[s.key]
+		if !ok {
+			return nil, fmt.Errorf("key '%s' not found", s.key)
+		}
+		return []interface{}{v}, nil
+	case []interface{}:
+		if s.wildcard {
+			return value, nil
+		}
+		if !s.isIndex {
+			return nil, fmt.Errorf("cannot access key '%s' of an array", s.key)
+		}
+		index := s.index
+		if index < 0 {
+			index = len(value) + index
+		}
+		if index < 0 || index >= len(value) {
+			return nil, fmt.Errorf("index %d out of range", s.index)
+		}
+		return []interface{}{value[index]}, nil
+	default:
+		if s.isIndex {
+			return nil, fmt.Errorf("cannot access index %d of a scalar value", s.index)
+		}
+		return nil, fmt.Errorf("cannot access key '%s' of a scalar value", s.key)
+	}
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath '%s' must start with '$'", path)
+	}
+
+	segments := []jsonPathSegment{}
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("JSONPath '%s' contains an empty key", path)
+			}
+			segments = append(segments, jsonPathSegment{key: key, wildcard: key == "*"})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("JSONPath '%s' contains an unclosed bracket", path)
+			}
+			segment, err := parseJSONPathBracket(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath '%s' is invalid: %w", path, err)
+			}
+			segments = append(segments, segment)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("JSONPath '%s' contains an unexpected character '%c'", path, rest[0])
+		}
+	}
+	return segments, nil
+}
+
+func parseJSONPathBracket(content string) (jsonPathSegment, error) {
+	if content == "*" {
+		return jsonPathSegment{wildcard: true}, nil
+	}
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return jsonPathSegment{key: content[1 : len(content)-1]}, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("invalid index '%s'", content)
+	}
+	return jsonPathSegment{index: index, isIndex: true}, nil
+}
@@ -0,0 +1,48 @@
+package lib
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateJSONPath(t *testing.T) {
+	var document interface{}
+	err := json.Unmarshal([]byte(`{"build":{"id":42,"state":"SUCCESS","stages":[{"name":"build"},{"name":"test"}]},"my-key":{"a":1,"b":2}}`), &document)
+	require.Nil(t, err)
+
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{path: "$.build.id", want: float64(42)},
+		{path: "$.build.state", want: "SUCCESS"},
+		{path: "$['build']['state']", want: "SUCCESS"},
+		{path: "$.build.stages[1].name", want: "test"},
+		{path: "$.build.stages[-1].name", want: "test"},
+		{path: "$.build.stages[*].name", want: []interface{}{"build", "test"}},
+		{path: "$['my-key'].*", want: []interface{}{float64(1), float64(2)}},
+		{path: "$", want: document},
+		{path: "$.build.unknown", wantErr: true},
+		{path: "$.build.stages[2]", wantErr: true},
+		{path: "$.build.id.value", wantErr: true},
+		{path: "$.build[0]", wantErr: true},
+		{path: "$.build.stages[", wantErr: true},
+		{path: "$.build.stages[a]", wantErr: true},
+		{path: "build.id", wantErr: true},
+		{path: "$..id", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := EvaluateJSONPath(document, tt.path)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
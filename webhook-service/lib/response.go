@@ -0,0 +1,170 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// StatusCodeWriteOutOption is appended to curl requests for which the status code of the response is required.
+// It prints the status code in the last line of the output
+const StatusCodeWriteOutOption = `--write-out '\n%{http_code}'`
+
+// Response defines how the response of a webhook request is processed
+type Response struct {
+	// StatusCodes are the expected status codes of the response. If the response has a different status code, the request fails
+	StatusCodes []int `yaml:"statusCodes,omitempty"`
+	// Data maps the names of fields in the .finished event data to JSONPath expressions that are evaluated on the response body
+	Data map[string]string `yaml:"data,omitempty"`
+	// Result derives the result of the .finished event from one of the extracted fields
+	Result *ResponseResult `yaml:"result,omitempty"`
+}
+
+// ResponseResult maps the values of an extracted field to the results of the .finished event. Values that are neither listed in Pass nor in Warning result in a failed task
+type ResponseResult struct {
+	Field   string   `yaml:"field"`
+	Pass    []string `yaml:"pass,omitempty"`
+	Warning []string `yaml:"warning,omitempty"`
+}
+
+// ParsedResponse contains the body of a response and the data that has been extracted from it
+type ParsedResponse struct {
+	Body       string
+	StatusCode int
+	Data       map[string]interface{}
+	Result     keptnv2.ResultType
+	Message    string
+}
+
+// RequiresStatusCode returns whether the status code of the response is required to process it
+func (r *Response) RequiresStatusCode() bool {
+	return r != nil && len(r.StatusCodes) > 0
+}
+
+// ParseCurlOutput splits the output of a curl request that has been performed with the StatusCodeWriteOutOption into the body and the status code.
+// If the output does not end with a status code, the status code of the returned response is 0
+func ParseCurlOutput(output string) *HTTPResponse {
+	separatorIndex := strings.LastIndex(output, "\n")
+	statusCode, err := strconv.Atoi(strings.TrimSpace(output[separatorIndex+1:]))
+	if err != nil || separatorIndex < 0 {
+		return &HTTPResponse{Body: output}
+	}
+	return &HTTPResponse{StatusCode: statusCode, Body: output[:separatorIndex]}
+}
+
+// ParseResponse processes the response of a webhook request according to the response configuration. A status code of 0 means that the status code is unknown.
+// Responses with a status code >= 400 are considered as failed, unless the status code is explicitly expected
+func ParseResponse(response *HTTPResponse, config *Response) (*ParsedResponse, error) {
+	parsed := &ParsedResponse{Body: response.Body, StatusCode: response.StatusCode, Result: keptnv2.ResultPass}
+
+	if config.RequiresStatusCode() {
+		if response.StatusCode == 0 {
+			return nil, errors.New("could not determine status code of the response")
+		}
+		if !containsStatusCode(config.StatusCodes, response.StatusCode) {
+			return nil, fmt.Errorf("unexpected status code %d, expected one of %v", response.StatusCode, config.StatusCodes)
+		}
+	} else if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("request failed with status code %d.\nResponse: \n%s", response.StatusCode, response.Body)
+	}
+	if config == nil {
+		return parsed, nil
+	}
+
+	if len(config.Data) > 0 {
+		var body interface{}
+		if err := json.Unmarshal([]byte(parsed.Body), &body); err != nil {
+			return nil, fmt.Errorf("could not parse response body as JSON: %w", err)
+		}
+		parsed.Data = map[string]interface{}{}
+		for field, path := range config.Data {
+			value, err := EvaluateJSONPath(body, path)
+			if err != nil {
+				return nil, fmt.Errorf("could not extract field '%s': %w", field, err)
+			}
+			parsed.Data[field] = value
+		}
+	}
+
+	if config.Result != nil {
+		parsed.evaluateResult(config.Result)
+	}
+	return parsed, nil
+}
+
+// TemplateData returns the response in the form in which it is available in the templates of subsequent requests.
+// If the body is valid JSON, it is provided as parsed JSON document, otherwise as string
+func (p *ParsedResponse) TemplateData() map[string]interface{} {
+	var body interface{}
+	if err := json.Unmarshal([]byte(p.Body), &body); err != nil {
+		body = p.Body
+	}
+	return map[string]interface{}{
+		"body":       body,
+		"statusCode": p.StatusCode,
+	}
+}
+
+func (p *ParsedResponse) evaluateResult(result *ResponseResult) {
+	value := fmt.Sprint(p.Data[result.Field])
+	switch {
+	case containsString(result.Pass, value):
+		p.Result = keptnv2.ResultPass
+	case containsString(result.Warning, value):
+		p.Result = keptnv2.ResultWarning
+		p.Message = fmt.Sprintf("field '%s' has the value '%s'", result.Field, value)
+	default:
+		p.Result = keptnv2.ResultFailed
+		p.Message = fmt.Sprintf("field '%s' has the value '%s'", result.Field, value)
+	}
+}
+
+func verifyResponse(response *Response) error {
+	if response == nil {
+		return nil
+	}
+	for _, statusCode := range response.StatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf(webhookConfInvalid+"invalid status code %d", statusCode)
+		}
+	}
+	for field, path := range response.Data {
+		if field == "" {
+			return errors.New(webhookConfInvalid + "response data field name empty")
+		}
+		if field == "responses" {
+			return errors.New(webhookConfInvalid + "response data field name 'responses' is reserved")
+		}
+		if _, err := parseJSONPath(path); err != nil {
+			return fmt.Errorf(webhookConfInvalid+"%s", err.Error())
+		}
+	}
+	if response.Result != nil {
+		if _, ok := response.Data[response.Result.Field]; !ok {
+			return fmt.Errorf(webhookConfInvalid+"response result field '%s' is not defined in the response data", response.Result.Field)
+		}
+	}
+	return nil
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, s := range statusCodes {
+		if s == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package lib
+
+import (
+	"testing"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResponse(t *testing.T) {
+	config := &Response{
+		StatusCodes: []int{200, 201},
+		Data:        map[string]string{"buildId": "$.build.id", "state": "$.build.state"},
+		Result:      &ResponseResult{Field: "state", Pass: []string{"SUCCESS"}, Warning: []string{"UNSTABLE"}},
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		config  *Response
+		want    *ParsedResponse
+		wantErr bool
+	}{
+		{
+			name:   "no response config",
+			output: "success",
+			want:   &ParsedResponse{Body: "success", Result: keptnv2.ResultPass},
+		},
+		{
+			name:   "pass",
+			output: "{\"build\":{\"id\":\"42\",\"state\":\"SUCCESS\"}}\n201",
+			config: config,
+			want: &ParsedResponse{
+				Body:       `{"build":{"id":"42","state":"SUCCESS"}}`,
+				StatusCode: 201,
+				Data:       map[string]interface{}{"buildId": "42", "state": "SUCCESS"},
+				Result:     keptnv2.ResultPass,
+			},
+		},
+		{
+			name:   "warning",
+			output: "{\"build\":{\"id\":\"42\",\"state\":\"UNSTABLE\"}}\n200",
+			config: config,
+			want: &ParsedResponse{
+				Body:       `{"build":{"id":"42","state":"UNSTABLE"}}`,
+				StatusCode: 200,
+				Data:       map[string]interface{}{"buildId": "42", "state": "UNSTABLE"},
+				Result:     keptnv2.ResultWarning,
+				Message:    "field 'state' has the value 'UNSTABLE'",
+			},
+		},
+		{
+			name:   "fail",
+			output: "{\"build\":{\"id\":\"42\",\"state\":\"FAILED\"}}\n200",
+			config: config,
+			want: &ParsedResponse{
+				Body:       `{"build":{"id":"42","state":"FAILED"}}`,
+				StatusCode: 200,
+				Data:       map[string]interface{}{"buildId": "42", "state": "FAILED"},
+				Result:     keptnv2.ResultFailed,
+				Message:    "field 'state' has the value 'FAILED'",
+			},
+		},
+		{
+			name:    "failed request",
+			output:  "not found\n404",
+			wantErr: true,
+		},
+		{
+			name:   "expected status code of failed request",
+			output: "not found\n404",
+			config: &Response{StatusCodes: []int{404}},
+			want:   &ParsedResponse{Body: "not found", StatusCode: 404, Result: keptnv2.ResultPass},
+		},
+		{
+			name:    "unexpected status code",
+			output:  "{}\n202",
+			config:  config,
+			wantErr: true,
+		},
+		{
+			name:    "missing status code",
+			output:  "{}",
+			config:  config,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			output:  "invalid\n200",
+			config:  config,
+			wantErr: true,
+		},
+		{
+			name:    "missing field",
+			output:  "{\"build\":{\"id\":\"42\"}}\n200",
+			config:  config,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResponse(ParseCurlOutput(tt.output), tt.config)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
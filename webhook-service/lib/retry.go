@@ -0,0 +1,133 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// RetryOnConnectionError is a retryOn value that retries requests for which no response has been received
+const RetryOnConnectionError = "connection-error"
+
+const (
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultBackoffFactor  = 2.0
+	maxRetries            = 10
+)
+
+var defaultRetryOn = []string{RetryOnConnectionError, "502", "503", "504"}
+
+// Backoff defines the delay between the attempts of a request. The delay starts with Initial and is multiplied by Factor after each attempt, up to Max
+type Backoff struct {
+	Initial string  `yaml:"initial,omitempty"`
+	Max     string  `yaml:"max,omitempty"`
+	Factor  float64 `yaml:"factor,omitempty"`
+}
+
+// ConnectionError indicates that a request has been performed, but no response has been received
+type ConnectionError struct {
+	err error
+}
+
+func NewConnectionError(err error) *ConnectionError {
+	return &ConnectionError{err: err}
+}
+
+func (c *ConnectionError) Error() string {
+	return c.err.Error()
+}
+
+func (c *ConnectionError) Unwrap() error {
+	return c.err
+}
+
+func IsConnectionError(err error) bool {
+	var connectionErr *ConnectionError
+	return errors.As(err, &connectionErr)
+}
+
+// RequiresStatusCode returns whether the status code of the response is required, either to process the response or to decide whether the request should be retried
+func (r Request) RequiresStatusCode() bool {
+	return r.Response.RequiresStatusCode() || r.Retries > 0
+}
+
+// ShouldRetry returns whether a request that resulted in the given response or error should be retried, according to the retryOn settings of the request.
+// If retryOn is not set, connection errors and the status codes 502, 503 and 504 are retried
+func (r Request) ShouldRetry(response *HTTPResponse, err error) bool {
+	retryOn := r.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = defaultRetryOn
+	}
+	if err != nil {
+		return IsConnectionError(err) && containsString(retryOn, RetryOnConnectionError)
+	}
+	if response == nil || response.StatusCode == 0 {
+		return false
+	}
+	statusCode := strconv.Itoa(response.StatusCode)
+	for _, value := range retryOn {
+		// status code classes, e.g. 5xx, match all status codes with the same first digit
+		if value == statusCode || isStatusCodeClass(value) && value[0] == statusCode[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBackoff returns the delay before the given retry attempt, starting with 1 for the first retry
+func (r Request) GetBackoff(attempt int) time.Duration {
+	initial, maxBackoff, factor := defaultInitialBackoff, defaultMaxBackoff, defaultBackoffFactor
+	if r.Backoff != nil {
+		// the values are validated when the webhook config is decoded
+		if d, err := time.ParseDuration(r.Backoff.Initial); err == nil {
+			initial = d
+		}
+		if d, err := time.ParseDuration(r.Backoff.Max); err == nil {
+			maxBackoff = d
+		}
+		if r.Backoff.Factor != 0 {
+			factor = r.Backoff.Factor
+		}
+	}
+	backoff := float64(initial) * math.Pow(factor, float64(attempt-1))
+	if backoff > float64(maxBackoff) {
+		return maxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+func verifyRetry(request Request) error {
+	if request.Retries < 0 || request.Retries > maxRetries {
+		return fmt.Errorf(webhookConfInvalid+"webhook request retries must be between 0 and %d", maxRetries)
+	}
+	for _, value := range request.RetryOn {
+		if value == RetryOnConnectionError || isStatusCodeClass(value) {
+			continue
+		}
+		if statusCode, err := strconv.Atoi(value); err != nil || statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf(webhookConfInvalid+"invalid retryOn value '%s'", value)
+		}
+	}
+	if request.Backoff == nil {
+		return nil
+	}
+	for _, duration := range []string{request.Backoff.Initial, request.Backoff.Max} {
+		if duration == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(duration); err != nil || d <= 0 {
+			return fmt.Errorf(webhookConfInvalid+"invalid backoff duration '%s'", duration)
+		}
+	}
+	if request.Backoff.Factor != 0 && request.Backoff.Factor < 1 {
+		return errors.New(webhookConfInvalid + "backoff factor must be at least 1")
+	}
+	return nil
+}
+
+func isStatusCodeClass(value string) bool {
+	return len(value) == 3 && value[0] >= '1' && value[0] <= '5' && value[1:] == "xx"
+}
@@ -0,0 +1,137 @@
+package lib
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequest_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		retryOn  []string
+		response *HTTPResponse
+		err      error
+		want     bool
+	}{
+		{
+			name:     "default retries 502",
+			response: &HTTPResponse{StatusCode: 502},
+			want:     true,
+		},
+		{
+			name:     "default does not retry 500",
+			response: &HTTPResponse{StatusCode: 500},
+			want:     false,
+		},
+		{
+			name: "default retries connection errors",
+			err:  NewConnectionError(errors.New("connection refused")),
+			want: true,
+		},
+		{
+			name: "other errors are not retried",
+			err:  errors.New("invalid request"),
+			want: false,
+		},
+		{
+			name:     "status code class",
+			retryOn:  []string{"5xx"},
+			response: &HTTPResponse{StatusCode: 500},
+			want:     true,
+		},
+		{
+			name:     "status code not listed",
+			retryOn:  []string{"429"},
+			response: &HTTPResponse{StatusCode: 503},
+			want:     false,
+		},
+		{
+			name:    "connection errors not listed",
+			retryOn: []string{"429"},
+			err:     NewConnectionError(errors.New("connection refused")),
+			want:    false,
+		},
+		{
+			name:     "successful response",
+			retryOn:  []string{"5xx", "429"},
+			response: &HTTPResponse{StatusCode: 200},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := Request{Retries: 3, RetryOn: tt.retryOn}
+			require.Equal(t, tt.want, request.ShouldRetry(tt.response, tt.err))
+		})
+	}
+}
+
+func TestRequest_GetBackoff(t *testing.T) {
+	request := Request{}
+	require.Equal(t, 1*time.Second, request.GetBackoff(1))
+	require.Equal(t, 2*time.Second, request.GetBackoff(2))
+	require.Equal(t, 16*time.Second, request.GetBackoff(5))
+	require.Equal(t, 30*time.Second, request.GetBackoff(6))
+
+	request = Request{Backoff: &Backoff{Initial: "100ms", Max: "1s", Factor: 3}}
+	require.Equal(t, 100*time.Millisecond, request.GetBackoff(1))
+	require.Equal(t, 300*time.Millisecond, request.GetBackoff(2))
+	require.Equal(t, 900*time.Millisecond, request.GetBackoff(3))
+	require.Equal(t, 1*time.Second, request.GetBackoff(4))
+}
+
+func TestVerifyRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		request Request
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			request: Request{Retries: 3, RetryOn: []string{"connection-error", "429", "5xx"}, Backoff: &Backoff{Initial: "1s", Max: "10s", Factor: 1.5}},
+		},
+		{
+			name:    "negative retries",
+			request: Request{Retries: -1},
+			wantErr: true,
+		},
+		{
+			name:    "too many retries",
+			request: Request{Retries: 11},
+			wantErr: true,
+		},
+		{
+			name:    "invalid status code",
+			request: Request{Retries: 1, RetryOn: []string{"999"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid retryOn value",
+			request: Request{Retries: 1, RetryOn: []string{"timeout"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid backoff duration",
+			request: Request{Retries: 1, Backoff: &Backoff{Initial: "soon"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid backoff factor",
+			request: Request{Retries: 1, Backoff: &Backoff{Factor: 0.5}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyRetry(tt.request)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
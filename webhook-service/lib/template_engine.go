@@ -2,6 +2,7 @@ package lib
 
 import (
 	"bytes"
+	"strings"
 	"text/template"
 )
 
@@ -13,7 +14,7 @@ type ITemplateEngine interface {
 type TemplateEngine struct{}
 
 func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (string, error) {
-	tmpl, err := template.New("").Option("missingkey=error").Parse(templateStr)
+	tmpl, err := template.New("").Option("missingkey=error").Parse(rewriteIndexExpressions(templateStr))
 	if err != nil {
 		return "", err
 	}
@@ -23,3 +24,102 @@ func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (st
 	}
 	return tpl.String(), nil
 }
+
+// rewriteIndexExpressions rewrites index expressions within the actions of a template, e.g. {{.responses[0].body.id}}, to calls of the index function,
+// e.g. {{(index .responses 0).body.id}}, since text/template does not support them. The text outside of actions is not modified
+func rewriteIndexExpressions(templateStr string) string {
+	var result strings.Builder
+	rest := templateStr
+	for {
+		start := strings.Index(rest, "{{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end == -1 {
+			break
+		}
+		end += start
+		result.WriteString(rest[:start])
+		result.WriteString(rewriteActionIndexExpressions(rest[start:end]))
+		rest = rest[end:]
+		// write the closing delimiter, so that it is not considered as part of the next action
+		result.WriteString("}}")
+		rest = rest[2:]
+	}
+	result.WriteString(rest)
+	return result.String()
+}
+
+func rewriteActionIndexExpressions(action string) string {
+	for i := 0; i < len(action); i++ {
+		switch action[i] {
+		case '"', '`':
+			// skip string literals
+			if closing := strings.IndexByte(action[i+1:], action[i]); closing != -1 {
+				i += closing + 1
+			}
+		case '[':
+			closing := strings.IndexByte(action[i:], ']')
+			if closing == -1 || !isDigits(action[i+1:i+closing]) {
+				continue
+			}
+			operandStart := findOperandStart(action, i)
+			if operandStart == i {
+				continue
+			}
+			operand := action[operandStart:i]
+			replacement := "(index " + operand + " " + action[i+1:i+closing] + ")"
+			action = action[:operandStart] + replacement + action[i+closing+1:]
+			i = operandStart + len(replacement) - 1
+		}
+	}
+	return action
+}
+
+// findOperandStart returns the start of the operand that ends before the given position, e.g. a field chain like .data.items or a parenthesized expression
+func findOperandStart(action string, end int) int {
+	start := end
+	for start > 0 {
+		c := action[start-1]
+		switch {
+		case c == '.' || c == '$' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9':
+			start--
+		case c == ')':
+			depth := 0
+			for start > 0 {
+				start--
+				if action[start] == ')' {
+					depth++
+				} else if action[start] == '(' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+		default:
+			return validOperandStart(action, start, end)
+		}
+	}
+	return validOperandStart(action, start, end)
+}
+
+func validOperandStart(action string, start int, end int) int {
+	if start == end || action[start] != '.' && action[start] != '$' && action[start] != '(' {
+		return end
+	}
+	return start
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
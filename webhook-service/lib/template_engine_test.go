@@ -75,6 +75,31 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 			wantErr: true,
 			errMsg:  ".env.barz",
 		},
+		{
+			name: "index expressions",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{
+						map[string]interface{}{"body": map[string]interface{}{"id": "42", "tags": []interface{}{"a", "b"}}},
+					},
+				},
+				templateStr: `{"id": "{{.responses[0].body.id}}", "tag": "{{ .responses[0].body.tags[1] }}", "items[0]": "{{ "[0]" }}"}`,
+			},
+			want:    `{"id": "42", "tag": "b", "items[0]": "[0]"}`,
+			wantErr: false,
+		},
+		{
+			name: "index out of range",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{},
+				},
+				templateStr: "foo {{.responses[1].body.id}}",
+			},
+			want:    "",
+			wantErr: true,
+			errMsg:  "index out of range",
+		},
 	}
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
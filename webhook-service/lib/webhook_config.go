@@ -3,6 +3,7 @@ package lib
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v3"
@@ -25,12 +26,16 @@ type WebHookConfigSpec struct {
 }
 
 type Webhook struct {
-	Type           string        `yaml:"type"`
-	SubscriptionID string        `yaml:"subscriptionID"`
-	SendFinished   bool          `yaml:"sendFinished"`
-	SendStarted    *bool         `yaml:"sendStarted,omitempty"`
-	EnvFrom        []EnvFrom     `yaml:"envFrom"`
-	Requests       []interface{} `yaml:"requests"`
+	Type           string `yaml:"type"`
+	SubscriptionID string `yaml:"subscriptionID"`
+	SendFinished   bool   `yaml:"sendFinished"`
+	SendStarted    *bool  `yaml:"sendStarted,omitempty"`
+	// Executor defines how the requests are performed. Either 'curl' (default) or 'http', which is only supported for v1beta1 requests
+	Executor string `yaml:"executor,omitempty"`
+	// Callback enables the callback mode, in which the .finished event is sent when the external system calls the callback URL
+	Callback *Callback     `yaml:"callback,omitempty"`
+	EnvFrom  []EnvFrom     `yaml:"envFrom"`
+	Requests []interface{} `yaml:"requests"`
 }
 
 type EnvFrom struct {
@@ -44,6 +49,18 @@ type Request struct {
 	Headers []Header `yaml:"headers,omitempty"`
 	Payload string   `yaml:"payload,omitempty"`
 	Options string   `yaml:"options,omitempty"`
+	// Timeout is the timeout of the request, e.g. '30s'. Only supported by the http executor
+	Timeout string `yaml:"timeout,omitempty"`
+	// InsecureSkipTLSVerify disables the verification of the server certificate. Only supported by the http executor
+	InsecureSkipTLSVerify bool `yaml:"insecureSkipTLSVerify,omitempty"`
+	// Response defines how the response of the request is processed. If it is not set, the response is added to the .finished event as it is
+	Response *Response `yaml:"response,omitempty"`
+	// Retries is the number of times the request is retried if it fails with one of the errors listed in RetryOn
+	Retries int `yaml:"retries,omitempty"`
+	// RetryOn lists the status codes (e.g. '502' or '5xx') and 'connection-error' for which the request is retried. Defaults to connection errors, 502, 503 and 504
+	RetryOn []string `yaml:"retryOn,omitempty"`
+	// Backoff defines the delay between the attempts of the request
+	Backoff *Backoff `yaml:"backoff,omitempty"`
 }
 
 type Header struct {
@@ -62,6 +79,11 @@ const alphaApiVersion = "webhookconfig.keptn.sh/v1alpha1"
 
 var supportedCurlMethods = [4]string{"POST", "PUT", "GET", "HEAD"}
 
+const (
+	CurlExecutorType = "curl"
+	HTTPExecutorType = "http"
+)
+
 // DecodeWebHookConfigYAML takes a webhook config string formatted as YAML and decodes it to
 // Shipyard value
 func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
@@ -91,6 +113,18 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 		if len(webhook.Requests) == 0 {
 			return nil, errors.New(webhookConfInvalid + "missing 'webhooks[].Requests[]' part")
 		}
+
+		if webhook.Executor != "" && webhook.Executor != CurlExecutorType && webhook.Executor != HTTPExecutorType {
+			return nil, fmt.Errorf(webhookConfInvalid+"unsupported executor '%s'", webhook.Executor)
+		}
+
+		if webhook.UsesHTTPExecutor() && webHookConfig.ApiVersion != betaApiVersion {
+			return nil, errors.New(webhookConfInvalid + "the http executor requires version " + betaApiVersion)
+		}
+
+		if err := verifyCallback(webhook); err != nil {
+			return nil, err
+		}
 	}
 
 	if webHookConfig.ApiVersion == betaApiVersion {
@@ -109,6 +143,9 @@ func normalizeBeta1Requests(webhooks []Webhook) error {
 			if err := verifyBeta1Request(convertedRequest); err != nil {
 				return err
 			}
+			if err := verifyRequestForExecutor(convertedRequest, webhook.UsesHTTPExecutor()); err != nil {
+				return err
+			}
 			webhooks[i].Requests[j] = convertedRequest
 		}
 	}
@@ -132,6 +169,27 @@ func verifyBeta1Request(request Request) error {
 			}
 		}
 	}
+	if err := verifyRetry(request); err != nil {
+		return err
+	}
+	return verifyResponse(request.Response)
+}
+
+func verifyRequestForExecutor(request Request, usesHTTPExecutor bool) error {
+	if usesHTTPExecutor {
+		if request.Options != "" {
+			return errors.New(webhookConfInvalid + "webhook request options are not supported by the http executor")
+		}
+		if request.Timeout != "" {
+			if timeout, err := time.ParseDuration(request.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf(webhookConfInvalid+"invalid webhook request timeout '%s'", request.Timeout)
+			}
+		}
+		return nil
+	}
+	if request.Timeout != "" || request.InsecureSkipTLSVerify {
+		return errors.New(webhookConfInvalid + "webhook request timeout and insecureSkipTLSVerify are only supported by the http executor")
+	}
 	return nil
 }
 
@@ -155,8 +213,23 @@ func (wh Webhook) ShouldSendFinishedEvent() bool {
 	return wh.SendFinished
 }
 
+// UsesCallback returns whether the .finished event of the webhook is sent when the external system calls the callback URL
+func (wh Webhook) UsesCallback() bool {
+	return wh.Callback != nil
+}
+
+// UsesHTTPExecutor returns whether the requests of the webhook are performed with the native HTTP executor instead of curl
+func (wh Webhook) UsesHTTPExecutor() bool {
+	return wh.Executor == HTTPExecutorType
+}
+
 func ConvertToRequest(data interface{}) Request {
 	requestStruct := Request{}
-	mapstructure.Decode(data, &requestStruct)
+	// decode weakly typed input, so that status codes in retryOn can be written as numbers
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{WeaklyTypedInput: true, Result: &requestStruct})
+	if err != nil {
+		return requestStruct
+	}
+	decoder.Decode(data)
 	return requestStruct
 }
@@ -417,6 +417,375 @@ spec:
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "valid beta input with response",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          response:
+            statusCodes: [200, 201]
+            data:
+              state: "$.build.state"
+            result:
+              field: state
+              pass: ["SUCCESS"]`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									URL:    "http://localhost:8080",
+									Method: "GET",
+									Response: &Response{
+										StatusCodes: []int{200, 201},
+										Data:        map[string]string{"state": "$.build.state"},
+										Result:      &ResponseResult{Field: "state", Pass: []string{"SUCCESS"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "beta input with invalid response result field",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          response:
+            data:
+              state: "$.build.state"
+            result:
+              field: status
+              pass: ["SUCCESS"]`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "beta input with invalid response JSONPath",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          response:
+            data:
+              state: "build.state"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "valid beta input with http executor",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: http
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          timeout: 10s
+          insecureSkipTLSVerify: true`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Executor:       "http",
+							Requests: []interface{}{
+								Request{
+									URL:                   "https://localhost:8080",
+									Method:                "GET",
+									Timeout:               "10s",
+									InsecureSkipTLSVerify: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported executor",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: wget
+      requests:
+        - url: http://localhost:8080
+          method: GET`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "http executor with alpha version",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: http
+      requests:
+        - "curl http://localhost:8080"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "http executor with curl options",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: http
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          options: --insecure`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "http executor with invalid timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: http
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          timeout: ten seconds`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "curl executor with timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      executor: curl
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          timeout: 10s`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "request with retries",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          retries: 3
+          retryOn:
+            - connection-error
+            - 429
+            - 5xx
+          backoff:
+            initial: 500ms
+            max: 10s
+            factor: 1.5`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									URL:     "http://localhost:8080",
+									Method:  "GET",
+									Retries: 3,
+									RetryOn: []string{"connection-error", "429", "5xx"},
+									Backoff: &Backoff{Initial: "500ms", Max: "10s", Factor: 1.5},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "request with invalid retryOn value",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          method: GET
+          retries: 3
+          retryOn:
+            - timeout`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "callback without sendFinished",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      callback:
+        timeout: 30m
+      requests:
+        - url: http://localhost:8080
+          method: GET`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "callback with invalid timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      callback:
+        timeout: later
+      requests:
+        - url: http://localhost:8080
+          method: GET`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "callback with timeout exceeding the maximum",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      callback:
+        timeout: 48h
+      requests:
+        - url: http://localhost:8080
+          method: GET`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"net/http"
 	"os"
 
 	"github.com/keptn/keptn/go-sdk/pkg/sdk"
@@ -37,9 +38,19 @@ func main() {
 	ipResolver := lib.NewIPResolver()
 	denyListProvider := lib.NewDenyListProvider(kubeAPI)
 	requestValidator := lib.NewRequestValidator(denyListProvider, ipResolver)
-	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, requestValidator, secretReader)
+	httpExecutor := lib.NewHTTPExecutor(requestValidator, denyListProvider)
+	callbackHandler := handler.NewCallbackHandler(lib.GetCallbackBaseURL(lib.GetEnv()),
+		handler.WithCallbackStore(lib.NewK8sCallbackStore(kubeAPI)),
+	)
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, httpExecutor, requestValidator, secretReader,
+		handler.WithIntegrationID(lib.GetIntegrationID(serviceName)),
+		handler.WithCallbackHandler(callbackHandler),
+	)
+
+	// the callback endpoint is served by the same server as the health endpoint
+	http.Handle(lib.CallbackPath, callbackHandler)
 
-	log.Fatal(sdk.NewKeptn(
+	keptn := sdk.NewKeptn(
 		serviceName,
 		sdk.WithTaskHandler(
 			eventTypeWildcard,
@@ -47,7 +58,11 @@ func main() {
 		),
 		sdk.WithAutomaticResponse(false),
 		sdk.WithLogger(log.New()),
-	).Start())
+	)
+	// the callbacks of tasks that have been pending before a restart cannot be received anymore
+	callbackHandler.FailOrphanedTasks(keptn)
+
+	log.Fatal(keptn.Start())
 }
 
 func createKubeAPI() (*kubernetes.Clientset, error) {